@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	infraMongo "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/mongo"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+)
+
+// RunAdmin runs an account administration command. "grant <email>" gives the
+// account registered with email the admin role. The RBAC migration assigns
+// existing accounts the user role only, so this is how the first
+// administrator is made; later ones can be granted through the role API.
+func RunAdmin(args []string) error {
+	if len(args) != 2 || args[0] != "grant" {
+		return errors.New("usage: go run . admin grant <email>")
+	}
+	email := args[1]
+
+	cfg, err := core.LoadConfig("config/config.yaml")
+	if err != nil {
+		return err
+	}
+	featureFlag, err := core.LoadFeatureFlags("config/featureflags.yaml")
+	if err != nil {
+		return err
+	}
+	if err := setupLogging(cfg); err != nil {
+		return err
+	}
+
+	dialect, err := featureFlag.Repository.SQLDialect()
+	if err != nil {
+		return err
+	}
+	db, err := openSQL(cfg, dialect)
+	if err != nil {
+		if featureFlag.Repository.UsesSQL() {
+			return err
+		}
+		logger.WithField("error", err).Warn("SQL database connection failed")
+	}
+	defer func() {
+		if db != nil {
+			db.Close()
+		}
+	}()
+
+	mongo, err := infraMongo.OpenMongo(cfg.App.Database.Mongo.MongoURL)
+	if err != nil {
+		if featureFlag.Repository.UsesMongo() {
+			return err
+		}
+		logger.WithField("error", err).Warn("MongoDB connection failed")
+	}
+	defer func() {
+		if mongo != nil {
+			infraMongo.CloseMongo(mongo)
+		}
+	}()
+
+	container := core.NewContainer(*featureFlag, cfg, db, nil, mongo)
+	if container == nil {
+		return errors.New("failed to create container")
+	}
+	defer container.EventBus.Close()
+
+	return grantAdmin(context.Background(), container.AuthRepository, email)
+}
+
+// grantAdmin assigns the admin role to the account registered with email;
+// granting it again is a no-op
+func grantAdmin(ctx context.Context, repo authDomain.Repository, email string) error {
+	cred, err := repo.GetCredentialByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("no account is registered with %s: %w", email, err)
+	}
+	role, err := repo.GetRoleByName(ctx, authDomain.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("the %s role is missing, run the migrations first: %w", authDomain.RoleAdmin, err)
+	}
+	if err := repo.AssignRole(ctx, &authDomain.UserRole{
+		UserID:     cred.UserID,
+		RoleID:     role.ID,
+		AssignedBy: "cli",
+	}); err != nil {
+		return fmt.Errorf("failed to assign the %s role: %w", authDomain.RoleAdmin, err)
+	}
+
+	logger.WithField("user_id", cred.UserID).Info("Granted the admin role")
+	return nil
+}
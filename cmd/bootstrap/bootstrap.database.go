@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	infraSQL "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/sql"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// openSQL connects to the primary SQL database of dialect
+func openSQL(cfg *core.Config, dialect sqldialect.Dialect) (*sqlx.DB, error) {
+	return infraSQL.Open(dialect, cfg.App.Database.SQL.DBUrl, sqlPoolConfig(cfg))
+}
+
+// openReplicas opens the configured read replicas without waiting for them;
+// one that cannot be opened is logged and left out
+func openReplicas(cfg *core.Config, dialect sqldialect.Dialect) []*sqlx.DB {
+	var replicas []*sqlx.DB
+	for i, dsn := range cfg.App.Database.SQL.Replicas {
+		db, err := infraSQL.OpenReplica(dialect, dsn, sqlPoolConfig(cfg))
+		if err != nil {
+			logger.WithField("replica", i).WithField("error", err).Error("SQL replica could not be opened")
+			continue
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas
+}
+
+func closeReplicas(replicas []*sqlx.DB) {
+	for _, db := range replicas {
+		db.Close()
+	}
+}
+
+// sqlPoolConfig converts the YAML pool settings; empty or invalid values keep
+// the defaults
+func sqlPoolConfig(cfg *core.Config) infraSQL.PoolConfig {
+	pool := cfg.App.Database.SQL.Pool
+	poolConfig := infraSQL.PoolConfig{
+		MaxOpenConns: pool.MaxOpenConns,
+		MaxIdleConns: pool.MaxIdleConns,
+	}
+	if d, err := time.ParseDuration(pool.ConnMaxLifetime); err == nil {
+		poolConfig.ConnMaxLifetime = d
+	}
+	if d, err := time.ParseDuration(pool.ConnMaxIdleTime); err == nil {
+		poolConfig.ConnMaxIdleTime = d
+	}
+	return poolConfig
+}
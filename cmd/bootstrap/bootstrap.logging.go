@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+)
+
+// setupLogging replaces the default logger with one built from the logging
+// config. It returns an error for unknown backends, formats or levels so a
+// typo does not silently drop logs.
+func setupLogging(cfg *core.Config) error {
+	logging := cfg.App.Logging
+	output, err := logger.OpenOutputs(logging.Outputs)
+	if err != nil {
+		return err
+	}
+	var tick time.Duration
+	if logging.Sampling.Tick != "" {
+		if tick, err = time.ParseDuration(logging.Sampling.Tick); err != nil {
+			return fmt.Errorf("logging sampling tick: %w", err)
+		}
+	}
+	l, err := logger.NewFromConfig(logger.Config{
+		Backend: logging.Backend,
+		Format:  logging.Format,
+		Level:   logging.Level,
+		Modules: logging.Modules,
+		Output:  output,
+		Sampling: logger.SamplingConfig{
+			Tick:       tick,
+			Initial:    logging.Sampling.Initial,
+			Thereafter: logging.Sampling.Thereafter,
+		},
+		RedactKeys: logging.RedactKeys,
+		ShowEmails: logging.ShowEmails,
+	})
+	if err != nil {
+		return fmt.Errorf("logging: %w", err)
+	}
+	logger.SetLogger(l)
+	return nil
+}
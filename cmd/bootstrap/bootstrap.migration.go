@@ -12,26 +12,38 @@ import (
 	"github.com/kamil5b/go-pste-monolith/internal/app/core"
 	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 
+	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/pressly/goose/v3"
 )
 
 // RunMigration runs SQL migrations using goose. args is expected to contain the migration action ("up" or "down").
+// The migrations of the dialect the repository feature flags use are applied.
 func RunMigrationSQL(args []string) error {
 	cfg, err := core.LoadConfig("config/config.yaml")
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	migrationsDir := filepath.Join("internal", "infrastructure", "db", "sql", "migration")
+	featureFlag, err := core.LoadFeatureFlags("config/featureflags.yaml")
+	if err != nil {
+		return fmt.Errorf("load feature flags: %w", err)
+	}
+	dialect, err := featureFlag.Repository.SQLDialect()
+	if err != nil {
+		return err
+	}
+
+	migrationsDir := filepath.Join("internal", "infrastructure", "db", "sql", "migration", string(dialect))
 
-	db, err := sql.Open("postgres", cfg.App.Database.SQL.DBUrl)
+	db, err := sql.Open(dialect.DriverName(), cfg.App.Database.SQL.DBUrl)
 	if err != nil {
 		return fmt.Errorf("open db: %w", err)
 	}
 	defer db.Close()
 
-	if err := goose.SetDialect("postgres"); err != nil {
+	if err := goose.SetDialect(dialect.GooseDialect()); err != nil {
 		return fmt.Errorf("set dialect: %w", err)
 	}
 
@@ -11,13 +11,13 @@ import (
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	appGrpc "github.com/kamil5b/go-pste-monolith/internal/app/grpc"
 	appHttp "github.com/kamil5b/go-pste-monolith/internal/app/http"
 	infraMongo "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/mongo"
-	infraSQL "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/sql"
 	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
-	productGRPC "github.com/kamil5b/go-pste-monolith/internal/modules/product/handler/grpc"
 	grpctransport "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/valyala/fasthttp"
 )
 
@@ -30,13 +30,23 @@ func RunServer() error {
 	if err != nil {
 		return err
 	}
+	if err := setupLogging(cfg); err != nil {
+		return err
+	}
 
-	db, err := infraSQL.Open(cfg.App.Database.SQL.DBUrl)
+	// Install the exporter before anything creates spans
+	defer setupTracing(cfg, featureFlag)()
+
+	dialect, err := featureFlag.Repository.SQLDialect()
+	if err != nil {
+		return err
+	}
+	db, err := openSQL(cfg, dialect)
 	if err != nil {
-		if featureFlag.Repository.Product == "postgres" {
+		if featureFlag.Repository.UsesSQL() {
 			return err
 		}
-		logger.WithField("error", err).Error("PostgreSQL connection failed")
+		logger.WithField("error", err).Error("SQL database connection failed")
 	}
 	defer func() {
 		if db != nil {
@@ -44,9 +54,15 @@ func RunServer() error {
 		}
 	}()
 
+	var replicas []*sqlx.DB
+	if db != nil {
+		replicas = openReplicas(cfg, dialect)
+		defer closeReplicas(replicas)
+	}
+
 	mongo, err := infraMongo.OpenMongo(cfg.App.Database.Mongo.MongoURL)
 	if err != nil {
-		if featureFlag.Repository.Product == "mongo" {
+		if featureFlag.Repository.UsesMongo() {
 			return err
 		}
 		logger.WithField("error", err).Error("MongoDB connection failed")
@@ -57,7 +73,7 @@ func RunServer() error {
 		}
 	}()
 
-	container := core.NewContainer(*featureFlag, cfg, db, mongo)
+	container := core.NewContainer(*featureFlag, cfg, db, replicas, mongo)
 	if container == nil {
 		return errors.New("failed to create container")
 	}
@@ -66,6 +82,11 @@ func RunServer() error {
 	shutdownCtx, shutdownCancel := context.WithCancel(context.Background())
 	defer shutdownCancel()
 
+	// Eject unreachable read replicas until shutdown
+	if container.SQLRouter != nil {
+		go container.SQLRouter.Run(shutdownCtx)
+	}
+
 	// Setup signal handling for graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
@@ -102,12 +123,7 @@ func RunServer() error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		grpcServerInstance = grpctransport.NewServer()
-
-		// Register gRPC services
-		if container.ProductGRPCHandler != nil {
-			grpcServerInstance.RegisterService(productGRPC.RegisterService(container.ProductGRPCHandler))
-		}
+		grpcServerInstance = appGrpc.NewGRPCServer(container, cfg.App.GRPC, featureFlag.GRPC)
 
 		logger.WithField("port", cfg.App.Server.GRPCPort).Info("Starting gRPC server")
 		if err := grpcServerInstance.Start(shutdownCtx, ":"+cfg.App.Server.GRPCPort); err != nil {
@@ -136,13 +152,22 @@ func RunServer() error {
 			cancel()
 		}
 
+		// Drain async event handlers before the worker client they spill
+		// overflow to goes away
+		if container.EventBus != nil {
+			if err := container.EventBus.Close(); err != nil {
+				logger.WithField("error", err).Error("Error closing event bus")
+			}
+		}
+
 		if container.WorkerClient != nil {
 			if err := container.WorkerClient.Close(); err != nil {
 				logger.WithField("error", err).Error("Error closing worker client")
 			}
 		}
 
-		// gRPC server stops via context cancellation (shutdownCtx)
+		// gRPC server drains on context cancellation (shutdownCtx): health turns
+		// NOT_SERVING and in-flight calls get grpc.shutdown_timeout to finish
 		// HTTP server stops via signal handling in framework
 
 		// Wait for servers to finish shutdown with timeout
@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	infratracing "github.com/kamil5b/go-pste-monolith/internal/infrastructure/tracing"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+)
+
+// setupTracing installs the configured span exporter when the tracing feature
+// flag is on. The returned function flushes pending spans; it is a no-op when
+// tracing is off or could not be set up.
+func setupTracing(cfg *core.Config, featureFlag *core.FeatureFlag) func() {
+	if !featureFlag.Tracing.Enabled {
+		return func() {}
+	}
+	shutdown, err := infratracing.Setup(context.Background(), infratracing.Config{
+		Exporter:    featureFlag.Tracing.Exporter,
+		ServiceName: cfg.App.Tracing.ServiceName,
+		Endpoint:    cfg.App.Tracing.Endpoint,
+		Insecure:    cfg.App.Tracing.Insecure,
+		Headers:     cfg.App.Tracing.Headers,
+		SampleRatio: cfg.App.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.WithField("error", err).Error("Tracing setup failed, spans will not be exported")
+		return func() {}
+	}
+	logger.WithField("exporter", featureFlag.Tracing.Exporter).Info("Tracing enabled")
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.WithField("error", err).Warn("Error flushing spans")
+		}
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/kamil5b/go-pste-monolith/internal/app/core"
 	"github.com/kamil5b/go-pste-monolith/internal/app/worker"
 	infraMongo "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/mongo"
-	infraSQL "github.com/kamil5b/go-pste-monolith/internal/infrastructure/db/sql"
 	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 	userworker "github.com/kamil5b/go-pste-monolith/internal/modules/user/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // RunWorker initializes and starts the worker server
@@ -28,19 +30,30 @@ func RunWorker() error {
 	if err != nil {
 		return err
 	}
+	if err := setupLogging(cfg); err != nil {
+		return err
+	}
 
-	// Check if workers are enabled
-	if !featureFlag.Worker.Enabled || featureFlag.Worker.Backend == "disable" {
+	// Check if workers are enabled (the outbox relay alone is enough to run)
+	workersEnabled := featureFlag.Worker.Enabled && featureFlag.Worker.Backend != "disable"
+	if !workersEnabled && !featureFlag.Outbox.Enabled {
 		return errors.New("workers are not enabled")
 	}
 
+	// Install the exporter before anything creates spans
+	defer setupTracing(cfg, featureFlag)()
+
 	// Initialize databases
-	db, err := infraSQL.Open(cfg.App.Database.SQL.DBUrl)
+	dialect, err := featureFlag.Repository.SQLDialect()
 	if err != nil {
-		if featureFlag.Repository.User == "postgres" || featureFlag.Repository.Product == "postgres" || featureFlag.Repository.Authentication == "postgres" {
+		return err
+	}
+	db, err := openSQL(cfg, dialect)
+	if err != nil {
+		if featureFlag.Repository.UsesSQL() {
 			return err
 		}
-		logger.WithField("error", err).Warn("PostgreSQL connection failed")
+		logger.WithField("error", err).Warn("SQL database connection failed")
 	}
 	defer func() {
 		if db != nil {
@@ -48,9 +61,15 @@ func RunWorker() error {
 		}
 	}()
 
+	var replicas []*sqlx.DB
+	if db != nil {
+		replicas = openReplicas(cfg, dialect)
+		defer closeReplicas(replicas)
+	}
+
 	mongo, err := infraMongo.OpenMongo(cfg.App.Database.Mongo.MongoURL)
 	if err != nil {
-		if featureFlag.Repository.Product == "mongo" || featureFlag.Repository.Authentication == "mongo" {
+		if featureFlag.Repository.UsesMongo() {
 			return err
 		}
 		logger.WithField("error", err).Warn("MongoDB connection failed")
@@ -62,7 +81,7 @@ func RunWorker() error {
 	}()
 
 	// Create container with all dependencies
-	container := core.NewContainer(*featureFlag, cfg, db, mongo)
+	container := core.NewContainer(*featureFlag, cfg, db, replicas, mongo)
 	if container == nil {
 		return errors.New("failed to create container")
 	}
@@ -89,6 +108,9 @@ func RunWorker() error {
 		return fmt.Errorf("failed to register module tasks: %w", err)
 	}
 
+	// Events that overflowed an async in-memory bus are replayed here
+	workerManager.GetRegistry().Register(events.SpillTaskName, events.NewSpillTaskHandler(container.EventBus, container.EventRegistry))
+
 	// Register all collected tasks with the worker server
 	logger.Info("Registering all tasks with worker server...")
 	if err := workerManager.RegisterTasks(); err != nil {
@@ -112,6 +134,21 @@ func RunWorker() error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	// Eject unreachable read replicas until ctx is canceled
+	if container.SQLRouter != nil {
+		go container.SQLRouter.Run(ctx)
+	}
+
+	// Start the outbox relays; they stop when ctx is canceled
+	for _, relay := range container.OutboxRelays {
+		go func() {
+			logger.Info("Outbox relay running")
+			if err := relay.Run(ctx); err != nil {
+				logger.WithField("error", err).Error("Outbox relay stopped")
+			}
+		}()
+	}
+
 	// Start the worker server in a goroutine
 	errChan := make(chan error, 1)
 	go func() {
@@ -130,6 +167,11 @@ func RunWorker() error {
 		if err := workerManager.Stop(shutdownCtx); err != nil {
 			logger.WithField("error", err).Warn("Error during worker shutdown")
 		}
+		if container.EventBus != nil {
+			if err := container.EventBus.Close(); err != nil {
+				logger.WithField("error", err).Warn("Error closing event bus")
+			}
+		}
 		logger.Info("Worker server stopped")
 		return nil
 	case err := <-errChan:
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/middleware"
+	authv1 "github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/v1"
+	authProvider "github.com/kamil5b/go-pste-monolith/internal/modules/auth/provider"
+	serviceV1Auth "github.com/kamil5b/go-pste-monolith/internal/modules/auth/service/v1"
+	productv1 "github.com/kamil5b/go-pste-monolith/internal/modules/product/proto/v1"
+	userv1 "github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/v1"
+)
+
+// newAuthConfig converts the YAML jwt/auth settings, keeping defaults for
+// anything left empty or invalid.
+func newAuthConfig(config *Config) serviceV1Auth.AuthConfig {
+	authConfig := serviceV1Auth.DefaultAuthConfig()
+	if config == nil {
+		return authConfig
+	}
+	if config.App.JWT.Secret != "" {
+		authConfig.JWTSecret = config.App.JWT.Secret
+	}
+	if config.App.Auth.DefaultRole != "" {
+		authConfig.DefaultRole = config.App.Auth.DefaultRole
+	}
+	if config.App.Auth.PasswordResetURL != "" {
+		authConfig.PasswordResetURL = config.App.Auth.PasswordResetURL
+	}
+	if d, err := time.ParseDuration(config.App.Auth.PasswordResetTokenDuration); err == nil {
+		authConfig.PasswordResetTokenDuration = d
+	}
+	if config.App.Auth.EmailVerificationURL != "" {
+		authConfig.EmailVerificationURL = config.App.Auth.EmailVerificationURL
+	}
+	if d, err := time.ParseDuration(config.App.Auth.EmailVerificationTokenDuration); err == nil {
+		authConfig.EmailVerificationTokenDuration = d
+	}
+	if d, err := time.ParseDuration(config.App.Auth.VerificationResendInterval); err == nil {
+		authConfig.VerificationResendInterval = d
+	}
+	if config.App.Auth.MFAIssuer != "" {
+		authConfig.MFAIssuer = config.App.Auth.MFAIssuer
+	}
+	if d, err := time.ParseDuration(config.App.Auth.MFAChallengeDuration); err == nil {
+		authConfig.MFAChallengeDuration = d
+	}
+	if d, err := time.ParseDuration(config.App.JWT.KeyRotationGracePeriod); err == nil {
+		authConfig.KeyRotationGracePeriod = d
+	}
+	if d, err := time.ParseDuration(config.App.Auth.OIDC.StateDuration); err == nil {
+		authConfig.OIDCStateDuration = d
+	}
+	return authConfig
+}
+
+// checkJWTSecret refuses the placeholder JWT secret. Besides HS256 access
+// tokens it keys the MFA challenge and email verification tokens, which are
+// signed with it even when asymmetric signing keys are configured.
+func checkJWTSecret(authConfig serviceV1Auth.AuthConfig) error {
+	if authConfig.JWTSecret == serviceV1Auth.DefaultJWTSecret {
+		return errors.New("app.jwt.secret is unset or the published default; set it to a long random value")
+	}
+	return nil
+}
+
+// newIdentityProviders builds the configured OAuth2/OIDC login providers.
+// An unknown provider type is an error rather than a silently missing login option.
+func newIdentityProviders(config *Config) (map[string]authDomain.IdentityProvider, error) {
+	providers := make(map[string]authDomain.IdentityProvider)
+	if config == nil {
+		return providers, nil
+	}
+
+	base := strings.TrimSuffix(config.App.Auth.OIDC.RedirectBaseURL, "/")
+	for name, pc := range config.App.Auth.OIDC.Providers {
+		redirectURL := base + "/" + name + "/callback"
+		switch pc.Type {
+		case "oidc":
+			if pc.Issuer == "" {
+				return nil, fmt.Errorf("identity provider %q: issuer is required", name)
+			}
+			providers[name] = authProvider.NewOIDCProvider(authProvider.OIDCConfig{
+				Issuer:       pc.Issuer,
+				ClientID:     pc.ClientID,
+				ClientSecret: pc.ClientSecret,
+				RedirectURL:  redirectURL,
+				Scopes:       pc.Scopes,
+			})
+		case "github":
+			providers[name] = authProvider.NewGitHubProvider(authProvider.GitHubConfig{
+				ClientID:     pc.ClientID,
+				ClientSecret: pc.ClientSecret,
+				RedirectURL:  redirectURL,
+				Scopes:       pc.Scopes,
+				AuthURL:      pc.AuthURL,
+				TokenURL:     pc.TokenURL,
+				APIURL:       pc.APIURL,
+			})
+		default:
+			return nil, fmt.Errorf("identity provider %q: unsupported type %q", name, pc.Type)
+		}
+	}
+	return providers, nil
+}
+
+// loadSigningKeys reads the configured JWT signing keys from their PEM files.
+// Unlike the settings above, a broken key is an error: silently falling back
+// to HS256 would hand out tokens that JWKS consumers cannot verify.
+func loadSigningKeys(config *Config) ([]serviceV1Auth.SigningKey, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	keys := make([]serviceV1Auth.SigningKey, 0, len(config.App.JWT.SigningKeys))
+	seen := make(map[string]bool)
+	for _, kc := range config.App.JWT.SigningKeys {
+		if seen[kc.ID] {
+			return nil, fmt.Errorf("duplicate signing key id %q", kc.ID)
+		}
+		seen[kc.ID] = true
+
+		var activeFrom time.Time
+		if kc.ActiveFrom != "" {
+			t, err := time.Parse(time.RFC3339, kc.ActiveFrom)
+			if err != nil {
+				return nil, fmt.Errorf("signing key %q: invalid active_from: %w", kc.ID, err)
+			}
+			activeFrom = t
+		}
+
+		data, err := os.ReadFile(kc.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %w", kc.ID, err)
+		}
+		key, err := serviceV1Auth.NewSigningKey(kc.ID, data, activeFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// newGRPCInterceptorConfig maps gRPC methods to the permissions their HTTP
+// routes require, plus any role requirements from the grpc config section.
+func newGRPCInterceptorConfig(config *Config) middleware.GRPCInterceptorConfig {
+	interceptorConfig := middleware.GRPCInterceptorConfig{
+		PublicMethods: []string{
+			authv1.AuthService_Login_FullMethodName,
+			authv1.AuthService_RefreshToken_FullMethodName,
+			authv1.AuthService_ValidateToken_FullMethodName,
+			// Probes and tooling run without credentials; reflection is
+			// only registered when its feature flag is on
+			"/grpc.health.v1.Health/",
+			"/grpc.reflection.v1.ServerReflection/",
+			"/grpc.reflection.v1alpha.ServerReflection/",
+		},
+		MethodRoles: make(map[string][]string),
+		MethodPermissions: map[string][]string{
+			productv1.ProductService_Create_FullMethodName: {authDomain.PermissionProductWrite},
+			productv1.ProductService_Get_FullMethodName:    {authDomain.PermissionProductRead},
+			productv1.ProductService_List_FullMethodName:   {authDomain.PermissionProductRead},
+			productv1.ProductService_Update_FullMethodName: {authDomain.PermissionProductWrite},
+			productv1.ProductService_Delete_FullMethodName: {authDomain.PermissionProductWrite},
+			userv1.UserService_Create_FullMethodName:       {authDomain.PermissionUserWrite},
+			userv1.UserService_Get_FullMethodName:          {authDomain.PermissionUserRead},
+			userv1.UserService_List_FullMethodName:         {authDomain.PermissionUserRead},
+			userv1.UserService_Update_FullMethodName:       {authDomain.PermissionUserWrite},
+			userv1.UserService_Delete_FullMethodName:       {authDomain.PermissionUserWrite},
+		},
+	}
+	if config == nil {
+		return interceptorConfig
+	}
+	for method, roles := range config.App.GRPC.MethodRoles {
+		interceptorConfig.MethodRoles[method] = roles
+	}
+	return interceptorConfig
+}
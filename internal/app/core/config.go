@@ -12,7 +12,26 @@ type ServerConfig struct {
 }
 
 type SQLConfig struct {
-	DBUrl string `yaml:"db_url"`
+	DBUrl    string           `yaml:"db_url"`   // primary; takes every write and transaction
+	Replicas []string         `yaml:"replicas"` // read replica URLs; empty reads from the primary
+	Pool     SQLPoolConfig    `yaml:"pool"`     // applied to the primary and each replica
+	Routing  SQLRoutingConfig `yaml:"routing"`
+}
+
+// SQLPoolConfig sizes a connection pool; zero values keep the defaults
+type SQLPoolConfig struct {
+	MaxOpenConns    int    `yaml:"max_open_conns"`
+	MaxIdleConns    int    `yaml:"max_idle_conns"`
+	ConnMaxLifetime string `yaml:"conn_max_lifetime"`  // e.g. "5m"
+	ConnMaxIdleTime string `yaml:"conn_max_idle_time"` // e.g. "1m"
+}
+
+// SQLRoutingConfig controls how reads are spread over the replicas
+type SQLRoutingConfig struct {
+	Policy               string `yaml:"policy"`                  // round_robin, random or least_conn
+	ReadYourWritesWindow string `yaml:"read_your_writes_window"` // e.g. "5s"; reads of a user who just wrote stay on the primary
+	HealthCheckInterval  string `yaml:"health_check_interval"`   // e.g. "5s"
+	FailureThreshold     int    `yaml:"failure_threshold"`       // failed pings in a row before a replica is ejected
 }
 
 type DatabaseConfig struct {
@@ -39,12 +58,52 @@ type JWTConfig struct {
 	Secret               string `yaml:"secret"`
 	AccessTokenDuration  string `yaml:"access_token_duration"`
 	RefreshTokenDuration string `yaml:"refresh_token_duration"`
+
+	SigningKeys            []JWTSigningKeyConfig `yaml:"signing_keys"`              // asymmetric keys; empty keeps HS256 with secret
+	KeyRotationGracePeriod string                `yaml:"key_rotation_grace_period"` // e.g. "24h"
+}
+
+type JWTSigningKeyConfig struct {
+	ID             string `yaml:"id"`               // published as the token "kid"
+	PrivateKeyFile string `yaml:"private_key_file"` // PEM encoded RSA, ECDSA or Ed25519 private key
+	ActiveFrom     string `yaml:"active_from"`      // RFC 3339; empty means active immediately
 }
 
 type AuthConfig struct {
 	Type          string `yaml:"type"`           // jwt, session, basic, none
 	SessionCookie string `yaml:"session_cookie"` // cookie name for session-based auth
 	BcryptCost    int    `yaml:"bcrypt_cost"`    // bcrypt cost for password hashing
+	DefaultRole   string `yaml:"default_role"`   // role assigned on registration
+
+	PasswordResetURL           string `yaml:"password_reset_url"`            // page that receives ?token=
+	PasswordResetTokenDuration string `yaml:"password_reset_token_duration"` // e.g. "1h"
+
+	EmailVerificationURL           string `yaml:"email_verification_url"`            // page that receives ?token=
+	EmailVerificationTokenDuration string `yaml:"email_verification_token_duration"` // e.g. "24h"
+	VerificationResendInterval     string `yaml:"verification_resend_interval"`      // e.g. "1m"
+
+	MFAIssuer            string `yaml:"mfa_issuer"`             // issuer label in authenticator apps
+	MFAChallengeDuration string `yaml:"mfa_challenge_duration"` // e.g. "5m"
+
+	OIDC OIDCConfig `yaml:"oidc"`
+}
+
+type OIDCConfig struct {
+	RedirectBaseURL string                        `yaml:"redirect_base_url"` // callback is <base>/<provider>/callback
+	StateDuration   string                        `yaml:"state_duration"`    // e.g. "10m"
+	Providers       map[string]OIDCProviderConfig `yaml:"providers"`         // keyed by the name used in routes
+}
+
+type OIDCProviderConfig struct {
+	Type         string   `yaml:"type"`   // oidc or github
+	Issuer       string   `yaml:"issuer"` // oidc only; discovery starts here
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes"`
+	// GitHub Enterprise Server endpoints; github.com is used when empty
+	AuthURL  string `yaml:"auth_url"`
+	TokenURL string `yaml:"token_url"`
+	APIURL   string `yaml:"api_url"`
 }
 
 type AsynqWorkerConfig struct {
@@ -140,8 +199,120 @@ type StorageConfig struct {
 	GCS     GCSStorageConfig   `yaml:"gcs"`
 }
 
+type MemoryEventBusConfig struct {
+	Async           bool   `yaml:"async"`
+	WorkersPerEvent int    `yaml:"workers_per_event"`
+	QueueSize       int    `yaml:"queue_size"`
+	Overflow        string `yaml:"overflow"`        // block, drop, spill
+	MaxRetries      int    `yaml:"max_retries"`     // 0 keeps the default policy
+	InitialBackoff  string `yaml:"initial_backoff"` // e.g. "100ms"
+	MaxBackoff      string `yaml:"max_backoff"`     // e.g. "5s"
+	DrainTimeout    string `yaml:"drain_timeout"`   // how long Close waits for queued events, e.g. "30s"
+}
+
+type RabbitMQEventBusConfig struct {
+	URL                string `yaml:"url"`
+	Exchange           string `yaml:"exchange"`
+	Queue              string `yaml:"queue"`
+	PrefetchCount      int    `yaml:"prefetch_count"`
+	DeadLetterExchange string `yaml:"dead_letter_exchange"` // default: <exchange>.dlx
+	MaxRetries         int    `yaml:"max_retries"`          // 0 keeps the default policy
+}
+
+type RedpandaEventBusConfig struct {
+	Brokers         []string `yaml:"brokers"`
+	Topic           string   `yaml:"topic"`
+	ConsumerGroup   string   `yaml:"consumer_group"`
+	DeadLetterTopic string   `yaml:"dead_letter_topic"` // default: <topic>-dlq
+	MaxRetries      int      `yaml:"max_retries"`       // 0 keeps the default policy
+}
+
+type EventBusConfig struct {
+	Memory   MemoryEventBusConfig   `yaml:"memory"`
+	RabbitMQ RabbitMQEventBusConfig `yaml:"rabbitmq"`
+	Redpanda RedpandaEventBusConfig `yaml:"redpanda"`
+}
+
+type OutboxConfig struct {
+	PollInterval   string `yaml:"poll_interval"` // e.g. "1s"
+	BatchSize      int    `yaml:"batch_size"`
+	Lease          string `yaml:"lease"` // how long a relay holds claimed messages, e.g. "30s"
+	MaxAttempts    int    `yaml:"max_attempts"`
+	InitialBackoff string `yaml:"initial_backoff"` // retry delay after the first failure, doubling after each, e.g. "1s"
+	MaxBackoff     string `yaml:"max_backoff"`     // e.g. "5m"
+}
+
+// GRPCConfig configures the gRPC transport. Method names are full gRPC names
+// such as "/product.v1.ProductService/Delete"; "/product.v1.ProductService/"
+// matches every method of the service.
+type GRPCConfig struct {
+	MethodRoles map[string][]string `yaml:"method_roles"` // roles of which the caller needs at least one
+
+	MaxRecvMsgSize      int                 `yaml:"max_recv_msg_size"` // bytes; gRPC defaults to 4 MiB
+	MaxSendMsgSize      int                 `yaml:"max_send_msg_size"` // bytes; unlimited by default
+	Keepalive           GRPCKeepaliveConfig `yaml:"keepalive"`
+	HealthCheckInterval string              `yaml:"health_check_interval"` // e.g. "10s"
+	ShutdownTimeout     string              `yaml:"shutdown_timeout"`      // how long in-flight calls may drain, e.g. "15s"
+}
+
+// GRPCKeepaliveConfig maps to keepalive.ServerParameters and
+// keepalive.EnforcementPolicy; durations such as "2h" or "20s", empty keeps
+// the gRPC default.
+type GRPCKeepaliveConfig struct {
+	Time                  string `yaml:"time"`    // ping a client after this long without activity
+	Timeout               string `yaml:"timeout"` // close the connection if a ping is not answered in time
+	MaxConnectionIdle     string `yaml:"max_connection_idle"`
+	MaxConnectionAge      string `yaml:"max_connection_age"`
+	MaxConnectionAgeGrace string `yaml:"max_connection_age_grace"`
+	MinTime               string `yaml:"min_time"` // minimum interval between client pings
+	PermitWithoutStream   bool   `yaml:"permit_without_stream"`
+}
+
+// HealthConfig tunes the /readyz checks; durations such as "2s"
+type HealthConfig struct {
+	Timeout  string `yaml:"timeout"`   // per-check deadline
+	CacheTTL string `yaml:"cache_ttl"` // reuse a readiness result for this long
+}
+
+type MetricsConfig struct {
+	Namespace string `yaml:"namespace"` // prefix of every metric name, default "app"
+}
+
+// TracingConfig describes the service and the OTLP collector; the exporter
+// itself is chosen by the tracing feature flag
+type TracingConfig struct {
+	ServiceName string            `yaml:"service_name"` // service.name of exported spans
+	Endpoint    string            `yaml:"endpoint"`     // OTLP gRPC collector host:port, e.g. "localhost:4317"
+	Insecure    bool              `yaml:"insecure"`     // connect to the collector without TLS
+	Headers     map[string]string `yaml:"headers"`      // sent with every export, e.g. collector API keys
+	SampleRatio float64           `yaml:"sample_ratio"` // fraction of new traces recorded; 0 records all
+}
+
+// LoggingConfig selects the logging backend and what reaches it. Module
+// names are dotted, e.g. "worker.redpanda"; a module without its own level
+// uses its parent's, then the global level.
+type LoggingConfig struct {
+	Backend    string                `yaml:"backend"` // slog, zap, logrus
+	Format     string                `yaml:"format"`  // json, text
+	Level      string                `yaml:"level"`   // debug, info, warn, error
+	Modules    map[string]string     `yaml:"modules"` // per-module level overrides
+	Outputs    []string              `yaml:"outputs"` // stdout, stderr or file paths; default stdout
+	Sampling   LoggingSamplingConfig `yaml:"sampling"`
+	RedactKeys []string              `yaml:"redact_keys"` // masked in addition to passwords, tokens and secrets
+	ShowEmails bool                  `yaml:"show_emails"` // log email addresses unmasked
+}
+
+// LoggingSamplingConfig caps repeated debug, info and warn entries; errors
+// are always logged
+type LoggingSamplingConfig struct {
+	Tick       string `yaml:"tick"`       // window, e.g. "1s"; empty disables sampling
+	Initial    int    `yaml:"initial"`    // entries with the same message logged per tick
+	Thereafter int    `yaml:"thereafter"` // then every Nth; 0 drops the rest of the tick
+}
+
 type AppConfig struct {
 	Server   ServerConfig   `yaml:"server"`
+	GRPC     GRPCConfig     `yaml:"grpc"`
 	Database DatabaseConfig `yaml:"database"`
 	Redis    RedisConfig    `yaml:"redis"`
 	JWT      JWTConfig      `yaml:"jwt"`
@@ -149,6 +320,12 @@ type AppConfig struct {
 	Worker   WorkerConfig   `yaml:"worker"`
 	Email    EmailConfig    `yaml:"email"`
 	Storage  StorageConfig  `yaml:"storage"`
+	EventBus EventBusConfig `yaml:"event_bus"`
+	Outbox   OutboxConfig   `yaml:"outbox"`
+	Health   HealthConfig   `yaml:"health"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Logging  LoggingConfig  `yaml:"logging"`
 }
 
 type Config struct {
@@ -3,14 +3,21 @@ package core
 import (
 	// Shared packages
 	"context"
+	"fmt"
 
 	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/health"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/storage"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+
 	// Worker infrastructure
 	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
 	asynqworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker/asynq"
@@ -39,13 +46,16 @@ import (
 
 	// User module
 	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	handlerGRPCUser "github.com/kamil5b/go-pste-monolith/internal/modules/user/handler/grpc"
 	handlerV1User "github.com/kamil5b/go-pste-monolith/internal/modules/user/handler/v1"
+	repoMongoUser "github.com/kamil5b/go-pste-monolith/internal/modules/user/repository/mongo"
 	repoSQLUser "github.com/kamil5b/go-pste-monolith/internal/modules/user/repository/sql"
 	serviceV1User "github.com/kamil5b/go-pste-monolith/internal/modules/user/service/v1"
 
 	// Auth module
 	authACL "github.com/kamil5b/go-pste-monolith/internal/modules/auth/acl"
 	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	handlerGRPCAuth "github.com/kamil5b/go-pste-monolith/internal/modules/auth/handler/grpc"
 	handlerNoopAuth "github.com/kamil5b/go-pste-monolith/internal/modules/auth/handler/noop"
 	handlerV1Auth "github.com/kamil5b/go-pste-monolith/internal/modules/auth/handler/v1"
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/middleware"
@@ -56,10 +66,14 @@ import (
 	serviceV1Auth "github.com/kamil5b/go-pste-monolith/internal/modules/auth/service/v1"
 
 	// Unit of Work
-	"github.com/kamil5b/go-pste-monolith/internal/modules/unitofwork"
+
+	// Event bus infrastructure
+	rabbitmqeventbus "github.com/kamil5b/go-pste-monolith/internal/infrastructure/eventbus/rabbitmq"
+	redpandaeventbus "github.com/kamil5b/go-pste-monolith/internal/infrastructure/eventbus/redpanda"
 
 	// Infrastructure
 	infracache "github.com/kamil5b/go-pste-monolith/internal/infrastructure/cache"
+	infraoutbox "github.com/kamil5b/go-pste-monolith/internal/infrastructure/outbox"
 
 	"github.com/jmoiron/sqlx"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -70,7 +84,9 @@ type Container struct {
 	Cache cache.Cache
 
 	// Event Bus (shared)
-	EventBus events.EventBus
+	EventBus      events.EventBus
+	EventRegistry *events.Registry
+	OutboxRelays  []*events.OutboxRelay // one per database holding outbox rows; empty when the outbox is disabled
 
 	// Email Service (shared)
 	EmailClient email.EmailService
@@ -78,6 +94,21 @@ type Container struct {
 	// Storage Service (shared)
 	StorageService storage.StorageService
 
+	// Prometheus metrics (shared); nil when the metrics feature flag is off
+	Metrics *metrics.Metrics
+
+	// Tracing reports whether the tracing feature flag is on; transports then
+	// open a span per request
+	Tracing bool
+
+	// SQLRouter spreads repository reads over the read replicas; nil without
+	// a SQL database. Its Run loop ejects replicas that stop answering.
+	SQLRouter *dbrouter.Router
+
+	// Health checks of the shared dependencies, served over gRPC and HTTP
+	Health       *health.Registry
+	HealthProbes *health.Probes
+
 	// Product module
 	ProductRepository  productDomain.Repository
 	ProductService     productDomain.Service
@@ -85,15 +116,18 @@ type Container struct {
 	ProductGRPCHandler *handlerGRPC.GRPCHandler
 
 	// User module
-	UserRepository userDomain.Repository
-	UserService    userDomain.Service
-	UserHandler    userDomain.Handler
+	UserRepository  userDomain.Repository
+	UserService     userDomain.Service
+	UserHandler     userDomain.Handler
+	UserGRPCHandler *handlerGRPCUser.GRPCHandler // nil when the user service is disabled
 
 	// Auth module
-	AuthRepository authDomain.Repository
-	AuthService    authDomain.Service
-	AuthHandler    authDomain.Handler
-	AuthMiddleware *middleware.AuthMiddleware
+	AuthRepository  authDomain.Repository
+	AuthService     authDomain.Service
+	AuthHandler     authDomain.Handler
+	AuthMiddleware  *middleware.AuthMiddleware
+	AuthGRPC        *middleware.GRPCInterceptor
+	AuthGRPCHandler *handlerGRPCAuth.GRPCHandler
 
 	// Worker (infrastructure)
 	WorkerClient sharedworker.Client
@@ -104,6 +138,7 @@ func NewContainer(
 	featureFlag FeatureFlag,
 	config *Config,
 	db *sqlx.DB,
+	replicas []*sqlx.DB,
 	mongoClient *mongo.Client,
 ) *Container {
 	var (
@@ -115,6 +150,7 @@ func NewContainer(
 		userRepository     userDomain.Repository
 		userService        userDomain.Service
 		userHandler        userDomain.Handler
+		userGRPCHandler    *handlerGRPCUser.GRPCHandler
 		authRepository     authDomain.Repository
 		authService        authDomain.Service
 		authHandler        authDomain.Handler
@@ -122,6 +158,28 @@ func NewContainer(
 		unitOfWork         uow.UnitOfWork
 	)
 
+	// Initialize metrics first so the components below can record into them
+	var appMetrics *metrics.Metrics
+	if featureFlag.Metrics.Enabled {
+		namespace := ""
+		if config != nil {
+			namespace = config.App.Metrics.Namespace
+		}
+		appMetrics = metrics.New(namespace)
+		if db != nil {
+			_ = appMetrics.RegisterDB("primary", db.DB)
+		}
+		for i, replica := range replicas {
+			_ = appMetrics.RegisterDB(fmt.Sprintf("replica_%d", i), replica.DB)
+		}
+	}
+
+	// Reads outside transactions may go to a replica; writes use db
+	var sqlRouter *dbrouter.Router
+	if db != nil {
+		sqlRouter = dbrouter.New(db, replicas, newRouterConfig(config))
+	}
+
 	// Initialize cache (shared across all modules)
 	switch featureFlag.Cache {
 	case "redis":
@@ -150,8 +208,145 @@ func NewContainer(
 		cacheInstance = cache.NewInMemoryCache()
 	}
 
+	// Initialize worker client and server (before the event bus, which may spill to it)
+	var workerClient sharedworker.Client
+	var workerServer sharedworker.Server
+
+	if featureFlag.Worker.Enabled && featureFlag.Worker.Backend != "disable" && config != nil {
+		// Initialize worker client
+		switch featureFlag.Worker.Backend {
+		case "asynq":
+			workerClient = asynqworker.NewAsynqClient(config.App.Worker.Asynq.RedisURL)
+		case "rabbitmq":
+			if client, err := rabbitmqworker.NewRabbitMQClient(
+				config.App.Worker.RabbitMQ.URL,
+				config.App.Worker.RabbitMQ.Exchange,
+				config.App.Worker.RabbitMQ.Queue,
+			); err == nil {
+				workerClient = client
+			} else {
+				workerClient = infraworker.NewNoOpClient()
+			}
+		case "redpanda":
+			workerClient = redpandaworker.NewRedpandaClient(
+				config.App.Worker.Redpanda.Brokers,
+				config.App.Worker.Redpanda.Topic,
+			)
+		default:
+			workerClient = infraworker.NewNoOpClient()
+		}
+
+		// Initialize worker server
+		switch featureFlag.Worker.Backend {
+		case "asynq":
+			server := asynqworker.NewAsynqServer(
+				config.App.Worker.Asynq.RedisURL,
+				config.App.Worker.Asynq.Concurrency,
+			)
+			server.SetMetrics(appMetrics)
+			workerServer = server
+		case "rabbitmq":
+			if server, err := rabbitmqworker.NewRabbitMQServer(
+				config.App.Worker.RabbitMQ.URL,
+				config.App.Worker.RabbitMQ.Exchange,
+				config.App.Worker.RabbitMQ.Queue,
+				config.App.Worker.RabbitMQ.PrefetchCount,
+			); err == nil {
+				server.SetMetrics(appMetrics)
+				workerServer = server
+			} else {
+				workerServer = infraworker.NewNoOpServer()
+			}
+		case "redpanda":
+			server := redpandaworker.NewRedpandaServer(
+				config.App.Worker.Redpanda.Brokers,
+				config.App.Worker.Redpanda.Topic,
+				config.App.Worker.Redpanda.ConsumerGroup,
+				config.App.Worker.Redpanda.WorkerCount,
+			)
+			server.SetMetrics(appMetrics)
+			workerServer = server
+		default:
+			workerServer = infraworker.NewNoOpServer()
+		}
+	} else {
+		// Use no-op implementations when workers are disabled
+		workerClient = infraworker.NewNoOpClient()
+		workerServer = infraworker.NewNoOpServer()
+	}
+
 	// Initialize event bus (shared across all modules)
-	eventBus := events.NewInMemoryEventBus()
+	var eventBus events.EventBus
+	eventRegistry := newEventRegistry()
+	switch featureFlag.EventBus {
+	case "rabbitmq":
+		if config != nil {
+			if bus, err := rabbitmqeventbus.NewRabbitMQEventBus(rabbitmqeventbus.RabbitMQEventBusConfig{
+				URL:                config.App.EventBus.RabbitMQ.URL,
+				Exchange:           config.App.EventBus.RabbitMQ.Exchange,
+				Queue:              config.App.EventBus.RabbitMQ.Queue,
+				PrefetchCount:      config.App.EventBus.RabbitMQ.PrefetchCount,
+				DeadLetterExchange: config.App.EventBus.RabbitMQ.DeadLetterExchange,
+				MaxRetries:         config.App.EventBus.RabbitMQ.MaxRetries,
+			}, eventRegistry); err == nil {
+				eventBus = bus
+			} else {
+				// Fallback to in-memory event bus if RabbitMQ connection fails;
+				// events then stay in this process and are lost on restart
+				logger.WithField("error", err).Error("Failed to connect to RabbitMQ, falling back to the in-memory event bus")
+				eventBus = events.NewInMemoryEventBus()
+			}
+		} else {
+			eventBus = events.NewInMemoryEventBus()
+		}
+	case "redpanda":
+		if config != nil {
+			eventBus = redpandaeventbus.NewRedpandaEventBus(redpandaeventbus.RedpandaEventBusConfig{
+				Brokers:         config.App.EventBus.Redpanda.Brokers,
+				Topic:           config.App.EventBus.Redpanda.Topic,
+				ConsumerGroup:   config.App.EventBus.Redpanda.ConsumerGroup,
+				DeadLetterTopic: config.App.EventBus.Redpanda.DeadLetterTopic,
+				MaxRetries:      config.App.EventBus.Redpanda.MaxRetries,
+			}, eventRegistry)
+		} else {
+			eventBus = events.NewInMemoryEventBus()
+		}
+	case "memory":
+		fallthrough
+	default:
+		if config != nil && config.App.EventBus.Memory.Async {
+			eventBus = events.NewAsyncInMemoryEventBus(newAsyncEventBusConfig(config, workerClient))
+		} else {
+			eventBus = events.NewInMemoryEventBus()
+		}
+	}
+
+	// Time publishes and handlers on the underlying bus, so relayed events count too
+	eventBus = metrics.InstrumentEventBus(eventBus, appMetrics)
+	if featureFlag.Tracing.Enabled {
+		eventBus = events.NewTracingEventBus(eventBus)
+	}
+
+	// Transactional outbox: events published inside a unit of work are stored
+	// with the module data and relayed to the bus by the worker process. A
+	// unit of work writes to the outbox of its own database, so repositories
+	// split between SQL and MongoDB get one store and relay per database.
+	var outboxRelays []*events.OutboxRelay
+	if featureFlag.Outbox.Enabled {
+		var outboxStores []events.OutboxStore
+		if featureFlag.Repository.UsesSQL() && db != nil {
+			outboxStores = append(outboxStores, infraoutbox.NewSQLStore(db))
+		}
+		if featureFlag.Repository.UsesMongo() && mongoClient != nil && config != nil {
+			outboxStores = append(outboxStores, infraoutbox.NewMongoStore(mongoClient, config.App.Database.Mongo.MongoDB))
+		}
+		relayBus, relayConfig := eventBus, newOutboxRelayConfig(config)
+		for _, store := range outboxStores {
+			outboxRelays = append(outboxRelays, events.NewOutboxRelay(store, relayBus, eventRegistry, relayConfig))
+			// A store without a transaction in ctx passes the event on to the next
+			eventBus = events.NewOutboxEventBus(eventBus, store)
+		}
+	}
 
 	// Initialize email service (before modules that depend on it)
 	var emailService email.EmailService
@@ -176,6 +371,9 @@ func NewContainer(
 		default:
 			emailService = email.NewNoOpEmailService()
 		}
+		if featureFlag.Tracing.Enabled {
+			emailService = tracing.InstrumentEmail(emailService, featureFlag.Email.Provider)
+		}
 	} else {
 		// Use no-op implementation when email is disabled or provider is noop
 		emailService = email.NewNoOpEmailService()
@@ -185,19 +383,21 @@ func NewContainer(
 	switch featureFlag.Repository.Product {
 	case "mongo":
 		productRepository = repoMongo.NewMongoRepository(mongoClient, config.App.Database.Mongo.MongoDB)
-	case "postgres":
-		productRepository = repoSQL.NewSQLRepository(db)
+	case "postgres", "mysql", "sqlite":
+		repo := repoSQL.NewSQLRepository(db)
+		repo.SetRouter(sqlRouter)
+		productRepository = repo
 	default:
 		// productRepository = repoUnimplemented.NewUnimplementedRepository()
 	}
 
-	// uow
-	unitOfWork = unitofwork.NewDefaultUnitOfWork(db, mongoClient)
+	// uow: open transactions only on the database the product repository uses
+	unitOfWork = newUnitOfWork(db, mongoClient, featureFlag.Repository.Product)
 
 	// service
 	switch featureFlag.Service.Product {
 	case "v1":
-		productService = serviceV1.NewServiceV1(productRepository, unitOfWork, eventBus, cacheInstance)
+		productService = serviceV1.NewServiceV1(productRepository, unitOfWork, eventBus, metrics.InstrumentCache(cacheInstance, "product", appMetrics))
 	default:
 		productService = serviceUnimplemented.NewUnimplementedService()
 	}
@@ -215,15 +415,19 @@ func NewContainer(
 
 	// user repo
 	switch featureFlag.Repository.User {
-	case "postgres":
-		userRepository = repoSQLUser.NewSQLRepository(db)
+	case "mongo":
+		userRepository = repoMongoUser.NewMongoRepository(mongoClient, config.App.Database.Mongo.MongoDB)
+	case "postgres", "mysql", "sqlite":
+		repo := repoSQLUser.NewSQLRepository(db)
+		repo.SetRouter(sqlRouter)
+		userRepository = repo
 	default:
 	}
 
 	// user service
 	switch featureFlag.Service.User {
 	case "v1":
-		userService = serviceV1User.NewServiceV1(userRepository, eventBus, emailService, cacheInstance)
+		userService = serviceV1User.NewServiceV1(userRepository, eventBus, emailService, metrics.InstrumentCache(cacheInstance, "user", appMetrics))
 	default:
 	}
 
@@ -234,12 +438,19 @@ func NewContainer(
 	default:
 	}
 
+	// user gRPC handler
+	if userService != nil {
+		userGRPCHandler = handlerGRPCUser.NewGRPCHandler(userService)
+	}
+
 	// auth repo
 	switch featureFlag.Repository.Authentication {
 	case "mongo":
 		authRepository = repoMongoAuth.NewMongoRepository(mongoClient, "appdb")
-	case "postgres":
-		authRepository = repoSQLAuth.NewSQLRepository(db)
+	case "postgres", "mysql", "sqlite":
+		repo := repoSQLAuth.NewSQLRepository(db)
+		repo.SetRouter(sqlRouter)
+		authRepository = repo
 	default:
 		authRepository = repoNoopAuth.NewNoopRepository()
 	}
@@ -247,13 +458,31 @@ func NewContainer(
 	// auth service
 	switch featureFlag.Service.Authentication {
 	case "v1":
-		authConfig := serviceV1Auth.DefaultAuthConfig()
-		if config != nil && config.App.JWT.Secret != "" {
-			authConfig.JWTSecret = config.App.JWT.Secret
+		authConfig := newAuthConfig(config)
+		authConfig.RequireEmailVerification = featureFlag.Auth.EmailVerification
+		if err := checkJWTSecret(authConfig); err != nil {
+			logger.WithField("error", err).Error("Refusing to start the auth service")
+			return nil
+		}
+		signingKeys, err := loadSigningKeys(config)
+		if err != nil {
+			logger.WithField("error", err).Error("Failed to load JWT signing keys")
+			return nil
 		}
-		// Create ACL adapter for user creation - auth module doesn't directly depend on user module
+		authConfig.SigningKeys = signingKeys
+		identityProviders, err := newIdentityProviders(config)
+		if err != nil {
+			logger.WithField("error", err).Error("Failed to configure identity providers")
+			return nil
+		}
+		authConfig.IdentityProviders = identityProviders
+		// Create ACL adapters - auth module doesn't directly depend on user module
 		userCreator := authACL.NewUserCreatorAdapter(userRepository)
-		authService = serviceV1Auth.NewServiceV1(authRepository, userCreator, authConfig)
+		resetNotifier := authACL.NewPasswordResetNotifierAdapter(workerClient)
+		// Registration creates the user through userCreator, so its
+		// transaction spans the user repository's database as well
+		authUnitOfWork := newUnitOfWork(db, mongoClient, featureFlag.Repository.Authentication, featureFlag.Repository.User)
+		authService = serviceV1Auth.NewServiceV1(authRepository, authUnitOfWork, userCreator, resetNotifier, eventBus, emailService, cacheInstance, authConfig)
 	default:
 		authService = serviceNoopAuth.NewNoopService()
 	}
@@ -266,6 +495,9 @@ func NewContainer(
 		authHandler = handlerNoopAuth.NewNoopHandler()
 	}
 
+	// auth gRPC handler
+	authGRPCHandler := handlerGRPCAuth.NewGRPCHandler(authService)
+
 	// auth middleware
 	middlewareConfig := middleware.DefaultMiddlewareConfig()
 	if config != nil && config.App.Auth.Type != "" {
@@ -275,68 +507,7 @@ func NewContainer(
 		middlewareConfig.SessionCookie = config.App.Auth.SessionCookie
 	}
 	authMiddleware = middleware.NewAuthMiddleware(authService, middlewareConfig)
-
-	// Initialize worker client and server
-	var workerClient sharedworker.Client
-	var workerServer sharedworker.Server
-
-	if featureFlag.Worker.Enabled && featureFlag.Worker.Backend != "disable" && config != nil {
-		// Initialize worker client
-		switch featureFlag.Worker.Backend {
-		case "asynq":
-			workerClient = asynqworker.NewAsynqClient(config.App.Worker.Asynq.RedisURL)
-		case "rabbitmq":
-			if client, err := rabbitmqworker.NewRabbitMQClient(
-				config.App.Worker.RabbitMQ.URL,
-				config.App.Worker.RabbitMQ.Exchange,
-				config.App.Worker.RabbitMQ.Queue,
-			); err == nil {
-				workerClient = client
-			} else {
-				workerClient = infraworker.NewNoOpClient()
-			}
-		case "redpanda":
-			workerClient = redpandaworker.NewRedpandaClient(
-				config.App.Worker.Redpanda.Brokers,
-				config.App.Worker.Redpanda.Topic,
-			)
-		default:
-			workerClient = infraworker.NewNoOpClient()
-		}
-
-		// Initialize worker server
-		switch featureFlag.Worker.Backend {
-		case "asynq":
-			workerServer = asynqworker.NewAsynqServer(
-				config.App.Worker.Asynq.RedisURL,
-				config.App.Worker.Asynq.Concurrency,
-			)
-		case "rabbitmq":
-			if server, err := rabbitmqworker.NewRabbitMQServer(
-				config.App.Worker.RabbitMQ.URL,
-				config.App.Worker.RabbitMQ.Exchange,
-				config.App.Worker.RabbitMQ.Queue,
-				config.App.Worker.RabbitMQ.PrefetchCount,
-			); err == nil {
-				workerServer = server
-			} else {
-				workerServer = infraworker.NewNoOpServer()
-			}
-		case "redpanda":
-			workerServer = redpandaworker.NewRedpandaServer(
-				config.App.Worker.Redpanda.Brokers,
-				config.App.Worker.Redpanda.Topic,
-				config.App.Worker.Redpanda.ConsumerGroup,
-				config.App.Worker.Redpanda.WorkerCount,
-			)
-		default:
-			workerServer = infraworker.NewNoOpServer()
-		}
-	} else {
-		// Use no-op implementations when workers are disabled
-		workerClient = infraworker.NewNoOpClient()
-		workerServer = infraworker.NewNoOpServer()
-	}
+	authGRPC := middleware.NewGRPCInterceptor(authService, newGRPCInterceptorConfig(config))
 
 	// Initialize storage service (before modules that depend on it)
 	var storageService storage.StorageService
@@ -388,16 +559,40 @@ func NewContainer(
 		default:
 			storageService = noop.NewNoOpStorageService()
 		}
+		if featureFlag.Tracing.Enabled {
+			storageService = tracing.InstrumentStorage(storageService, featureFlag.Storage.Backend)
+		}
 	} else {
 		// Use no-op implementation when storage is disabled
 		storageService = noop.NewNoOpStorageService()
 	}
 
+	healthRegistry := health.NewRegistry()
+	healthRegistry.Register("cache", cacheInstance)
+	healthRegistry.Register("storage", storageService)
+	healthRegistry.Register("email", emailService)
+	healthRegistry.Register("worker", workerClient)
+	if db != nil {
+		healthRegistry.Register("database", health.CheckerFunc(db.PingContext))
+	}
+	if mongoClient != nil {
+		healthRegistry.Register("mongo", health.CheckerFunc(func(ctx context.Context) error {
+			return mongoClient.Ping(ctx, nil)
+		}))
+	}
+
 	return &Container{
 		Cache:              cacheInstance,
 		EventBus:           eventBus,
+		EventRegistry:      eventRegistry,
+		OutboxRelays:       outboxRelays,
 		EmailClient:        emailService,
 		StorageService:     storageService,
+		Metrics:            appMetrics,
+		Tracing:            featureFlag.Tracing.Enabled,
+		SQLRouter:          sqlRouter,
+		Health:             healthRegistry,
+		HealthProbes:       health.NewProbes(healthRegistry, newProbeConfig(config)),
 		ProductRepository:  productRepository,
 		ProductService:     productService,
 		ProductHandler:     productHandler,
@@ -405,10 +600,13 @@ func NewContainer(
 		UserRepository:     userRepository,
 		UserService:        userService,
 		UserHandler:        userHandler,
+		UserGRPCHandler:    userGRPCHandler,
 		AuthRepository:     authRepository,
 		AuthService:        authService,
 		AuthHandler:        authHandler,
 		AuthMiddleware:     authMiddleware,
+		AuthGRPC:           authGRPC,
+		AuthGRPCHandler:    authGRPCHandler,
 		WorkerClient:       workerClient,
 		WorkerServer:       workerServer,
 	}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/unitofwork"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
+
+	"github.com/jmoiron/sqlx"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// newRouterConfig converts the YAML read routing settings; empty or invalid
+// values keep the router defaults.
+func newRouterConfig(config *Config) dbrouter.Config {
+	routerConfig := dbrouter.DefaultConfig()
+	if config == nil {
+		return routerConfig
+	}
+	routing := config.App.Database.SQL.Routing
+	if routing.Policy != "" {
+		routerConfig.Policy = dbrouter.Policy(routing.Policy)
+	}
+	if d, err := time.ParseDuration(routing.ReadYourWritesWindow); err == nil {
+		routerConfig.ReadYourWritesWindow = d
+	}
+	if d, err := time.ParseDuration(routing.HealthCheckInterval); err == nil {
+		routerConfig.HealthCheckInterval = d
+	}
+	if routing.FailureThreshold > 0 {
+		routerConfig.FailureThreshold = routing.FailureThreshold
+	}
+	return routerConfig
+}
+
+// newUnitOfWork opens transactions only on the databases the given repository
+// backends use, so a service never waits on a database it does not write to.
+func newUnitOfWork(db *sqlx.DB, mongoClient *mongo.Client, backends ...string) uow.UnitOfWork {
+	var sqlDB *sqlx.DB
+	var mongoDB *mongo.Client
+	for _, backend := range backends {
+		switch backend {
+		case "mongo":
+			mongoDB = mongoClient
+		case "postgres", "mysql", "sqlite":
+			sqlDB = db
+		}
+	}
+	return unitofwork.NewDefaultUnitOfWork(sqlDB, mongoDB)
+}
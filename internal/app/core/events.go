@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	productDomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
+	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+)
+
+// newEventRegistry registers every module event so serialized events
+// (outbox, brokers) are delivered to subscribers in their typed form.
+func newEventRegistry() *events.Registry {
+	registry := events.NewRegistry()
+
+	// Product module
+	events.RegisterType[productDomain.ProductCreatedEvent](registry)
+	events.RegisterType[productDomain.ProductUpdatedEvent](registry)
+	events.RegisterType[productDomain.ProductDeletedEvent](registry)
+
+	// User module
+	events.RegisterType[userDomain.UserCreatedEvent](registry)
+	events.RegisterType[userDomain.UserUpdatedEvent](registry)
+	events.RegisterType[userDomain.UserDeletedEvent](registry)
+
+	// Auth module
+	events.RegisterType[authDomain.UserLoggedInEvent](registry)
+	events.RegisterType[authDomain.UserRegisteredEvent](registry)
+	events.RegisterType[authDomain.UserLoggedOutEvent](registry)
+	events.RegisterType[authDomain.PasswordChangedEvent](registry)
+	events.RegisterType[authDomain.SessionRevokedEvent](registry)
+
+	return registry
+}
+
+// newOutboxRelayConfig converts the YAML outbox settings, keeping defaults
+// for anything left empty or invalid.
+func newOutboxRelayConfig(config *Config) events.OutboxRelayConfig {
+	relayConfig := events.DefaultOutboxRelayConfig()
+	if config == nil {
+		return relayConfig
+	}
+	if d, err := time.ParseDuration(config.App.Outbox.PollInterval); err == nil {
+		relayConfig.PollInterval = d
+	}
+	if d, err := time.ParseDuration(config.App.Outbox.Lease); err == nil {
+		relayConfig.Lease = d
+	}
+	if config.App.Outbox.BatchSize > 0 {
+		relayConfig.BatchSize = config.App.Outbox.BatchSize
+	}
+	if config.App.Outbox.MaxAttempts > 0 {
+		relayConfig.MaxAttempts = config.App.Outbox.MaxAttempts
+	}
+	if d, err := time.ParseDuration(config.App.Outbox.InitialBackoff); err == nil {
+		relayConfig.InitialBackoff = d
+	}
+	if d, err := time.ParseDuration(config.App.Outbox.MaxBackoff); err == nil {
+		relayConfig.MaxBackoff = d
+	}
+	return relayConfig
+}
+
+// newAsyncEventBusConfig converts the YAML in-memory bus settings. Overflowing
+// events spill to workerClient when the overflow policy is "spill".
+func newAsyncEventBusConfig(config *Config, workerClient sharedworker.Client) events.AsyncConfig {
+	memory := config.App.EventBus.Memory
+
+	asyncConfig := events.DefaultAsyncConfig()
+	if memory.WorkersPerEvent > 0 {
+		asyncConfig.WorkersPerEvent = memory.WorkersPerEvent
+	}
+	if memory.QueueSize > 0 {
+		asyncConfig.QueueSize = memory.QueueSize
+	}
+	switch events.OverflowPolicy(memory.Overflow) {
+	case events.OverflowDrop, events.OverflowSpill:
+		asyncConfig.Overflow = events.OverflowPolicy(memory.Overflow)
+	}
+	asyncConfig.SpillClient = workerClient
+	if d, err := time.ParseDuration(memory.DrainTimeout); err == nil {
+		asyncConfig.DrainTimeout = d
+	}
+
+	retryPolicy := infraworker.DefaultRetryPolicy()
+	if memory.MaxRetries > 0 {
+		retryPolicy.MaxRetries = memory.MaxRetries
+	}
+	if d, err := time.ParseDuration(memory.InitialBackoff); err == nil {
+		retryPolicy.InitialBackoff = d
+	}
+	if d, err := time.ParseDuration(memory.MaxBackoff); err == nil {
+		retryPolicy.MaxBackoff = d
+	}
+	asyncConfig.RetryPolicy = retryPolicy
+
+	asyncConfig.ErrorHandler = func(ctx context.Context, event events.Event, err error) {
+		logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"event": event.EventName(),
+			"error": err,
+		}).Error("Event handlers failed")
+	}
+
+	return asyncConfig
+}
@@ -1,8 +1,11 @@
 package core
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,9 +22,45 @@ type ServiceFeatureFlag struct {
 }
 
 type RepositoryFeatureFlag struct {
-	Authentication string `yaml:"authentication"` // disable, postgres, mongo
-	Product        string `yaml:"product"`        // disable, postgres, mongo
-	User           string `yaml:"user"`           // disable, postgres, mongo
+	Authentication string `yaml:"authentication"` // disable, postgres, mysql, sqlite, mongo
+	Product        string `yaml:"product"`        // disable, postgres, mysql, sqlite, mongo
+	User           string `yaml:"user"`           // disable, postgres, mysql, sqlite, mongo
+}
+
+// UsesSQL reports whether any repository is backed by the SQL database
+func (r RepositoryFeatureFlag) UsesSQL() bool {
+	for _, flag := range []string{r.Authentication, r.Product, r.User} {
+		if _, err := sqldialect.Parse(flag); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesMongo reports whether any repository is backed by MongoDB
+func (r RepositoryFeatureFlag) UsesMongo() bool {
+	return r.Authentication == "mongo" || r.Product == "mongo" || r.User == "mongo"
+}
+
+// SQLDialect returns the dialect of the SQL database the repositories share.
+// Repositories on different SQL dialects are rejected; without SQL
+// repositories the dialect is Postgres.
+func (r RepositoryFeatureFlag) SQLDialect() (sqldialect.Dialect, error) {
+	dialect := sqldialect.Dialect("")
+	for _, flag := range []string{r.Authentication, r.Product, r.User} {
+		d, err := sqldialect.Parse(flag)
+		if err != nil {
+			continue
+		}
+		if dialect != "" && d != dialect {
+			return "", fmt.Errorf("repositories use different SQL dialects: %s and %s", dialect, d)
+		}
+		dialect = d
+	}
+	if dialect == "" {
+		return sqldialect.Postgres, nil
+	}
+	return dialect, nil
 }
 
 type WorkerTaskFeatureFlag struct {
@@ -60,9 +99,31 @@ type StorageFeatureFlag struct {
 	GCS     StorageGCSFeatureFlag `yaml:"gcs"`
 }
 
+type OutboxFeatureFlag struct {
+	Enabled bool `yaml:"enabled"` // route events published inside a unit of work through the transactional outbox
+}
+
+type AuthFeatureFlag struct {
+	EmailVerification bool `yaml:"email_verification"` // new accounts must verify their email before logging in
+}
+
+type GRPCFeatureFlag struct {
+	Reflection bool `yaml:"reflection"` // serve the gRPC reflection API for tools such as grpcurl
+}
+
+type MetricsFeatureFlag struct {
+	Enabled bool `yaml:"enabled"` // record Prometheus metrics and serve them on /metrics
+}
+
+type TracingFeatureFlag struct {
+	Enabled  bool   `yaml:"enabled"`  // record OpenTelemetry spans and propagate trace context
+	Exporter string `yaml:"exporter"` // otlp, stdout
+}
+
 type FeatureFlag struct {
 	HTTPHandler string `yaml:"http_handler"` // echo, gin
 	Cache       string `yaml:"cache"`        // redis, memory, disable
+	EventBus    string `yaml:"event_bus"`    // memory, rabbitmq, redpanda
 
 	Handler    HandlerFeatureFlag    `yaml:"handler"`
 	Service    ServiceFeatureFlag    `yaml:"service"`
@@ -70,6 +131,11 @@ type FeatureFlag struct {
 	Worker     WorkerFeatureFlag     `yaml:"worker"`
 	Email      EmailFeatureFlag      `yaml:"email"`
 	Storage    StorageFeatureFlag    `yaml:"storage"`
+	Outbox     OutboxFeatureFlag     `yaml:"outbox"`
+	Auth       AuthFeatureFlag       `yaml:"auth"`
+	GRPC       GRPCFeatureFlag       `yaml:"grpc"`
+	Metrics    MetricsFeatureFlag    `yaml:"metrics"`
+	Tracing    TracingFeatureFlag    `yaml:"tracing"`
 }
 
 // LoadFeatureFlags loads feature flag configuration from a YAML file.
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/health"
+)
+
+// newProbeConfig converts the YAML health settings; empty or invalid values
+// keep the probe defaults.
+func newProbeConfig(config *Config) health.ProbeConfig {
+	var probeConfig health.ProbeConfig
+	if config == nil {
+		return probeConfig
+	}
+	if d, err := time.ParseDuration(config.App.Health.Timeout); err == nil {
+		probeConfig.Timeout = d
+	}
+	if d, err := time.ParseDuration(config.App.Health.CacheTTL); err == nil {
+		probeConfig.CacheTTL = d
+	}
+	return probeConfig
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	authGRPC "github.com/kamil5b/go-pste-monolith/internal/modules/auth/handler/grpc"
+	productGRPC "github.com/kamil5b/go-pste-monolith/internal/modules/product/handler/grpc"
+	userGRPC "github.com/kamil5b/go-pste-monolith/internal/modules/user/handler/grpc"
+	grpctransport "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+var errUnhealthy = errors.New("one or more dependencies are unhealthy")
+
+// NewGRPCServer builds the gRPC server with request ID, tracing, metrics,
+// error mapping and authentication interceptors and registers the module
+// services. Interceptors run in order: errors from the auth interceptor are
+// already gRPC statuses and pass through, and tracing and metrics see the
+// final status code.
+func NewGRPCServer(c *core.Container, config core.GRPCConfig, featureFlag core.GRPCFeatureFlag) *grpctransport.Server {
+	unary := []grpc.UnaryServerInterceptor{grpctransport.UnaryRequestIDInterceptor()}
+	stream := []grpc.StreamServerInterceptor{grpctransport.StreamRequestIDInterceptor()}
+	if c.Tracing {
+		unary = append(unary, grpctransport.UnaryTracingInterceptor())
+		stream = append(stream, grpctransport.StreamTracingInterceptor())
+	}
+	if c.Metrics != nil {
+		unary = append(unary, grpctransport.UnaryMetricsInterceptor(c.Metrics))
+		stream = append(stream, grpctransport.StreamMetricsInterceptor(c.Metrics))
+	}
+	unary = append(unary, grpctransport.UnaryErrorInterceptor(), c.AuthGRPC.Unary())
+	stream = append(stream, grpctransport.StreamErrorInterceptor(), c.AuthGRPC.Stream())
+
+	opts := append(serverOptions(config),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	server := grpctransport.NewServer(opts...)
+
+	if c.ProductGRPCHandler != nil {
+		server.RegisterService(productGRPC.RegisterService(c.ProductGRPCHandler))
+	}
+	if c.UserGRPCHandler != nil {
+		server.RegisterService(userGRPC.RegisterService(c.UserGRPCHandler))
+	}
+	if c.AuthGRPCHandler != nil {
+		server.RegisterService(authGRPC.RegisterService(c.AuthGRPCHandler))
+	}
+
+	var interval time.Duration
+	if d, err := time.ParseDuration(config.HealthCheckInterval); err == nil {
+		interval = d
+	}
+	if c.Health != nil {
+		server.EnableHealth(func(ctx context.Context) error {
+			if !c.Health.Check(ctx).Healthy() {
+				return errUnhealthy
+			}
+			return nil
+		}, interval)
+	}
+	if featureFlag.Reflection {
+		server.EnableReflection()
+	}
+	if d, err := time.ParseDuration(config.ShutdownTimeout); err == nil {
+		server.SetShutdownTimeout(d)
+	}
+	return server
+}
+
+// serverOptions maps the message size and keepalive settings; unset or
+// invalid values keep the gRPC defaults
+func serverOptions(config core.GRPCConfig) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if config.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(config.MaxSendMsgSize))
+	}
+
+	ka := config.Keepalive
+	var params keepalive.ServerParameters
+	setDuration(&params.Time, ka.Time)
+	setDuration(&params.Timeout, ka.Timeout)
+	setDuration(&params.MaxConnectionIdle, ka.MaxConnectionIdle)
+	setDuration(&params.MaxConnectionAge, ka.MaxConnectionAge)
+	setDuration(&params.MaxConnectionAgeGrace, ka.MaxConnectionAgeGrace)
+	if params != (keepalive.ServerParameters{}) {
+		opts = append(opts, grpc.KeepaliveParams(params))
+	}
+
+	var policy keepalive.EnforcementPolicy
+	setDuration(&policy.MinTime, ka.MinTime)
+	policy.PermitWithoutStream = ka.PermitWithoutStream
+	if policy != (keepalive.EnforcementPolicy{}) {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+	return opts
+}
+
+func setDuration(dst *time.Duration, value string) {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		*dst = d
+	}
+}
@@ -27,12 +27,21 @@ func NewEchoServer(c *core.Container) *echo.Echo {
 		switch h := route.Handler.(type) {
 		case func(sharedctx.Context) error:
 			// Apply middlewares if any
-			finalHandler := applyMiddlewares(h, route.Middlewares)
-			route.Handler = finalHandler
+			route.Handler = instrument(c, route.Method, "/v1"+route.Path, applyMiddlewares(h, route.Middlewares))
 			v1 = transportEcho.AdapterToEchoRoutes(v1, &route, func(c echo.Context) sharedctx.Context {
 				return transportEcho.NewEchoContext(c)
 			}).Group("")
 		}
 	}
+
+	root := e.Group("")
+	for _, route := range *NewProbeRoutes(c.HealthProbes) {
+		transportEcho.AdapterToEchoRoutes(root, &route, func(c echo.Context) sharedctx.Context {
+			return transportEcho.NewEchoContext(c)
+		})
+	}
+	if c.Metrics != nil {
+		e.GET("/metrics", echo.WrapHandler(c.Metrics.Handler()))
+	}
 	return e
 }
@@ -7,6 +7,7 @@ import (
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 )
 
 func NewFastHTTPServer(c *core.Container) fasthttp.RequestHandler {
@@ -23,12 +24,20 @@ func NewFastHTTPServer(c *core.Container) fasthttp.RequestHandler {
 	for _, route := range *routes {
 		switch h := route.Handler.(type) {
 		case func(sharedctx.Context) error:
-			finalHandler := applyMiddlewares(h, route.Middlewares)
-			route.Handler = finalHandler
+			route.Handler = instrument(c, route.Method, "/v1"+route.Path, applyMiddlewares(h, route.Middlewares))
 			transportFast.AdapterToFastHTTPRoutes(v1, &route, func(ctx *fasthttp.RequestCtx) sharedctx.Context {
 				return transportFast.NewFastHTTPContext(ctx)
 			})
 		}
 	}
+
+	for _, route := range *NewProbeRoutes(c.HealthProbes) {
+		transportFast.AdapterToFastHTTPRoutes(r, &route, func(ctx *fasthttp.RequestCtx) sharedctx.Context {
+			return transportFast.NewFastHTTPContext(ctx)
+		})
+	}
+	if c.Metrics != nil {
+		r.GET("/metrics", fasthttpadaptor.NewFastHTTPHandler(c.Metrics.Handler()))
+	}
 	return r.Handler
 }
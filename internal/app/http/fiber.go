@@ -6,6 +6,7 @@ import (
 	transportFiber "github.com/kamil5b/go-pste-monolith/internal/transports/http/fiber"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 )
 
 func NewFiberServer(c *core.Container) *fiber.App {
@@ -22,12 +23,20 @@ func NewFiberServer(c *core.Container) *fiber.App {
 	for _, route := range *routes {
 		switch h := route.Handler.(type) {
 		case func(sharedctx.Context) error:
-			finalHandler := applyMiddlewares(h, route.Middlewares)
-			route.Handler = finalHandler
+			route.Handler = instrument(c, route.Method, "/v1"+route.Path, applyMiddlewares(h, route.Middlewares))
 			transportFiber.AdapterToFiberRoutes(v1, &route, func(ctx *fiber.Ctx) sharedctx.Context {
 				return transportFiber.NewFiberContext(ctx)
 			})
 		}
 	}
+
+	for _, route := range *NewProbeRoutes(c.HealthProbes) {
+		transportFiber.AdapterToFiberRoutes(app, &route, func(ctx *fiber.Ctx) sharedctx.Context {
+			return transportFiber.NewFiberContext(ctx)
+		})
+	}
+	if c.Metrics != nil {
+		app.Get("/metrics", adaptor.HTTPHandler(c.Metrics.Handler()))
+	}
 	return app
 }
@@ -24,12 +24,20 @@ func NewGinServer(c *core.Container) *gin.Engine {
 		switch h := route.Handler.(type) {
 		case func(sharedctx.Context) error:
 			// Apply middlewares if any
-			finalHandler := applyMiddlewares(h, route.Middlewares)
-			route.Handler = finalHandler
+			route.Handler = instrument(c, route.Method, "/v1"+route.Path, applyMiddlewares(h, route.Middlewares))
 			transportGin.AdapterToGinRoutes(v1, &route, func(ctx *gin.Context) sharedctx.Context {
 				return transportGin.NewGinContext(ctx)
 			})
 		}
 	}
+
+	for _, route := range *NewProbeRoutes(c.HealthProbes) {
+		transportGin.AdapterToGinRoutes(&r.RouterGroup, &route, func(ctx *gin.Context) sharedctx.Context {
+			return transportGin.NewGinContext(ctx)
+		})
+	}
+	if c.Metrics != nil {
+		r.GET("/metrics", gin.WrapH(c.Metrics.Handler()))
+	}
 	return r
 }
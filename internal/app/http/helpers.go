@@ -1,5 +1,13 @@
 package http
 
+import (
+	"github.com/kamil5b/go-pste-monolith/internal/app/core"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+)
+
 // applyMiddlewares applies a chain of middlewares to a handler
 func applyMiddlewares[T any](handler func(T) error, middlewares []any) func(T) error {
 	result := handler
@@ -11,3 +19,13 @@ func applyMiddlewares[T any](handler func(T) error, middlewares []any) func(T) e
 	}
 	return result
 }
+
+// instrument wraps a route handler with metrics, tracing when enabled and the
+// request ID, outermost last, so every server records routes the same way
+func instrument(c *core.Container, method, path string, handler func(sharedctx.Context) error) func(sharedctx.Context) error {
+	handler = metrics.HTTPMiddleware(c.Metrics, method, path)(handler)
+	if c.Tracing {
+		handler = tracing.HTTPMiddleware(method, path)(handler)
+	}
+	return requestid.HTTPMiddleware()(handler)
+}
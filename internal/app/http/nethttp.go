@@ -24,12 +24,20 @@ func NewNetHTTPServer(c *core.Container) http.Handler {
 	for _, route := range *routes {
 		switch h := route.Handler.(type) {
 		case func(sharedctx.Context) error:
-			finalHandler := applyMiddlewares(h, route.Middlewares)
-			route.Handler = finalHandler
+			route.Handler = instrument(c, route.Method, "/v1"+route.Path, applyMiddlewares(h, route.Middlewares))
 			transportNet.AdapterToNetHTTPRoutes(v1, &route, func(w http.ResponseWriter, r *http.Request) sharedctx.Context {
 				return transportNet.NewNetHTTPContext(w, r)
 			})
 		}
 	}
+
+	for _, route := range *NewProbeRoutes(c.HealthProbes) {
+		transportNet.AdapterToNetHTTPRoutes(r, &route, func(w http.ResponseWriter, r *http.Request) sharedctx.Context {
+			return transportNet.NewNetHTTPContext(w, r)
+		})
+	}
+	if c.Metrics != nil {
+		r.Handle("/metrics", c.Metrics.Handler()).Methods("GET")
+	}
 	return r
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/middleware"
 	productdomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	userdomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/health"
 	"github.com/kamil5b/go-pste-monolith/internal/transports/http"
 )
 
@@ -43,6 +44,56 @@ func NewRoutes(
 			Handler: authHandler.ValidateToken,
 			Flags:   []string{"public"},
 		},
+		{
+			Method:  "POST",
+			Path:    "/auth/password/reset",
+			Handler: authHandler.ResetPassword,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "POST",
+			Path:    "/auth/password/reset/confirm",
+			Handler: authHandler.ConfirmResetPassword,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "POST",
+			Path:    "/auth/verify",
+			Handler: authHandler.VerifyEmail,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "POST",
+			Path:    "/auth/verify/resend",
+			Handler: authHandler.ResendVerification,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "POST",
+			Path:    "/auth/mfa/verify",
+			Handler: authHandler.VerifyMFA,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "GET",
+			Path:    "/auth/oidc/:provider/login",
+			Handler: authHandler.StartOIDCLogin,
+			Flags:   []string{"public"},
+		},
+		{
+			// Public for logins; completing a link needs the linking user's credentials
+			Method:      "GET",
+			Path:        "/auth/oidc/:provider/callback",
+			Handler:     authHandler.OIDCCallback,
+			Middlewares: []any{authMiddleware.OptionalAuth()},
+			Flags:       []string{"public"},
+		},
+		{
+			Method:  "GET",
+			Path:    "/.well-known/jwks.json",
+			Handler: authHandler.JWKS,
+			Flags:   []string{"public"},
+		},
 
 		// Auth routes (protected - with auth middleware)
 		{
@@ -66,6 +117,48 @@ func NewRoutes(
 			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
 			Flags:       []string{"protected"},
 		},
+		{
+			Method:      "POST",
+			Path:        "/auth/mfa/enroll",
+			Handler:     authHandler.EnrollMFA,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/mfa/enable",
+			Handler:     authHandler.EnableMFA,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/mfa/disable",
+			Handler:     authHandler.DisableMFA,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/oidc/:provider/link",
+			Handler:     authHandler.StartOIDCLink,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "GET",
+			Path:        "/auth/identities",
+			Handler:     authHandler.ListIdentities,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "DELETE",
+			Path:        "/auth/identities/:provider",
+			Handler:     authHandler.UnlinkIdentity,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Flags:       []string{"protected"},
+		},
 		{
 			Method:      "GET",
 			Path:        "/auth/sessions",
@@ -88,57 +181,178 @@ func NewRoutes(
 			Flags:       []string{"protected"},
 		},
 
-		// Product routes (can add middleware here if needed)
+		// OAuth2 endpoints for machine clients; clients authenticate in the handler
+		{
+			Method:  "POST",
+			Path:    "/oauth/token",
+			Handler: authHandler.IssueClientToken,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "POST",
+			Path:    "/oauth/introspect",
+			Handler: authHandler.IntrospectToken,
+			Flags:   []string{"public"},
+		},
+
+		// RBAC administration
+		{
+			Method:      "GET",
+			Path:        "/auth/admin/roles",
+			Handler:     authHandler.ListRoles,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/admin/roles",
+			Handler:     authHandler.CreateRole,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "DELETE",
+			Path:        "/auth/admin/roles/:id",
+			Handler:     authHandler.DeleteRole,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "PUT",
+			Path:        "/auth/admin/roles/:id/permissions",
+			Handler:     authHandler.SetRolePermissions,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "GET",
+			Path:        "/auth/admin/permissions",
+			Handler:     authHandler.ListPermissions,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "GET",
+			Path:        "/auth/admin/users/:id/roles",
+			Handler:     authHandler.GetUserRoles,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/admin/users/:id/roles",
+			Handler:     authHandler.AssignRole,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "DELETE",
+			Path:        "/auth/admin/users/:id/roles/:role_id",
+			Handler:     authHandler.RevokeRole,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+
+		// Machine client administration; clients can be granted any permission
+		{
+			Method:      "GET",
+			Path:        "/auth/admin/clients",
+			Handler:     authHandler.ListClients,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/admin/clients",
+			Handler:     authHandler.RegisterClient,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "DELETE",
+			Path:        "/auth/admin/clients/:id",
+			Handler:     authHandler.DeleteClient,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+		{
+			Method:      "POST",
+			Path:        "/auth/admin/clients/:id/secret",
+			Handler:     authHandler.RotateClientSecret,
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionRBACManage)},
+			Flags:       []string{"protected"},
+		},
+
+		// Product routes
 		{
 			Method:      "GET",
 			Path:        "/product",
 			Handler:     productHandler.List,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionProductRead)},
 			Flags:       []string{"protected"},
 		},
 		{
 			Method:      "POST",
 			Path:        "/product",
 			Handler:     productHandler.Create,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionProductWrite)},
 			Flags:       []string{"protected"},
 		},
 
-		// User CRUD (can add middleware here if needed)
+		// User CRUD
 		{
 			Method:      "GET",
 			Path:        "/user",
 			Handler:     userHandler.List,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionUserRead)},
 			Flags:       []string{"protected"},
 		},
 		{
 			Method:      "POST",
 			Path:        "/user",
 			Handler:     userHandler.Create,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionUserWrite)},
 			Flags:       []string{"protected"},
 		},
 		{
 			Method:      "GET",
 			Path:        "/user/:id",
 			Handler:     userHandler.Get,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionUserRead)},
 			Flags:       []string{"protected"},
 		},
 		{
 			Method:      "PUT",
 			Path:        "/user/:id",
 			Handler:     userHandler.Update,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionUserWrite)},
 			Flags:       []string{"protected"},
 		},
 		{
 			Method:      "DELETE",
 			Path:        "/user/:id",
 			Handler:     userHandler.Delete,
-			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth()},
+			Middlewares: []any{authMiddleware.Authenticate(), authMiddleware.RequireAuth(), authMiddleware.RequirePermission(authdomain.PermissionUserWrite)},
 			Flags:       []string{"protected"},
 		},
 	}
 }
+
+// NewProbeRoutes returns the liveness and readiness endpoints; they are served
+// outside /v1 so orchestrator probes do not follow API versions.
+func NewProbeRoutes(probes *health.Probes) *[]http.Route {
+	return &[]http.Route{
+		{
+			Method:  "GET",
+			Path:    "/healthz",
+			Handler: probes.Liveness,
+			Flags:   []string{"public"},
+		},
+		{
+			Method:  "GET",
+			Path:    "/readyz",
+			Handler: probes.Readiness,
+			Flags:   []string{"public"},
+		},
+	}
+}
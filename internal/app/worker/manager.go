@@ -6,9 +6,12 @@ import (
 
 	"github.com/kamil5b/go-pste-monolith/internal/app/core"
 	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 )
 
+var workerLog = logger.Module("worker")
+
 // TaskRegistry holds a collection of task registrations
 type TaskRegistry struct {
 	registrations []TaskRegistration
@@ -42,7 +45,7 @@ func (r *TaskRegistry) RegisterAll(server sharedworker.Server) error {
 		if err := server.RegisterHandler(reg.TaskName, reg.Handler); err != nil {
 			return fmt.Errorf("failed to register handler for task %s: %w", reg.TaskName, err)
 		}
-		fmt.Printf("[INFO] Registered handler: %s\n", reg.TaskName)
+		workerLog.WithField("task_name", reg.TaskName).Info("Registered handler")
 	}
 	return nil
 }
@@ -80,13 +83,12 @@ func (m *WorkerManager) RegisterTasks() error {
 
 // Start initializes and starts the worker server
 func (m *WorkerManager) Start(ctx context.Context) error {
-	fmt.Println("[INFO] Starting worker server...")
-	fmt.Printf("[INFO] Worker server running (backend: %s)\n", "configured")
+	workerLog.Info("Starting worker server")
 
 	// Start cron scheduler in background
 	go func() {
 		if err := m.cronScheduler.Start(context.Background()); err != nil {
-			fmt.Printf("[ERROR] Cron scheduler error: %v\n", err)
+			workerLog.WithField("error", err).Error("Cron scheduler error")
 		}
 	}()
 
@@ -95,10 +97,10 @@ func (m *WorkerManager) Start(ctx context.Context) error {
 
 // Stop gracefully stops the worker server
 func (m *WorkerManager) Stop(ctx context.Context) error {
-	fmt.Println("[INFO] Stopping worker server...")
+	workerLog.Info("Stopping worker server")
 	// Stop cron scheduler
 	if err := m.cronScheduler.Stop(); err != nil {
-		fmt.Printf("[WARN] Error stopping cron scheduler: %v\n", err)
+		workerLog.WithField("error", err).Warn("Error stopping cron scheduler")
 	}
 	return m.container.WorkerServer.Stop(ctx)
 }
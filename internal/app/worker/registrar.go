@@ -2,7 +2,6 @@ package worker
 
 import (
 	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
-	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 )
 
@@ -57,7 +56,7 @@ func (r *ModuleRegistry) RegisterAllTasks(
 	reportGenerationEnabled bool,
 ) error {
 	for i, provider := range r.modules {
-		logger.WithFields(map[string]interface{}{
+		workerLog.WithFields(map[string]interface{}{
 			"module_index": i + 1,
 			"total":        len(r.modules),
 		}).Info("Getting tasks from module")
@@ -70,7 +69,7 @@ func (r *ModuleRegistry) RegisterAllTasks(
 		)
 
 		for _, taskDef := range taskDefs {
-			logger.WithField("task_name", taskDef.TaskName).Info("Registering task")
+			workerLog.WithField("task_name", taskDef.TaskName).Info("Registering task")
 			registry.Register(taskDef.TaskName, taskDef.Handler)
 		}
 	}
@@ -83,14 +82,14 @@ func (r *ModuleRegistry) RegisterAllCronJobs(
 	emailNotificationsEnabled bool,
 ) error {
 	for i, provider := range r.modules {
-		logger.WithFields(map[string]interface{}{
+		workerLog.WithFields(map[string]interface{}{
 			"module_index": i + 1,
 			"total":        len(r.modules),
 		}).Info("Getting cron jobs from module")
 		cronDefs := provider.GetCronJobDefinitions(emailNotificationsEnabled)
 
 		for _, cronDef := range cronDefs {
-			logger.WithField("cron_job_id", cronDef.JobID).Info("Scheduling cron job")
+			workerLog.WithField("cron_job_id", cronDef.JobID).Info("Scheduling cron job")
 			// Use the CronExpression from the definition, or default to Monthly(15, 9, 0)
 			cronExpr := cronDef.CronExpression
 			if cronExpr == (sharedworker.CronExpression{}) {
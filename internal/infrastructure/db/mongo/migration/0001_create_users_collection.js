@@ -0,0 +1,47 @@
+// MongoDB migration: create `users` collection with JSON schema validator and indexes
+// Run with mongosh or mongo shell. Example:
+// mongosh "mongodb://localhost:27017/app" migrations/mongo/0001_create_users_collection.js
+
+// switch to DB (replace 'app' with your DB name if different)
+db = db.getSiblingDB(typeof db === 'object' && db._name ? db._name : 'app');
+
+const validator = {
+  $jsonSchema: {
+    bsonType: 'object',
+    required: ['id', 'email', 'created_at'],
+    properties: {
+      id: { bsonType: 'string', description: 'UUID string' },
+      name: { bsonType: ['string', 'null'] },
+      email: { bsonType: 'string' },
+      created_at: { bsonType: 'date' },
+      created_by: { bsonType: ['string', 'null'] },
+      updated_at: { bsonType: ['date', 'null'] },
+      updated_by: { bsonType: ['string', 'null'] },
+      deleted_at: { bsonType: ['date', 'null'] },
+      deleted_by: { bsonType: ['string', 'null'] }
+    }
+  }
+};
+
+const collName = 'users';
+if (!db.getCollectionNames().includes(collName)) {
+  print(`Creating collection ${collName} with validator`);
+  db.createCollection(collName, { validator });
+} else {
+  print(`${collName} already exists — updating validator`);
+  db.runCommand({ collMod: collName, validator });
+}
+
+// Emails stay unique across soft-deleted users, as in the SQL schema
+print('Creating indexes on users');
+db[collName].createIndex({ id: 1 }, { unique: true });
+db[collName].createIndex({ email: 1 }, { unique: true });
+db[collName].createIndex({ deleted_at: 1 });
+
+// Keyset pagination sorts by (name|email|created_at, id)
+db[collName].createIndex({ created_at: -1, id: -1 });
+db[collName].createIndex({ name: 1, id: 1 });
+db[collName].createIndex({ email: 1, id: 1 });
+db[collName].createIndex({ created_by: 1 });
+
+print('MongoDB migration completed.');
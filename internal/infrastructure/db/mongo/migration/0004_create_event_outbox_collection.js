@@ -0,0 +1,14 @@
+// MongoDB migration for the transactional event outbox
+// Run this in MongoDB shell or use mongosh
+// Note: outbox writes happen inside multi-document transactions, which require a replica set
+
+// Create event_outbox collection with indexes
+db.createCollection("event_outbox");
+
+// Pending messages are polled oldest first by the relay
+db.event_outbox.createIndex({ "created_at": 1 }, { partialFilterExpression: { dispatched_at: null } });
+
+// TTL index to automatically delete dispatched messages after 7 days
+db.event_outbox.createIndex({ "dispatched_at": 1 }, { expireAfterSeconds: 604800 });
+
+print("Event outbox collection and indexes created successfully");
@@ -0,0 +1,8 @@
+// MongoDB migration: compound indexes for keyset pagination of products
+// Listing sorts by (name|created_at, id) and pages with a range on the same pair
+
+db.products.createIndex({ created_at: -1, id: -1 });
+db.products.createIndex({ name: 1, id: 1 });
+db.products.createIndex({ created_by: 1 });
+
+print("Product pagination indexes created successfully");
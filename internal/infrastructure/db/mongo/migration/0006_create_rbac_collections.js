@@ -0,0 +1,75 @@
+// MongoDB migration for role-based access control
+// Run this in MongoDB shell or use mongosh
+// Roles embed the names of the permissions they grant
+
+const { randomUUID } = require("crypto");
+const now = new Date();
+
+// Create auth_permissions collection and seed built-in permissions
+db.createCollection("auth_permissions");
+db.auth_permissions.createIndex({ "name": 1 }, { unique: true });
+
+[
+  ["product:read", "List and view products"],
+  ["product:write", "Create, update and delete products"],
+  ["user:read", "List and view users"],
+  ["user:write", "Create, update and delete users"],
+  ["rbac:manage", "Manage roles, permissions and role assignments"],
+].forEach(([name, description]) => {
+  db.auth_permissions.updateOne(
+    { name: name },
+    { $setOnInsert: { id: randomUUID(), name: name, description: description, created_at: now } },
+    { upsert: true }
+  );
+});
+
+// Create auth_roles collection and seed built-in roles
+db.createCollection("auth_roles");
+db.auth_roles.createIndex({ "id": 1 }, { unique: true });
+db.auth_roles.createIndex({ "name": 1 }, { unique: true });
+
+db.auth_roles.updateOne(
+  { name: "admin" },
+  {
+    $setOnInsert: {
+      id: randomUUID(),
+      name: "admin",
+      description: "Full access, including role management",
+      permissions: ["product:read", "product:write", "user:read", "user:write", "rbac:manage"],
+      created_at: now,
+    },
+  },
+  { upsert: true }
+);
+
+db.auth_roles.updateOne(
+  { name: "user" },
+  {
+    $setOnInsert: {
+      id: randomUUID(),
+      name: "user",
+      description: "Default role for registered users",
+      permissions: ["product:read", "product:write", "user:read"],
+      created_at: now,
+    },
+  },
+  { upsert: true }
+);
+
+// Create auth_user_roles collection with indexes
+db.createCollection("auth_user_roles");
+db.auth_user_roles.createIndex({ "user_id": 1, "role_id": 1 }, { unique: true });
+db.auth_user_roles.createIndex({ "role_id": 1 });
+
+// Existing accounts keep the access they had before permissions were enforced
+// (the user role); grant the first admin with `go run . admin grant <email>`
+const userRole = db.auth_roles.findOne({ name: "user" });
+db.auth_credentials.find({ deleted_at: null }).forEach((cred) => {
+  db.auth_user_roles.updateOne(
+    { user_id: cred.user_id, role_id: userRole.id },
+    { $setOnInsert: { user_id: cred.user_id, role_id: userRole.id, assigned_by: "", assigned_at: now } },
+    { upsert: true }
+  );
+});
+
+print("RBAC collections, indexes and built-in roles created successfully");
@@ -0,0 +1,12 @@
+// MongoDB migration for password reset tokens
+// Run this in MongoDB shell or use mongosh
+
+// Create auth_password_reset_tokens collection with indexes
+db.createCollection("auth_password_reset_tokens");
+db.auth_password_reset_tokens.createIndex({ "token_hash": 1 }, { unique: true });
+db.auth_password_reset_tokens.createIndex({ "user_id": 1 }, { partialFilterExpression: { used_at: null } });
+
+// TTL index to automatically delete tokens one day after they expire
+db.auth_password_reset_tokens.createIndex({ "expires_at": 1 }, { expireAfterSeconds: 86400 });
+
+print("Password reset tokens collection and indexes created successfully");
@@ -0,0 +1,10 @@
+// MongoDB migration for email verification
+// Run this in MongoDB shell or use mongosh
+
+// Credentials created before email verification existed are treated as verified
+const result = db.auth_credentials.updateMany(
+  { email_verified_at: { $exists: false } },
+  [{ $set: { email_verified_at: "$created_at" } }]
+);
+
+print("Marked " + result.modifiedCount + " existing credentials as email verified");
@@ -0,0 +1,12 @@
+// MongoDB migration for TOTP multi-factor authentication
+// Run this in MongoDB shell or use mongosh
+
+// Create auth_mfa_enrollments collection with indexes
+db.createCollection("auth_mfa_enrollments");
+db.auth_mfa_enrollments.createIndex({ "user_id": 1 }, { unique: true });
+
+// Create auth_mfa_recovery_codes collection with indexes
+db.createCollection("auth_mfa_recovery_codes");
+db.auth_mfa_recovery_codes.createIndex({ "user_id": 1, "code_hash": 1 }, { unique: true });
+
+print("MFA collections and indexes created successfully");
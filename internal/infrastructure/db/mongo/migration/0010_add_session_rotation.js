@@ -0,0 +1,12 @@
+// MongoDB migration for refresh token rotation
+// Run this in MongoDB shell or use mongosh
+
+// Sessions created before rotation existed each start their own family
+const result = db.auth_sessions.updateMany(
+  { family_id: { $exists: false } },
+  [{ $set: { family_id: "$id" } }]
+);
+
+db.auth_sessions.createIndex({ "family_id": 1 }, { partialFilterExpression: { revoked_at: null } });
+
+print("Assigned a session family to " + result.modifiedCount + " existing sessions");
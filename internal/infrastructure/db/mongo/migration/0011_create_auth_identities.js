@@ -0,0 +1,9 @@
+// MongoDB migration for OAuth2/OIDC social login
+// Run this in MongoDB shell or use mongosh
+
+// Create auth_identities collection with indexes; one account per provider and user
+db.createCollection("auth_identities");
+db.auth_identities.createIndex({ "provider": 1, "subject": 1 }, { unique: true });
+db.auth_identities.createIndex({ "user_id": 1, "provider": 1 }, { unique: true });
+
+print("Identity collection and indexes created successfully");
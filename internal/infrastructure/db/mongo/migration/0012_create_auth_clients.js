@@ -0,0 +1,8 @@
+// MongoDB migration for OAuth2 machine clients
+// Run this in MongoDB shell or use mongosh
+
+// Create auth_clients collection; the client id is the OAuth2 client_id
+db.createCollection("auth_clients");
+db.auth_clients.createIndex({ "id": 1 }, { unique: true });
+
+print("Client collection and indexes created successfully");
@@ -8,10 +8,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// OpenMongo connects to MongoDB; commands run with a traced context become
+// child spans of the caller
 func OpenMongo(uri string) (*mongo.Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetMonitor(newTracingMonitor()))
 	if err != nil {
 		return nil, err
 	}
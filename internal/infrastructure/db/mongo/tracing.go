@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTracingMonitor opens a client span per command, a child of the span in
+// the context the command was issued with; commands outside a trace are not
+// recorded. Command bodies are left out since they carry user data.
+func newTracingMonitor() *event.CommandMonitor {
+	var spans sync.Map // request ID -> trace.Span
+
+	end := func(requestID int64, err error) {
+		if span, ok := spans.LoadAndDelete(requestID); ok {
+			tracing.End(span.(trace.Span), err)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				return
+			}
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemNameMongoDB,
+				semconv.DBNamespace(evt.DatabaseName),
+				semconv.DBOperationName(evt.CommandName),
+			}
+			name := evt.CommandName
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				attrs = append(attrs, semconv.DBCollectionName(collection))
+				name += " " + collection
+			}
+			_, span := tracing.Start(ctx, name, trace.SpanKindClient, attrs...)
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			end(evt.RequestID, nil)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			end(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS users (
+  id CHAR(36) PRIMARY KEY,
+  name VARCHAR(255) NOT NULL DEFAULT '',
+  email VARCHAR(255) UNIQUE NOT NULL,
+  created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+  created_by VARCHAR(36) NOT NULL DEFAULT '',
+  updated_at DATETIME(6),
+  updated_by VARCHAR(36),
+  deleted_at DATETIME(6),
+  deleted_by VARCHAR(36)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS users;
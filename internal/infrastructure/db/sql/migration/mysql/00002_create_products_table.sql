@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS products (
+  id CHAR(36) PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL,
+  created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+  created_by VARCHAR(36) NOT NULL DEFAULT '',
+  updated_at DATETIME(6),
+  updated_by VARCHAR(36),
+  deleted_at DATETIME(6),
+  deleted_by VARCHAR(36)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS products;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- Auth credentials table
+CREATE TABLE IF NOT EXISTS auth_credentials (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    username VARCHAR(50) UNIQUE NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    email_verified_at DATETIME(6),
+    last_login_at DATETIME(6),
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6),
+    deleted_at DATETIME(6),
+    INDEX idx_auth_credentials_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Auth sessions table; each session chain created by a login is a family
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    family_id CHAR(36) NOT NULL,
+    token VARCHAR(255) UNIQUE NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6),
+    revoked_at DATETIME(6),
+    consumed_at DATETIME(6),
+    user_agent TEXT NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    INDEX idx_auth_sessions_user_id (user_id),
+    INDEX idx_auth_sessions_family_id (family_id),
+    INDEX idx_auth_sessions_expires_at (expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_sessions;
+DROP TABLE IF EXISTS auth_credentials;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Keyset pagination walks (sort column, id); one index per sort key
+CREATE INDEX idx_products_created_at_id ON products(created_at, id);
+CREATE INDEX idx_products_name_id ON products(name, id);
+CREATE INDEX idx_products_created_by ON products(created_by);
+
+CREATE INDEX idx_users_created_at_id ON users(created_at, id);
+CREATE INDEX idx_users_email_id ON users(email, id);
+
+-- +goose Down
+DROP INDEX idx_users_email_id ON users;
+DROP INDEX idx_users_created_at_id ON users;
+DROP INDEX idx_products_created_by ON products;
+DROP INDEX idx_products_name_id ON products;
+DROP INDEX idx_products_created_at_id ON products;
@@ -0,0 +1,62 @@
+-- +goose Up
+-- Roles group permissions; users are granted permissions through role assignments
+CREATE TABLE IF NOT EXISTS auth_roles (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6)
+);
+
+CREATE TABLE IF NOT EXISTS auth_permissions (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE TABLE IF NOT EXISTS auth_role_permissions (
+    role_id CHAR(36) NOT NULL,
+    permission_id CHAR(36) NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    FOREIGN KEY (role_id) REFERENCES auth_roles(id) ON DELETE CASCADE,
+    FOREIGN KEY (permission_id) REFERENCES auth_permissions(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS auth_user_roles (
+    user_id CHAR(36) NOT NULL,
+    role_id CHAR(36) NOT NULL,
+    assigned_by VARCHAR(36) NOT NULL DEFAULT '',
+    assigned_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (user_id, role_id),
+    INDEX idx_auth_user_roles_role_id (role_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (role_id) REFERENCES auth_roles(id) ON DELETE CASCADE
+);
+
+-- Built-in permissions and roles
+INSERT IGNORE INTO auth_permissions (id, name, description) VALUES
+    (UUID(), 'product:read', 'List and view products'),
+    (UUID(), 'product:write', 'Create, update and delete products'),
+    (UUID(), 'user:read', 'List and view users'),
+    (UUID(), 'user:write', 'Create, update and delete users'),
+    (UUID(), 'rbac:manage', 'Manage roles, permissions and role assignments');
+
+INSERT IGNORE INTO auth_roles (id, name, description) VALUES
+    (UUID(), 'admin', 'Full access, including role management'),
+    (UUID(), 'user', 'Default role for registered users');
+
+INSERT IGNORE INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r CROSS JOIN auth_permissions p
+WHERE r.name = 'admin';
+
+INSERT IGNORE INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r JOIN auth_permissions p
+    ON p.name IN ('product:read', 'product:write', 'user:read')
+WHERE r.name = 'user';
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_user_roles;
+DROP TABLE IF EXISTS auth_role_permissions;
+DROP TABLE IF EXISTS auth_permissions;
+DROP TABLE IF EXISTS auth_roles;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Single-use password reset tokens; only the SHA-256 of the token is stored
+CREATE TABLE IF NOT EXISTS auth_password_reset_tokens (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    used_at DATETIME(6),
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_auth_password_reset_tokens_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_password_reset_tokens;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- TOTP enrollment; MFA is enforced once enabled_at is set
+CREATE TABLE IF NOT EXISTS auth_mfa_enrollments (
+    user_id CHAR(36) PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    enabled_at DATETIME(6),
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Single-use recovery codes; only the SHA-256 of the code is stored
+CREATE TABLE IF NOT EXISTS auth_mfa_recovery_codes (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at DATETIME(6),
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_mfa_recovery_codes;
+DROP TABLE IF EXISTS auth_mfa_enrollments;
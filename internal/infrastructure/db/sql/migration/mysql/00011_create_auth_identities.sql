@@ -0,0 +1,16 @@
+-- +goose Up
+-- External OAuth2/OIDC accounts linked to users; one account per provider and user
+CREATE TABLE IF NOT EXISTS auth_identities (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_identities;
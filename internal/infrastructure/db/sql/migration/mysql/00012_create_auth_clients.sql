@@ -0,0 +1,14 @@
+-- +goose Up
+-- OAuth2 machine clients; scopes is a JSON array of permission names the client may request
+CREATE TABLE IF NOT EXISTS auth_clients (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    scopes JSON NOT NULL,
+    created_by CHAR(36),
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_clients;
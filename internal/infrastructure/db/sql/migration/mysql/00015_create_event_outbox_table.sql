@@ -0,0 +1,20 @@
+-- +goose Up
+-- Transactional outbox: events written in the same transaction as the data they describe.
+-- Same columns as the Postgres table after its 00004, 00013 and 00014 migrations
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id CHAR(36) PRIMARY KEY,
+    event_name VARCHAR(255) NOT NULL,
+    payload JSON NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_until DATETIME(6),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    dispatched_at DATETIME(6),
+    trace_context JSON,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    -- Pending messages are polled oldest first by the relay
+    INDEX idx_event_outbox_pending (dispatched_at, created_at)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS event_outbox;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Transactional outbox: events written in the same transaction as the data they describe
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY,
+    event_name VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Pending messages are polled oldest first by the relay
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(created_at) WHERE dispatched_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS event_outbox;
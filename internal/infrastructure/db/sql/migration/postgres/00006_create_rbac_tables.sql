@@ -0,0 +1,70 @@
+-- +goose Up
+-- Roles group permissions; users are granted permissions through role assignments
+CREATE TABLE IF NOT EXISTS auth_roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS auth_permissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS auth_role_permissions (
+    role_id UUID NOT NULL REFERENCES auth_roles(id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES auth_permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS auth_user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES auth_roles(id) ON DELETE CASCADE,
+    assigned_by TEXT NOT NULL DEFAULT '',
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_user_roles_role_id ON auth_user_roles(role_id);
+
+-- Built-in permissions and roles
+INSERT INTO auth_permissions (name, description) VALUES
+    ('product:read', 'List and view products'),
+    ('product:write', 'Create, update and delete products'),
+    ('user:read', 'List and view users'),
+    ('user:write', 'Create, update and delete users'),
+    ('rbac:manage', 'Manage roles, permissions and role assignments')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO auth_roles (name, description) VALUES
+    ('admin', 'Full access, including role management'),
+    ('user', 'Default role for registered users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r CROSS JOIN auth_permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r JOIN auth_permissions p
+    ON p.name IN ('product:read', 'product:write', 'user:read')
+WHERE r.name = 'user'
+ON CONFLICT DO NOTHING;
+
+-- Existing accounts keep the access they had before permissions were enforced
+-- (the user role); grant the first admin with `go run . admin grant <email>`
+INSERT INTO auth_user_roles (user_id, role_id)
+SELECT c.user_id, r.id FROM auth_credentials c JOIN auth_roles r ON r.name = 'user'
+WHERE c.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_user_roles;
+DROP TABLE IF EXISTS auth_role_permissions;
+DROP TABLE IF EXISTS auth_permissions;
+DROP TABLE IF EXISTS auth_roles;
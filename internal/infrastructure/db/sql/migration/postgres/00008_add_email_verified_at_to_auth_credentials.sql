@@ -0,0 +1,8 @@
+-- +goose Up
+-- Credentials created before email verification existed are treated as verified
+ALTER TABLE auth_credentials ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE auth_credentials SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+-- +goose Down
+ALTER TABLE auth_credentials DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- TOTP enrollment; MFA is enforced once enabled_at is set
+CREATE TABLE IF NOT EXISTS auth_mfa_enrollments (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Single-use recovery codes; only the SHA-256 of the code is stored
+CREATE TABLE IF NOT EXISTS auth_mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_mfa_recovery_codes;
+DROP TABLE IF EXISTS auth_mfa_enrollments;
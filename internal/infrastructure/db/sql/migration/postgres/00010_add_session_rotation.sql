@@ -0,0 +1,16 @@
+-- +goose Up
+-- Each session chain created by a login is a family; rotated sessions keep the
+-- family id and are marked consumed instead of being deleted
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS family_id UUID;
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS consumed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE auth_sessions SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE auth_sessions ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_family_id ON auth_sessions(family_id) WHERE revoked_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_auth_sessions_family_id;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS consumed_at;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- External OAuth2/OIDC accounts linked to users; one account per provider and user
+CREATE TABLE IF NOT EXISTS auth_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_identities;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- OAuth2 machine clients; scopes are permission names the client may request
+CREATE TABLE IF NOT EXISTS auth_clients (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_clients;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Trace context of the publishing request, continued by the relay on delivery
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS trace_context JSONB;
+
+-- +goose Down
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS trace_context;
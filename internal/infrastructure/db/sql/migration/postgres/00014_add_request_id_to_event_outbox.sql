@@ -0,0 +1,6 @@
+-- +goose Up
+-- Request ID of the publishing request, restored by the relay on delivery
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS request_id VARCHAR(128) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE event_outbox DROP COLUMN IF EXISTS request_id;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS users (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL DEFAULT '',
+  email TEXT UNIQUE NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  created_by TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMP,
+  updated_by TEXT,
+  deleted_at TIMESTAMP,
+  deleted_by TEXT
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS users;
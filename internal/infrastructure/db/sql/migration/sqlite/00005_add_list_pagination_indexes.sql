@@ -0,0 +1,15 @@
+-- +goose Up
+-- Keyset pagination walks (sort column, id); one index per sort key
+CREATE INDEX IF NOT EXISTS idx_products_created_at_id ON products(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_name_id ON products(name, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_created_by ON products(created_by) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_users_email_id ON users(email, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_email_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_products_created_by;
+DROP INDEX IF EXISTS idx_products_name_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
@@ -0,0 +1,63 @@
+-- +goose Up
+-- Roles group permissions; users are granted permissions through role assignments
+CREATE TABLE IF NOT EXISTS auth_roles (
+    id TEXT PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS auth_permissions (
+    id TEXT PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS auth_role_permissions (
+    role_id TEXT NOT NULL REFERENCES auth_roles(id) ON DELETE CASCADE,
+    permission_id TEXT NOT NULL REFERENCES auth_permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS auth_user_roles (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id TEXT NOT NULL REFERENCES auth_roles(id) ON DELETE CASCADE,
+    assigned_by TEXT NOT NULL DEFAULT '',
+    assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_user_roles_role_id ON auth_user_roles(role_id);
+
+-- Built-in permissions and roles; SQLite cannot generate UUIDs, so they are fixed
+INSERT INTO auth_permissions (id, name, description) VALUES
+    ('6f1c7d2e-0b1a-4c1e-9a51-3f0e8b2d6a01', 'product:read', 'List and view products'),
+    ('6f1c7d2e-0b1a-4c1e-9a51-3f0e8b2d6a02', 'product:write', 'Create, update and delete products'),
+    ('6f1c7d2e-0b1a-4c1e-9a51-3f0e8b2d6a03', 'user:read', 'List and view users'),
+    ('6f1c7d2e-0b1a-4c1e-9a51-3f0e8b2d6a04', 'user:write', 'Create, update and delete users'),
+    ('6f1c7d2e-0b1a-4c1e-9a51-3f0e8b2d6a05', 'rbac:manage', 'Manage roles, permissions and role assignments')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO auth_roles (id, name, description) VALUES
+    ('8a4e2b9c-5d3f-4e7a-b6c1-2d9f0e1a7b01', 'admin', 'Full access, including role management'),
+    ('8a4e2b9c-5d3f-4e7a-b6c1-2d9f0e1a7b02', 'user', 'Default role for registered users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r CROSS JOIN auth_permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO auth_role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM auth_roles r JOIN auth_permissions p
+    ON p.name IN ('product:read', 'product:write', 'user:read')
+WHERE r.name = 'user'
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_user_roles;
+DROP TABLE IF EXISTS auth_role_permissions;
+DROP TABLE IF EXISTS auth_permissions;
+DROP TABLE IF EXISTS auth_roles;
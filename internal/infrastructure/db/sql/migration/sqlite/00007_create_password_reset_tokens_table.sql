@@ -0,0 +1,15 @@
+-- +goose Up
+-- Single-use password reset tokens; only the SHA-256 of the token is stored
+CREATE TABLE IF NOT EXISTS auth_password_reset_tokens (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_password_reset_tokens_user_id ON auth_password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_password_reset_tokens;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- TOTP enrollment; MFA is enforced once enabled_at is set
+CREATE TABLE IF NOT EXISTS auth_mfa_enrollments (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    enabled_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+-- Single-use recovery codes; only the SHA-256 of the code is stored
+CREATE TABLE IF NOT EXISTS auth_mfa_recovery_codes (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_mfa_recovery_codes;
+DROP TABLE IF EXISTS auth_mfa_enrollments;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- External OAuth2/OIDC accounts linked to users; one account per provider and user
+CREATE TABLE IF NOT EXISTS auth_identities (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_identities;
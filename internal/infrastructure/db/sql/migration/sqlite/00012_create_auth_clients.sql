@@ -0,0 +1,14 @@
+-- +goose Up
+-- OAuth2 machine clients; scopes is a JSON array of permission names the client may request
+CREATE TABLE IF NOT EXISTS auth_clients (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '[]',
+    created_by TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS auth_clients;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Transactional outbox: events written in the same transaction as the data they describe.
+-- Same columns as the Postgres table after its 00004, 00013 and 00014 migrations
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id TEXT PRIMARY KEY,
+    event_name TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMP,
+    trace_context TEXT,
+    request_id TEXT NOT NULL DEFAULT ''
+);
+
+-- Pending messages are polled oldest first by the relay
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(created_at) WHERE dispatched_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS event_outbox;
@@ -1,19 +1,100 @@
 package sql
 
 import (
+	"context"
+	"database/sql/driver"
 	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
+
+	"github.com/XSAM/otelsql"
+	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func Open(dsn string) (*sqlx.DB, error) {
-	db, err := sqlx.Connect("postgres", dsn)
+// PoolConfig sizes the connection pool of one database
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // zero keeps idle connections until ConnMaxLifetime
+}
+
+// DefaultPoolConfig returns the pool settings used when none are configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    25,
+		ConnMaxLifetime: 5 * time.Minute,
+	}
+}
+
+// Open connects to the database of dialect through an instrumented driver:
+// queries run with a traced context become child spans of the caller, queries
+// outside a trace (such as outbox polling) are not recorded. MySQL DSNs need
+// parseTime=true so timestamps scan into time.Time.
+func Open(dialect sqldialect.Dialect, dsn string, pool PoolConfig) (*sqlx.DB, error) {
+	db, err := open(dialect, dsn, pool)
 	if err != nil {
 		return nil, err
 	}
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
 	return db, nil
 }
+
+// OpenReplica is Open without the initial ping, so an unreachable replica does
+// not stop startup; the read router ejects it until it answers
+func OpenReplica(dialect sqldialect.Dialect, dsn string, pool PoolConfig) (*sqlx.DB, error) {
+	return open(dialect, dsn, pool)
+}
+
+func open(dialect sqldialect.Dialect, dsn string, pool PoolConfig) (*sqlx.DB, error) {
+	sqlDB, err := otelsql.Open(dialect.DriverName(), dsn,
+		otelsql.WithAttributes(dbSystem(dialect)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := DefaultPoolConfig()
+	if pool.MaxOpenConns <= 0 {
+		pool.MaxOpenConns = defaults.MaxOpenConns
+	}
+	if pool.MaxIdleConns <= 0 {
+		pool.MaxIdleConns = min(defaults.MaxIdleConns, pool.MaxOpenConns)
+	}
+	if pool.ConnMaxLifetime <= 0 {
+		pool.ConnMaxLifetime = defaults.ConnMaxLifetime
+	}
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	return sqlx.NewDb(sqlDB, dialect.DriverName()), nil
+}
+
+func dbSystem(dialect sqldialect.Dialect) attribute.KeyValue {
+	switch dialect {
+	case sqldialect.MySQL:
+		return semconv.DBSystemNameMySQL
+	case sqldialect.SQLite:
+		return semconv.DBSystemNameSQLite
+	default:
+		return semconv.DBSystemNamePostgreSQL
+	}
+}
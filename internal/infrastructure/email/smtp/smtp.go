@@ -49,6 +49,14 @@ func NewSMTPEmailService(config SMTPConfig) *SMTPEmailService {
 		[]string{"reset_link"},
 	)
 
+	_ = loader.RegisterTemplate(
+		"verification",
+		"Verify Your Email Address",
+		`<h1>Hi {{.username}}</h1><p><a href="{{.verification_link}}">Click here</a> to verify your email address.</p>`,
+		`Hi {{.username}}\n\nVerify your email address: {{.verification_link}}`,
+		[]string{"verification_link"},
+	)
+
 	return &SMTPEmailService{
 		config:         config,
 		addr:           fmt.Sprintf("%s:%d", config.Host, config.Port),
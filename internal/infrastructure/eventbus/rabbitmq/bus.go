@@ -0,0 +1,280 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var busLog = logger.Module("eventbus.rabbitmq")
+
+// RabbitMQEventBusConfig holds connection and topology settings for the event bus
+type RabbitMQEventBusConfig struct {
+	URL      string
+	Exchange string // topic exchange events are published to, routed by event name
+	// Queue is the durable queue bound to every event name. All processes
+	// opening the bus with the same queue, the server and `go run . worker`
+	// included, compete for its deliveries: each event reaches only one of
+	// them, so they must subscribe the same handlers.
+	Queue         string
+	PrefetchCount int
+	// DeadLetterExchange receives events whose handlers still fail after
+	// MaxRetries retries, and events that cannot be decoded (default:
+	// Exchange + ".dlx"). They are kept in the queue Queue + ".dlq".
+	DeadLetterExchange string
+	MaxRetries         int // 0 keeps the default retry policy
+}
+
+// publishChannel is the part of *amqp.Channel used to publish events
+type publishChannel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Close() error
+}
+
+// RabbitMQEventBus is a RabbitMQ-backed implementation of events.EventBus.
+// Events are published as JSON envelopes to a topic exchange using the event
+// name as routing key. Every instance consumes the service queue and hands
+// decoded, typed events to handlers registered through Subscribe.
+//
+// A delivery is acknowledged once the handlers succeeded; failures are
+// retried as the retry policy allows, then rejected to the dead-letter exchange.
+type RabbitMQEventBus struct {
+	conn        io.Closer
+	pubCh       publishChannel
+	subCh       io.Closer
+	exchange    string
+	registry    *events.Registry
+	retryPolicy infraworker.RetryPolicy
+	local       *events.InMemoryEventBus
+	done        chan struct{}
+	wg          sync.WaitGroup
+	once        sync.Once
+}
+
+// NewRabbitMQEventBus connects, declares the exchanges and queues, and starts consuming
+func NewRabbitMQEventBus(config RabbitMQEventBusConfig, registry *events.Registry) (*RabbitMQEventBus, error) {
+	conn, err := amqp.Dial(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	pubCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open publish channel: %w", err)
+	}
+
+	subCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open consume channel: %w", err)
+	}
+
+	if config.PrefetchCount > 0 {
+		if err := subCh.Qos(config.PrefetchCount, 0, false); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set QoS: %w", err)
+		}
+	}
+
+	// Declare exchange
+	if err := pubCh.ExchangeDeclare(config.Exchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Declare the dead-letter exchange and the queue keeping what reaches it
+	dlx := config.DeadLetterExchange
+	if dlx == "" {
+		dlx = config.Exchange + ".dlx"
+	}
+	if err := subCh.ExchangeDeclare(dlx, "fanout", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+	dlq := config.Queue + ".dlq"
+	if _, err := subCh.QueueDeclare(dlq, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err := subCh.QueueBind(dlq, "", dlx, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	// Declare queue and receive every event; unhandled names are acked and dropped
+	args := amqp.Table{"x-dead-letter-exchange": dlx}
+	if _, err := subCh.QueueDeclare(config.Queue, true, false, false, false, args); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare queue: %w", err)
+	}
+	if err := subCh.QueueBind(config.Queue, "#", config.Exchange, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to bind queue: %w", err)
+	}
+
+	msgs, err := subCh.Consume(config.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to consume messages: %w", err)
+	}
+
+	retryPolicy := infraworker.DefaultRetryPolicy()
+	if config.MaxRetries > 0 {
+		retryPolicy.MaxRetries = config.MaxRetries
+	}
+
+	return newRabbitMQEventBus(conn, pubCh, subCh, config.Exchange, msgs, registry, retryPolicy), nil
+}
+
+// newRabbitMQEventBus wires the bus around its channels and starts consuming msgs
+func newRabbitMQEventBus(conn io.Closer, pubCh publishChannel, subCh io.Closer, exchange string, msgs <-chan amqp.Delivery, registry *events.Registry, retryPolicy infraworker.RetryPolicy) *RabbitMQEventBus {
+	if registry == nil {
+		registry = events.NewRegistry()
+	}
+
+	b := &RabbitMQEventBus{
+		conn:        conn,
+		pubCh:       pubCh,
+		subCh:       subCh,
+		exchange:    exchange,
+		registry:    registry,
+		retryPolicy: retryPolicy,
+		local:       events.NewInMemoryEventBus(),
+		done:        make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.consume(msgs)
+
+	return b
+}
+
+// Publish sends the event to the exchange; handlers run when it is consumed
+func (b *RabbitMQEventBus) Publish(ctx context.Context, event events.Event) error {
+	if b.local.IsClosed() {
+		return events.ErrEventBusClosed
+	}
+
+	env, err := events.NewEnvelope(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to build envelope: %w", err)
+	}
+	body, err := env.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	return b.pubCh.PublishWithContext(
+		ctx,
+		b.exchange,
+		env.Name, // routing key
+		false,    // mandatory
+		false,    // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    env.ID,
+			Type:         env.Name,
+			Timestamp:    env.OccurredAt,
+			Body:         body,
+		},
+	)
+}
+
+// Subscribe registers a handler for events consumed from the queue
+func (b *RabbitMQEventBus) Subscribe(eventName string, handler events.EventHandler) {
+	b.local.Subscribe(eventName, handler)
+}
+
+// Unsubscribe removes a handler
+func (b *RabbitMQEventBus) Unsubscribe(eventName string, handler events.EventHandler) {
+	b.local.Unsubscribe(eventName, handler)
+}
+
+// Close stops consuming, waits for the in-flight message and closes the connection
+func (b *RabbitMQEventBus) Close() error {
+	var err error
+	b.once.Do(func() {
+		close(b.done)
+		_ = b.subCh.Close()
+		b.wg.Wait()
+		_ = b.local.Close()
+		_ = b.pubCh.Close()
+		err = b.conn.Close()
+	})
+	return err
+}
+
+func (b *RabbitMQEventBus) consume(msgs <-chan amqp.Delivery) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			b.handle(msg)
+		}
+	}
+}
+
+// handle hands msg to the local handlers, retrying failures as allowed by the
+// retry policy, and rejects messages that cannot be handled to the
+// dead-letter exchange
+func (b *RabbitMQEventBus) handle(msg amqp.Delivery) {
+	env, err := events.UnmarshalEnvelope(msg.Body)
+	if err != nil {
+		busLog.WithField("error", err).Error("Dead-lettering malformed event envelope")
+		_ = msg.Nack(false, false)
+		return
+	}
+
+	ctx, event, err := env.Decode(context.Background(), b.registry)
+	if err != nil {
+		busLog.WithFields(map[string]interface{}{"event": env.Name, "event_id": env.ID, "error": err}).
+			Error("Dead-lettering undecodable event")
+		_ = msg.Nack(false, false)
+		return
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = b.local.Publish(ctx, event)
+		if err == nil {
+			_ = msg.Ack(false)
+			return
+		}
+		if !b.retryPolicy.ShouldRetry(attempt, err.Error()) {
+			break
+		}
+		backoff := b.retryPolicy.CalculateBackoff(attempt + 1)
+		busLog.WithFields(map[string]interface{}{
+			"event":    env.Name,
+			"event_id": env.ID,
+			"attempt":  attempt + 1,
+			"backoff":  backoff.String(),
+			"error":    err,
+		}).Warn("Event handler failed, retrying")
+		select {
+		case <-b.done:
+			// Left unacknowledged; closing the channel requeues it
+			return
+		case <-time.After(backoff):
+		}
+	}
+
+	busLog.WithFields(map[string]interface{}{"event": env.Name, "event_id": env.ID, "error": err}).
+		Error("Event handler failed, moving to DLQ")
+	_ = msg.Nack(false, false)
+}
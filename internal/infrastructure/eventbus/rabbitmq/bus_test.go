@@ -0,0 +1,210 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlacedEvent struct {
+	OrderID string `json:"order_id"`
+}
+
+func (e orderPlacedEvent) EventName() string { return "order.placed" }
+func (e orderPlacedEvent) Payload() any      { return e }
+
+// fakeChannel records publishes and closes
+type fakeChannel struct {
+	mu        sync.Mutex
+	exchange  string
+	key       string
+	published []amqp.Publishing
+	closed    bool
+}
+
+func (c *fakeChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exchange, c.key = exchange, key
+	c.published = append(c.published, msg)
+	return nil
+}
+
+func (c *fakeChannel) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *fakeChannel) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// settlement is how a delivery was acknowledged
+type settlement struct {
+	ack     bool
+	requeue bool
+}
+
+// fakeAcknowledger reports how each delivery was settled
+type fakeAcknowledger struct{ settled chan settlement }
+
+func newFakeAcknowledger() *fakeAcknowledger {
+	return &fakeAcknowledger{settled: make(chan settlement, 1)}
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.settled <- settlement{ack: true}
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.settled <- settlement{requeue: requeue}
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func (a *fakeAcknowledger) wait(t *testing.T) settlement {
+	t.Helper()
+	select {
+	case s := <-a.settled:
+		return s
+	case <-time.After(time.Second):
+		t.Fatal("delivery was not settled")
+		return settlement{}
+	}
+}
+
+var fastRetries = infraworker.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1}
+
+func newTestBus(t *testing.T, policy infraworker.RetryPolicy) (*RabbitMQEventBus, *fakeChannel, *fakeChannel, chan amqp.Delivery) {
+	t.Helper()
+	registry := events.NewRegistry()
+	events.RegisterType[orderPlacedEvent](registry)
+	conn, pubCh, subCh := &fakeChannel{}, &fakeChannel{}, &fakeChannel{}
+	msgs := make(chan amqp.Delivery)
+	b := newRabbitMQEventBus(conn, pubCh, subCh, "events", msgs, registry, policy)
+	t.Cleanup(func() { b.Close() })
+	return b, pubCh, conn, msgs
+}
+
+func envelopeBody(t *testing.T, event events.Event) []byte {
+	t.Helper()
+	env, err := events.NewEnvelope(context.Background(), event)
+	require.NoError(t, err)
+	body, err := env.Marshal()
+	require.NoError(t, err)
+	return body
+}
+
+func TestRabbitMQEventBus_PublishSendsEnvelope(t *testing.T) {
+	b, pubCh, _, _ := newTestBus(t, fastRetries)
+
+	require.NoError(t, b.Publish(context.Background(), orderPlacedEvent{OrderID: "o-1"}))
+
+	require.Len(t, pubCh.published, 1)
+	msg := pubCh.published[0]
+	assert.Equal(t, "events", pubCh.exchange)
+	assert.Equal(t, "order.placed", pubCh.key, "events are routed by name")
+	assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+
+	env, err := events.UnmarshalEnvelope(msg.Body)
+	require.NoError(t, err)
+	assert.Equal(t, env.ID, msg.MessageId)
+	_, event, err := env.Decode(context.Background(), b.registry)
+	require.NoError(t, err)
+	assert.Equal(t, orderPlacedEvent{OrderID: "o-1"}, event)
+}
+
+func TestRabbitMQEventBus_Consume(t *testing.T) {
+	b, _, _, msgs := newTestBus(t, fastRetries)
+
+	var failures int
+	handled := make(chan orderPlacedEvent, 1)
+	b.Subscribe("order.placed", func(ctx context.Context, event events.Event) error {
+		if failures > 0 {
+			failures--
+			return errors.New("handler down")
+		}
+		handled <- event.(orderPlacedEvent)
+		return nil
+	})
+
+	// Rejecting without requeue moves the delivery to the dead-letter exchange
+	deadLettered := settlement{}
+
+	tests := []struct {
+		name     string
+		body     []byte
+		failures int
+		want     settlement
+	}{
+		{name: "handled", body: envelopeBody(t, orderPlacedEvent{OrderID: "o-1"}), want: settlement{ack: true}},
+		{name: "failures within the retries are retried", body: envelopeBody(t, orderPlacedEvent{OrderID: "o-1"}), failures: 2, want: settlement{ack: true}},
+		{name: "exhausted retries are dead-lettered", body: envelopeBody(t, orderPlacedEvent{}), failures: 3, want: deadLettered},
+		{name: "malformed envelope is dead-lettered", body: []byte("not json"), want: deadLettered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures = tt.failures
+			ack := newFakeAcknowledger()
+			msgs <- amqp.Delivery{Acknowledger: ack, Body: tt.body}
+
+			assert.Equal(t, tt.want, ack.wait(t))
+			if tt.want.ack {
+				assert.Equal(t, "o-1", (<-handled).OrderID)
+			}
+		})
+	}
+}
+
+func TestRabbitMQEventBus_CloseLeavesRetryingDeliveryUnsettled(t *testing.T) {
+	slowRetries := infraworker.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute, BackoffMultiplier: 1}
+	b, _, _, msgs := newTestBus(t, slowRetries)
+
+	attempted := make(chan struct{}, 1)
+	b.Subscribe("order.placed", func(ctx context.Context, event events.Event) error {
+		attempted <- struct{}{}
+		return errors.New("handler down")
+	})
+
+	ack := newFakeAcknowledger()
+	msgs <- amqp.Delivery{Acknowledger: ack, Body: envelopeBody(t, orderPlacedEvent{})}
+	<-attempted
+
+	require.NoError(t, b.Close())
+
+	// The broker requeues unacknowledged deliveries when the channel closes
+	select {
+	case s := <-ack.settled:
+		t.Fatalf("delivery was settled during shutdown: %+v", s)
+	default:
+	}
+}
+
+func TestRabbitMQEventBus_Close(t *testing.T) {
+	b, pubCh, conn, _ := newTestBus(t, fastRetries)
+
+	require.NoError(t, b.Close())
+	require.NoError(t, b.Close(), "Close is idempotent")
+
+	assert.True(t, pubCh.isClosed())
+	assert.True(t, conn.isClosed())
+	assert.ErrorIs(t, b.Publish(context.Background(), orderPlacedEvent{}), events.ErrEventBusClosed)
+}
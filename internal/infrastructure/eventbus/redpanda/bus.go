@@ -0,0 +1,270 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var busLog = logger.Module("eventbus.redpanda")
+
+// RedpandaEventBusConfig holds broker and consumer group settings for the event bus
+type RedpandaEventBusConfig struct {
+	Brokers       []string
+	Topic         string
+	ConsumerGroup string // instances of the same service share a group
+	// DeadLetterTopic receives events whose handlers still fail after
+	// MaxRetries retries (default: Topic + "-dlq")
+	DeadLetterTopic string
+	MaxRetries      int // 0 keeps the default retry policy
+}
+
+// messageReader is the part of *kafka.Reader the consumer uses
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter is the part of *kafka.Writer the bus uses
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// RedpandaEventBus is a Redpanda/Kafka-backed implementation of events.EventBus.
+// Events are written as JSON envelopes to a single topic keyed by event name;
+// the consumer group reads them back and hands decoded, typed events to
+// handlers registered through Subscribe.
+//
+// Offsets are committed once the handlers succeeded or the event was moved to
+// the dead-letter topic, so events are delivered at least once.
+type RedpandaEventBus struct {
+	writer      messageWriter
+	dlqWriter   messageWriter
+	reader      messageReader
+	registry    *events.Registry
+	retryPolicy infraworker.RetryPolicy
+	local       *events.InMemoryEventBus
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	once        sync.Once
+}
+
+// NewRedpandaEventBus creates the writers and starts the group consumer
+func NewRedpandaEventBus(config RedpandaEventBusConfig, registry *events.Registry) *RedpandaEventBus {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(config.Brokers...),
+		Topic:    config.Topic,
+		Balancer: &kafka.Hash{},
+	}
+
+	dlqTopic := config.DeadLetterTopic
+	if dlqTopic == "" {
+		dlqTopic = config.Topic + "-dlq"
+	}
+	dlqWriter := &kafka.Writer{
+		Addr:     kafka.TCP(config.Brokers...),
+		Topic:    dlqTopic,
+		Balancer: &kafka.Hash{},
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        config.Brokers,
+		Topic:          config.Topic,
+		GroupID:        config.ConsumerGroup,
+		StartOffset:    kafka.LastOffset,
+		CommitInterval: time.Second,
+		MaxBytes:       10e6, // 10MB
+	})
+
+	retryPolicy := infraworker.DefaultRetryPolicy()
+	if config.MaxRetries > 0 {
+		retryPolicy.MaxRetries = config.MaxRetries
+	}
+
+	return newRedpandaEventBus(writer, dlqWriter, reader, registry, retryPolicy)
+}
+
+// newRedpandaEventBus wires the bus around its clients and starts the consumer
+func newRedpandaEventBus(writer, dlqWriter messageWriter, reader messageReader, registry *events.Registry, retryPolicy infraworker.RetryPolicy) *RedpandaEventBus {
+	if registry == nil {
+		registry = events.NewRegistry()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &RedpandaEventBus{
+		writer:      writer,
+		dlqWriter:   dlqWriter,
+		reader:      reader,
+		registry:    registry,
+		retryPolicy: retryPolicy,
+		local:       events.NewInMemoryEventBus(),
+		cancel:      cancel,
+	}
+
+	b.wg.Add(1)
+	go b.consume(ctx)
+
+	return b
+}
+
+// Publish writes the event to the topic; handlers run when it is consumed
+func (b *RedpandaEventBus) Publish(ctx context.Context, event events.Event) error {
+	if b.local.IsClosed() {
+		return events.ErrEventBusClosed
+	}
+
+	env, err := events.NewEnvelope(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to build envelope: %w", err)
+	}
+	body, err := env.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	return b.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(env.Name),
+		Value: body,
+		Time:  env.OccurredAt,
+		Headers: []kafka.Header{
+			{Key: "event_name", Value: []byte(env.Name)},
+			{Key: "event_id", Value: []byte(env.ID)},
+		},
+	})
+}
+
+// Subscribe registers a handler for events consumed from the topic
+func (b *RedpandaEventBus) Subscribe(eventName string, handler events.EventHandler) {
+	b.local.Subscribe(eventName, handler)
+}
+
+// Unsubscribe removes a handler
+func (b *RedpandaEventBus) Unsubscribe(eventName string, handler events.EventHandler) {
+	b.local.Unsubscribe(eventName, handler)
+}
+
+// Close stops the consumer, waits for the in-flight message and closes the clients
+func (b *RedpandaEventBus) Close() error {
+	var err error
+	b.once.Do(func() {
+		b.cancel()
+		b.wg.Wait()
+		_ = b.local.Close()
+		err = errors.Join(b.reader.Close(), b.writer.Close(), b.dlqWriter.Close())
+	})
+	return err
+}
+
+func (b *RedpandaEventBus) consume(ctx context.Context) {
+	defer b.wg.Done()
+
+	for {
+		msg, err := b.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			busLog.WithField("error", err).Error("Failed to read event")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		// Leave the offset uncommitted when shutdown interrupts the
+		// message, so the group redelivers it
+		if err := b.handle(ctx, msg); err != nil {
+			return
+		}
+		if err := b.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			busLog.WithField("error", err).Error("Failed to commit event offset")
+		}
+	}
+}
+
+// handle hands msg to the local handlers, retrying failures as allowed by the
+// retry policy, and dead-letters messages that cannot be handled. It only
+// returns an error when ctx ends before msg was handled or dead-lettered.
+func (b *RedpandaEventBus) handle(ctx context.Context, msg kafka.Message) error {
+	env, err := events.UnmarshalEnvelope(msg.Value)
+	if err != nil {
+		busLog.WithField("error", err).Error("Dead-lettering malformed event envelope")
+		return b.deadLetter(ctx, msg, err)
+	}
+
+	eventCtx, event, err := env.Decode(ctx, b.registry)
+	if err != nil {
+		busLog.WithFields(map[string]interface{}{"event": env.Name, "event_id": env.ID, "error": err}).
+			Error("Dead-lettering undecodable event")
+		return b.deadLetter(ctx, msg, err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = b.local.Publish(eventCtx, event)
+		if err == nil {
+			return nil
+		}
+		if !b.retryPolicy.ShouldRetry(attempt, err.Error()) {
+			break
+		}
+		backoff := b.retryPolicy.CalculateBackoff(attempt + 1)
+		busLog.WithFields(map[string]interface{}{
+			"event":    env.Name,
+			"event_id": env.ID,
+			"attempt":  attempt + 1,
+			"backoff":  backoff.String(),
+			"error":    err,
+		}).Warn("Event handler failed, retrying")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+
+	busLog.WithFields(map[string]interface{}{"event": env.Name, "event_id": env.ID, "error": err}).
+		Error("Event handler failed, moving to DLQ")
+	return b.deadLetter(ctx, msg, err)
+}
+
+// deadLetter copies msg to the dead-letter topic with the failure attached,
+// retrying until the write succeeds or ctx ends
+func (b *RedpandaEventBus) deadLetter(ctx context.Context, msg kafka.Message, cause error) error {
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "error", Value: []byte(cause.Error())},
+		kafka.Header{Key: "dlq_timestamp", Value: []byte(time.Now().Format(time.RFC3339))},
+	)
+	dead := kafka.Message{Key: msg.Key, Value: msg.Value, Headers: headers}
+
+	for {
+		err := b.dlqWriter.WriteMessages(ctx, dead)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		busLog.WithField("error", err).Error("Failed to dead-letter event")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlacedEvent struct {
+	OrderID string `json:"order_id"`
+}
+
+func (e orderPlacedEvent) EventName() string { return "order.placed" }
+func (e orderPlacedEvent) Payload() any      { return e }
+
+// fakeReader serves queued messages and records commits
+type fakeReader struct {
+	msgs      chan kafka.Message
+	mu        sync.Mutex
+	committed []kafka.Message
+	closed    bool
+}
+
+func newFakeReader(msgs ...kafka.Message) *fakeReader {
+	r := &fakeReader{msgs: make(chan kafka.Message, len(msgs))}
+	for _, msg := range msgs {
+		r.msgs <- msg
+	}
+	return r
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	case msg := <-r.msgs:
+		return msg, nil
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return nil
+}
+
+func (r *fakeReader) commits() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.committed)
+}
+
+// fakeWriter records the messages written to it
+type fakeWriter struct {
+	mu      sync.Mutex
+	written []kafka.Message
+	closed  bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func (w *fakeWriter) messages() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]kafka.Message(nil), w.written...)
+}
+
+var fastRetries = infraworker.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1}
+
+func newTestBus(t *testing.T, reader *fakeReader, policy infraworker.RetryPolicy) (*RedpandaEventBus, *fakeWriter, *fakeWriter) {
+	t.Helper()
+	registry := events.NewRegistry()
+	events.RegisterType[orderPlacedEvent](registry)
+	writer, dlqWriter := &fakeWriter{}, &fakeWriter{}
+	b := newRedpandaEventBus(writer, dlqWriter, reader, registry, policy)
+	t.Cleanup(func() { b.Close() })
+	return b, writer, dlqWriter
+}
+
+func envelopeMessage(t *testing.T, event events.Event) kafka.Message {
+	t.Helper()
+	env, err := events.NewEnvelope(context.Background(), event)
+	require.NoError(t, err)
+	body, err := env.Marshal()
+	require.NoError(t, err)
+	return kafka.Message{Key: []byte(env.Name), Value: body}
+}
+
+func TestRedpandaEventBus_CommitsAfterHandlersSucceed(t *testing.T) {
+	reader := newFakeReader(envelopeMessage(t, orderPlacedEvent{OrderID: "o-1"}))
+	b, _, dlqWriter := newTestBus(t, reader, fastRetries)
+
+	var calls atomic.Int32
+	var got orderPlacedEvent
+	b.Subscribe("order.placed", func(ctx context.Context, event events.Event) error {
+		if calls.Add(1) == 1 {
+			return errors.New("temporary")
+		}
+		got = event.(orderPlacedEvent)
+		return nil
+	})
+
+	require.Eventually(t, func() bool { return reader.commits() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), calls.Load(), "the failed attempt is retried")
+	assert.Equal(t, "o-1", got.OrderID)
+	assert.Empty(t, dlqWriter.messages())
+}
+
+func TestRedpandaEventBus_DeadLettersFailingEvents(t *testing.T) {
+	msg := envelopeMessage(t, orderPlacedEvent{OrderID: "o-1"})
+	reader := newFakeReader(msg)
+	b, _, dlqWriter := newTestBus(t, reader, fastRetries)
+
+	var calls atomic.Int32
+	b.Subscribe("order.placed", func(ctx context.Context, event events.Event) error {
+		calls.Add(1)
+		return errors.New("handler down")
+	})
+
+	require.Eventually(t, func() bool { return reader.commits() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(3), calls.Load(), "one attempt and two retries")
+
+	dead := dlqWriter.messages()
+	require.Len(t, dead, 1)
+	assert.Equal(t, msg.Value, dead[0].Value)
+	assert.Contains(t, dead[0].Headers, kafka.Header{Key: "error", Value: []byte("handler down")})
+}
+
+func TestRedpandaEventBus_CloseLeavesInterruptedEventUncommitted(t *testing.T) {
+	reader := newFakeReader(envelopeMessage(t, orderPlacedEvent{OrderID: "o-1"}))
+	slowRetries := infraworker.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute, BackoffMultiplier: 1}
+	b, _, dlqWriter := newTestBus(t, reader, slowRetries)
+
+	failed := make(chan struct{})
+	var once sync.Once
+	b.Subscribe("order.placed", func(ctx context.Context, event events.Event) error {
+		once.Do(func() { close(failed) })
+		return errors.New("temporary")
+	})
+	<-failed
+
+	start := time.Now()
+	require.NoError(t, b.Close())
+	assert.Less(t, time.Since(start), time.Second, "Close does not wait out the backoff")
+	assert.Zero(t, reader.commits(), "the group redelivers the event")
+	assert.Empty(t, dlqWriter.messages())
+}
+
+func TestRedpandaEventBus_PublishWritesEnvelope(t *testing.T) {
+	b, writer, _ := newTestBus(t, newFakeReader(), fastRetries)
+
+	require.NoError(t, b.Publish(context.Background(), orderPlacedEvent{OrderID: "o-1"}))
+
+	written := writer.messages()
+	require.Len(t, written, 1)
+	msg := written[0]
+	assert.Equal(t, "order.placed", string(msg.Key), "events are keyed by name")
+
+	env, err := events.UnmarshalEnvelope(msg.Value)
+	require.NoError(t, err)
+	assert.Contains(t, msg.Headers, kafka.Header{Key: "event_id", Value: []byte(env.ID)})
+	_, event, err := env.Decode(context.Background(), b.registry)
+	require.NoError(t, err)
+	assert.Equal(t, orderPlacedEvent{OrderID: "o-1"}, event)
+}
+
+func TestRedpandaEventBus_DeadLettersMalformedEnvelopes(t *testing.T) {
+	reader := newFakeReader(kafka.Message{Value: []byte("not json")})
+	_, _, dlqWriter := newTestBus(t, reader, fastRetries)
+
+	require.Eventually(t, func() bool { return reader.commits() == 1 }, time.Second, time.Millisecond)
+	dead := dlqWriter.messages()
+	require.Len(t, dead, 1)
+	assert.Equal(t, []byte("not json"), dead[0].Value)
+}
+
+func TestRedpandaEventBus_Close(t *testing.T) {
+	reader := newFakeReader()
+	b, writer, dlqWriter := newTestBus(t, reader, fastRetries)
+
+	require.NoError(t, b.Close())
+	require.NoError(t, b.Close(), "Close is idempotent")
+
+	assert.True(t, reader.closed)
+	assert.True(t, writer.closed)
+	assert.True(t, dlqWriter.closed)
+	assert.ErrorIs(t, b.Publish(context.Background(), orderPlacedEvent{}), events.ErrEventBusClosed)
+}
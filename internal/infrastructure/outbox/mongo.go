@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore keeps outbox messages in the event_outbox collection.
+// Saving requires a transaction, so MongoDB must run as a replica set.
+type MongoStore struct {
+	col *mongo.Collection
+}
+
+func NewMongoStore(client *mongo.Client, dbName string) *MongoStore {
+	col := client.Database(dbName).Collection("event_outbox")
+	return &MongoStore{col: col}
+}
+
+func (s *MongoStore) Save(ctx context.Context, msg *events.OutboxMessage) error {
+	session := sharedCtx.GetObjectFromContext[mongo.Session](ctx, sharedCtx.MongoSessionKey)
+	if session == nil {
+		return events.ErrNoTransaction
+	}
+	doc := bson.M{
+		"_id":        msg.ID,
+		"event_name": msg.EventName,
+		"payload":    string(msg.Payload),
+		"attempts":   0,
+		"created_at": msg.CreatedAt,
+	}
+	if len(msg.TraceContext) > 0 {
+		doc["trace_context"] = map[string]string(msg.TraceContext)
+	}
+	if msg.RequestID != "" {
+		doc["request_id"] = msg.RequestID
+	}
+	_, err := s.col.InsertOne(mongo.NewSessionContext(ctx, *session), doc)
+	return err
+}
+
+// ClaimPending leases messages one at a time; FindOneAndUpdate keeps each
+// claim atomic when several relays poll the same collection.
+func (s *MongoStore) ClaimPending(ctx context.Context, opts events.ClaimOptions) ([]events.OutboxMessage, error) {
+	var msgs []events.OutboxMessage
+	for len(msgs) < opts.Limit {
+		now := time.Now().UTC()
+		filter := bson.M{
+			"dispatched_at": nil,
+			"attempts":      bson.M{"$lt": opts.MaxAttempts},
+			"$or": bson.A{
+				bson.M{"locked_until": nil},
+				bson.M{"locked_until": bson.M{"$lt": now}},
+			},
+		}
+		upd := bson.M{"$set": bson.M{"locked_until": now.Add(opts.Lease)}}
+		findOpts := options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "created_at", Value: 1}}).
+			SetReturnDocument(options.After)
+
+		var doc mongoOutboxMessage
+		if err := s.col.FindOneAndUpdate(ctx, filter, upd, findOpts).Decode(&doc); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+			return nil, err
+		}
+		msgs = append(msgs, doc.toMessage())
+	}
+	return msgs, nil
+}
+
+func (s *MongoStore) MarkDispatched(ctx context.Context, id string) error {
+	upd := bson.M{
+		"$set":   bson.M{"dispatched_at": time.Now().UTC()},
+		"$unset": bson.M{"locked_until": ""},
+	}
+	_, err := s.col.UpdateOne(ctx, bson.M{"_id": id}, upd)
+	return err
+}
+
+// MarkFailed keeps the lease until retryAt so the message backs off before
+// it is claimed again
+func (s *MongoStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	upd := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_error": cause.Error(), "locked_until": retryAt.UTC()},
+	}
+	_, err := s.col.UpdateOne(ctx, bson.M{"_id": id}, upd)
+	return err
+}
+
+// mongoOutboxMessage stores the payload as a string so it stays readable in mongosh
+type mongoOutboxMessage struct {
+	ID           string            `bson:"_id"`
+	EventName    string            `bson:"event_name"`
+	Payload      string            `bson:"payload"`
+	Attempts     int               `bson:"attempts"`
+	LastError    *string           `bson:"last_error,omitempty"`
+	LockedUntil  *time.Time        `bson:"locked_until,omitempty"`
+	CreatedAt    time.Time         `bson:"created_at"`
+	DispatchedAt *time.Time        `bson:"dispatched_at,omitempty"`
+	TraceContext map[string]string `bson:"trace_context,omitempty"`
+	RequestID    string            `bson:"request_id,omitempty"`
+}
+
+func (m mongoOutboxMessage) toMessage() events.OutboxMessage {
+	return events.OutboxMessage{
+		ID:           m.ID,
+		EventName:    m.EventName,
+		Payload:      []byte(m.Payload),
+		Attempts:     m.Attempts,
+		LastError:    m.LastError,
+		LockedUntil:  m.LockedUntil,
+		CreatedAt:    m.CreatedAt,
+		DispatchedAt: m.DispatchedAt,
+		TraceContext: m.TraceContext,
+		RequestID:    m.RequestID,
+	}
+}
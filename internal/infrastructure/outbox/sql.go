@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const outboxColumns = `id,event_name,payload,attempts,last_error,locked_until,created_at,dispatched_at,trace_context,request_id`
+
+// sqliteOutboxColumns reads payload as a BLOB: SQLite returns TEXT as a
+// string, which json.RawMessage cannot scan
+const sqliteOutboxColumns = `id,event_name,CAST(payload AS BLOB) AS payload,attempts,last_error,locked_until,created_at,dispatched_at,trace_context,request_id`
+
+// SQLStore keeps outbox messages in the event_outbox table of a Postgres,
+// MySQL or SQLite database
+type SQLStore struct {
+	db      *sqlx.DB
+	dialect sqldialect.Dialect
+}
+
+func NewSQLStore(db *sqlx.DB) *SQLStore {
+	return &SQLStore{db: db, dialect: sqldialect.Of(db)}
+}
+
+func (s *SQLStore) Save(ctx context.Context, msg *events.OutboxMessage) error {
+	tx := sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey)
+	if tx == nil {
+		return events.ErrNoTransaction
+	}
+	query := tx.Rebind(`INSERT INTO event_outbox (id,event_name,payload,created_at,trace_context,request_id) VALUES (?,?,?,?,?,?)`)
+	_, err := tx.ExecContext(ctx, query, msg.ID, msg.EventName, string(msg.Payload), msg.CreatedAt, msg.TraceContext, msg.RequestID)
+	return err
+}
+
+// ClaimPending leases up to opts.Limit pending messages. Concurrent relays
+// skip rows another relay has locked on Postgres and MySQL; SQLite serializes
+// writers, so the single claiming statement cannot race.
+func (s *SQLStore) ClaimPending(ctx context.Context, opts events.ClaimOptions) ([]events.OutboxMessage, error) {
+	if s.dialect == sqldialect.MySQL {
+		return s.claimPendingInTx(ctx, opts)
+	}
+
+	lock, columns := "", outboxColumns
+	switch s.dialect {
+	case sqldialect.Postgres:
+		lock = "FOR UPDATE SKIP LOCKED"
+	case sqldialect.SQLite:
+		columns = sqliteOutboxColumns
+	}
+	var msgs []events.OutboxMessage
+	now := time.Now().UTC()
+	query := s.db.Rebind(`UPDATE event_outbox SET locked_until=?
+		WHERE id IN (
+			SELECT id FROM event_outbox
+			WHERE dispatched_at IS NULL AND attempts < ? AND (locked_until IS NULL OR locked_until < ?)
+			ORDER BY created_at
+			LIMIT ?
+			` + lock + `
+		)
+		RETURNING ` + columns)
+	if err := s.db.SelectContext(ctx, &msgs, query, now.Add(opts.Lease), opts.MaxAttempts, now, opts.Limit); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// claimPendingInTx claims messages on MySQL, which has neither RETURNING nor
+// updates of a table filtered by a subquery on the same table: the rows are
+// locked (SKIP LOCKED needs MySQL 8.0), leased and read back in one transaction
+func (s *SQLStore) claimPendingInTx(ctx context.Context, opts events.ClaimOptions) (msgs []events.OutboxMessage, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	now := time.Now().UTC()
+	var ids []string
+	query := `SELECT id FROM event_outbox
+		WHERE dispatched_at IS NULL AND attempts < ? AND (locked_until IS NULL OR locked_until < ?)
+		ORDER BY created_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED`
+	if err := tx.SelectContext(ctx, &ids, query, opts.MaxAttempts, now, opts.Limit); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`UPDATE event_outbox SET locked_until=? WHERE id IN (?)`, now.Add(opts.Lease), ids)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(`SELECT `+outboxColumns+` FROM event_outbox WHERE id IN (?) ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SelectContext(ctx, &msgs, query, args...); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+func (s *SQLStore) MarkDispatched(ctx context.Context, id string) error {
+	query := s.db.Rebind(`UPDATE event_outbox SET dispatched_at=?, locked_until=NULL WHERE id=?`)
+	_, err := s.db.ExecContext(ctx, query, time.Now().UTC(), id)
+	return err
+}
+
+// MarkFailed keeps the lease until retryAt so the message backs off before
+// it is claimed again
+func (s *SQLStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	query := s.db.Rebind(`UPDATE event_outbox SET attempts=attempts+1, last_error=?, locked_until=? WHERE id=?`)
+	_, err := s.db.ExecContext(ctx, query, cause.Error(), retryAt.UTC(), id)
+	return err
+}
@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect/sqltest"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLStore_SQLite(t *testing.T) {
+	db := sqltest.OpenSQLite(t)
+	s := NewSQLStore(db)
+	ctx := context.Background()
+	opts := events.ClaimOptions{Limit: 10, Lease: time.Minute, MaxAttempts: 2}
+
+	msg := &events.OutboxMessage{
+		ID:           "msg-1",
+		EventName:    "order.placed",
+		Payload:      []byte(`{"order_id":"o-1"}`),
+		CreatedAt:    time.Now().UTC(),
+		TraceContext: tracing.Carrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		RequestID:    "req-1",
+	}
+	assert.ErrorIs(t, s.Save(ctx, msg), events.ErrNoTransaction)
+
+	tx, err := db.Beginx()
+	require.NoError(t, err)
+	require.NoError(t, s.Save(context.WithValue(ctx, sharedCtx.PostgresTxKey, tx), msg))
+	require.NoError(t, tx.Commit())
+
+	claimed, err := s.ClaimPending(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "order.placed", claimed[0].EventName)
+	assert.JSONEq(t, `{"order_id":"o-1"}`, string(claimed[0].Payload))
+	assert.Equal(t, msg.TraceContext, claimed[0].TraceContext)
+	assert.Equal(t, "req-1", claimed[0].RequestID)
+	assert.NotNil(t, claimed[0].LockedUntil)
+
+	claimed, err = s.ClaimPending(ctx, opts)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "a leased message is not claimed again")
+
+	require.NoError(t, s.MarkFailed(ctx, "msg-1", errors.New("bus down"), time.Now().Add(time.Minute)))
+	claimed, err = s.ClaimPending(ctx, opts)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "a failed message backs off")
+
+	_, err = db.Exec(`UPDATE event_outbox SET locked_until = ?`, time.Now().UTC().Add(-time.Second))
+	require.NoError(t, err)
+	claimed, err = s.ClaimPending(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1, "a failed message is retried after its backoff")
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.Equal(t, "bus down", *claimed[0].LastError)
+
+	require.NoError(t, s.MarkDispatched(ctx, "msg-1"))
+	_, err = db.Exec(`UPDATE event_outbox SET locked_until = NULL`)
+	require.NoError(t, err)
+	claimed, err = s.ClaimPending(ctx, opts)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "a dispatched message is done")
+}
@@ -0,0 +1,94 @@
+// Package tracing installs the OpenTelemetry SDK as the global tracer provider
+// with an OTLP or stdout exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// DefaultServiceName names the service in exported spans unless configured
+const DefaultServiceName = "go-pste-monolith"
+
+// Config selects the exporter and describes the service
+type Config struct {
+	Exporter    string            // otlp, stdout
+	ServiceName string            // defaults to DefaultServiceName
+	Endpoint    string            // OTLP gRPC collector host:port; empty uses OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317
+	Insecure    bool              // OTLP without TLS
+	Headers     map[string]string // OTLP request headers, e.g. collector API keys
+	SampleRatio float64           // fraction of new traces recorded; <= 0 or >= 1 records all
+}
+
+// Setup installs a tracer provider exporting through the configured exporter
+// and the W3C trace context and baggage propagators. The returned function
+// flushes pending spans and must be called before the process exits.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	exporter, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 && config.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(config.SampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Continue the caller's sampling decision so traces are never cut in half
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q (want %s or %s)", config.Exporter, ExporterOTLP, ExporterStdout)
+	}
+}
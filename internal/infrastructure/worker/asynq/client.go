@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	"github.com/hibiken/asynq"
@@ -23,14 +25,17 @@ func NewAsynqClient(redisURL string) *AsynqClient {
 	}
 }
 
-// Enqueue enqueues a task immediately
+// Enqueue enqueues a task immediately; the trace context and request ID travel in the payload
 func (c *AsynqClient) Enqueue(
 	ctx context.Context,
 	taskName string,
 	payload sharedworker.TaskPayload,
 	options ...sharedworker.Option,
-) error {
-	data, err := json.Marshal(payload)
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
+	data, err := json.Marshal(requestid.InjectTaskPayload(ctx, tracing.InjectTaskPayload(ctx, payload)))
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
@@ -67,8 +72,11 @@ func (c *AsynqClient) EnqueueDelayed(
 	payload sharedworker.TaskPayload,
 	delay time.Duration,
 	options ...sharedworker.Option,
-) error {
-	data, err := json.Marshal(payload)
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
+	data, err := json.Marshal(requestid.InjectTaskPayload(ctx, tracing.InjectTaskPayload(ctx, payload)))
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
@@ -98,6 +106,11 @@ func (c *AsynqClient) EnqueueDelayed(
 	return nil
 }
 
+// Health pings the Redis server backing the queue
+func (c *AsynqClient) Health(ctx context.Context) error {
+	return c.client.Ping()
+}
+
 // Close closes the Asynq client
 func (c *AsynqClient) Close() error {
 	return c.client.Close()
@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	"github.com/hibiken/asynq"
@@ -15,8 +19,12 @@ type AsynqServer struct {
 	srv      *asynq.Server
 	mux      *asynq.ServeMux
 	handlers map[string]sharedworker.TaskHandler
+	metrics  *metrics.Metrics
 }
 
+// backendName labels the task metrics and spans of this backend
+const backendName = "asynq"
+
 // NewAsynqServer creates a new Asynq server
 func NewAsynqServer(redisURL string, concurrency int) *AsynqServer {
 	return &AsynqServer{
@@ -36,21 +44,55 @@ func NewAsynqServer(redisURL string, concurrency int) *AsynqServer {
 	}
 }
 
+// SetMetrics records task metrics; nil disables them
+func (s *AsynqServer) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
 // RegisterHandler registers a handler for a task type
 func (s *AsynqServer) RegisterHandler(taskName string, handler sharedworker.TaskHandler) error {
 	s.handlers[taskName] = handler
 	s.mux.HandleFunc(taskName, func(ctx context.Context, t *asynq.Task) error {
-		// Convert Asynq task payload to sharedworker.TaskPayload
-		var payload sharedworker.TaskPayload
-		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
-			return fmt.Errorf("failed to unmarshal payload: %w", err)
-		}
-
-		return handler(ctx, payload)
+		start := time.Now()
+		err := s.process(ctx, taskName, t, handler)
+		s.observe(ctx, taskName, time.Since(start), err)
+		return err
 	})
 	return nil
 }
 
+// process decodes the payload and runs handler in a span continuing the trace
+// of the enqueueing side
+func (s *AsynqServer) process(ctx context.Context, taskName string, t *asynq.Task, handler sharedworker.TaskHandler) (err error) {
+	// Convert Asynq task payload to sharedworker.TaskPayload
+	var payload sharedworker.TaskPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	ctx = requestid.ExtractTaskPayload(ctx, payload)
+	ctx = tracing.ExtractTaskPayload(ctx, payload)
+	ctx, span := tracing.StartTask(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+	return handler(ctx, payload)
+}
+
+// observe records the run; Asynq archives a failed task once its retries are
+// used up, which is counted as dead-lettered
+func (s *AsynqServer) observe(ctx context.Context, taskName string, duration time.Duration, err error) {
+	s.metrics.ObserveTask(backendName, taskName, duration, err)
+	if err == nil {
+		return
+	}
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retried >= maxRetry {
+		s.metrics.TaskDeadLettered(backendName, taskName)
+	} else {
+		s.metrics.TaskRetried(backendName, taskName)
+	}
+}
+
 // Start starts the Asynq worker server
 func (s *AsynqServer) Start(ctx context.Context) error {
 	return s.srv.Start(s.mux)
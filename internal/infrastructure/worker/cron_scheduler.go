@@ -3,10 +3,10 @@ package worker
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 )
 
@@ -22,6 +22,8 @@ var (
 	Monthly     = sharedworker.Monthly
 )
 
+var cronLog = logger.Module("worker.cron")
+
 // CronScheduler schedules recurring tasks based on cron expressions
 type CronScheduler struct {
 	jobs      map[string]*CronJob
@@ -75,7 +77,7 @@ func (cs *CronScheduler) AddJob(id, taskName string, schedule CronExpression, pa
 	}
 
 	cs.jobs[id] = job
-	log.Printf("Added cron job: %s | Next run: %v\n", id, job.NextRun)
+	cronLog.WithFields(map[string]interface{}{"cron_job_id": id, "next_run": job.NextRun}).Info("Added cron job")
 	return nil
 }
 
@@ -89,7 +91,7 @@ func (cs *CronScheduler) RemoveJob(id string) error {
 	}
 
 	delete(cs.jobs, id)
-	log.Printf("Removed cron job: %s\n", id)
+	cronLog.WithField("cron_job_id", id).Info("Removed cron job")
 	return nil
 }
 
@@ -123,7 +125,7 @@ func (cs *CronScheduler) DisableJob(id string) error {
 
 // Start starts the cron scheduler
 func (cs *CronScheduler) Start(ctx context.Context) error {
-	log.Println("Starting cron scheduler...")
+	cronLog.Info("Starting cron scheduler")
 
 	for {
 		select {
@@ -179,15 +181,18 @@ func (cs *CronScheduler) executeJob(ctx context.Context, job *CronJob) {
 
 	// Enqueue the task
 	if err := cs.client.Enqueue(ctx, job.TaskName, job.Payload); err != nil {
-		log.Printf("Failed to enqueue cron job %s: %v\n", job.ID, err)
+		cronLog.WithFields(map[string]interface{}{"cron_job_id": job.ID, "error": err}).Error("Failed to enqueue cron job")
 		return
 	}
 
 	job.LastRun = time.Now()
 	job.NextRun = cs.calculateNextRun(job.Schedule, time.Now())
 
-	log.Printf("Executed cron job: %s | Last run: %v | Next run: %v\n",
-		job.ID, job.LastRun, job.NextRun)
+	cronLog.WithFields(map[string]interface{}{
+		"cron_job_id": job.ID,
+		"last_run":    job.LastRun,
+		"next_run":    job.NextRun,
+	}).Info("Executed cron job")
 }
 
 // calculateNextRun calculates the next execution time for a job
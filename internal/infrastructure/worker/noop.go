@@ -42,6 +42,11 @@ func (c *NoOpClient) Close() error {
 	return nil
 }
 
+// Health is always healthy for no-op
+func (c *NoOpClient) Health(ctx context.Context) error {
+	return nil
+}
+
 // NoOpServer is a no-op implementation of the Server interface
 // Used when workers are disabled via feature flags
 type NoOpServer struct{}
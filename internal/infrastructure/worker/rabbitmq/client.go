@@ -3,9 +3,11 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -54,13 +56,16 @@ func NewRabbitMQClient(url, exchange, queue string) (*RabbitMQClient, error) {
 	}, nil
 }
 
-// Enqueue enqueues a task immediately
+// Enqueue enqueues a task immediately; the trace context travels in the headers
 func (c *RabbitMQClient) Enqueue(
 	ctx context.Context,
 	taskName string,
 	payload sharedworker.TaskPayload,
 	options ...sharedworker.Option,
-) error {
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
@@ -76,6 +81,7 @@ func (c *RabbitMQClient) Enqueue(
 			ContentType:  "application/json",
 			DeliveryMode: amqp.Persistent,
 			Body:         data,
+			Headers:      injectHeaders(ctx, nil),
 		},
 	)
 }
@@ -89,7 +95,10 @@ func (c *RabbitMQClient) EnqueueDelayed(
 	payload sharedworker.TaskPayload,
 	delay time.Duration,
 	options ...sharedworker.Option,
-) error {
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
 	// Use RabbitMQ Delayed Message Plugin for delayed delivery
 	// Plugin: https://github.com/rabbitmq/rabbitmq-delayed-message-exchange
 	// Install with: rabbitmq-plugins enable rabbitmq_delayed_message_exchange
@@ -99,9 +108,9 @@ func (c *RabbitMQClient) EnqueueDelayed(
 	}
 
 	// Set x-delay header in milliseconds
-	headers := amqp.Table{
+	headers := injectHeaders(ctx, amqp.Table{
 		"x-delay": int64(delay.Milliseconds()),
-	}
+	})
 
 	return c.channel.PublishWithContext(
 		ctx,
@@ -118,6 +127,14 @@ func (c *RabbitMQClient) EnqueueDelayed(
 	)
 }
 
+// Health reports whether the connection and channel are still open
+func (c *RabbitMQClient) Health(ctx context.Context) error {
+	if c.conn.IsClosed() || c.channel.IsClosed() {
+		return errors.New("rabbitmq connection is closed")
+	}
+	return nil
+}
+
 // Close closes the RabbitMQ client
 func (c *RabbitMQClient) Close() error {
 	if err := c.channel.Close(); err != nil {
@@ -0,0 +1,45 @@
+package rabbitmq
+
+import (
+	"context"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// injectHeaders adds the trace context and request ID of ctx to the message
+// headers
+func injectHeaders(ctx context.Context, headers amqp.Table) amqp.Table {
+	carrier := tracing.Inject(ctx)
+	if id := requestid.FromContext(ctx); id != "" {
+		if carrier == nil {
+			carrier = tracing.Carrier{}
+		}
+		carrier[requestid.MetadataKey] = id
+	}
+	for k, v := range carrier {
+		if headers == nil {
+			headers = amqp.Table{}
+		}
+		headers[k] = v
+	}
+	return headers
+}
+
+// extractHeaders continues the trace and restores the request ID carried in
+// the message headers
+func extractHeaders(ctx context.Context, headers amqp.Table) context.Context {
+	carrier := tracing.Carrier{}
+	for k, v := range headers {
+		if s, ok := v.(string); ok {
+			carrier[k] = s
+		}
+	}
+	if id := carrier[requestid.MetadataKey]; requestid.Valid(id) {
+		ctx = sharedctx.WithRequestID(ctx, id)
+	}
+	return tracing.Extract(ctx, carrier)
+}
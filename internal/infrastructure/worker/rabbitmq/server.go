@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -18,8 +21,12 @@ type RabbitMQServer struct {
 	queue    string
 	handlers map[string]sharedworker.TaskHandler
 	done     chan struct{}
+	metrics  *metrics.Metrics
 }
 
+// backendName labels the task metrics and spans of this backend
+const backendName = "rabbitmq"
+
 // NewRabbitMQServer creates a new RabbitMQ server
 func NewRabbitMQServer(url, exchange, queue string, prefetchCount int) (*RabbitMQServer, error) {
 	conn, err := amqp.Dial(url)
@@ -64,6 +71,11 @@ func NewRabbitMQServer(url, exchange, queue string, prefetchCount int) (*RabbitM
 	}, nil
 }
 
+// SetMetrics records task metrics; nil disables them
+func (s *RabbitMQServer) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
 // RegisterHandler registers a handler for a task type
 func (s *RabbitMQServer) RegisterHandler(taskName string, handler sharedworker.TaskHandler) error {
 	s.handlers[taskName] = handler
@@ -104,13 +116,19 @@ func (s *RabbitMQServer) Start(ctx context.Context) error {
 			var payload sharedworker.TaskPayload
 			if err := json.Unmarshal(msg.Body, &payload); err != nil {
 				// Payload is invalid, nack and don't requeue
+				s.metrics.ObserveTask(backendName, msg.RoutingKey, 0, err)
+				s.metrics.TaskDeadLettered(backendName, msg.RoutingKey)
 				msg.Nack(false, false)
 				continue
 			}
 
 			// Process the task
-			if err := handler(ctx, payload); err != nil {
+			start := time.Now()
+			err := s.process(ctx, msg, handler, payload)
+			s.metrics.ObserveTask(backendName, msg.RoutingKey, time.Since(start), err)
+			if err != nil {
 				// Task failed, nack and requeue
+				s.metrics.TaskRetried(backendName, msg.RoutingKey)
 				msg.Nack(false, true)
 				continue
 			}
@@ -121,6 +139,14 @@ func (s *RabbitMQServer) Start(ctx context.Context) error {
 	}
 }
 
+// process runs handler in a span continuing the trace of the enqueueing side
+func (s *RabbitMQServer) process(ctx context.Context, msg amqp.Delivery, handler sharedworker.TaskHandler, payload sharedworker.TaskPayload) (err error) {
+	ctx = extractHeaders(ctx, msg.Headers)
+	ctx, span := tracing.StartTask(ctx, backendName, msg.RoutingKey)
+	defer func() { tracing.End(span, err) }()
+	return handler(ctx, payload)
+}
+
 // Stop gracefully stops the RabbitMQ worker server
 func (s *RabbitMQServer) Stop(ctx context.Context) error {
 	close(s.done)
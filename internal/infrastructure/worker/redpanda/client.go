@@ -3,9 +3,11 @@ package redpanda
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	"github.com/segmentio/kafka-go"
@@ -13,8 +15,9 @@ import (
 
 // RedpandaClient is a Redpanda/Kafka-based implementation of the worker.Client interface
 type RedpandaClient struct {
-	writer *kafka.Writer
-	topic  string
+	writer  *kafka.Writer
+	topic   string
+	brokers []string
 }
 
 // NewRedpandaClient creates a new Redpanda client
@@ -26,26 +29,31 @@ func NewRedpandaClient(brokers []string, topic string) *RedpandaClient {
 	}
 
 	return &RedpandaClient{
-		writer: writer,
-		topic:  topic,
+		writer:  writer,
+		topic:   topic,
+		brokers: brokers,
 	}
 }
 
-// Enqueue enqueues a task immediately
+// Enqueue enqueues a task immediately; the trace context travels in the headers
 func (c *RedpandaClient) Enqueue(
 	ctx context.Context,
 	taskName string,
 	payload sharedworker.TaskPayload,
 	options ...sharedworker.Option,
-) error {
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
 	return c.writer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(taskName),
-		Value: data,
+		Key:     []byte(taskName),
+		Value:   data,
+		Headers: injectHeaders(ctx, nil),
 	})
 }
 
@@ -58,7 +66,10 @@ func (c *RedpandaClient) EnqueueDelayed(
 	payload sharedworker.TaskPayload,
 	delay time.Duration,
 	options ...sharedworker.Option,
-) error {
+) (err error) {
+	ctx, span := tracing.StartEnqueue(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
@@ -77,7 +88,7 @@ func (c *RedpandaClient) EnqueueDelayed(
 	return delayedWriter.WriteMessages(ctx, kafka.Message{
 		Key:   []byte(taskName),
 		Value: data,
-		Headers: []kafka.Header{
+		Headers: injectHeaders(ctx, []kafka.Header{
 			{
 				Key:   "scheduled_at",
 				Value: []byte(fmt.Sprintf("%d", scheduledTime)),
@@ -90,10 +101,22 @@ func (c *RedpandaClient) EnqueueDelayed(
 				Key:   "enqueued_at",
 				Value: []byte(time.Now().Format(time.RFC3339)),
 			},
-		},
+		}),
 	})
 }
 
+// Health succeeds when any broker accepts a connection
+func (c *RedpandaClient) Health(ctx context.Context) error {
+	err := errors.New("no redpanda brokers configured")
+	for _, broker := range c.brokers {
+		var conn *kafka.Conn
+		if conn, err = kafka.DialContext(ctx, "tcp", broker); err == nil {
+			return conn.Close()
+		}
+	}
+	return err
+}
+
 // Close closes the Redpanda client
 func (c *RedpandaClient) Close() error {
 	return c.writer.Close()
@@ -0,0 +1,47 @@
+package redpanda
+
+import (
+	"context"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// injectHeaders appends the trace context and request ID of ctx to the
+// message headers
+func injectHeaders(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	for k, v := range tracing.Inject(ctx) {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		headers = append(headers, kafka.Header{Key: requestid.MetadataKey, Value: []byte(id)})
+	}
+	return headers
+}
+
+// extractHeaders continues the trace and restores the request ID carried in
+// the message headers; retries append headers, so the last value of a key wins
+func extractHeaders(ctx context.Context, headers []kafka.Header) context.Context {
+	carrier := tracing.Carrier{}
+	for _, h := range headers {
+		carrier[h.Key] = string(h.Value)
+	}
+	if id := carrier[requestid.MetadataKey]; requestid.Valid(id) {
+		ctx = sharedctx.WithRequestID(ctx, id)
+	}
+	return tracing.Extract(ctx, carrier)
+}
+
+// lastHeader returns the last value of key in headers, or "" when absent
+func lastHeader(headers []kafka.Header, key string) string {
+	value := ""
+	for _, h := range headers {
+		if h.Key == key {
+			value = string(h.Value)
+		}
+	}
+	return value
+}
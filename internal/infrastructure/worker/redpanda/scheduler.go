@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -45,7 +44,7 @@ func NewDelayedTaskScheduler(brokers []string, delayedTopic, mainTopic string) *
 
 // Start starts the delayed task scheduler
 func (s *DelayedTaskScheduler) Start(ctx context.Context) error {
-	log.Println("Starting delayed task scheduler...")
+	redpandaLog.Info("Starting delayed task scheduler")
 
 	for {
 		select {
@@ -61,7 +60,7 @@ func (s *DelayedTaskScheduler) Start(ctx context.Context) error {
 			if err == context.Canceled || err == context.DeadlineExceeded {
 				return nil
 			}
-			log.Printf("failed to read delayed message: %v\n", err)
+			redpandaLog.WithField("error", err).Error("Failed to read delayed message")
 			continue
 		}
 
@@ -72,14 +71,14 @@ func (s *DelayedTaskScheduler) Start(ctx context.Context) error {
 		if scheduledTime <= now {
 			// Task is ready to be processed
 			if err := s.promoteToMainTopic(ctx, msg); err != nil {
-				log.Printf("failed to promote task to main topic: %v\n", err)
+				redpandaLog.WithField("error", err).Error("Failed to promote task to main topic")
 				continue
 			}
 		} else {
 			// Task is not ready yet, requeue it with metadata
 			waitTime := time.Duration(scheduledTime-now) * time.Second
 			if err := s.requeueDelayedTask(ctx, msg, waitTime); err != nil {
-				log.Printf("failed to requeue delayed task: %v\n", err)
+				redpandaLog.WithField("error", err).Error("Failed to requeue delayed task")
 			}
 		}
 	}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
 	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 
 	"github.com/segmentio/kafka-go"
@@ -36,8 +39,14 @@ type RedpandaServer struct {
 	retryPolicy   infraworker.RetryPolicy
 	dlqWriter     *kafka.Writer
 	topic         string
+	metrics       *metrics.Metrics
 }
 
+// backendName labels the task metrics and spans of this backend
+const backendName = "redpanda"
+
+var redpandaLog = logger.Module("worker.redpanda")
+
 // NewRedpandaServer creates a new Redpanda server with retry policy
 func NewRedpandaServer(brokers []string, topic, consumerGroup string, workerCount int) *RedpandaServer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
@@ -78,6 +87,11 @@ func (s *RedpandaServer) SetRetryPolicy(policy infraworker.RetryPolicy) {
 	s.retryPolicy = policy
 }
 
+// SetMetrics records task metrics; nil disables them
+func (s *RedpandaServer) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
 // RegisterHandler registers a handler for a task type
 func (s *RedpandaServer) RegisterHandler(taskName string, handler sharedworker.TaskHandler) error {
 	s.handlers[taskName] = handler
@@ -86,7 +100,7 @@ func (s *RedpandaServer) RegisterHandler(taskName string, handler sharedworker.T
 
 // Start starts the Redpanda worker server with retry mechanism
 func (s *RedpandaServer) Start(ctx context.Context) error {
-	log.Println("Starting Redpanda worker server...")
+	redpandaLog.Info("Starting Redpanda worker server")
 
 	for {
 		select {
@@ -109,7 +123,7 @@ func (s *RedpandaServer) Start(ctx context.Context) error {
 		taskName := string(msg.Key)
 		handler, ok := s.handlers[taskName]
 		if !ok {
-			log.Printf("No handler registered for task: %s\n", taskName)
+			redpandaLog.WithField("task_name", taskName).Warn("No handler registered for task")
 			continue
 		}
 
@@ -117,6 +131,8 @@ func (s *RedpandaServer) Start(ctx context.Context) error {
 		var payload sharedworker.TaskPayload
 		if err := json.Unmarshal(msg.Value, &payload); err != nil {
 			// Payload is invalid, send to DLQ
+			s.metrics.ObserveTask(backendName, taskName, 0, err)
+			s.metrics.TaskDeadLettered(backendName, taskName)
 			s.sendToDeadLetterTopic(ctx, taskName, msg, fmt.Errorf("invalid payload: %w", err), nil)
 			continue
 		}
@@ -124,11 +140,17 @@ func (s *RedpandaServer) Start(ctx context.Context) error {
 		// Get or create metadata for tracking
 		taskID := s.getTaskID(msg)
 		metadata := s.getTaskMetadata(taskID)
+		if metadata.CorrelationID == "" {
+			metadata.CorrelationID = s.getCorrelationID(msg)
+		}
 		metadata.ProcessingSteps = append(metadata.ProcessingSteps,
 			fmt.Sprintf("attempt_%d_at_%s", metadata.RetryCount+1, time.Now().Format(time.RFC3339)))
 
 		// Process the task
-		if err := handler(ctx, payload); err != nil {
+		start := time.Now()
+		err = s.process(ctx, taskName, msg, handler, payload)
+		s.metrics.ObserveTask(backendName, taskName, time.Since(start), err)
+		if err != nil {
 			metadata.LastError = err.Error()
 			metadata.RetryCount++
 
@@ -137,16 +159,25 @@ func (s *RedpandaServer) Start(ctx context.Context) error {
 				// Calculate backoff
 				backoff := s.retryPolicy.CalculateBackoff(metadata.RetryCount)
 
-				log.Printf("Task %s failed (attempt %d), retrying in %v: %v\n",
-					taskName, metadata.RetryCount, backoff, err)
+				redpandaLog.WithFields(map[string]interface{}{
+					"task_name": taskName,
+					"attempt":   metadata.RetryCount,
+					"backoff":   backoff.String(),
+					"error":     err,
+				}).Warn("Task failed, retrying")
 
 				// Enqueue for retry with delay
+				s.metrics.TaskRetried(backendName, taskName)
 				s.requeueForRetry(ctx, taskName, msg, backoff, metadata)
 				s.removeTaskMetadata(taskID)
 			} else {
 				// Send to DLQ
-				log.Printf("Task %s failed after %d attempts, moving to DLQ: %v\n",
-					taskName, metadata.RetryCount, err)
+				redpandaLog.WithFields(map[string]interface{}{
+					"task_name": taskName,
+					"attempts":  metadata.RetryCount,
+					"error":     err,
+				}).Error("Task failed, moving to DLQ")
+				s.metrics.TaskDeadLettered(backendName, taskName)
 				s.sendToDeadLetterTopic(ctx, taskName, msg, err, metadata)
 				s.removeTaskMetadata(taskID)
 			}
@@ -154,11 +185,22 @@ func (s *RedpandaServer) Start(ctx context.Context) error {
 		}
 
 		// Task succeeded, clean up metadata
-		log.Printf("Task %s completed successfully after %d attempts\n", taskName, metadata.RetryCount)
+		redpandaLog.WithFields(map[string]interface{}{
+			"task_name": taskName,
+			"retries":   metadata.RetryCount,
+		}).Info("Task completed")
 		s.removeTaskMetadata(taskID)
 	}
 }
 
+// process runs handler in a span continuing the trace of the enqueueing side
+func (s *RedpandaServer) process(ctx context.Context, taskName string, msg kafka.Message, handler sharedworker.TaskHandler, payload sharedworker.TaskPayload) (err error) {
+	ctx = extractHeaders(ctx, msg.Headers)
+	ctx, span := tracing.StartTask(ctx, backendName, taskName)
+	defer func() { tracing.End(span, err) }()
+	return handler(ctx, payload)
+}
+
 // Stop gracefully stops the Redpanda worker server
 func (s *RedpandaServer) Stop(ctx context.Context) error {
 	close(s.done)
@@ -190,6 +232,11 @@ func (s *RedpandaServer) requeueForRetry(ctx context.Context, taskName string, m
 
 // sendToDeadLetterTopic sends failed tasks to a dead-letter topic with full metadata
 func (s *RedpandaServer) sendToDeadLetterTopic(ctx context.Context, taskName string, msg kafka.Message, err error, metadata *TaskMetadata) error {
+	// Invalid payloads are dead-lettered before any attempt
+	if metadata == nil {
+		metadata = &TaskMetadata{}
+	}
+
 	// Build comprehensive metadata for DLQ
 	dlqMetadata := TaskMetadata{
 		RetryCount:      metadata.RetryCount,
@@ -226,12 +273,14 @@ func (s *RedpandaServer) getTaskID(msg kafka.Message) string {
 	return fmt.Sprintf("%s-%d-%d", msg.Topic, msg.Partition, msg.Offset)
 }
 
-// getCorrelationID extracts or generates a correlation ID from message headers
+// getCorrelationID reuses the request ID of the enqueueing request, else the
+// correlation ID of an earlier attempt, else generates one
 func (s *RedpandaServer) getCorrelationID(msg kafka.Message) string {
-	for _, h := range msg.Headers {
-		if h.Key == "correlation_id" {
-			return string(h.Value)
-		}
+	if id := lastHeader(msg.Headers, requestid.MetadataKey); id != "" {
+		return id
+	}
+	if id := lastHeader(msg.Headers, "correlation_id"); id != "" {
+		return id
 	}
 	// Generate new correlation ID if not present
 	return fmt.Sprintf("%s-%d", time.Now().Format(time.RFC3339Nano), msg.Offset)
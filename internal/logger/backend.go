@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	BackendSlog   = "slog"
+	BackendZap    = "zap"
+	BackendLogrus = "logrus"
+
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// timestampFormat is used by every backend so switching backends keeps
+// timestamps comparable
+const timestampFormat = time.RFC3339
+
+// backend renders entries that already passed level, sampling and redaction
+type backend interface {
+	write(ctx context.Context, level Level, t time.Time, msg string, fields Fields)
+	sync() error
+}
+
+func newBackend(name, format string, w io.Writer) (backend, error) {
+	if format != "" && format != FormatJSON && format != FormatText {
+		return nil, fmt.Errorf("unknown log format %q (want %s or %s)", format, FormatJSON, FormatText)
+	}
+	switch name {
+	case "", BackendLogrus:
+		return newLogrusBackend(format, w), nil
+	case BackendSlog:
+		return newSlogBackend(format, w), nil
+	case BackendZap:
+		return newZapBackend(format, w), nil
+	default:
+		return nil, fmt.Errorf("unknown log backend %q (want %s, %s or %s)", name, BackendSlog, BackendZap, BackendLogrus)
+	}
+}
+
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type logrusBackend struct {
+	logger *logrus.Logger
+}
+
+var logrusLevels = [...]logrus.Level{logrus.DebugLevel, logrus.InfoLevel, logrus.WarnLevel, logrus.ErrorLevel, logrus.FatalLevel}
+
+func newLogrusBackend(format string, w io.Writer) *logrusBackend {
+	l := logrus.New()
+	l.SetOutput(w)
+	l.SetLevel(logrus.TraceLevel)
+	if format == FormatText {
+		l.SetFormatter(&logrus.TextFormatter{TimestampFormat: timestampFormat, FullTimestamp: true, DisableColors: true})
+	} else {
+		l.SetFormatter(&logrus.JSONFormatter{TimestampFormat: timestampFormat})
+	}
+	return &logrusBackend{logger: l}
+}
+
+func (b *logrusBackend) write(ctx context.Context, level Level, t time.Time, msg string, fields Fields) {
+	entry := b.logger.WithFields(logrus.Fields(fields)).WithTime(t)
+	if ctx != nil {
+		entry = entry.WithContext(ctx)
+	}
+	// Entry.Log never exits, even at fatal level; the caller decides
+	entry.Log(logrusLevels[level], msg)
+}
+
+func (b *logrusBackend) sync() error { return nil }
+
+type slogBackend struct {
+	handler slog.Handler
+}
+
+const slogFatal = slog.LevelError + 4
+
+var slogLevels = [...]slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError, slogFatal}
+
+func newSlogBackend(format string, w io.Writer) *slogBackend {
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) > 0 {
+				return a
+			}
+			switch a.Key {
+			case slog.TimeKey:
+				a.Value = slog.StringValue(a.Value.Time().Format(timestampFormat))
+			case slog.LevelKey:
+				if a.Value.Any() == slogFatal {
+					a.Value = slog.StringValue("FATAL")
+				}
+			}
+			return a
+		},
+	}
+	if format == FormatText {
+		return &slogBackend{handler: slog.NewTextHandler(w, opts)}
+	}
+	return &slogBackend{handler: slog.NewJSONHandler(w, opts)}
+}
+
+func (b *slogBackend) write(ctx context.Context, level Level, t time.Time, msg string, fields Fields) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	record := slog.NewRecord(t, slogLevels[level], msg, 0)
+	for _, k := range sortedKeys(fields) {
+		record.AddAttrs(slog.Any(k, fields[k]))
+	}
+	_ = b.handler.Handle(ctx, record)
+}
+
+func (b *slogBackend) sync() error { return nil }
+
+type zapBackend struct {
+	core zapcore.Core
+}
+
+var zapLevels = [...]zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.FatalLevel}
+
+func newZapBackend(format string, w io.Writer) *zapBackend {
+	config := zap.NewProductionEncoderConfig()
+	config.TimeKey = "time"
+	config.EncodeTime = zapcore.TimeEncoderOfLayout(timestampFormat)
+	encoder := zapcore.NewJSONEncoder(config)
+	if format == FormatText {
+		config.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(config)
+	}
+	return &zapBackend{core: zapcore.NewCore(encoder, zapcore.AddSync(w), zapcore.DebugLevel)}
+}
+
+func (b *zapBackend) write(_ context.Context, level Level, t time.Time, msg string, fields Fields) {
+	zapFields := make([]zapcore.Field, 0, len(fields))
+	for _, k := range sortedKeys(fields) {
+		zapFields = append(zapFields, zap.Any(k, fields[k]))
+	}
+	// Core.Write never exits, even at fatal level; the caller decides
+	_ = b.core.Write(zapcore.Entry{Level: zapLevels[level], Time: t, Message: msg}, zapFields)
+}
+
+func (b *zapBackend) sync() error { return b.core.Sync() }
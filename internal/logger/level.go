@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a log entry
+type Level int8
+
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+)
+
+var levelNames = [...]string{"debug", "info", "warn", "error", "fatal"}
+
+func (l Level) String() string {
+	if l < DebugLevel || l > FatalLevel {
+		return fmt.Sprintf("level(%d)", l)
+	}
+	return levelNames[l]
+}
+
+// ParseLevel converts a level name such as "info" or "WARN"; an empty name
+// is info
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return DebugLevel, nil
+	case "", "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("unknown log level %q", name)
+	}
+}
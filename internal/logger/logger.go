@@ -1,28 +1,56 @@
+// Package logger is the application logging facade. Entries pass per-module
+// level filtering, sampling and redaction before one of the slog, zap or
+// logrus backends renders them.
 package logger
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
-	"github.com/sirupsen/logrus"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 )
 
-// Logger wraps logrus.Logger with additional utilities
-type Logger struct {
-	*logrus.Logger
+// Fields are the structured key/value pairs of an entry
+type Fields map[string]interface{}
+
+// Config selects the backend and tunes what reaches it
+type Config struct {
+	Backend    string            // slog, zap, logrus; defaults to logrus
+	Format     string            // json, text; defaults to json
+	Level      string            // minimum level; defaults to info
+	Modules    map[string]string // per-module minimum levels, e.g. {"worker": "debug"}
+	Output     io.Writer         // defaults to os.Stdout
+	Sampling   SamplingConfig
+	RedactKeys []string // field keys masked in addition to DefaultRedactKeys
+	ShowEmails bool     // log email addresses unmasked
 }
 
-var defaultLogger *Logger
+// SamplingConfig limits entries with the same level and message per tick.
+// Error and fatal entries are never sampled.
+type SamplingConfig struct {
+	Tick       time.Duration // sampling window; zero disables sampling
+	Initial    int           // entries logged per tick before sampling starts
+	Thereafter int           // then log every Nth entry; zero drops the rest of the tick
+}
 
-func init() {
-	l := logrus.New()
-	l.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: "2006-01-02T15:04:05Z07:00",
-		PrettyPrint:     false,
-	})
-	l.SetLevel(logrus.InfoLevel)
-	defaultLogger = &Logger{l}
+// Logger filters, samples and redacts entries before handing them to the
+// configured backend
+type Logger struct {
+	backend  backend
+	level    atomic.Int32
+	modules  map[string]Level
+	sampler  *sampler
+	redactor *redactor
+	exit     func(code int)
 }
 
+var defaultLogger = New()
+
 // GetDefaultLogger returns the default logger instance
 func GetDefaultLogger() *Logger {
 	return defaultLogger
@@ -33,29 +61,231 @@ func SetLogger(l *Logger) {
 	defaultLogger = l
 }
 
-// New creates a new logger instance
+// New creates a logger writing JSON to stdout at info level through logrus
 func New() *Logger {
-	l := logrus.New()
-	l.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: "2006-01-02T15:04:05Z07:00",
-		PrettyPrint:     false,
-	})
-	l.SetLevel(logrus.InfoLevel)
-	return &Logger{l}
+	l, _ := NewFromConfig(Config{})
+	return l
+}
+
+// NewFromConfig creates a logger from config, rejecting unknown backends,
+// formats and levels
+func NewFromConfig(config Config) (*Logger, error) {
+	output := config.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	b, err := newBackend(config.Backend, config.Format, output)
+	if err != nil {
+		return nil, err
+	}
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+	modules := make(map[string]Level, len(config.Modules))
+	for name, value := range config.Modules {
+		if modules[name], err = ParseLevel(value); err != nil {
+			return nil, fmt.Errorf("module %s: %w", name, err)
+		}
+	}
+
+	l := &Logger{
+		backend:  b,
+		modules:  modules,
+		sampler:  newSampler(config.Sampling),
+		redactor: newRedactor(config.RedactKeys, !config.ShowEmails),
+		exit:     os.Exit,
+	}
+	l.level.Store(int32(level))
+	return l, nil
+}
+
+// GetLevel returns the minimum level of entries without a module override
+func (l *Logger) GetLevel() Level {
+	return Level(l.level.Load())
+}
+
+// SetLevel changes the minimum level of entries without a module override
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// Sync flushes entries buffered by the backend
+func (l *Logger) Sync() error {
+	return l.backend.sync()
+}
+
+// enabled reports whether module logs at level. Module names are dotted
+// paths; "worker.redpanda" falls back to the level of "worker".
+func (l *Logger) enabled(module string, level Level) bool {
+	threshold := l.GetLevel()
+	for name := module; name != ""; {
+		if override, ok := l.modules[name]; ok {
+			threshold = override
+			break
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return level >= threshold
+}
+
+func (l *Logger) entry() *Entry {
+	return &Entry{Logger: l}
+}
+
+// Module returns an entry logging at the level configured for name, with
+// name in the module field
+func (l *Logger) Module(name string) *Entry {
+	return &Entry{Logger: l, Data: Fields{"module": name}, module: name}
+}
+
+// WithContext returns an entry carrying ctx
+func (l *Logger) WithContext(ctx context.Context) *Entry { return l.entry().WithContext(ctx) }
+
+// WithFields returns an entry with fields
+func (l *Logger) WithFields(fields map[string]interface{}) *Entry {
+	return l.entry().WithFields(fields)
+}
+
+// WithField returns an entry with a single field
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return l.entry().WithField(key, value)
+}
+
+func (l *Logger) Debug(args ...interface{})                 { l.entry().Debug(args...) }
+func (l *Logger) Debugf(format string, args ...interface{}) { l.entry().Debugf(format, args...) }
+func (l *Logger) Info(args ...interface{})                  { l.entry().Info(args...) }
+func (l *Logger) Infof(format string, args ...interface{})  { l.entry().Infof(format, args...) }
+func (l *Logger) Warn(args ...interface{})                  { l.entry().Warn(args...) }
+func (l *Logger) Warnf(format string, args ...interface{})  { l.entry().Warnf(format, args...) }
+func (l *Logger) Error(args ...interface{})                 { l.entry().Error(args...) }
+func (l *Logger) Errorf(format string, args ...interface{}) { l.entry().Errorf(format, args...) }
+func (l *Logger) Fatal(args ...interface{})                 { l.entry().Fatal(args...) }
+func (l *Logger) Fatalf(format string, args ...interface{}) { l.entry().Fatalf(format, args...) }
+
+// Entry is a log entry under construction. The With methods return a new
+// entry and leave the receiver untouched, so entries can be shared.
+type Entry struct {
+	Logger  *Logger // nil logs through the default logger
+	Data    Fields
+	Context context.Context
+	module  string
+}
+
+func (e *Entry) logger() *Logger {
+	if e.Logger != nil {
+		return e.Logger
+	}
+	return defaultLogger
+}
+
+// WithContext returns a copy of the entry carrying ctx. The request ID of
+// ctx, if any, is added as the request_id field.
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	out := e.with(len(e.Data) + 1)
+	out.Context = ctx
+	if id, ok := sharedctx.GetRequestID(ctx); ok && id != "" {
+		out.Data["request_id"] = id
+	}
+	return out
+}
+
+// WithFields returns a copy of the entry with fields added
+func (e *Entry) WithFields(fields map[string]interface{}) *Entry {
+	out := e.with(len(e.Data) + len(fields))
+	for k, v := range fields {
+		out.Data[k] = v
+	}
+	return out
+}
+
+// WithField returns a copy of the entry with a single field added
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	out := e.with(len(e.Data) + 1)
+	out.Data[key] = value
+	return out
+}
+
+// WithError adds err as the error field
+func (e *Entry) WithError(err error) *Entry {
+	return e.WithField("error", err)
+}
+
+func (e *Entry) with(size int) *Entry {
+	data := make(Fields, size)
+	for k, v := range e.Data {
+		data[k] = v
+	}
+	return &Entry{Logger: e.Logger, Data: data, Context: e.Context, module: e.module}
 }
 
-// WithContext returns a logger entry with context values
-func WithContext(ctx context.Context) *logrus.Entry {
+func (e *Entry) Debug(args ...interface{}) { e.log(DebugLevel, args) }
+func (e *Entry) Info(args ...interface{})  { e.log(InfoLevel, args) }
+func (e *Entry) Warn(args ...interface{})  { e.log(WarnLevel, args) }
+func (e *Entry) Error(args ...interface{}) { e.log(ErrorLevel, args) }
+func (e *Entry) Fatal(args ...interface{}) { e.log(FatalLevel, args) }
+
+func (e *Entry) Debugf(format string, args ...interface{}) { e.logf(DebugLevel, format, args) }
+func (e *Entry) Infof(format string, args ...interface{})  { e.logf(InfoLevel, format, args) }
+func (e *Entry) Warnf(format string, args ...interface{})  { e.logf(WarnLevel, format, args) }
+func (e *Entry) Errorf(format string, args ...interface{}) { e.logf(ErrorLevel, format, args) }
+func (e *Entry) Fatalf(format string, args ...interface{}) { e.logf(FatalLevel, format, args) }
+
+// log and logf check the level before formatting, so disabled entries cost
+// no allocations for the message
+func (e *Entry) log(level Level, args []interface{}) {
+	if e.enabled(level) {
+		e.write(level, fmt.Sprint(args...))
+	}
+}
+
+func (e *Entry) logf(level Level, format string, args []interface{}) {
+	if e.enabled(level) {
+		e.write(level, fmt.Sprintf(format, args...))
+	}
+}
+
+// enabled never filters fatal entries since they exit the process
+func (e *Entry) enabled(level Level) bool {
+	return level == FatalLevel || e.logger().enabled(e.module, level)
+}
+
+func (e *Entry) write(level Level, msg string) {
+	l := e.logger()
+	if !l.sampler.allow(level, msg) {
+		return
+	}
+	l.backend.write(e.Context, level, time.Now(), msg, l.redactor.fields(e.Data))
+	if level == FatalLevel {
+		_ = l.backend.sync()
+		l.exit(1)
+	}
+}
+
+// Module returns an entry for the named module that logs through whichever
+// logger is the default when it is used, so it can be kept in a package
+// variable declared before SetLogger runs
+func Module(name string) *Entry {
+	return &Entry{Data: Fields{"module": name}, module: name}
+}
+
+// WithContext returns a logger entry with context values. The request ID
+// of ctx, if any, is added as the request_id field.
+func WithContext(ctx context.Context) *Entry {
 	return defaultLogger.WithContext(ctx)
 }
 
 // WithFields returns a logger entry with fields
-func WithFields(fields map[string]interface{}) *logrus.Entry {
+func WithFields(fields map[string]interface{}) *Entry {
 	return defaultLogger.WithFields(fields)
 }
 
 // WithField returns a logger entry with a single field
-func WithField(key string, value interface{}) *logrus.Entry {
+func WithField(key string, value interface{}) *Entry {
 	return defaultLogger.WithField(key, value)
 }
 
@@ -108,6 +338,3 @@ func Fatal(args ...interface{}) {
 func Fatalf(format string, args ...interface{}) {
 	defaultLogger.Fatalf(format, args...)
 }
-
-// Entry represents a log entry
-type Entry = logrus.Entry
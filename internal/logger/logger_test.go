@@ -1,36 +1,66 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newBufferLogger returns a logger writing to a buffer instead of stdout
+func newBufferLogger(t *testing.T, config Config) (*Logger, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	config.Output = &buf
+	l, err := NewFromConfig(config)
+	require.NoError(t, err)
+	return l, &buf
+}
+
+// decodeLines parses one JSON object per output line
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &m), line)
+		lines = append(lines, m)
+	}
+	return lines
+}
+
 func TestNew(t *testing.T) {
 	logger := New()
 	require.NotNil(t, logger)
-	assert.NotNil(t, logger.Logger)
-	assert.Equal(t, logrus.InfoLevel, logger.Level)
+	assert.Equal(t, InfoLevel, logger.GetLevel())
 }
 
 func TestGetDefaultLogger(t *testing.T) {
 	logger := GetDefaultLogger()
 	require.NotNil(t, logger)
-	assert.Equal(t, logrus.InfoLevel, logger.Level)
+	assert.Equal(t, InfoLevel, logger.GetLevel())
 }
 
 func TestSetLogger(t *testing.T) {
 	newLogger := New()
-	newLogger.SetLevel(logrus.DebugLevel)
+	newLogger.SetLevel(DebugLevel)
 
 	SetLogger(newLogger)
 	retrieved := GetDefaultLogger()
 
 	assert.Equal(t, newLogger, retrieved)
-	assert.Equal(t, logrus.DebugLevel, retrieved.Level)
+	assert.Equal(t, DebugLevel, retrieved.GetLevel())
 }
 
 func TestWithContext(t *testing.T) {
@@ -39,6 +69,15 @@ func TestWithContext(t *testing.T) {
 
 	require.NotNil(t, entry)
 	assert.Equal(t, ctx, entry.Context)
+	assert.NotContains(t, entry.Data, "request_id")
+}
+
+func TestWithContext_RequestID(t *testing.T) {
+	ctx := sharedctx.WithRequestID(context.Background(), "req-123")
+	entry := WithContext(ctx)
+
+	require.NotNil(t, entry)
+	assert.Equal(t, "req-123", entry.Data["request_id"])
 }
 
 func TestWithFields(t *testing.T) {
@@ -62,6 +101,14 @@ func TestWithField(t *testing.T) {
 	assert.Equal(t, "req-123", entry.Data["request_id"])
 }
 
+func TestWithField_DoesNotModifyParent(t *testing.T) {
+	parent := WithField("a", 1)
+	child := parent.WithField("b", 2)
+
+	assert.NotContains(t, parent.Data, "b")
+	assert.Equal(t, 1, child.Data["a"])
+}
+
 func TestDebug(t *testing.T) {
 	logger := New()
 	SetLogger(logger)
@@ -99,20 +146,222 @@ func TestError(t *testing.T) {
 }
 
 func TestLoggerLevel(t *testing.T) {
-	logger := New()
-	logger.SetLevel(logrus.DebugLevel)
+	logger, buf := newBufferLogger(t, Config{})
+	logger.SetLevel(DebugLevel)
+	assert.Equal(t, DebugLevel, logger.GetLevel())
+	logger.Debug("shown")
 
-	assert.Equal(t, logrus.DebugLevel, logger.Level)
+	logger.SetLevel(ErrorLevel)
+	assert.Equal(t, ErrorLevel, logger.GetLevel())
+	logger.Warn("hidden")
 
-	logger.SetLevel(logrus.ErrorLevel)
-	assert.Equal(t, logrus.ErrorLevel, logger.Level)
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "shown", lines[0]["msg"])
 }
 
-func TestJSONFormatter(t *testing.T) {
-	logger := New()
-	formatter, ok := logger.Formatter.(*logrus.JSONFormatter)
+func TestNewFromConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "backend", config: Config{Backend: "log4j"}},
+		{name: "format", config: Config{Format: "xml"}},
+		{name: "level", config: Config{Level: "loud"}},
+		{name: "module level", config: Config{Modules: map[string]string{"worker": "loud"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFromConfig(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBackends_JSON(t *testing.T) {
+	tests := []struct {
+		backend    string
+		messageKey string
+		levelKey   string
+		warnLevel  string
+	}{
+		{backend: BackendLogrus, messageKey: "msg", levelKey: "level", warnLevel: "warning"},
+		{backend: BackendSlog, messageKey: "msg", levelKey: "level", warnLevel: "WARN"},
+		{backend: BackendZap, messageKey: "msg", levelKey: "level", warnLevel: "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			logger, buf := newBufferLogger(t, Config{Backend: tt.backend, Format: FormatJSON})
+			logger.WithFields(map[string]interface{}{
+				"user_id": "u-1",
+				"error":   errors.New("boom"),
+			}).Warn("something happened")
+
+			lines := decodeLines(t, buf)
+			require.Len(t, lines, 1)
+			line := lines[0]
+			assert.Equal(t, "something happened", line[tt.messageKey])
+			assert.Equal(t, tt.warnLevel, line[tt.levelKey])
+			assert.Equal(t, "u-1", line["user_id"])
+			assert.Equal(t, "boom", line["error"])
+
+			_, err := time.Parse(time.RFC3339, line["time"].(string))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBackends_Text(t *testing.T) {
+	for _, backend := range []string{BackendLogrus, BackendSlog, BackendZap} {
+		t.Run(backend, func(t *testing.T) {
+			logger, buf := newBufferLogger(t, Config{Backend: backend, Format: FormatText})
+			logger.WithField("user_id", "u-1").Info("hello")
+
+			out := buf.String()
+			assert.Contains(t, out, "hello")
+			assert.Contains(t, out, "u-1")
+			assert.False(t, json.Valid(bytes.TrimSpace(buf.Bytes())))
+		})
+	}
+}
+
+func TestModuleLevels(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{
+		Level:   "warn",
+		Modules: map[string]string{"worker": "debug", "worker.cron": "error"},
+	})
+
+	logger.Info("root info")                          // below warn
+	logger.Module("worker").Debug("worker debug")     // override
+	logger.Module("worker.redpanda").Debug("rp")      // inherits worker
+	logger.Module("worker.cron").Warn("cron warn")    // below its own override
+	logger.Module("eventbus").Warn("eventbus warn")   // falls back to root
+	logger.Module("worker.cron").Error("cron failed") // at its override
+
+	var messages []string
+	for _, line := range decodeLines(t, buf) {
+		messages = append(messages, line["msg"].(string))
+	}
+	assert.Equal(t, []string{"worker debug", "rp", "eventbus warn", "cron failed"}, messages)
+}
+
+func TestModule_Field(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{})
+	logger.Module("worker").WithField("task_name", "send_email").Info("done")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "worker", lines[0]["module"])
+	assert.Equal(t, "send_email", lines[0]["task_name"])
+}
+
+func TestModule_FollowsSetLogger(t *testing.T) {
+	defer SetLogger(GetDefaultLogger())
+	entry := Module("worker")
+
+	logger, buf := newBufferLogger(t, Config{Modules: map[string]string{"worker": "debug"}})
+	SetLogger(logger)
+	entry.Debug("after SetLogger")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "worker", lines[0]["module"])
+}
+
+func TestSampling(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{
+		Sampling: SamplingConfig{Tick: time.Minute, Initial: 2, Thereafter: 3},
+	})
+
+	for i := 0; i < 8; i++ {
+		logger.Info("hot path")
+	}
+	for i := 0; i < 3; i++ {
+		logger.Error("hot path")
+	}
+	logger.Info("other message")
+
+	counts := map[string]int{}
+	for _, line := range decodeLines(t, buf) {
+		counts[line["level"].(string)+" "+line["msg"].(string)]++
+	}
+	// 2 initial entries, then the 3rd and 6th of the remaining 6
+	assert.Equal(t, 4, counts["info hot path"])
+	assert.Equal(t, 3, counts["error hot path"], "errors are never sampled")
+	assert.Equal(t, 1, counts["info other message"])
+}
+
+func TestSampling_NewTick(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := newSampler(SamplingConfig{Tick: time.Second, Initial: 1})
+	s.now = func() time.Time { return now }
+
+	assert.True(t, s.allow(InfoLevel, "msg"))
+	assert.False(t, s.allow(InfoLevel, "msg"))
+	now = now.Add(time.Second)
+	assert.True(t, s.allow(InfoLevel, "msg"))
+}
+
+func TestRedaction(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{RedactKeys: []string{"ssn"}})
+	fields := map[string]interface{}{
+		"password_hash": "$2a$10$abc",
+		"refresh_token": "rt-1",
+		"client_secret": "cs-1",
+		"ssn":           "123-45-6789",
+		"email":         "alice@example.com",
+		"to":            []string{"bob@example.org"},
+		"user_id":       "u-1",
+		"claims":        map[string]interface{}{"access_token": "at-1", "sub": "u-1"},
+	}
+	logger.WithFields(fields).Info("login")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	line := lines[0]
+	assert.Equal(t, Redacted, line["password_hash"])
+	assert.Equal(t, Redacted, line["refresh_token"])
+	assert.Equal(t, Redacted, line["client_secret"])
+	assert.Equal(t, Redacted, line["ssn"])
+	assert.Equal(t, "a***@example.com", line["email"])
+	assert.Equal(t, []interface{}{"b***@example.org"}, line["to"])
+	assert.Equal(t, "u-1", line["user_id"])
+	assert.Equal(t, map[string]interface{}{"access_token": Redacted, "sub": "u-1"}, line["claims"])
+	assert.Equal(t, "$2a$10$abc", fields["password_hash"], "caller fields must not be modified")
+}
+
+func TestRedaction_ShowEmails(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{ShowEmails: true})
+	logger.WithField("email", "alice@example.com").Info("login")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "alice@example.com", lines[0]["email"])
+}
+
+func TestFatal_Exits(t *testing.T) {
+	logger, buf := newBufferLogger(t, Config{Level: "error"})
+	var code int
+	logger.exit = func(c int) { code = c }
+
+	logger.Fatalf("cannot start: %s", "port in use")
+
+	assert.Equal(t, 1, code)
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "cannot start: port in use", lines[0]["msg"])
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARNING")
+	require.NoError(t, err)
+	assert.Equal(t, WarnLevel, level)
+
+	level, err = ParseLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, InfoLevel, level)
 
-	assert.True(t, ok)
-	assert.Equal(t, "2006-01-02T15:04:05Z07:00", formatter.TimestampFormat)
-	assert.False(t, formatter.PrettyPrint)
+	_, err = ParseLevel("verbose")
+	assert.Error(t, err)
 }
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// OpenOutputs returns a writer duplicating entries to every named sink:
+// "stdout", "stderr" or a file path, which is created or appended to. No
+// names means stdout. Files stay open for the life of the process.
+func OpenOutputs(names []string) (io.Writer, error) {
+	writers := make([]io.Writer, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "", "stdout":
+			writers = append(writers, os.Stdout)
+		case "stderr":
+			writers = append(writers, os.Stderr)
+		default:
+			f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("open log output %s: %w", name, err)
+			}
+			writers = append(writers, f)
+		}
+	}
+	switch len(writers) {
+	case 0:
+		return os.Stdout, nil
+	case 1:
+		return writers[0], nil
+	default:
+		return io.MultiWriter(writers...), nil
+	}
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the value of fields whose key is redacted
+const Redacted = "[REDACTED]"
+
+// DefaultRedactKeys are always redacted. A key matches when it equals an
+// entry or ends with "_" and the entry, so "token" also covers
+// "refresh_token" and "secret" covers "client_secret".
+var DefaultRedactKeys = []string{
+	"password",
+	"password_hash",
+	"token",
+	"secret",
+	"authorization",
+	"cookie",
+	"api_key",
+	"private_key",
+}
+
+var emailPattern = regexp.MustCompile(`([a-zA-Z0-9._%+-])[a-zA-Z0-9._%+-]*@([a-zA-Z0-9.-]+\.[a-zA-Z]{2,})`)
+
+// redactor masks sensitive field values before they reach a backend. Field
+// maps are copied, never modified in place.
+type redactor struct {
+	keys       []string
+	maskEmails bool
+}
+
+func newRedactor(extraKeys []string, maskEmails bool) *redactor {
+	keys := append([]string(nil), DefaultRedactKeys...)
+	for _, key := range extraKeys {
+		keys = append(keys, strings.ToLower(key))
+	}
+	return &redactor{keys: keys, maskEmails: maskEmails}
+}
+
+func (r *redactor) fields(fields Fields) Fields {
+	if len(fields) == 0 {
+		return fields
+	}
+	out := make(Fields, len(fields))
+	for k, v := range fields {
+		out[k] = r.value(k, v)
+	}
+	return out
+}
+
+func (r *redactor) value(key string, value interface{}) interface{} {
+	if r.redactsKey(key) {
+		return Redacted
+	}
+	switch v := value.(type) {
+	case string:
+		return r.string(v)
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = r.string(s)
+		}
+		return out
+	case Fields:
+		return r.fields(v)
+	case map[string]interface{}:
+		return map[string]interface{}(r.fields(v))
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for k, s := range v {
+			if r.redactsKey(k) {
+				out[k] = Redacted
+			} else {
+				out[k] = r.string(s)
+			}
+		}
+		return out
+	}
+	return value
+}
+
+func (r *redactor) redactsKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if key == k || strings.HasSuffix(key, "_"+k) {
+			return true
+		}
+	}
+	return false
+}
+
+// string keeps the first character of the local part and the domain of any
+// email address in s, e.g. "a***@example.com"
+func (r *redactor) string(s string) string {
+	if !r.maskEmails || !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
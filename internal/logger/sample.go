@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+)
+
+const samplerBuckets = 4096
+
+// sampler caps how often the same message is logged at the same level within
+// a tick, so a hot path cannot flood the output. Messages are hashed into a
+// fixed number of buckets; a collision only makes sampling stricter. Error
+// and fatal entries are never dropped.
+type sampler struct {
+	tick       time.Duration
+	initial    uint64
+	thereafter uint64
+	now        func() time.Time
+	counters   [ErrorLevel][samplerBuckets]sampleCounter
+}
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+func newSampler(config SamplingConfig) *sampler {
+	if config.Tick <= 0 {
+		return nil
+	}
+	return &sampler{
+		tick:       config.Tick,
+		initial:    uint64(max(config.Initial, 0)),
+		thereafter: uint64(max(config.Thereafter, 0)),
+		now:        time.Now,
+	}
+}
+
+func (s *sampler) allow(level Level, msg string) bool {
+	if s == nil || level >= ErrorLevel || level < DebugLevel {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(msg))
+	n := s.counters[level][h.Sum32()%samplerBuckets].incr(s.now().UnixNano(), s.tick)
+	if n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
+
+// incr counts an entry in the current tick, starting a new tick when the
+// previous one has passed
+func (c *sampleCounter) incr(now int64, tick time.Duration) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+	if c.resetAt.CompareAndSwap(resetAt, now+tick.Nanoseconds()) {
+		c.count.Store(1)
+		return 1
+	}
+	return c.count.Add(1)
+}
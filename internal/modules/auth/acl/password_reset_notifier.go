@@ -0,0 +1,35 @@
+package acl
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+)
+
+// taskSendPasswordResetEmail matches the task the user module's worker registers.
+// ACL code may only import module domains, so the name is repeated here.
+const taskSendPasswordResetEmail = "user:send_password_reset_email"
+
+// PasswordResetNotifierAdapter implements domain.PasswordResetNotifier by
+// enqueueing the user module's password reset email task.
+type PasswordResetNotifierAdapter struct {
+	client sharedworker.Client
+}
+
+// NewPasswordResetNotifierAdapter creates a new ACL adapter for password reset delivery.
+func NewPasswordResetNotifierAdapter(client sharedworker.Client) *PasswordResetNotifierAdapter {
+	return &PasswordResetNotifierAdapter{
+		client: client,
+	}
+}
+
+// SendPasswordReset implements domain.PasswordResetNotifier interface.
+// It translates the notice into the user module's task payload.
+func (a *PasswordResetNotifierAdapter) SendPasswordReset(ctx context.Context, notice *domain.PasswordResetNotice) error {
+	return a.client.Enqueue(ctx, taskSendPasswordResetEmail, sharedworker.TaskPayload{
+		"user_id":    notice.UserID,
+		"email":      notice.Email,
+		"reset_link": notice.ResetLink,
+	})
+}
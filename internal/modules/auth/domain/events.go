@@ -46,10 +46,19 @@ type PasswordChangedEvent struct {
 func (e PasswordChangedEvent) EventName() string { return "auth.password_changed" }
 func (e PasswordChangedEvent) Payload() any      { return e }
 
+// Reasons carried by SessionRevokedEvent
+const (
+	// SessionRevokedReasonTokenReuse means a rotated refresh token was presented
+	// again, so the whole token family was revoked
+	SessionRevokedReasonTokenReuse = "refresh_token_reuse"
+)
+
 // SessionRevokedEvent is published when a session is revoked
 type SessionRevokedEvent struct {
 	UserID    string    `json:"user_id"`
 	SessionID string    `json:"session_id"`
+	FamilyID  string    `json:"family_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
 	RevokedAt time.Time `json:"revoked_at"`
 }
 
@@ -14,10 +14,42 @@ type Handler interface {
 	RefreshToken(c sharedctx.Context) error
 	ValidateToken(c sharedctx.Context) error
 	ChangePassword(c sharedctx.Context) error
+	ResetPassword(c sharedctx.Context) error
+	ConfirmResetPassword(c sharedctx.Context) error
+	VerifyEmail(c sharedctx.Context) error
+	ResendVerification(c sharedctx.Context) error
+	EnrollMFA(c sharedctx.Context) error
+	EnableMFA(c sharedctx.Context) error
+	DisableMFA(c sharedctx.Context) error
+	VerifyMFA(c sharedctx.Context) error
+	JWKS(c sharedctx.Context) error
+	IssueClientToken(c sharedctx.Context) error
+	IntrospectToken(c sharedctx.Context) error
+	StartOIDCLogin(c sharedctx.Context) error
+	OIDCCallback(c sharedctx.Context) error
+	StartOIDCLink(c sharedctx.Context) error
+	ListIdentities(c sharedctx.Context) error
+	UnlinkIdentity(c sharedctx.Context) error
 	GetProfile(c sharedctx.Context) error
 	GetSessions(c sharedctx.Context) error
 	RevokeSession(c sharedctx.Context) error
 	RevokeAllSessions(c sharedctx.Context) error
+
+	// RBAC administration
+	ListRoles(c sharedctx.Context) error
+	CreateRole(c sharedctx.Context) error
+	DeleteRole(c sharedctx.Context) error
+	SetRolePermissions(c sharedctx.Context) error
+	ListPermissions(c sharedctx.Context) error
+	GetUserRoles(c sharedctx.Context) error
+	AssignRole(c sharedctx.Context) error
+	RevokeRole(c sharedctx.Context) error
+
+	// Machine client administration
+	ListClients(c sharedctx.Context) error
+	RegisterClient(c sharedctx.Context) error
+	DeleteClient(c sharedctx.Context) error
+	RotateClientSecret(c sharedctx.Context) error
 }
 
 // Service defines the interface for authentication business logic
@@ -28,21 +60,59 @@ type Service interface {
 	Logout(ctx context.Context, userID string, req *LogoutRequest) error
 	RefreshToken(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error)
 	ValidateToken(ctx context.Context, token string) (*ValidateTokenResponse, error)
+	ValidateSession(ctx context.Context, token string) (*ValidateTokenResponse, error)
 
 	// Password management
 	ChangePassword(ctx context.Context, userID string, req *ChangePasswordRequest) error
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
 	ConfirmResetPassword(ctx context.Context, req *ConfirmResetPasswordRequest) error
 
+	// Email verification
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest) error
+
+	// Multi-factor authentication
+	EnrollMFA(ctx context.Context, userID string) (*MFAEnrollResponse, error)
+	EnableMFA(ctx context.Context, userID string, req *MFACodeRequest) (*MFARecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID string, req *MFACodeRequest) error
+	VerifyMFA(ctx context.Context, req *VerifyMFARequest, userAgent, ipAddress string) (*LoginResponse, error)
+
+	// External identity providers
+	StartOIDCLogin(ctx context.Context, provider string) (*OIDCAuthorizationResponse, error)
+	// StartOIDCLink starts a flow whose callback links the provider account to userID
+	StartOIDCLink(ctx context.Context, userID, provider string) (*OIDCAuthorizationResponse, error)
+	CompleteOIDCLogin(ctx context.Context, req *OIDCCallbackRequest, userAgent, ipAddress string) (*LoginResponse, error)
+	ListIdentities(ctx context.Context, userID string) (*IdentityListResponse, error)
+	UnlinkIdentity(ctx context.Context, userID, provider string) error
+
 	// Session management
 	GetSessions(ctx context.Context, userID string) (*SessionListResponse, error)
 	RevokeSession(ctx context.Context, userID, sessionID string) error
 	RevokeAllSessions(ctx context.Context, userID string) error
 
+	// Role and permission management
+	ListRoles(ctx context.Context) (*RoleListResponse, error)
+	CreateRole(ctx context.Context, req *CreateRoleRequest) (*Role, error)
+	DeleteRole(ctx context.Context, roleID string) error
+	SetRolePermissions(ctx context.Context, roleID string, req *SetRolePermissionsRequest) (*Role, error)
+	ListPermissions(ctx context.Context) (*PermissionListResponse, error)
+	GetUserRoles(ctx context.Context, userID string) (*UserRolesResponse, error)
+	AssignRole(ctx context.Context, assignedBy, userID string, req *AssignRoleRequest) error
+	RevokeRole(ctx context.Context, userID, roleID string) error
+
+	// OAuth2 authorization server for machine clients
+	ListClients(ctx context.Context) (*ClientListResponse, error)
+	RegisterClient(ctx context.Context, createdBy string, req *RegisterClientRequest) (*ClientSecretResponse, error)
+	DeleteClient(ctx context.Context, clientID string) error
+	RotateClientSecret(ctx context.Context, clientID string) (*ClientSecretResponse, error)
+	IssueClientToken(ctx context.Context, req *ClientTokenRequest) (*ClientTokenResponse, error)
+	IntrospectToken(ctx context.Context, req *IntrospectTokenRequest) (*IntrospectionResponse, error)
+
 	// Token utilities
 	GenerateAccessToken(claims *TokenClaims) (string, error)
 	GenerateRefreshToken(userID string) (string, error)
 	ParseToken(token string) (*TokenClaims, error)
+	JWKS(ctx context.Context) (*JWKSResponse, error)
 
 	// Password utilities
 	HashPassword(password string) (string, error)
@@ -51,9 +121,6 @@ type Service interface {
 
 // Repository defines the interface for authentication data access
 type Repository interface {
-	StartContext(ctx context.Context) context.Context
-	DeferErrorContext(ctx context.Context, err error)
-
 	// Credential operations
 	CreateCredential(ctx context.Context, cred *Credential) error
 	GetCredentialByUsername(ctx context.Context, username string) (*Credential, error)
@@ -62,6 +129,7 @@ type Repository interface {
 	UpdateCredential(ctx context.Context, cred *Credential) error
 	UpdatePassword(ctx context.Context, userID, passwordHash string) error
 	UpdateLastLogin(ctx context.Context, userID string) error
+	MarkEmailVerified(ctx context.Context, userID string) error
 
 	// Session operations
 	CreateSession(ctx context.Context, session *Session) error
@@ -71,6 +139,70 @@ type Repository interface {
 	RevokeSession(ctx context.Context, sessionID string) error
 	RevokeAllUserSessions(ctx context.Context, userID string) error
 	DeleteExpiredSessions(ctx context.Context) error
+	// GetConsumedSessionByToken finds a session whose refresh token was already rotated
+	GetConsumedSessionByToken(ctx context.Context, token string) (*Session, error)
+	// ConsumeSession atomically marks an active session as rotated; it fails if already consumed or revoked
+	ConsumeSession(ctx context.Context, sessionID string) error
+	RevokeSessionFamily(ctx context.Context, familyID string) error
+
+	// Password reset token operations
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+	// ConsumePasswordResetToken atomically marks an unused, unexpired token as used and returns it
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	InvalidatePasswordResetTokens(ctx context.Context, userID string) error
+
+	// MFA operations
+	// SaveMFAEnrollment creates or replaces the user's enrollment
+	SaveMFAEnrollment(ctx context.Context, enrollment *MFAEnrollment) error
+	// GetMFAEnrollment returns nil without an error when the user has not enrolled
+	GetMFAEnrollment(ctx context.Context, userID string) (*MFAEnrollment, error)
+	EnableMFA(ctx context.Context, userID string) error
+	// DeleteMFAEnrollment removes the enrollment and all recovery codes
+	DeleteMFAEnrollment(ctx context.Context, userID string) error
+	// UseMFAStep atomically records step as used; it fails unless step is newer than the last used step
+	UseMFAStep(ctx context.Context, userID string, step int64) error
+	ReplaceMFARecoveryCodes(ctx context.Context, userID string, codes []MFARecoveryCode) error
+	// ConsumeMFARecoveryCode atomically marks an unused recovery code as used
+	ConsumeMFARecoveryCode(ctx context.Context, userID, codeHash string) error
+
+	// External identity operations
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	// GetIdentity returns nil without an error when no user is linked to the provider subject
+	GetIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	GetIdentitiesByUserID(ctx context.Context, userID string) ([]Identity, error)
+	DeleteIdentity(ctx context.Context, id string) error
+
+	// Machine client operations
+	CreateClient(ctx context.Context, client *Client) error
+	// GetClientByID returns nil without an error when the client does not exist
+	GetClientByID(ctx context.Context, id string) (*Client, error)
+	ListClients(ctx context.Context) ([]Client, error)
+	UpdateClientSecret(ctx context.Context, id, secretHash string) error
+	DeleteClient(ctx context.Context, id string) error
+
+	// Role operations; returned roles carry their permission names
+	CreateRole(ctx context.Context, role *Role) error
+	GetRoleByID(ctx context.Context, id string) (*Role, error)
+	GetRoleByName(ctx context.Context, name string) (*Role, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	DeleteRole(ctx context.Context, id string) error
+	SetRolePermissions(ctx context.Context, roleID string, permissions []string) error
+	ListPermissions(ctx context.Context) ([]Permission, error)
+
+	// User-role assignment operations
+	AssignRole(ctx context.Context, userRole *UserRole) error
+	RevokeRole(ctx context.Context, userID, roleID string) error
+	GetUserRoles(ctx context.Context, userID string) ([]Role, error)
+}
+
+// IdentityProvider is an external OAuth2/OIDC login provider such as Google or GitHub.
+// Implementations use the authorization code flow with PKCE.
+type IdentityProvider interface {
+	// AuthCodeURL returns the provider URL the browser is sent to
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange redeems an authorization code and returns the verified account.
+	// nonce must match the ID token for OIDC providers.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ExternalIdentity, error)
 }
 
 // Middleware defines the interface for authentication middleware
@@ -86,6 +218,9 @@ type Middleware interface {
 
 	// RequireRoles ensures the authenticated user has specific roles
 	RequireRoles(roles ...string) func(next func(sharedctx.Context) error) func(sharedctx.Context) error
+
+	// RequirePermission ensures the authenticated user holds all of the given permissions
+	RequirePermission(permissions ...string) func(next func(sharedctx.Context) error) func(sharedctx.Context) error
 }
 
 // =============================================================================
@@ -114,3 +249,16 @@ type NewUser struct {
 	Email     string
 	CreatedBy string
 }
+
+// PasswordResetNotifier is an ACL interface for delivering password reset links.
+// The auth module decides when a reset is requested; delivery belongs to the user module.
+type PasswordResetNotifier interface {
+	SendPasswordReset(ctx context.Context, notice *PasswordResetNotice) error
+}
+
+// PasswordResetNotice carries what is needed to deliver a password reset link.
+type PasswordResetNotice struct {
+	UserID    string
+	Email     string
+	ResetLink string
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/modules/auth/domain/interfaces.go
+// Source: interfaces.go
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -36,6 +36,20 @@ func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
 	return m.recorder
 }
 
+// AssignRole mocks base method.
+func (m *MockHandler) AssignRole(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockHandlerMockRecorder) AssignRole(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockHandler)(nil).AssignRole), c)
+}
+
 // ChangePassword mocks base method.
 func (m *MockHandler) ChangePassword(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -50,6 +64,104 @@ func (mr *MockHandlerMockRecorder) ChangePassword(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockHandler)(nil).ChangePassword), c)
 }
 
+// ConfirmResetPassword mocks base method.
+func (m *MockHandler) ConfirmResetPassword(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmResetPassword", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmResetPassword indicates an expected call of ConfirmResetPassword.
+func (mr *MockHandlerMockRecorder) ConfirmResetPassword(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmResetPassword", reflect.TypeOf((*MockHandler)(nil).ConfirmResetPassword), c)
+}
+
+// CreateRole mocks base method.
+func (m *MockHandler) CreateRole(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockHandlerMockRecorder) CreateRole(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockHandler)(nil).CreateRole), c)
+}
+
+// DeleteClient mocks base method.
+func (m *MockHandler) DeleteClient(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockHandlerMockRecorder) DeleteClient(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockHandler)(nil).DeleteClient), c)
+}
+
+// DeleteRole mocks base method.
+func (m *MockHandler) DeleteRole(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockHandlerMockRecorder) DeleteRole(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockHandler)(nil).DeleteRole), c)
+}
+
+// DisableMFA mocks base method.
+func (m *MockHandler) DisableMFA(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockHandlerMockRecorder) DisableMFA(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockHandler)(nil).DisableMFA), c)
+}
+
+// EnableMFA mocks base method.
+func (m *MockHandler) EnableMFA(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockHandlerMockRecorder) EnableMFA(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockHandler)(nil).EnableMFA), c)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockHandler) EnrollMFA(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockHandlerMockRecorder) EnrollMFA(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockHandler)(nil).EnrollMFA), c)
+}
+
 // GetProfile mocks base method.
 func (m *MockHandler) GetProfile(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -78,6 +190,118 @@ func (mr *MockHandlerMockRecorder) GetSessions(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockHandler)(nil).GetSessions), c)
 }
 
+// GetUserRoles mocks base method.
+func (m *MockHandler) GetUserRoles(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockHandlerMockRecorder) GetUserRoles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockHandler)(nil).GetUserRoles), c)
+}
+
+// IntrospectToken mocks base method.
+func (m *MockHandler) IntrospectToken(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockHandlerMockRecorder) IntrospectToken(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockHandler)(nil).IntrospectToken), c)
+}
+
+// IssueClientToken mocks base method.
+func (m *MockHandler) IssueClientToken(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueClientToken", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IssueClientToken indicates an expected call of IssueClientToken.
+func (mr *MockHandlerMockRecorder) IssueClientToken(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueClientToken", reflect.TypeOf((*MockHandler)(nil).IssueClientToken), c)
+}
+
+// JWKS mocks base method.
+func (m *MockHandler) JWKS(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockHandlerMockRecorder) JWKS(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockHandler)(nil).JWKS), c)
+}
+
+// ListClients mocks base method.
+func (m *MockHandler) ListClients(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockHandlerMockRecorder) ListClients(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockHandler)(nil).ListClients), c)
+}
+
+// ListIdentities mocks base method.
+func (m *MockHandler) ListIdentities(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentities", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListIdentities indicates an expected call of ListIdentities.
+func (mr *MockHandlerMockRecorder) ListIdentities(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentities", reflect.TypeOf((*MockHandler)(nil).ListIdentities), c)
+}
+
+// ListPermissions mocks base method.
+func (m *MockHandler) ListPermissions(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockHandlerMockRecorder) ListPermissions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockHandler)(nil).ListPermissions), c)
+}
+
+// ListRoles mocks base method.
+func (m *MockHandler) ListRoles(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockHandlerMockRecorder) ListRoles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockHandler)(nil).ListRoles), c)
+}
+
 // Login mocks base method.
 func (m *MockHandler) Login(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -106,6 +330,20 @@ func (mr *MockHandlerMockRecorder) Logout(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockHandler)(nil).Logout), c)
 }
 
+// OIDCCallback mocks base method.
+func (m *MockHandler) OIDCCallback(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCCallback", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OIDCCallback indicates an expected call of OIDCCallback.
+func (mr *MockHandlerMockRecorder) OIDCCallback(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCCallback", reflect.TypeOf((*MockHandler)(nil).OIDCCallback), c)
+}
+
 // RefreshToken mocks base method.
 func (m *MockHandler) RefreshToken(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -134,6 +372,48 @@ func (mr *MockHandlerMockRecorder) Register(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockHandler)(nil).Register), c)
 }
 
+// RegisterClient mocks base method.
+func (m *MockHandler) RegisterClient(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockHandlerMockRecorder) RegisterClient(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockHandler)(nil).RegisterClient), c)
+}
+
+// ResendVerification mocks base method.
+func (m *MockHandler) ResendVerification(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockHandlerMockRecorder) ResendVerification(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockHandler)(nil).ResendVerification), c)
+}
+
+// ResetPassword mocks base method.
+func (m *MockHandler) ResetPassword(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockHandlerMockRecorder) ResetPassword(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockHandler)(nil).ResetPassword), c)
+}
+
 // RevokeAllSessions mocks base method.
 func (m *MockHandler) RevokeAllSessions(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -148,6 +428,20 @@ func (mr *MockHandlerMockRecorder) RevokeAllSessions(c interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockHandler)(nil).RevokeAllSessions), c)
 }
 
+// RevokeRole mocks base method.
+func (m *MockHandler) RevokeRole(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockHandlerMockRecorder) RevokeRole(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockHandler)(nil).RevokeRole), c)
+}
+
 // RevokeSession mocks base method.
 func (m *MockHandler) RevokeSession(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -162,6 +456,76 @@ func (mr *MockHandlerMockRecorder) RevokeSession(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockHandler)(nil).RevokeSession), c)
 }
 
+// RotateClientSecret mocks base method.
+func (m *MockHandler) RotateClientSecret(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateClientSecret", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateClientSecret indicates an expected call of RotateClientSecret.
+func (mr *MockHandlerMockRecorder) RotateClientSecret(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateClientSecret", reflect.TypeOf((*MockHandler)(nil).RotateClientSecret), c)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockHandler) SetRolePermissions(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockHandlerMockRecorder) SetRolePermissions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockHandler)(nil).SetRolePermissions), c)
+}
+
+// StartOIDCLink mocks base method.
+func (m *MockHandler) StartOIDCLink(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLink", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartOIDCLink indicates an expected call of StartOIDCLink.
+func (mr *MockHandlerMockRecorder) StartOIDCLink(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLink", reflect.TypeOf((*MockHandler)(nil).StartOIDCLink), c)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *MockHandler) StartOIDCLogin(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockHandlerMockRecorder) StartOIDCLogin(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*MockHandler)(nil).StartOIDCLogin), c)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockHandler) UnlinkIdentity(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockHandlerMockRecorder) UnlinkIdentity(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockHandler)(nil).UnlinkIdentity), c)
+}
+
 // ValidateToken mocks base method.
 func (m *MockHandler) ValidateToken(c context0.Context) error {
 	m.ctrl.T.Helper()
@@ -176,6 +540,34 @@ func (mr *MockHandlerMockRecorder) ValidateToken(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockHandler)(nil).ValidateToken), c)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockHandler) VerifyEmail(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockHandlerMockRecorder) VerifyEmail(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockHandler)(nil).VerifyEmail), c)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockHandler) VerifyMFA(c context0.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockHandlerMockRecorder) VerifyMFA(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockHandler)(nil).VerifyMFA), c)
+}
+
 // MockService is a mock of Service interface.
 type MockService struct {
 	ctrl     *gomock.Controller
@@ -194,37 +586,153 @@ func NewMockService(ctrl *gomock.Controller) *MockService {
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockService) EXPECT() *MockServiceMockRecorder {
-	return m.recorder
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockService) AssignRole(ctx context.Context, assignedBy, userID string, req *domain.AssignRoleRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, assignedBy, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockServiceMockRecorder) AssignRole(ctx, assignedBy, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockService)(nil).AssignRole), ctx, assignedBy, userID, req)
+}
+
+// ChangePassword mocks base method.
+func (m *MockService) ChangePassword(ctx context.Context, userID string, req *domain.ChangePasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockServiceMockRecorder) ChangePassword(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockService)(nil).ChangePassword), ctx, userID, req)
+}
+
+// CompleteOIDCLogin mocks base method.
+func (m *MockService) CompleteOIDCLogin(ctx context.Context, req *domain.OIDCCallbackRequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOIDCLogin", ctx, req, userAgent, ipAddress)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOIDCLogin indicates an expected call of CompleteOIDCLogin.
+func (mr *MockServiceMockRecorder) CompleteOIDCLogin(ctx, req, userAgent, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOIDCLogin", reflect.TypeOf((*MockService)(nil).CompleteOIDCLogin), ctx, req, userAgent, ipAddress)
+}
+
+// ConfirmResetPassword mocks base method.
+func (m *MockService) ConfirmResetPassword(ctx context.Context, req *domain.ConfirmResetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmResetPassword", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmResetPassword indicates an expected call of ConfirmResetPassword.
+func (mr *MockServiceMockRecorder) ConfirmResetPassword(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmResetPassword", reflect.TypeOf((*MockService)(nil).ConfirmResetPassword), ctx, req)
+}
+
+// CreateRole mocks base method.
+func (m *MockService) CreateRole(ctx context.Context, req *domain.CreateRoleRequest) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, req)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockServiceMockRecorder) CreateRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockService)(nil).CreateRole), ctx, req)
+}
+
+// DeleteClient mocks base method.
+func (m *MockService) DeleteClient(ctx context.Context, clientID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, clientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockServiceMockRecorder) DeleteClient(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockService)(nil).DeleteClient), ctx, clientID)
 }
 
-// ChangePassword mocks base method.
-func (m *MockService) ChangePassword(ctx context.Context, userID string, req *domain.ChangePasswordRequest) error {
+// DeleteRole mocks base method.
+func (m *MockService) DeleteRole(ctx context.Context, roleID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, req)
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, roleID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ChangePassword indicates an expected call of ChangePassword.
-func (mr *MockServiceMockRecorder) ChangePassword(ctx, userID, req interface{}) *gomock.Call {
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockServiceMockRecorder) DeleteRole(ctx, roleID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockService)(nil).ChangePassword), ctx, userID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockService)(nil).DeleteRole), ctx, roleID)
 }
 
-// ConfirmResetPassword mocks base method.
-func (m *MockService) ConfirmResetPassword(ctx context.Context, req *domain.ConfirmResetPasswordRequest) error {
+// DisableMFA mocks base method.
+func (m *MockService) DisableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfirmResetPassword", ctx, req)
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, userID, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ConfirmResetPassword indicates an expected call of ConfirmResetPassword.
-func (mr *MockServiceMockRecorder) ConfirmResetPassword(ctx, req interface{}) *gomock.Call {
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockServiceMockRecorder) DisableMFA(ctx, userID, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmResetPassword", reflect.TypeOf((*MockService)(nil).ConfirmResetPassword), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockService)(nil).DisableMFA), ctx, userID, req)
+}
+
+// EnableMFA mocks base method.
+func (m *MockService) EnableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) (*domain.MFARecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", ctx, userID, req)
+	ret0, _ := ret[0].(*domain.MFARecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockServiceMockRecorder) EnableMFA(ctx, userID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockService)(nil).EnableMFA), ctx, userID, req)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockService) EnrollMFA(ctx context.Context, userID string) (*domain.MFAEnrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, userID)
+	ret0, _ := ret[0].(*domain.MFAEnrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockServiceMockRecorder) EnrollMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockService)(nil).EnrollMFA), ctx, userID)
 }
 
 // GenerateAccessToken mocks base method.
@@ -272,6 +780,21 @@ func (mr *MockServiceMockRecorder) GetSessions(ctx, userID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockService)(nil).GetSessions), ctx, userID)
 }
 
+// GetUserRoles mocks base method.
+func (m *MockService) GetUserRoles(ctx context.Context, userID string) (*domain.UserRolesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
+	ret0, _ := ret[0].(*domain.UserRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockServiceMockRecorder) GetUserRoles(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockService)(nil).GetUserRoles), ctx, userID)
+}
+
 // HashPassword mocks base method.
 func (m *MockService) HashPassword(password string) (string, error) {
 	m.ctrl.T.Helper()
@@ -287,6 +810,111 @@ func (mr *MockServiceMockRecorder) HashPassword(password interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashPassword", reflect.TypeOf((*MockService)(nil).HashPassword), password)
 }
 
+// IntrospectToken mocks base method.
+func (m *MockService) IntrospectToken(ctx context.Context, req *domain.IntrospectTokenRequest) (*domain.IntrospectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", ctx, req)
+	ret0, _ := ret[0].(*domain.IntrospectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockServiceMockRecorder) IntrospectToken(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockService)(nil).IntrospectToken), ctx, req)
+}
+
+// IssueClientToken mocks base method.
+func (m *MockService) IssueClientToken(ctx context.Context, req *domain.ClientTokenRequest) (*domain.ClientTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueClientToken", ctx, req)
+	ret0, _ := ret[0].(*domain.ClientTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueClientToken indicates an expected call of IssueClientToken.
+func (mr *MockServiceMockRecorder) IssueClientToken(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueClientToken", reflect.TypeOf((*MockService)(nil).IssueClientToken), ctx, req)
+}
+
+// JWKS mocks base method.
+func (m *MockService) JWKS(ctx context.Context) (*domain.JWKSResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS", ctx)
+	ret0, _ := ret[0].(*domain.JWKSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockServiceMockRecorder) JWKS(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockService)(nil).JWKS), ctx)
+}
+
+// ListClients mocks base method.
+func (m *MockService) ListClients(ctx context.Context) (*domain.ClientListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ctx)
+	ret0, _ := ret[0].(*domain.ClientListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockServiceMockRecorder) ListClients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockService)(nil).ListClients), ctx)
+}
+
+// ListIdentities mocks base method.
+func (m *MockService) ListIdentities(ctx context.Context, userID string) (*domain.IdentityListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentities", ctx, userID)
+	ret0, _ := ret[0].(*domain.IdentityListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentities indicates an expected call of ListIdentities.
+func (mr *MockServiceMockRecorder) ListIdentities(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentities", reflect.TypeOf((*MockService)(nil).ListIdentities), ctx, userID)
+}
+
+// ListPermissions mocks base method.
+func (m *MockService) ListPermissions(ctx context.Context) (*domain.PermissionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].(*domain.PermissionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockServiceMockRecorder) ListPermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockService)(nil).ListPermissions), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockService) ListRoles(ctx context.Context) (*domain.RoleListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].(*domain.RoleListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockServiceMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockService)(nil).ListRoles), ctx)
+}
+
 // Login mocks base method.
 func (m *MockService) Login(ctx context.Context, req *domain.LoginRequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -361,6 +989,35 @@ func (mr *MockServiceMockRecorder) Register(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockService)(nil).Register), ctx, req)
 }
 
+// RegisterClient mocks base method.
+func (m *MockService) RegisterClient(ctx context.Context, createdBy string, req *domain.RegisterClientRequest) (*domain.ClientSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterClient", ctx, createdBy, req)
+	ret0, _ := ret[0].(*domain.ClientSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterClient indicates an expected call of RegisterClient.
+func (mr *MockServiceMockRecorder) RegisterClient(ctx, createdBy, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterClient", reflect.TypeOf((*MockService)(nil).RegisterClient), ctx, createdBy, req)
+}
+
+// ResendVerification mocks base method.
+func (m *MockService) ResendVerification(ctx context.Context, req *domain.ResendVerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockServiceMockRecorder) ResendVerification(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockService)(nil).ResendVerification), ctx, req)
+}
+
 // ResetPassword mocks base method.
 func (m *MockService) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) error {
 	m.ctrl.T.Helper()
@@ -389,6 +1046,20 @@ func (mr *MockServiceMockRecorder) RevokeAllSessions(ctx, userID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockService)(nil).RevokeAllSessions), ctx, userID)
 }
 
+// RevokeRole mocks base method.
+func (m *MockService) RevokeRole(ctx context.Context, userID, roleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockServiceMockRecorder) RevokeRole(ctx, userID, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockService)(nil).RevokeRole), ctx, userID, roleID)
+}
+
 // RevokeSession mocks base method.
 func (m *MockService) RevokeSession(ctx context.Context, userID, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -403,6 +1074,95 @@ func (mr *MockServiceMockRecorder) RevokeSession(ctx, userID, sessionID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockService)(nil).RevokeSession), ctx, userID, sessionID)
 }
 
+// RotateClientSecret mocks base method.
+func (m *MockService) RotateClientSecret(ctx context.Context, clientID string) (*domain.ClientSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateClientSecret", ctx, clientID)
+	ret0, _ := ret[0].(*domain.ClientSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateClientSecret indicates an expected call of RotateClientSecret.
+func (mr *MockServiceMockRecorder) RotateClientSecret(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateClientSecret", reflect.TypeOf((*MockService)(nil).RotateClientSecret), ctx, clientID)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockService) SetRolePermissions(ctx context.Context, roleID string, req *domain.SetRolePermissionsRequest) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, roleID, req)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockServiceMockRecorder) SetRolePermissions(ctx, roleID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockService)(nil).SetRolePermissions), ctx, roleID, req)
+}
+
+// StartOIDCLink mocks base method.
+func (m *MockService) StartOIDCLink(ctx context.Context, userID, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLink", ctx, userID, provider)
+	ret0, _ := ret[0].(*domain.OIDCAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOIDCLink indicates an expected call of StartOIDCLink.
+func (mr *MockServiceMockRecorder) StartOIDCLink(ctx, userID, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLink", reflect.TypeOf((*MockService)(nil).StartOIDCLink), ctx, userID, provider)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *MockService) StartOIDCLogin(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin", ctx, provider)
+	ret0, _ := ret[0].(*domain.OIDCAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockServiceMockRecorder) StartOIDCLogin(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*MockService)(nil).StartOIDCLogin), ctx, provider)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockService) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", ctx, userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockServiceMockRecorder) UnlinkIdentity(ctx, userID, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockService)(nil).UnlinkIdentity), ctx, userID, provider)
+}
+
+// ValidateSession mocks base method.
+func (m *MockService) ValidateSession(ctx context.Context, token string) (*domain.ValidateTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSession", ctx, token)
+	ret0, _ := ret[0].(*domain.ValidateTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateSession indicates an expected call of ValidateSession.
+func (mr *MockServiceMockRecorder) ValidateSession(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSession", reflect.TypeOf((*MockService)(nil).ValidateSession), ctx, token)
+}
+
 // ValidateToken mocks base method.
 func (m *MockService) ValidateToken(ctx context.Context, token string) (*domain.ValidateTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -418,6 +1178,35 @@ func (mr *MockServiceMockRecorder) ValidateToken(ctx, token interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockService)(nil).ValidateToken), ctx, token)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockService) VerifyEmail(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockServiceMockRecorder) VerifyEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockService)(nil).VerifyEmail), ctx, req)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockService) VerifyMFA(ctx context.Context, req *domain.VerifyMFARequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, req, userAgent, ipAddress)
+	ret0, _ := ret[0].(*domain.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockServiceMockRecorder) VerifyMFA(ctx, req, userAgent, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockService)(nil).VerifyMFA), ctx, req, userAgent, ipAddress)
+}
+
 // VerifyPassword mocks base method.
 func (m *MockService) VerifyPassword(hashedPassword, password string) error {
 	m.ctrl.T.Helper()
@@ -455,18 +1244,131 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateCredential mocks base method.
-func (m *MockRepository) CreateCredential(ctx context.Context, cred *domain.Credential) error {
+// AssignRole mocks base method.
+func (m *MockRepository) AssignRole(ctx context.Context, userRole *domain.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, userRole)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockRepositoryMockRecorder) AssignRole(ctx, userRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockRepository)(nil).AssignRole), ctx, userRole)
+}
+
+// ConsumeMFARecoveryCode mocks base method.
+func (m *MockRepository) ConsumeMFARecoveryCode(ctx context.Context, userID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeMFARecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeMFARecoveryCode indicates an expected call of ConsumeMFARecoveryCode.
+func (mr *MockRepositoryMockRecorder) ConsumeMFARecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeMFARecoveryCode", reflect.TypeOf((*MockRepository)(nil).ConsumeMFARecoveryCode), ctx, userID, codeHash)
+}
+
+// ConsumePasswordResetToken mocks base method.
+func (m *MockRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePasswordResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePasswordResetToken indicates an expected call of ConsumePasswordResetToken.
+func (mr *MockRepositoryMockRecorder) ConsumePasswordResetToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePasswordResetToken", reflect.TypeOf((*MockRepository)(nil).ConsumePasswordResetToken), ctx, tokenHash)
+}
+
+// ConsumeSession mocks base method.
+func (m *MockRepository) ConsumeSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeSession indicates an expected call of ConsumeSession.
+func (mr *MockRepositoryMockRecorder) ConsumeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeSession", reflect.TypeOf((*MockRepository)(nil).ConsumeSession), ctx, sessionID)
+}
+
+// CreateClient mocks base method.
+func (m *MockRepository) CreateClient(ctx context.Context, client *domain.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClient", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClient indicates an expected call of CreateClient.
+func (mr *MockRepositoryMockRecorder) CreateClient(ctx, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockRepository)(nil).CreateClient), ctx, client)
+}
+
+// CreateCredential mocks base method.
+func (m *MockRepository) CreateCredential(ctx context.Context, cred *domain.Credential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredential", ctx, cred)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCredential indicates an expected call of CreateCredential.
+func (mr *MockRepositoryMockRecorder) CreateCredential(ctx, cred interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockRepository)(nil).CreateCredential), ctx, cred)
+}
+
+// CreateIdentity mocks base method.
+func (m *MockRepository) CreateIdentity(ctx context.Context, identity *domain.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockRepositoryMockRecorder) CreateIdentity(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockRepository)(nil).CreateIdentity), ctx, identity)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockRepositoryMockRecorder) CreatePasswordResetToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockRepository)(nil).CreatePasswordResetToken), ctx, token)
+}
+
+// CreateRole mocks base method.
+func (m *MockRepository) CreateRole(ctx context.Context, role *domain.Role) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCredential", ctx, cred)
+	ret := m.ctrl.Call(m, "CreateRole", ctx, role)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateCredential indicates an expected call of CreateCredential.
-func (mr *MockRepositoryMockRecorder) CreateCredential(ctx, cred interface{}) *gomock.Call {
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockRepositoryMockRecorder) CreateRole(ctx, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredential", reflect.TypeOf((*MockRepository)(nil).CreateCredential), ctx, cred)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRepository)(nil).CreateRole), ctx, role)
 }
 
 // CreateSession mocks base method.
@@ -483,16 +1385,18 @@ func (mr *MockRepositoryMockRecorder) CreateSession(ctx, session interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRepository)(nil).CreateSession), ctx, session)
 }
 
-// DeferErrorContext mocks base method.
-func (m *MockRepository) DeferErrorContext(ctx context.Context, err error) {
+// DeleteClient mocks base method.
+func (m *MockRepository) DeleteClient(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeferErrorContext", ctx, err)
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeferErrorContext indicates an expected call of DeferErrorContext.
-func (mr *MockRepositoryMockRecorder) DeferErrorContext(ctx, err interface{}) *gomock.Call {
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockRepositoryMockRecorder) DeleteClient(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferErrorContext", reflect.TypeOf((*MockRepository)(nil).DeferErrorContext), ctx, err)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockRepository)(nil).DeleteClient), ctx, id)
 }
 
 // DeleteExpiredSessions mocks base method.
@@ -509,6 +1413,92 @@ func (mr *MockRepositoryMockRecorder) DeleteExpiredSessions(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockRepository)(nil).DeleteExpiredSessions), ctx)
 }
 
+// DeleteIdentity mocks base method.
+func (m *MockRepository) DeleteIdentity(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdentity", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdentity indicates an expected call of DeleteIdentity.
+func (mr *MockRepositoryMockRecorder) DeleteIdentity(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentity", reflect.TypeOf((*MockRepository)(nil).DeleteIdentity), ctx, id)
+}
+
+// DeleteMFAEnrollment mocks base method.
+func (m *MockRepository) DeleteMFAEnrollment(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMFAEnrollment", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMFAEnrollment indicates an expected call of DeleteMFAEnrollment.
+func (mr *MockRepositoryMockRecorder) DeleteMFAEnrollment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMFAEnrollment", reflect.TypeOf((*MockRepository)(nil).DeleteMFAEnrollment), ctx, userID)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRepository) DeleteRole(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRepositoryMockRecorder) DeleteRole(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRepository)(nil).DeleteRole), ctx, id)
+}
+
+// EnableMFA mocks base method.
+func (m *MockRepository) EnableMFA(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockRepositoryMockRecorder) EnableMFA(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockRepository)(nil).EnableMFA), ctx, userID)
+}
+
+// GetClientByID mocks base method.
+func (m *MockRepository) GetClientByID(ctx context.Context, id string) (*domain.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientByID indicates an expected call of GetClientByID.
+func (mr *MockRepositoryMockRecorder) GetClientByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientByID", reflect.TypeOf((*MockRepository)(nil).GetClientByID), ctx, id)
+}
+
+// GetConsumedSessionByToken mocks base method.
+func (m *MockRepository) GetConsumedSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsumedSessionByToken", ctx, token)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsumedSessionByToken indicates an expected call of GetConsumedSessionByToken.
+func (mr *MockRepositoryMockRecorder) GetConsumedSessionByToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsumedSessionByToken", reflect.TypeOf((*MockRepository)(nil).GetConsumedSessionByToken), ctx, token)
+}
+
 // GetCredentialByEmail mocks base method.
 func (m *MockRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error) {
 	m.ctrl.T.Helper()
@@ -554,6 +1544,81 @@ func (mr *MockRepositoryMockRecorder) GetCredentialByUsername(ctx, username inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentialByUsername", reflect.TypeOf((*MockRepository)(nil).GetCredentialByUsername), ctx, username)
 }
 
+// GetIdentitiesByUserID mocks base method.
+func (m *MockRepository) GetIdentitiesByUserID(ctx context.Context, userID string) ([]domain.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentitiesByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentitiesByUserID indicates an expected call of GetIdentitiesByUserID.
+func (mr *MockRepositoryMockRecorder) GetIdentitiesByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentitiesByUserID", reflect.TypeOf((*MockRepository)(nil).GetIdentitiesByUserID), ctx, userID)
+}
+
+// GetIdentity mocks base method.
+func (m *MockRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*domain.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockRepositoryMockRecorder) GetIdentity(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockRepository)(nil).GetIdentity), ctx, provider, subject)
+}
+
+// GetMFAEnrollment mocks base method.
+func (m *MockRepository) GetMFAEnrollment(ctx context.Context, userID string) (*domain.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFAEnrollment", ctx, userID)
+	ret0, _ := ret[0].(*domain.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFAEnrollment indicates an expected call of GetMFAEnrollment.
+func (mr *MockRepositoryMockRecorder) GetMFAEnrollment(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFAEnrollment", reflect.TypeOf((*MockRepository)(nil).GetMFAEnrollment), ctx, userID)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockRepository) GetRoleByID(ctx context.Context, id string) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockRepositoryMockRecorder) GetRoleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRepository)(nil).GetRoleByID), ctx, id)
+}
+
+// GetRoleByName mocks base method.
+func (m *MockRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByName", ctx, name)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleByName indicates an expected call of GetRoleByName.
+func (mr *MockRepositoryMockRecorder) GetRoleByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*MockRepository)(nil).GetRoleByName), ctx, name)
+}
+
 // GetSessionByID mocks base method.
 func (m *MockRepository) GetSessionByID(ctx context.Context, id string) (*domain.Session, error) {
 	m.ctrl.T.Helper()
@@ -599,6 +1664,108 @@ func (mr *MockRepositoryMockRecorder) GetSessionsByUserID(ctx, userID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserID", reflect.TypeOf((*MockRepository)(nil).GetSessionsByUserID), ctx, userID)
 }
 
+// GetUserRoles mocks base method.
+func (m *MockRepository) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockRepositoryMockRecorder) GetUserRoles(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockRepository)(nil).GetUserRoles), ctx, userID)
+}
+
+// InvalidatePasswordResetTokens mocks base method.
+func (m *MockRepository) InvalidatePasswordResetTokens(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResetTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResetTokens indicates an expected call of InvalidatePasswordResetTokens.
+func (mr *MockRepositoryMockRecorder) InvalidatePasswordResetTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResetTokens", reflect.TypeOf((*MockRepository)(nil).InvalidatePasswordResetTokens), ctx, userID)
+}
+
+// ListClients mocks base method.
+func (m *MockRepository) ListClients(ctx context.Context) ([]domain.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", ctx)
+	ret0, _ := ret[0].([]domain.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockRepositoryMockRecorder) ListClients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockRepository)(nil).ListClients), ctx)
+}
+
+// ListPermissions mocks base method.
+func (m *MockRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", ctx)
+	ret0, _ := ret[0].([]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockRepositoryMockRecorder) ListPermissions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockRepository)(nil).ListPermissions), ctx)
+}
+
+// ListRoles mocks base method.
+func (m *MockRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRepositoryMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRepository)(nil).ListRoles), ctx)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockRepositoryMockRecorder) MarkEmailVerified(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockRepository)(nil).MarkEmailVerified), ctx, userID)
+}
+
+// ReplaceMFARecoveryCodes mocks base method.
+func (m *MockRepository) ReplaceMFARecoveryCodes(ctx context.Context, userID string, codes []domain.MFARecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceMFARecoveryCodes", ctx, userID, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceMFARecoveryCodes indicates an expected call of ReplaceMFARecoveryCodes.
+func (mr *MockRepositoryMockRecorder) ReplaceMFARecoveryCodes(ctx, userID, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceMFARecoveryCodes", reflect.TypeOf((*MockRepository)(nil).ReplaceMFARecoveryCodes), ctx, userID, codes)
+}
+
 // RevokeAllUserSessions mocks base method.
 func (m *MockRepository) RevokeAllUserSessions(ctx context.Context, userID string) error {
 	m.ctrl.T.Helper()
@@ -613,6 +1780,20 @@ func (mr *MockRepositoryMockRecorder) RevokeAllUserSessions(ctx, userID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllUserSessions", reflect.TypeOf((*MockRepository)(nil).RevokeAllUserSessions), ctx, userID)
 }
 
+// RevokeRole mocks base method.
+func (m *MockRepository) RevokeRole(ctx context.Context, userID, roleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRepositoryMockRecorder) RevokeRole(ctx, userID, roleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRepository)(nil).RevokeRole), ctx, userID, roleID)
+}
+
 // RevokeSession mocks base method.
 func (m *MockRepository) RevokeSession(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -627,18 +1808,60 @@ func (mr *MockRepositoryMockRecorder) RevokeSession(ctx, sessionID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockRepository)(nil).RevokeSession), ctx, sessionID)
 }
 
-// StartContext mocks base method.
-func (m *MockRepository) StartContext(ctx context.Context) context.Context {
+// RevokeSessionFamily mocks base method.
+func (m *MockRepository) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionFamily indicates an expected call of RevokeSessionFamily.
+func (mr *MockRepositoryMockRecorder) RevokeSessionFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionFamily", reflect.TypeOf((*MockRepository)(nil).RevokeSessionFamily), ctx, familyID)
+}
+
+// SaveMFAEnrollment mocks base method.
+func (m *MockRepository) SaveMFAEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMFAEnrollment", ctx, enrollment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMFAEnrollment indicates an expected call of SaveMFAEnrollment.
+func (mr *MockRepositoryMockRecorder) SaveMFAEnrollment(ctx, enrollment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMFAEnrollment", reflect.TypeOf((*MockRepository)(nil).SaveMFAEnrollment), ctx, enrollment)
+}
+
+// SetRolePermissions mocks base method.
+func (m *MockRepository) SetRolePermissions(ctx context.Context, roleID string, permissions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRolePermissions", ctx, roleID, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRolePermissions indicates an expected call of SetRolePermissions.
+func (mr *MockRepositoryMockRecorder) SetRolePermissions(ctx, roleID, permissions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRolePermissions", reflect.TypeOf((*MockRepository)(nil).SetRolePermissions), ctx, roleID, permissions)
+}
+
+// UpdateClientSecret mocks base method.
+func (m *MockRepository) UpdateClientSecret(ctx context.Context, id, secretHash string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartContext", ctx)
-	ret0, _ := ret[0].(context.Context)
+	ret := m.ctrl.Call(m, "UpdateClientSecret", ctx, id, secretHash)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// StartContext indicates an expected call of StartContext.
-func (mr *MockRepositoryMockRecorder) StartContext(ctx interface{}) *gomock.Call {
+// UpdateClientSecret indicates an expected call of UpdateClientSecret.
+func (mr *MockRepositoryMockRecorder) UpdateClientSecret(ctx, id, secretHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartContext", reflect.TypeOf((*MockRepository)(nil).StartContext), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClientSecret", reflect.TypeOf((*MockRepository)(nil).UpdateClientSecret), ctx, id, secretHash)
 }
 
 // UpdateCredential mocks base method.
@@ -683,6 +1906,73 @@ func (mr *MockRepositoryMockRecorder) UpdatePassword(ctx, userID, passwordHash i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockRepository)(nil).UpdatePassword), ctx, userID, passwordHash)
 }
 
+// UseMFAStep mocks base method.
+func (m *MockRepository) UseMFAStep(ctx context.Context, userID string, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMFAStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseMFAStep indicates an expected call of UseMFAStep.
+func (mr *MockRepositoryMockRecorder) UseMFAStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMFAStep", reflect.TypeOf((*MockRepository)(nil).UseMFAStep), ctx, userID, step)
+}
+
+// MockIdentityProvider is a mock of IdentityProvider interface.
+type MockIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProviderMockRecorder
+}
+
+// MockIdentityProviderMockRecorder is the mock recorder for MockIdentityProvider.
+type MockIdentityProviderMockRecorder struct {
+	mock *MockIdentityProvider
+}
+
+// NewMockIdentityProvider creates a new mock instance.
+func NewMockIdentityProvider(ctrl *gomock.Controller) *MockIdentityProvider {
+	mock := &MockIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProvider) EXPECT() *MockIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockIdentityProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, codeVerifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockIdentityProviderMockRecorder) AuthCodeURL(ctx, state, nonce, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockIdentityProvider)(nil).AuthCodeURL), ctx, state, nonce, codeVerifier)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier, nonce)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityProviderMockRecorder) Exchange(ctx, code, codeVerifier, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityProvider)(nil).Exchange), ctx, code, codeVerifier, nonce)
+}
+
 // MockMiddleware is a mock of Middleware interface.
 type MockMiddleware struct {
 	ctrl     *gomock.Controller
@@ -748,6 +2038,24 @@ func (mr *MockMiddlewareMockRecorder) RequireAuth() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireAuth", reflect.TypeOf((*MockMiddleware)(nil).RequireAuth))
 }
 
+// RequirePermission mocks base method.
+func (m *MockMiddleware) RequirePermission(permissions ...string) func(func(context0.Context) error) func(context0.Context) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range permissions {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequirePermission", varargs...)
+	ret0, _ := ret[0].(func(func(context0.Context) error) func(context0.Context) error)
+	return ret0
+}
+
+// RequirePermission indicates an expected call of RequirePermission.
+func (mr *MockMiddlewareMockRecorder) RequirePermission(permissions ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePermission", reflect.TypeOf((*MockMiddleware)(nil).RequirePermission), permissions...)
+}
+
 // RequireRoles mocks base method.
 func (m *MockMiddleware) RequireRoles(roles ...string) func(func(context0.Context) error) func(context0.Context) error {
 	m.ctrl.T.Helper()
@@ -802,3 +2110,40 @@ func (mr *MockUserCreatorMockRecorder) CreateUser(ctx, user interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserCreator)(nil).CreateUser), ctx, user)
 }
+
+// MockPasswordResetNotifier is a mock of PasswordResetNotifier interface.
+type MockPasswordResetNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetNotifierMockRecorder
+}
+
+// MockPasswordResetNotifierMockRecorder is the mock recorder for MockPasswordResetNotifier.
+type MockPasswordResetNotifierMockRecorder struct {
+	mock *MockPasswordResetNotifier
+}
+
+// NewMockPasswordResetNotifier creates a new mock instance.
+func NewMockPasswordResetNotifier(ctrl *gomock.Controller) *MockPasswordResetNotifier {
+	mock := &MockPasswordResetNotifier{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetNotifier) EXPECT() *MockPasswordResetNotifierMockRecorder {
+	return m.recorder
+}
+
+// SendPasswordReset mocks base method.
+func (m *MockPasswordResetNotifier) SendPasswordReset(ctx context.Context, notice *domain.PasswordResetNotice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordReset", ctx, notice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordReset indicates an expected call of SendPasswordReset.
+func (mr *MockPasswordResetNotifierMockRecorder) SendPasswordReset(ctx, notice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordReset", reflect.TypeOf((*MockPasswordResetNotifier)(nil).SendPasswordReset), ctx, notice)
+}
@@ -2,49 +2,190 @@ package domain
 
 import "time"
 
-// Session represents a user session stored in the database
+// Session represents a user session stored in the database.
+// Each refresh rotates the session: the current row is marked consumed and a
+// new row with a new token joins the same family, which starts at login.
 type Session struct {
+	ID         string     `db:"id" json:"id" bson:"id"`
+	UserID     string     `db:"user_id" json:"user_id" bson:"user_id"`
+	FamilyID   string     `db:"family_id" json:"family_id" bson:"family_id"`
+	Token      string     `db:"token" json:"token" bson:"token"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expires_at" bson:"expires_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	ConsumedAt *time.Time `db:"consumed_at" json:"consumed_at,omitempty" bson:"consumed_at,omitempty"`
+	UserAgent  string     `db:"user_agent" json:"user_agent" bson:"user_agent"`
+	IPAddress  string     `db:"ip_address" json:"ip_address" bson:"ip_address"`
+}
+
+// Credential represents user credentials for authentication
+type Credential struct {
+	ID              string     `db:"id" json:"id" bson:"id"`
+	UserID          string     `db:"user_id" json:"user_id" bson:"user_id"`
+	Username        string     `db:"username" json:"username" bson:"username"`
+	Email           string     `db:"email" json:"email" bson:"email"`
+	PasswordHash    string     `db:"password_hash" json:"-" bson:"password_hash"`
+	IsActive        bool       `db:"is_active" json:"is_active" bson:"is_active"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"email_verified_at,omitempty" bson:"email_verified_at,omitempty"`
+	LastLoginAt     *time.Time `db:"last_login_at" json:"last_login_at,omitempty" bson:"last_login_at,omitempty"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
+	UpdatedAt       *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+	DeletedAt       *time.Time `db:"deleted_at" json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+}
+
+// PasswordResetToken is a single-use token for resetting a forgotten password.
+// Only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
 	ID        string     `db:"id" json:"id" bson:"id"`
 	UserID    string     `db:"user_id" json:"user_id" bson:"user_id"`
-	Token     string     `db:"token" json:"token" bson:"token"`
+	TokenHash string     `db:"token_hash" json:"-" bson:"token_hash"`
 	ExpiresAt time.Time  `db:"expires_at" json:"expires_at" bson:"expires_at"`
+	UsedAt    *time.Time `db:"used_at" json:"used_at,omitempty" bson:"used_at,omitempty"`
 	CreatedAt time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
-	RevokedAt *time.Time `db:"revoked_at" json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
-	UserAgent string     `db:"user_agent" json:"user_agent" bson:"user_agent"`
-	IPAddress string     `db:"ip_address" json:"ip_address" bson:"ip_address"`
 }
 
-// Credential represents user credentials for authentication
-type Credential struct {
-	ID           string     `db:"id" json:"id" bson:"id"`
+// MFAEnrollment holds a user's TOTP secret. MFA is enforced once EnabledAt is set;
+// LastUsedStep is the newest accepted time step and prevents code replay.
+type MFAEnrollment struct {
 	UserID       string     `db:"user_id" json:"user_id" bson:"user_id"`
-	Username     string     `db:"username" json:"username" bson:"username"`
-	Email        string     `db:"email" json:"email" bson:"email"`
-	PasswordHash string     `db:"password_hash" json:"-" bson:"password_hash"`
-	IsActive     bool       `db:"is_active" json:"is_active" bson:"is_active"`
-	LastLoginAt  *time.Time `db:"last_login_at" json:"last_login_at,omitempty" bson:"last_login_at,omitempty"`
+	Secret       string     `db:"secret" json:"-" bson:"secret"`
+	LastUsedStep int64      `db:"last_used_step" json:"-" bson:"last_used_step"`
+	EnabledAt    *time.Time `db:"enabled_at" json:"enabled_at,omitempty" bson:"enabled_at,omitempty"`
 	CreatedAt    time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
 	UpdatedAt    *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
-	DeletedAt    *time.Time `db:"deleted_at" json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
 }
 
-// TokenClaims represents JWT token claims
+// MFARecoveryCode is a single-use fallback for a lost authenticator.
+// Only the SHA-256 hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        string     `db:"id" json:"id" bson:"id"`
+	UserID    string     `db:"user_id" json:"user_id" bson:"user_id"`
+	CodeHash  string     `db:"code_hash" json:"-" bson:"code_hash"`
+	UsedAt    *time.Time `db:"used_at" json:"used_at,omitempty" bson:"used_at,omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
+}
+
+// Identity links a user to an account at an external OAuth2/OIDC provider.
+// Provider and Subject together are unique.
+type Identity struct {
+	ID        string    `db:"id" json:"id" bson:"id"`
+	UserID    string    `db:"user_id" json:"user_id" bson:"user_id"`
+	Provider  string    `db:"provider" json:"provider" bson:"provider"`
+	Subject   string    `db:"subject" json:"subject" bson:"subject"`
+	Email     string    `db:"email" json:"email" bson:"email"`
+	CreatedAt time.Time `db:"created_at" json:"created_at" bson:"created_at"`
+}
+
+// ExternalIdentity is the verified account information returned by an identity provider
+type ExternalIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string // preferred username; the local username is derived from it when free
+}
+
+// Client is a machine client of the OAuth2 client_credentials grant. ID is
+// the OAuth2 client_id; Scopes are the permission names the client may request.
+// Only the SHA-256 hash of the client secret is stored.
+type Client struct {
+	ID         string     `db:"id" json:"client_id" bson:"id"`
+	Name       string     `db:"name" json:"name" bson:"name"`
+	SecretHash string     `db:"secret_hash" json:"-" bson:"secret_hash"`
+	Scopes     []string   `db:"-" json:"scopes" bson:"scopes"`
+	CreatedBy  string     `db:"created_by" json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+}
+
+// Role groups a set of permissions that can be assigned to users
+type Role struct {
+	ID          string     `db:"id" json:"id" bson:"id"`
+	Name        string     `db:"name" json:"name" bson:"name"`
+	Description string     `db:"description" json:"description" bson:"description"`
+	Permissions []string   `db:"-" json:"permissions" bson:"permissions"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at" bson:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at" json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+}
+
+// Permission is a named capability in the form "<resource>:<action>"
+type Permission struct {
+	ID          string    `db:"id" json:"id" bson:"id"`
+	Name        string    `db:"name" json:"name" bson:"name"`
+	Description string    `db:"description" json:"description" bson:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at" bson:"created_at"`
+}
+
+// UserRole represents the assignment of a role to a user
+type UserRole struct {
+	UserID     string    `db:"user_id" json:"user_id" bson:"user_id"`
+	RoleID     string    `db:"role_id" json:"role_id" bson:"role_id"`
+	AssignedBy string    `db:"assigned_by" json:"assigned_by" bson:"assigned_by"`
+	AssignedAt time.Time `db:"assigned_at" json:"assigned_at" bson:"assigned_at"`
+}
+
+// Built-in roles seeded by the auth migrations
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Built-in permissions seeded by the auth migrations
+const (
+	PermissionProductRead  = "product:read"
+	PermissionProductWrite = "product:write"
+	PermissionUserRead     = "user:read"
+	PermissionUserWrite    = "user:write"
+	PermissionRBACManage   = "rbac:manage"
+)
+
+// RoleNames returns the names of the given roles
+func RoleNames(roles []Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// RolePermissions returns the de-duplicated union of the permissions granted by roles
+func RolePermissions(roles []Role) []string {
+	seen := make(map[string]bool)
+	perms := make([]string, 0)
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				perms = append(perms, p)
+			}
+		}
+	}
+	return perms
+}
+
+// TokenClaims represents JWT token claims.
+// Tokens issued to machine clients carry ClientID; their UserID is the
+// client ID and their Permissions are the granted scopes.
 type TokenClaims struct {
-	UserID   string   `json:"user_id"`
-	Username string   `json:"username"`
-	Email    string   `json:"email"`
-	Roles    []string `json:"roles,omitempty"`
+	UserID      string   `json:"user_id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
 }
 
 // AuthUser represents the authenticated user info extracted from auth context
 type AuthUser struct {
-	UserID    string
-	Username  string
-	Email     string
-	Roles     []string
-	SessionID string // For session-based auth
-	AuthType  AuthType
+	UserID      string
+	Username    string
+	Email       string
+	Roles       []string
+	Permissions []string
+	SessionID   string // For session-based auth
+	ClientID    string // Set when the caller is a machine client
+	AuthType    AuthType
 }
 
 // AuthType represents the type of authentication used
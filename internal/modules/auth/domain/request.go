@@ -36,6 +36,55 @@ type ConfirmResetPasswordRequest struct {
 	NewPassword string `json:"new_password" binding:"required,min=8" validate:"required,min=8"`
 }
 
+// VerifyEmailRequest represents the email verification request payload
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required" validate:"required"`
+}
+
+// ResendVerificationRequest represents the resend verification email request payload
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email" validate:"required,email"`
+}
+
+// MFACodeRequest carries a TOTP code, or a recovery code where accepted
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" validate:"required"`
+}
+
+// VerifyMFARequest exchanges a login MFA challenge for tokens
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required" validate:"required"`
+	Code     string `json:"code" binding:"required" validate:"required"` // TOTP or recovery code
+}
+
+// OIDCCallbackRequest carries the query parameters of an identity provider redirect
+type OIDCCallbackRequest struct {
+	Provider string `json:"provider"`
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	UserID   string `json:"-"` // signed-in caller; a link flow completes only for the user that started it
+}
+
+// RegisterClientRequest registers a machine client; scopes are permission names
+type RegisterClientRequest struct {
+	Name   string   `json:"name" binding:"required,min=2,max=100" validate:"required,min=2,max=100"`
+	Scopes []string `json:"scopes"`
+}
+
+// ClientTokenRequest is a client_credentials token request (RFC 6749 section 4.4)
+type ClientTokenRequest struct {
+	ClientID     string
+	ClientSecret string
+	Scope        string // space-delimited; empty requests every scope of the client
+}
+
+// IntrospectTokenRequest is a token introspection request (RFC 7662) from an authenticated client
+type IntrospectTokenRequest struct {
+	ClientID     string
+	ClientSecret string
+	Token        string
+}
+
 // LogoutRequest represents the logout request payload
 type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
@@ -46,3 +95,20 @@ type LogoutRequest struct {
 type ValidateTokenRequest struct {
 	Token string `json:"token" binding:"required" validate:"required"`
 }
+
+// CreateRoleRequest represents the create role request payload
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetRolePermissionsRequest replaces the permissions granted by a role
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// AssignRoleRequest represents the assign role request payload
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required" validate:"required"` // role name
+}
@@ -2,23 +2,33 @@ package domain
 
 import "time"
 
-// LoginResponse represents the login response payload
+// LoginResponse represents the login response payload.
+// When MFARequired is set no tokens are issued; MFAToken must be exchanged
+// together with a TOTP or recovery code at /auth/mfa/verify, and
+// ExpiresIn/ExpiresAt describe the challenge instead of an access token.
+// When Linked is set a provider account was linked to the signed-in user and
+// no tokens are issued either.
 type LoginResponse struct {
-	AccessToken  string    `json:"access_token"`
+	AccessToken  string    `json:"access_token,omitempty"`
 	RefreshToken string    `json:"refresh_token,omitempty"`
-	TokenType    string    `json:"token_type"`
+	TokenType    string    `json:"token_type,omitempty"`
 	ExpiresIn    int64     `json:"expires_in"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	User         *UserInfo `json:"user,omitempty"`
+	MFARequired  bool      `json:"mfa_required,omitempty"`
+	MFAToken     string    `json:"mfa_token,omitempty"`
+	Linked       bool      `json:"linked,omitempty"`
 }
 
 // UserInfo represents basic user info in auth responses
 type UserInfo struct {
-	ID       string   `json:"id"`
-	Username string   `json:"username"`
-	Email    string   `json:"email"`
-	Name     string   `json:"name,omitempty"`
-	Roles    []string `json:"roles,omitempty"`
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Name        string   `json:"name,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"` // set for machine clients
 }
 
 // RegisterResponse represents the registration response payload
@@ -63,3 +73,104 @@ type MessageResponse struct {
 	Message string `json:"message"`
 	Success bool   `json:"success"`
 }
+
+// RoleListResponse represents the list of roles
+type RoleListResponse struct {
+	Roles []Role `json:"roles"`
+}
+
+// PermissionListResponse represents the list of known permissions
+type PermissionListResponse struct {
+	Permissions []Permission `json:"permissions"`
+}
+
+// UserRolesResponse represents the roles assigned to a user
+type UserRolesResponse struct {
+	UserID      string   `json:"user_id"`
+	Roles       []Role   `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// MFAEnrollResponse carries a new TOTP secret for the authenticator app
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret"`      // base32, for manual entry
+	OTPAuthURI string `json:"otpauth_uri"` // render as a QR code
+}
+
+// MFARecoveryCodesResponse returns recovery codes; they are shown only once
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// OIDCAuthorizationResponse points the browser at an identity provider
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// IdentityInfo represents a linked external identity
+type IdentityInfo struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IdentityListResponse represents the external identities linked to a user
+type IdentityListResponse struct {
+	Identities []IdentityInfo `json:"identities"`
+}
+
+// ClientListResponse represents the registered machine clients
+type ClientListResponse struct {
+	Clients []Client `json:"clients"`
+}
+
+// ClientSecretResponse returns a client with its secret; the secret is shown only once
+type ClientSecretResponse struct {
+	Client       *Client `json:"client"`
+	ClientSecret string  `json:"client_secret"`
+}
+
+// ClientTokenResponse is the client_credentials token response (RFC 6749 section 5.1)
+type ClientTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// IntrospectionResponse describes a token (RFC 7662); only Active is set for invalid tokens
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// OAuthErrorResponse is the error body of the OAuth2 endpoints (RFC 6749 section 5.2)
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// JWK is a public access token signing key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // EC or OKP curve
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSResponse is the key set served at /.well-known/jwks.json
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
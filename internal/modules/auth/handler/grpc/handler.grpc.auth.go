@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/adapters"
+	authv1 "github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	grpcAdapter "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GRPCHandler implements the Auth gRPC service
+type GRPCHandler struct {
+	service authDomain.Service
+	authv1.UnimplementedAuthServiceServer
+}
+
+// NewGRPCHandler creates a new GRPCHandler
+func NewGRPCHandler(service authDomain.Service) *GRPCHandler {
+	return &GRPCHandler{service: service}
+}
+
+// Login signs a user in
+func (h *GRPCHandler) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
+	resp, err := h.service.Login(ctx, adapters.PBLoginRequestToDomainRequest(req), grpcAdapter.UserAgent(ctx), grpcAdapter.ClientIP(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return adapters.DomainLoginResponseToPBLoginResponse(resp), nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair
+func (h *GRPCHandler) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.RefreshTokenResponse, error) {
+	resp, err := h.service.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return adapters.DomainRefreshTokenResponseToPBRefreshTokenResponse(resp), nil
+}
+
+// ValidateToken checks an access token
+func (h *GRPCHandler) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
+	resp, err := h.service.ValidateToken(ctx, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return adapters.DomainValidateTokenResponseToPBValidateTokenResponse(resp), nil
+}
+
+// Logout revokes the caller's refresh token or all of their sessions
+func (h *GRPCHandler) Logout(ctx context.Context, req *authv1.LogoutRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.Logout(ctx, userID, adapters.PBLogoutRequestToDomainRequest(req)); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListSessions lists the caller's active sessions
+func (h *GRPCHandler) ListSessions(ctx context.Context, req *authv1.ListSessionsRequest) (*authv1.ListSessionsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.service.GetSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return adapters.DomainSessionListToPBListSessionsResponse(resp), nil
+}
+
+// RevokeSession revokes one of the caller's sessions
+func (h *GRPCHandler) RevokeSession(ctx context.Context, req *authv1.RevokeSessionRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == "" {
+		return nil, sharederrors.ErrMissingField.WithMessage("session id required")
+	}
+
+	if err := h.service.RevokeSession(ctx, userID, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RevokeAllSessions revokes all of the caller's sessions
+func (h *GRPCHandler) RevokeAllSessions(ctx context.Context, req *authv1.RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// callerID returns the user set by the gRPC auth interceptor
+func callerID(ctx context.Context) (string, error) {
+	userID, _ := sharedctx.GetUserID(ctx)
+	if userID == "" {
+		return "", sharederrors.ErrUnauthorized
+	}
+	return userID, nil
+}
+
+// RegisterService registers the Auth service with the gRPC server
+func RegisterService(h *GRPCHandler) grpcAdapter.ServiceRegistrar {
+	return func(s *grpc.Server) {
+		authv1.RegisterAuthServiceServer(s, h)
+	}
+}
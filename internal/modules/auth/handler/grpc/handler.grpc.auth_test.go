@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	mockdomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain/mocks"
+	authv1 "github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+
+	gomock "github.com/golang/mock/gomock"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// TestGRPCHandler_Login tests that Login passes the caller's agent and address
+func TestGRPCHandler_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+
+	mockService.EXPECT().
+		Login(gomock.Any(), &authDomain.LoginRequest{Username: "testuser", Password: "secret"}, "test-client/1.0", "10.0.0.1").
+		Return(&authDomain.LoginResponse{
+			AccessToken: "access",
+			TokenType:   "Bearer",
+			User:        &authDomain.UserInfo{ID: "user-1", Username: "testuser"},
+		}, nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "test-client/1.0"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51234}})
+
+	resp, err := handler.Login(ctx, &authv1.LoginRequest{Username: "testuser", Password: "secret"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.AccessToken != "access" || resp.User.GetId() != "user-1" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+// TestGRPCHandler_Login_Error tests that service errors are returned for the error interceptor
+func TestGRPCHandler_Login_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	loginErr := sharederrors.ErrInvalidCredentials.WithMessage("invalid username or password")
+
+	mockService.EXPECT().
+		Login(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, loginErr)
+
+	handler := NewGRPCHandler(mockService)
+
+	_, err := handler.Login(context.Background(), &authv1.LoginRequest{Username: "testuser", Password: "wrong"})
+
+	if err != loginErr {
+		t.Errorf("expected %v, got %v", loginErr, err)
+	}
+}
+
+// TestGRPCHandler_ListSessions tests that sessions are listed for the authenticated caller
+func TestGRPCHandler_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+
+	mockService.EXPECT().
+		GetSessions(gomock.Any(), "user-1").
+		Return(&authDomain.SessionListResponse{
+			Sessions: []authDomain.SessionInfo{{ID: "s1"}, {ID: "s2"}},
+		}, nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := sharedctx.WithUserID(context.Background(), "user-1")
+
+	resp, err := handler.ListSessions(ctx, &authv1.ListSessionsRequest{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(resp.Sessions) != 2 {
+		t.Errorf("expected 2 sessions, got %d", len(resp.Sessions))
+	}
+}
+
+// TestGRPCHandler_RevokeSession tests the RevokeSession method
+func TestGRPCHandler_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+
+	mockService.EXPECT().
+		RevokeSession(gomock.Any(), "user-1", "s1").
+		Return(nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := sharedctx.WithUserID(context.Background(), "user-1")
+
+	if _, err := handler.RevokeSession(ctx, &authv1.RevokeSessionRequest{Id: "s1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err := handler.RevokeSession(ctx, &authv1.RevokeSessionRequest{})
+	if !sharederrors.Is(err, sharederrors.ErrMissingField) {
+		t.Errorf("expected missing field error, got %v", err)
+	}
+}
+
+// TestGRPCHandler_Unauthenticated tests that caller-bound methods need the interceptor's user
+func TestGRPCHandler_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewGRPCHandler(mockdomain.NewMockService(ctrl))
+	ctx := context.Background()
+
+	_, err := handler.Logout(ctx, &authv1.LogoutRequest{})
+	if !sharederrors.Is(err, sharederrors.ErrUnauthorized) {
+		t.Errorf("Logout: expected unauthorized, got %v", err)
+	}
+
+	_, err = handler.RevokeAllSessions(ctx, &authv1.RevokeAllSessionsRequest{})
+	if !sharederrors.Is(err, sharederrors.ErrUnauthorized) {
+		t.Errorf("RevokeAllSessions: expected unauthorized, got %v", err)
+	}
+}
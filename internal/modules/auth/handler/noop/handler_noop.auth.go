@@ -1,11 +1,12 @@
 package noop
 
 import (
-	"net/http"
-
 	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
+var errNotImplemented = sharederrors.ErrNotImplemented.WithMessage("auth not implemented")
+
 type NoopHandler struct{}
 
 func NewNoopHandler() *NoopHandler {
@@ -13,41 +14,153 @@ func NewNoopHandler() *NoopHandler {
 }
 
 func (h *NoopHandler) Login(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) Register(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) Logout(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) RefreshToken(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) ValidateToken(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) ChangePassword(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ResetPassword(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ConfirmResetPassword(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) VerifyEmail(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ResendVerification(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) EnrollMFA(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) EnableMFA(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) DisableMFA(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) VerifyMFA(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) JWKS(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) StartOIDCLogin(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) OIDCCallback(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) StartOIDCLink(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ListIdentities(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) UnlinkIdentity(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) GetProfile(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) GetSessions(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) RevokeSession(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *NoopHandler) RevokeAllSessions(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"error": "auth not implemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ListRoles(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) CreateRole(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) DeleteRole(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) SetRolePermissions(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ListPermissions(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) GetUserRoles(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) AssignRole(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) RevokeRole(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) IssueClientToken(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) IntrospectToken(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) ListClients(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) RegisterClient(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) DeleteClient(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
+}
+
+func (h *NoopHandler) RotateClientSecret(c sharedctx.Context) error {
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
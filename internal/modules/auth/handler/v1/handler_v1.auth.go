@@ -1,10 +1,15 @@
 package v1
 
 import (
+	"encoding/base64"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
 	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
 type Handler struct {
@@ -18,7 +23,7 @@ func NewHandler(s domain.Service) *Handler {
 func (h *Handler) Login(c sharedctx.Context) error {
 	var req domain.LoginRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 
 	userAgent := c.GetUserAgent()
@@ -26,7 +31,7 @@ func (h *Handler) Login(c sharedctx.Context) error {
 
 	resp, err := h.svc.Login(c.GetContext(), &req, userAgent, ipAddress)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -35,12 +40,12 @@ func (h *Handler) Login(c sharedctx.Context) error {
 func (h *Handler) Register(c sharedctx.Context) error {
 	var req domain.RegisterRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 
 	resp, err := h.svc.Register(c.GetContext(), &req)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, resp)
@@ -49,14 +54,14 @@ func (h *Handler) Register(c sharedctx.Context) error {
 func (h *Handler) Logout(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	var req domain.LogoutRequest
 	_ = c.Bind(&req)
 
 	if err := h.svc.Logout(c.GetContext(), userID, &req); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Logged out successfully", Success: true})
@@ -65,12 +70,12 @@ func (h *Handler) Logout(c sharedctx.Context) error {
 func (h *Handler) RefreshToken(c sharedctx.Context) error {
 	var req domain.RefreshTokenRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 
 	resp, err := h.svc.RefreshToken(c.GetContext(), req.RefreshToken)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -79,12 +84,12 @@ func (h *Handler) RefreshToken(c sharedctx.Context) error {
 func (h *Handler) ValidateToken(c sharedctx.Context) error {
 	var req domain.ValidateTokenRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 
 	resp, err := h.svc.ValidateToken(c.GetContext(), req.Token)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -93,25 +98,330 @@ func (h *Handler) ValidateToken(c sharedctx.Context) error {
 func (h *Handler) ChangePassword(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	var req domain.ChangePasswordRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 
 	if err := h.svc.ChangePassword(c.GetContext(), userID, &req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Password changed successfully", Success: true})
 }
 
+func (h *Handler) ResetPassword(c sharedctx.Context) error {
+	var req domain.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.ResetPassword(c.GetContext(), &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	// Same response whether or not the email is registered
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "If the email is registered, a reset link has been sent", Success: true})
+}
+
+func (h *Handler) ConfirmResetPassword(c sharedctx.Context) error {
+	var req domain.ConfirmResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.ConfirmResetPassword(c.GetContext(), &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Password reset successfully", Success: true})
+}
+
+func (h *Handler) VerifyEmail(c sharedctx.Context) error {
+	var req domain.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.VerifyEmail(c.GetContext(), &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Email verified successfully", Success: true})
+}
+
+func (h *Handler) ResendVerification(c sharedctx.Context) error {
+	var req domain.ResendVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.ResendVerification(c.GetContext(), &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	// Same response whether or not the email is registered or already verified
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "If the email awaits verification, a new link has been sent", Success: true})
+}
+
+func (h *Handler) EnrollMFA(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	resp, err := h.svc.EnrollMFA(c.GetContext(), userID)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) EnableMFA(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	resp, err := h.svc.EnableMFA(c.GetContext(), userID, &req)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) DisableMFA(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.DisableMFA(c.GetContext(), userID, &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "MFA disabled successfully", Success: true})
+}
+
+func (h *Handler) VerifyMFA(c sharedctx.Context) error {
+	var req domain.VerifyMFARequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	resp, err := h.svc.VerifyMFA(c.GetContext(), &req, c.GetUserAgent(), c.GetClientIP())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) StartOIDCLogin(c sharedctx.Context) error {
+	resp, err := h.svc.StartOIDCLogin(c.GetContext(), c.Param("provider"))
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// OIDCCallback receives the provider redirect and signs the user in. A link
+// flow is completed only when the caller is signed in as the user that started it.
+func (h *Handler) OIDCCallback(c sharedctx.Context) error {
+	if providerErr := c.QueryParam("error"); providerErr != "" {
+		message := providerErr
+		if description := c.QueryParam("error_description"); description != "" {
+			message += ": " + description
+		}
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(message))
+	}
+
+	req := domain.OIDCCallbackRequest{
+		Provider: c.Param("provider"),
+		Code:     c.QueryParam("code"),
+		State:    c.QueryParam("state"),
+		UserID:   c.GetUserID(),
+	}
+	if req.Code == "" || req.State == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("code and state are required"))
+	}
+
+	resp, err := h.svc.CompleteOIDCLogin(c.GetContext(), &req, c.GetUserAgent(), c.GetClientIP())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) StartOIDCLink(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	resp, err := h.svc.StartOIDCLink(c.GetContext(), userID, c.Param("provider"))
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ListIdentities(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	resp, err := h.svc.ListIdentities(c.GetContext(), userID)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) UnlinkIdentity(c sharedctx.Context) error {
+	userID := c.GetUserID()
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
+	}
+
+	if err := h.svc.UnlinkIdentity(c.GetContext(), userID, c.Param("provider")); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Identity unlinked successfully", Success: true})
+}
+
+// JWKS publishes the public keys that verify access tokens
+func (h *Handler) JWKS(c sharedctx.Context) error {
+	resp, err := h.svc.JWKS(c.GetContext())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	c.SetHeader("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, resp)
+}
+
+// IssueClientToken is the OAuth2 token endpoint for the client_credentials
+// grant. The body is form-encoded (RFC 6749 section 4.4).
+func (h *Handler) IssueClientToken(c sharedctx.Context) error {
+	c.SetHeader("Cache-Control", "no-store")
+	c.SetHeader("Pragma", "no-cache")
+
+	switch c.FormValue("grant_type") {
+	case "client_credentials":
+	case "":
+		return c.JSON(http.StatusBadRequest, domain.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "grant_type is required"})
+	default:
+		return c.JSON(http.StatusBadRequest, domain.OAuthErrorResponse{Error: "unsupported_grant_type"})
+	}
+
+	clientID, clientSecret := clientCredentials(c)
+	resp, err := h.svc.IssueClientToken(c.GetContext(), &domain.ClientTokenRequest{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        c.FormValue("scope"),
+	})
+	if err != nil {
+		return oauthError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// IntrospectToken is the OAuth2 token introspection endpoint (RFC 7662);
+// callers authenticate as a registered client
+func (h *Handler) IntrospectToken(c sharedctx.Context) error {
+	token := c.FormValue("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, domain.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "token is required"})
+	}
+
+	clientID, clientSecret := clientCredentials(c)
+	resp, err := h.svc.IntrospectToken(c.GetContext(), &domain.IntrospectTokenRequest{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Token:        token,
+	})
+	if err != nil {
+		return oauthError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// clientCredentials reads client authentication from HTTP Basic, falling back
+// to the client_id and client_secret form fields (RFC 6749 section 2.3.1)
+func clientCredentials(c sharedctx.Context) (string, string) {
+	authHeader := c.GetHeader("Authorization")
+	scheme, encoded, found := strings.Cut(authHeader, " ")
+	if !found || !strings.EqualFold(scheme, "basic") {
+		return c.FormValue("client_id"), c.FormValue("client_secret")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ""
+	}
+	id, secret, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", ""
+	}
+
+	// Both parts are form-urlencoded before Basic encoding
+	id, err = url.QueryUnescape(id)
+	if err != nil {
+		return "", ""
+	}
+	secret, err = url.QueryUnescape(secret)
+	if err != nil {
+		return "", ""
+	}
+	return id, secret
+}
+
+// oauthError writes an OAuth2 error response (RFC 6749 section 5.2)
+func oauthError(c sharedctx.Context, err error) error {
+	description := err.Error()
+	var domainErr *sharederrors.DomainError
+	if errors.As(err, &domainErr) {
+		description = domainErr.Message
+	}
+
+	switch {
+	case sharederrors.Is(err, sharederrors.ErrInvalidCredentials):
+		c.SetHeader("WWW-Authenticate", `Basic realm="oauth"`)
+		return c.JSON(http.StatusUnauthorized, domain.OAuthErrorResponse{Error: "invalid_client", ErrorDescription: description})
+	case sharederrors.Is(err, sharederrors.ErrInvalidInput):
+		return c.JSON(http.StatusBadRequest, domain.OAuthErrorResponse{Error: "invalid_scope", ErrorDescription: description})
+	}
+	return c.JSON(http.StatusInternalServerError, domain.OAuthErrorResponse{Error: "server_error"})
+}
+
 func (h *Handler) GetProfile(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	token := c.GetHeader("Authorization")
@@ -121,7 +431,7 @@ func (h *Handler) GetProfile(c sharedctx.Context) error {
 
 	resp, err := h.svc.ValidateToken(c.GetContext(), token)
 	if err != nil || !resp.Valid {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid token"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidToken)
 	}
 
 	return c.JSON(http.StatusOK, resp.User)
@@ -130,12 +440,12 @@ func (h *Handler) GetProfile(c sharedctx.Context) error {
 func (h *Handler) GetSessions(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	resp, err := h.svc.GetSessions(c.GetContext(), userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -144,16 +454,16 @@ func (h *Handler) GetSessions(c sharedctx.Context) error {
 func (h *Handler) RevokeSession(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	sessionID := c.Param("id")
 	if sessionID == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "session id required"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("session id required"))
 	}
 
 	if err := h.svc.RevokeSession(c.GetContext(), userID, sessionID); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Session revoked successfully", Success: true})
@@ -162,12 +472,176 @@ func (h *Handler) RevokeSession(c sharedctx.Context) error {
 func (h *Handler) RevokeAllSessions(c sharedctx.Context) error {
 	userID := c.GetUserID()
 	if userID == "" {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return sharederrors.WriteProblem(c, sharederrors.ErrUnauthorized)
 	}
 
 	if err := h.svc.RevokeAllSessions(c.GetContext(), userID); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return sharederrors.WriteProblem(c, err)
 	}
 
 	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "All sessions revoked successfully", Success: true})
 }
+
+// RBAC administration
+
+func (h *Handler) ListRoles(c sharedctx.Context) error {
+	resp, err := h.svc.ListRoles(c.GetContext())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) CreateRole(c sharedctx.Context) error {
+	var req domain.CreateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	role, err := h.svc.CreateRole(c.GetContext(), &req)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, role)
+}
+
+func (h *Handler) DeleteRole(c sharedctx.Context) error {
+	roleID := c.Param("id")
+	if roleID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("role id required"))
+	}
+
+	if err := h.svc.DeleteRole(c.GetContext(), roleID); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Role deleted successfully", Success: true})
+}
+
+func (h *Handler) SetRolePermissions(c sharedctx.Context) error {
+	roleID := c.Param("id")
+	if roleID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("role id required"))
+	}
+
+	var req domain.SetRolePermissionsRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	role, err := h.svc.SetRolePermissions(c.GetContext(), roleID, &req)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) ListPermissions(c sharedctx.Context) error {
+	resp, err := h.svc.ListPermissions(c.GetContext())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) GetUserRoles(c sharedctx.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("user id required"))
+	}
+
+	resp, err := h.svc.GetUserRoles(c.GetContext(), userID)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) AssignRole(c sharedctx.Context) error {
+	userID := c.Param("id")
+	if userID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("user id required"))
+	}
+
+	var req domain.AssignRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	if err := h.svc.AssignRole(c.GetContext(), c.GetUserID(), userID, &req); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Role assigned successfully", Success: true})
+}
+
+func (h *Handler) RevokeRole(c sharedctx.Context) error {
+	userID := c.Param("id")
+	roleID := c.Param("role_id")
+	if userID == "" || roleID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("user id and role id required"))
+	}
+
+	if err := h.svc.RevokeRole(c.GetContext(), userID, roleID); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Role revoked successfully", Success: true})
+}
+
+// Machine client administration
+
+func (h *Handler) ListClients(c sharedctx.Context) error {
+	resp, err := h.svc.ListClients(c.GetContext())
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) RegisterClient(c sharedctx.Context) error {
+	var req domain.RegisterClientRequest
+	if err := c.Bind(&req); err != nil {
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
+	}
+
+	resp, err := h.svc.RegisterClient(c.GetContext(), c.GetUserID(), &req)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) DeleteClient(c sharedctx.Context) error {
+	clientID := c.Param("id")
+	if clientID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("client id required"))
+	}
+
+	if err := h.svc.DeleteClient(c.GetContext(), clientID); err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, domain.MessageResponse{Message: "Client deleted successfully", Success: true})
+}
+
+func (h *Handler) RotateClientSecret(c sharedctx.Context) error {
+	clientID := c.Param("id")
+	if clientID == "" {
+		return sharederrors.WriteProblem(c, sharederrors.ErrMissingField.WithMessage("client id required"))
+	}
+
+	resp, err := h.svc.RotateClientSecret(c.GetContext(), clientID)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
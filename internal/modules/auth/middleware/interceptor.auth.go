@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	grpctransport "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCInterceptorConfig configures authentication of gRPC calls. Methods are
+// full gRPC method names such as "/product.v1.ProductService/Create"; a name
+// ending in "/" matches every method of that service.
+type GRPCInterceptorConfig struct {
+	// PublicMethods can be called without a token
+	PublicMethods []string
+	// MethodRoles lists roles of which the caller needs at least one
+	MethodRoles map[string][]string
+	// MethodPermissions lists permissions the caller needs all of
+	MethodPermissions map[string][]string
+}
+
+// GRPCInterceptor authenticates gRPC calls with the bearer token in the
+// "authorization" metadata, the gRPC counterpart of AuthMiddleware with JWT auth
+type GRPCInterceptor struct {
+	authService domain.Service
+	config      GRPCInterceptorConfig
+}
+
+func NewGRPCInterceptor(authService domain.Service, config GRPCInterceptorConfig) *GRPCInterceptor {
+	return &GRPCInterceptor{
+		authService: authService,
+		config:      config,
+	}
+}
+
+func (i *GRPCInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *GRPCInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, grpctransport.WrapServerStream(ss, ctx))
+	}
+}
+
+// authorize authenticates the caller and checks the method's requirements.
+// Public methods accept anonymous callers and still see a valid caller's identity.
+func (i *GRPCInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	public := matchMethod(i.config.PublicMethods, method)
+
+	authUser, err := i.authenticate(ctx)
+	if err != nil || authUser == nil {
+		if public {
+			return ctx, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	ctx = ContextWithAuthUser(ctx, authUser)
+	if public {
+		return ctx, nil
+	}
+
+	if roles, ok := methodRequirement(i.config.MethodRoles, method); ok && !hasAnyRole(authUser.Roles, roles) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	if permissions, ok := methodRequirement(i.config.MethodPermissions, method); ok && !hasAllPermissions(authUser.Permissions, permissions) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	return ctx, nil
+}
+
+// authenticate returns nil without an error when the call carries no bearer token
+func (i *GRPCInterceptor) authenticate(ctx context.Context) (*domain.AuthUser, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+	}
+
+	resp, err := i.authService.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, sharederrors.GRPCStatus(err)
+	}
+	if !resp.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return &domain.AuthUser{
+		UserID:      resp.User.ID,
+		Username:    resp.User.Username,
+		Email:       resp.User.Email,
+		Roles:       resp.User.Roles,
+		Permissions: resp.User.Permissions,
+		ClientID:    resp.User.ClientID,
+		AuthType:    domain.AuthTypeJWT,
+	}, nil
+}
+
+// methodRequirement looks a method up by full name, then by its service prefix
+func methodRequirement(requirements map[string][]string, method string) ([]string, bool) {
+	if required, ok := requirements[method]; ok {
+		return required, true
+	}
+	if idx := strings.LastIndex(method, "/"); idx > 0 {
+		required, ok := requirements[method[:idx+1]]
+		return required, ok
+	}
+	return nil, false
+}
+
+func matchMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method || (strings.HasSuffix(m, "/") && strings.HasPrefix(method, m)) {
+			return true
+		}
+	}
+	return false
+}
+
+type authUserKey struct{}
+
+// ContextWithAuthUser stores the authenticated caller in ctx, along with its
+// user ID under sharedctx.UserIDKey for handlers outside the auth module
+func ContextWithAuthUser(ctx context.Context, authUser *domain.AuthUser) context.Context {
+	ctx = context.WithValue(ctx, authUserKey{}, authUser)
+	return sharedctx.WithUserID(ctx, authUser.UserID)
+}
+
+// AuthUserFromContext returns the caller stored by the gRPC interceptor, or nil
+func AuthUserFromContext(ctx context.Context) *domain.AuthUser {
+	authUser, _ := ctx.Value(authUserKey{}).(*domain.AuthUser)
+	return authUser
+}
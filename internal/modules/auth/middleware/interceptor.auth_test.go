@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain/mocks"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testCreateMethod = "/product.v1.ProductService/Create"
+	testHealthMethod = "/grpc.health.v1.Health/Check"
+)
+
+func TestGRPCInterceptor_Unary(t *testing.T) {
+	config := GRPCInterceptorConfig{
+		PublicMethods:     []string{"/grpc.health.v1.Health/"},
+		MethodPermissions: map[string][]string{testCreateMethod: {domain.PermissionProductWrite}},
+		MethodRoles:       map[string][]string{"/admin.v1.AdminService/": {"admin"}},
+	}
+	writer := &domain.ValidateTokenResponse{Valid: true, User: &domain.UserInfo{
+		ID: "user-1", Roles: []string{"user"}, Permissions: []string{domain.PermissionProductWrite},
+	}}
+	reader := &domain.ValidateTokenResponse{Valid: true, User: &domain.UserInfo{
+		ID: "user-2", Roles: []string{"user"}, Permissions: []string{domain.PermissionProductRead},
+	}}
+
+	tests := []struct {
+		name       string
+		method     string
+		authHeader string
+		validate   *domain.ValidateTokenResponse
+		wantCode   codes.Code
+		wantUserID string
+	}{
+		{name: "missing token", method: testCreateMethod, wantCode: codes.Unauthenticated},
+		{name: "malformed metadata", method: testCreateMethod, authHeader: "Basic abc", wantCode: codes.Unauthenticated},
+		{name: "invalid token", method: testCreateMethod, authHeader: "Bearer bad", validate: &domain.ValidateTokenResponse{Valid: false}, wantCode: codes.Unauthenticated},
+		{name: "missing permission", method: testCreateMethod, authHeader: "Bearer token", validate: reader, wantCode: codes.PermissionDenied},
+		{name: "missing role", method: "/admin.v1.AdminService/Purge", authHeader: "Bearer token", validate: writer, wantCode: codes.PermissionDenied},
+		{name: "authorized", method: testCreateMethod, authHeader: "Bearer token", validate: writer, wantCode: codes.OK, wantUserID: "user-1"},
+		{name: "public anonymous", method: testHealthMethod, wantCode: codes.OK},
+		{name: "public with invalid token", method: testHealthMethod, authHeader: "Bearer bad", validate: &domain.ValidateTokenResponse{Valid: false}, wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authService := mocks.NewMockService(ctrl)
+			if tt.validate != nil {
+				authService.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).Return(tt.validate, nil)
+			}
+
+			ctx := context.Background()
+			if tt.authHeader != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authHeader))
+			}
+
+			var gotUserID string
+			handler := func(ctx context.Context, req any) (any, error) {
+				gotUserID, _ = sharedctx.GetUserID(ctx)
+				return "ok", nil
+			}
+
+			interceptor := NewGRPCInterceptor(authService, config)
+			_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantUserID, gotUserID)
+		})
+	}
+}
+
+func TestGRPCInterceptor_AuthUserFromContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mocks.NewMockService(ctrl)
+	authService.EXPECT().ValidateToken(gomock.Any(), "token").Return(&domain.ValidateTokenResponse{
+		Valid: true,
+		User:  &domain.UserInfo{ID: "client-1", ClientID: "client-1", Permissions: []string{domain.PermissionProductWrite}},
+	}, nil)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer token"))
+	var authUser *domain.AuthUser
+	handler := func(ctx context.Context, req any) (any, error) {
+		authUser = AuthUserFromContext(ctx)
+		return nil, nil
+	}
+
+	_, err := NewGRPCInterceptor(authService, GRPCInterceptorConfig{}).Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testCreateMethod}, handler)
+
+	require.NoError(t, err)
+	require.NotNil(t, authUser)
+	assert.Equal(t, "client-1", authUser.ClientID)
+	assert.Equal(t, domain.AuthTypeJWT, authUser.AuthType)
+}
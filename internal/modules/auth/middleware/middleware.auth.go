@@ -1,12 +1,19 @@
 package middleware
 
 import (
+	"context"
 	"encoding/base64"
-	"net/http"
 	"strings"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
 	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+)
+
+var (
+	errAuthenticationRequired  = sharederrors.ErrUnauthorized.WithMessage("authentication required")
+	errInsufficientPermissions = sharederrors.ErrForbidden.WithMessage("insufficient permissions")
+	errMFARequired             = sharederrors.ErrUnauthorized.WithMessage("mfa required")
 )
 
 type AuthType string
@@ -28,7 +35,7 @@ type MiddlewareConfig struct {
 func DefaultMiddlewareConfig() MiddlewareConfig {
 	return MiddlewareConfig{
 		AuthType:       AuthTypeJWT,
-		SkipPaths:      []string{"/auth/login", "/auth/register", "/health"},
+		SkipPaths:      []string{"/auth/login", "/auth/register", "/healthz", "/readyz"},
 		SessionCookie:  "session_token",
 		BasicAuthRealm: "Restricted",
 	}
@@ -66,12 +73,11 @@ func (m *AuthMiddleware) Authenticate() func(next func(sharedctx.Context) error)
 			}
 
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+				return sharederrors.WriteProblem(c, err)
 			}
 
 			if authUser != nil {
-				c.Set("auth_user", authUser)
-				c.Set("user_id", authUser.UserID)
+				c = withAuthUser(c, authUser)
 			}
 
 			return next(c)
@@ -84,7 +90,7 @@ func (m *AuthMiddleware) RequireAuth() func(next func(sharedctx.Context) error)
 		return func(c sharedctx.Context) error {
 			authUser := m.getAuthUser(c)
 			if authUser == nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+				return sharederrors.WriteProblem(c, errAuthenticationRequired)
 			}
 			return next(c)
 		}
@@ -106,8 +112,7 @@ func (m *AuthMiddleware) OptionalAuth() func(next func(sharedctx.Context) error)
 			}
 
 			if authUser != nil {
-				c.Set("auth_user", authUser)
-				c.Set("user_id", authUser.UserID)
+				c = withAuthUser(c, authUser)
 			}
 
 			return next(c)
@@ -120,11 +125,29 @@ func (m *AuthMiddleware) RequireRoles(roles ...string) func(next func(sharedctx.
 		return func(c sharedctx.Context) error {
 			authUser := m.getAuthUser(c)
 			if authUser == nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+				return sharederrors.WriteProblem(c, errAuthenticationRequired)
+			}
+
+			if !hasAnyRole(authUser.Roles, roles) {
+				return sharederrors.WriteProblem(c, errInsufficientPermissions)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission ensures the authenticated user holds every one of the given permissions
+func (m *AuthMiddleware) RequirePermission(permissions ...string) func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+	return func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+		return func(c sharedctx.Context) error {
+			authUser := m.getAuthUser(c)
+			if authUser == nil {
+				return sharederrors.WriteProblem(c, errAuthenticationRequired)
 			}
 
-			if !m.hasAnyRole(authUser.Roles, roles) {
-				return c.JSON(http.StatusForbidden, map[string]string{"error": "insufficient permissions"})
+			if !hasAllPermissions(authUser.Permissions, permissions) {
+				return sharederrors.WriteProblem(c, errInsufficientPermissions)
 			}
 
 			return next(c)
@@ -150,11 +173,13 @@ func (m *AuthMiddleware) authenticateJWT(c sharedctx.Context) (*domain.AuthUser,
 	}
 
 	return &domain.AuthUser{
-		UserID:   resp.User.ID,
-		Username: resp.User.Username,
-		Email:    resp.User.Email,
-		Roles:    resp.User.Roles,
-		AuthType: domain.AuthTypeJWT,
+		UserID:      resp.User.ID,
+		Username:    resp.User.Username,
+		Email:       resp.User.Email,
+		Roles:       resp.User.Roles,
+		Permissions: resp.User.Permissions,
+		ClientID:    resp.User.ClientID,
+		AuthType:    domain.AuthTypeJWT,
 	}, nil
 }
 
@@ -164,18 +189,19 @@ func (m *AuthMiddleware) authenticateSession(c sharedctx.Context) (*domain.AuthU
 		return nil, nil
 	}
 
-	resp, err := m.authService.ValidateToken(c.GetContext(), sessionToken)
+	resp, err := m.authService.ValidateSession(c.GetContext(), sessionToken)
 	if err != nil || !resp.Valid {
 		return nil, err
 	}
 
 	return &domain.AuthUser{
-		UserID:    resp.User.ID,
-		Username:  resp.User.Username,
-		Email:     resp.User.Email,
-		Roles:     resp.User.Roles,
-		SessionID: sessionToken,
-		AuthType:  domain.AuthTypeSession,
+		UserID:      resp.User.ID,
+		Username:    resp.User.Username,
+		Email:       resp.User.Email,
+		Roles:       resp.User.Roles,
+		Permissions: resp.User.Permissions,
+		SessionID:   sessionToken,
+		AuthType:    domain.AuthTypeSession,
 	}, nil
 }
 
@@ -212,15 +238,44 @@ func (m *AuthMiddleware) authenticateBasic(c sharedctx.Context) (*domain.AuthUse
 	if err != nil {
 		return nil, err
 	}
+	// Basic auth has no way to carry a second factor, so MFA accounts must
+	// authenticate through /auth/login and /auth/mfa/verify
+	if resp.MFARequired {
+		return nil, errMFARequired
+	}
 
 	return &domain.AuthUser{
-		UserID:   resp.User.ID,
-		Username: resp.User.Username,
-		Email:    resp.User.Email,
-		AuthType: domain.AuthTypeBasic,
+		UserID:      resp.User.ID,
+		Username:    resp.User.Username,
+		Email:       resp.User.Email,
+		Roles:       resp.User.Roles,
+		Permissions: resp.User.Permissions,
+		AuthType:    domain.AuthTypeBasic,
 	}, nil
 }
 
+// withAuthUser stores the authenticated user on c and returns c with the user
+// ID added to its request context, so services and repositories (e.g. the
+// read-your-writes routing of dbrouter) see who is calling
+func withAuthUser(c sharedctx.Context, authUser *domain.AuthUser) sharedctx.Context {
+	c.Set("auth_user", authUser)
+	c.Set("user_id", authUser.UserID)
+	return &authContext{
+		Context: c,
+		ctx:     sharedctx.WithUserID(c.GetContext(), authUser.UserID),
+	}
+}
+
+// authContext hands the authenticated user ID to handlers through GetContext
+type authContext struct {
+	sharedctx.Context
+	ctx context.Context
+}
+
+func (a *authContext) GetContext() context.Context {
+	return a.ctx
+}
+
 func (m *AuthMiddleware) getAuthUser(c sharedctx.Context) *domain.AuthUser {
 	val := c.Get("auth_user")
 	if val == nil {
@@ -232,7 +287,7 @@ func (m *AuthMiddleware) getAuthUser(c sharedctx.Context) *domain.AuthUser {
 	return nil
 }
 
-func (m *AuthMiddleware) hasAnyRole(userRoles, requiredRoles []string) bool {
+func hasAnyRole(userRoles, requiredRoles []string) bool {
 	roleSet := make(map[string]bool)
 	for _, r := range userRoles {
 		roleSet[r] = true
@@ -244,3 +299,16 @@ func (m *AuthMiddleware) hasAnyRole(userRoles, requiredRoles []string) bool {
 	}
 	return false
 }
+
+func hasAllPermissions(userPermissions, requiredPermissions []string) bool {
+	permissionSet := make(map[string]bool)
+	for _, p := range userPermissions {
+		permissionSet[p] = true
+	}
+	for _, p := range requiredPermissions {
+		if !permissionSet[p] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain/mocks"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect/sqltest"
+	transportEcho "github.com/kamil5b/go-pste-monolith/internal/transports/http/echo"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func basicHeader(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestAuthMiddleware_Basic(t *testing.T) {
+	tests := []struct {
+		name       string
+		login      *domain.LoginResponse
+		wantStatus int
+		wantUserID string
+	}{
+		{
+			name:       "authorized",
+			login:      &domain.LoginResponse{User: &domain.UserInfo{ID: "user-1", Username: "ada"}},
+			wantUserID: "user-1",
+		},
+		{
+			name:       "mfa required",
+			login:      &domain.LoginResponse{MFARequired: true, MFAToken: "challenge"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			authService := mocks.NewMockService(ctrl)
+			authService.EXPECT().
+				Login(gomock.Any(), &domain.LoginRequest{Username: "ada", Password: "secret"}, gomock.Any(), gomock.Any()).
+				Return(tt.login, nil)
+
+			c := ctxmocks.NewMockContext(ctrl)
+			c.EXPECT().GetHeader("Authorization").Return(basicHeader("ada", "secret"))
+			c.EXPECT().GetContext().Return(context.Background()).AnyTimes()
+			c.EXPECT().GetUserAgent().Return("test")
+			c.EXPECT().GetClientIP().Return("127.0.0.1")
+
+			var status int
+			if tt.wantStatus != 0 {
+				c.EXPECT().SetHeader("Content-Type", sharederrors.ProblemContentType)
+				c.EXPECT().JSON(gomock.Any(), gomock.Any()).DoAndReturn(func(code int, _ any) error {
+					status = code
+					return nil
+				})
+			} else {
+				c.EXPECT().Set("auth_user", gomock.Any())
+				c.EXPECT().Set("user_id", tt.wantUserID)
+			}
+
+			m := NewAuthMiddleware(authService, MiddlewareConfig{AuthType: AuthTypeBasic})
+			called := false
+			err := m.Authenticate()(func(c sharedctx.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantStatus == 0, called)
+		})
+	}
+}
+
+// TestAuthMiddleware_ReadYourWrites drives the middleware through Echo: the
+// user ID it puts in the request context keeps a user's read after their
+// write on the primary while other users still read from the replica
+func TestAuthMiddleware_ReadYourWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authService := mocks.NewMockService(ctrl)
+	authService.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, token string) (*domain.ValidateTokenResponse, error) {
+			return &domain.ValidateTokenResponse{Valid: true, User: &domain.UserInfo{ID: token}}, nil
+		}).AnyTimes()
+
+	primary, replica := sqltest.OpenSQLite(t), sqltest.OpenSQLite(t)
+	router := dbrouter.New(primary, []*sqlx.DB{replica}, dbrouter.Config{ReadYourWritesWindow: time.Minute})
+
+	var reader *sqlx.DB
+	m := NewAuthMiddleware(authService, MiddlewareConfig{AuthType: AuthTypeJWT})
+	handle := func(h func(sharedctx.Context) error) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return m.Authenticate()(h)(transportEcho.NewEchoContext(c))
+		}
+	}
+	e := echo.New()
+	e.POST("/products", handle(func(c sharedctx.Context) error {
+		router.MarkWrite(c.GetContext())
+		return c.JSON(http.StatusCreated, nil)
+	}))
+	e.GET("/products", handle(func(c sharedctx.Context) error {
+		reader = router.Reader(c.GetContext())
+		return c.JSON(http.StatusOK, nil)
+	}))
+
+	serve := func(method, token string) {
+		req := httptest.NewRequest(method, "/products", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Less(t, rec.Code, http.StatusBadRequest)
+	}
+
+	serve(http.MethodPost, "user-1")
+	serve(http.MethodGet, "user-1")
+	assert.Same(t, primary, reader, "the writer reads its own write from the primary")
+
+	serve(http.MethodGet, "user-2")
+	assert.Same(t, replica, reader, "other users read from the replica")
+}
@@ -0,0 +1,134 @@
+package adapters
+
+import (
+	"time"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	authv1 "github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/v1"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DomainUserInfoToPBUserInfo converts a domain UserInfo to a protobuf UserInfo
+func DomainUserInfoToPBUserInfo(domain *authDomain.UserInfo) *authv1.UserInfo {
+	if domain == nil {
+		return nil
+	}
+
+	return &authv1.UserInfo{
+		Id:          domain.ID,
+		Username:    domain.Username,
+		Email:       domain.Email,
+		Name:        domain.Name,
+		Roles:       domain.Roles,
+		Permissions: domain.Permissions,
+		ClientId:    domain.ClientID,
+	}
+}
+
+// PBLoginRequestToDomainRequest converts protobuf request to domain request
+func PBLoginRequestToDomainRequest(pb *authv1.LoginRequest) *authDomain.LoginRequest {
+	if pb == nil {
+		return nil
+	}
+
+	return &authDomain.LoginRequest{
+		Username: pb.GetUsername(),
+		Password: pb.GetPassword(),
+	}
+}
+
+// DomainLoginResponseToPBLoginResponse converts a domain login response to protobuf
+func DomainLoginResponseToPBLoginResponse(domain *authDomain.LoginResponse) *authv1.LoginResponse {
+	if domain == nil {
+		return nil
+	}
+
+	return &authv1.LoginResponse{
+		AccessToken:  domain.AccessToken,
+		RefreshToken: domain.RefreshToken,
+		TokenType:    domain.TokenType,
+		ExpiresIn:    domain.ExpiresIn,
+		ExpiresAt:    toPBTimestamp(domain.ExpiresAt),
+		User:         DomainUserInfoToPBUserInfo(domain.User),
+		MfaRequired:  domain.MFARequired,
+		MfaToken:     domain.MFAToken,
+	}
+}
+
+// DomainRefreshTokenResponseToPBRefreshTokenResponse converts a domain refresh response to protobuf
+func DomainRefreshTokenResponseToPBRefreshTokenResponse(domain *authDomain.RefreshTokenResponse) *authv1.RefreshTokenResponse {
+	if domain == nil {
+		return nil
+	}
+
+	return &authv1.RefreshTokenResponse{
+		AccessToken:  domain.AccessToken,
+		RefreshToken: domain.RefreshToken,
+		TokenType:    domain.TokenType,
+		ExpiresIn:    domain.ExpiresIn,
+		ExpiresAt:    toPBTimestamp(domain.ExpiresAt),
+	}
+}
+
+// DomainValidateTokenResponseToPBValidateTokenResponse converts a domain validation result to protobuf
+func DomainValidateTokenResponseToPBValidateTokenResponse(domain *authDomain.ValidateTokenResponse) *authv1.ValidateTokenResponse {
+	if domain == nil {
+		return nil
+	}
+
+	return &authv1.ValidateTokenResponse{
+		Valid:  domain.Valid,
+		User:   DomainUserInfoToPBUserInfo(domain.User),
+		Reason: domain.Reason,
+	}
+}
+
+// PBLogoutRequestToDomainRequest converts protobuf request to domain request
+func PBLogoutRequestToDomainRequest(pb *authv1.LogoutRequest) *authDomain.LogoutRequest {
+	if pb == nil {
+		return &authDomain.LogoutRequest{}
+	}
+
+	return &authDomain.LogoutRequest{
+		RefreshToken: pb.GetRefreshToken(),
+		AllDevices:   pb.GetAllDevices(),
+	}
+}
+
+// DomainSessionToPBSession converts a domain SessionInfo to a protobuf Session
+func DomainSessionToPBSession(domain *authDomain.SessionInfo) *authv1.Session {
+	if domain == nil {
+		return nil
+	}
+
+	return &authv1.Session{
+		Id:        domain.ID,
+		UserAgent: domain.UserAgent,
+		IpAddress: domain.IPAddress,
+		CreatedAt: toPBTimestamp(domain.CreatedAt),
+		ExpiresAt: toPBTimestamp(domain.ExpiresAt),
+		Current:   domain.Current,
+	}
+}
+
+// DomainSessionListToPBListSessionsResponse converts a domain session list to a protobuf list response
+func DomainSessionListToPBListSessionsResponse(domain *authDomain.SessionListResponse) *authv1.ListSessionsResponse {
+	if domain == nil {
+		return &authv1.ListSessionsResponse{}
+	}
+
+	pbSessions := make([]*authv1.Session, len(domain.Sessions))
+	for i := range domain.Sessions {
+		pbSessions[i] = DomainSessionToPBSession(&domain.Sessions[i])
+	}
+
+	return &authv1.ListSessionsResponse{Sessions: pbSessions}
+}
+
+// toPBTimestamp leaves zero times unset, as the product converters do
+func toPBTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
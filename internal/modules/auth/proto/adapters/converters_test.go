@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	authDomain "github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	authv1 "github.com/kamil5b/go-pste-monolith/internal/modules/auth/proto/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainLoginResponseToPBLoginResponse(t *testing.T) {
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	pbResp := DomainLoginResponseToPBLoginResponse(&authDomain.LoginResponse{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		ExpiresIn:    900,
+		ExpiresAt:    expiresAt,
+		User: &authDomain.UserInfo{
+			ID:          "user-1",
+			Username:    "testuser",
+			Roles:       []string{"user"},
+			Permissions: []string{"product:read"},
+		},
+	})
+
+	assert.NotNil(t, pbResp)
+	assert.Equal(t, "access", pbResp.GetAccessToken())
+	assert.Equal(t, "refresh", pbResp.GetRefreshToken())
+	assert.Equal(t, int64(900), pbResp.GetExpiresIn())
+	assert.True(t, pbResp.GetExpiresAt().AsTime().Equal(expiresAt))
+	assert.Equal(t, "user-1", pbResp.GetUser().GetId())
+	assert.Equal(t, []string{"user"}, pbResp.GetUser().GetRoles())
+	assert.Equal(t, []string{"product:read"}, pbResp.GetUser().GetPermissions())
+}
+
+func TestDomainLoginResponseToPBLoginResponseMFA(t *testing.T) {
+	pbResp := DomainLoginResponseToPBLoginResponse(&authDomain.LoginResponse{
+		MFARequired: true,
+		MFAToken:    "challenge",
+	})
+
+	assert.True(t, pbResp.GetMfaRequired())
+	assert.Equal(t, "challenge", pbResp.GetMfaToken())
+	assert.Nil(t, pbResp.GetExpiresAt())
+	assert.Nil(t, pbResp.GetUser())
+}
+
+func TestDomainLoginResponseToPBLoginResponseNil(t *testing.T) {
+	assert.Nil(t, DomainLoginResponseToPBLoginResponse(nil))
+}
+
+func TestDomainValidateTokenResponseToPBValidateTokenResponse(t *testing.T) {
+	pbResp := DomainValidateTokenResponseToPBValidateTokenResponse(&authDomain.ValidateTokenResponse{
+		Valid: true,
+		User:  &authDomain.UserInfo{ID: "client-1", ClientID: "client-1"},
+	})
+
+	assert.True(t, pbResp.GetValid())
+	assert.Equal(t, "client-1", pbResp.GetUser().GetClientId())
+
+	pbResp = DomainValidateTokenResponseToPBValidateTokenResponse(&authDomain.ValidateTokenResponse{Reason: "token expired"})
+
+	assert.False(t, pbResp.GetValid())
+	assert.Nil(t, pbResp.GetUser())
+	assert.Equal(t, "token expired", pbResp.GetReason())
+}
+
+func TestPBLogoutRequestToDomainRequest(t *testing.T) {
+	domainReq := PBLogoutRequestToDomainRequest(&authv1.LogoutRequest{RefreshToken: "refresh", AllDevices: true})
+
+	assert.Equal(t, "refresh", domainReq.RefreshToken)
+	assert.True(t, domainReq.AllDevices)
+
+	assert.Equal(t, &authDomain.LogoutRequest{}, PBLogoutRequestToDomainRequest(nil))
+}
+
+func TestDomainSessionListToPBListSessionsResponse(t *testing.T) {
+	now := time.Now()
+
+	pbResp := DomainSessionListToPBListSessionsResponse(&authDomain.SessionListResponse{
+		Sessions: []authDomain.SessionInfo{
+			{ID: "s1", UserAgent: "grpc-go", IPAddress: "10.0.0.1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+			{ID: "s2"},
+		},
+	})
+
+	assert.Len(t, pbResp.GetSessions(), 2)
+	assert.Equal(t, "s1", pbResp.GetSessions()[0].GetId())
+	assert.Equal(t, "10.0.0.1", pbResp.GetSessions()[0].GetIpAddress())
+	assert.NotNil(t, pbResp.GetSessions()[0].GetCreatedAt())
+	assert.Nil(t, pbResp.GetSessions()[1].GetCreatedAt())
+
+	assert.Empty(t, DomainSessionListToPBListSessionsResponse(nil).GetSessions())
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"golang.org/x/oauth2"
+)
+
+// GitHub endpoints; override them for GitHub Enterprise Server
+const (
+	gitHubAuthURL  = "https://github.com/login/oauth/authorize"
+	gitHubTokenURL = "https://github.com/login/oauth/access_token"
+	gitHubAPIURL   = "https://api.github.com"
+)
+
+// GitHubConfig configures GitHub sign-in through a GitHub OAuth app
+type GitHubConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // defaults to read:user and user:email
+	AuthURL      string
+	TokenURL     string
+	APIURL       string
+	HTTPClient   *http.Client
+}
+
+// GitHubProvider signs users in with GitHub. GitHub is plain OAuth2 without
+// ID tokens, so the account is read from the REST API and the nonce is unused.
+type GitHubProvider struct {
+	oauth  *oauth2.Config
+	apiURL string
+	client *http.Client
+}
+
+func NewGitHubProvider(config GitHubConfig) *GitHubProvider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"read:user", "user:email"}
+	}
+	if config.AuthURL == "" {
+		config.AuthURL = gitHubAuthURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = gitHubTokenURL
+	}
+	if config.APIURL == "" {
+		config.APIURL = gitHubAPIURL
+	}
+
+	return &GitHubProvider{
+		oauth: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+			Endpoint:     oauth2.Endpoint{AuthURL: config.AuthURL, TokenURL: config.TokenURL},
+		},
+		apiURL: strings.TrimSuffix(config.APIURL, "/"),
+		client: httpClient(config.HTTPClient),
+	}
+}
+
+func (p *GitHubProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	return p.oauth.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	token, err := p.oauth.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := getJSON(ctx, p.client, p.apiURL+"/user", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+
+	// The profile email may be hidden or unverified; use the verified primary address
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, p.client, p.apiURL+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &domain.ExternalIdentity{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+		Username: user.Login,
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+)
+
+// minKeyRefreshInterval limits JWKS refetches triggered by unknown key ids
+const minKeyRefreshInterval = time.Minute
+
+var ErrUnknownSigningKey = errors.New("unknown id_token signing key")
+
+// remoteKeySet caches a provider's JWKS and refetches it when a token names
+// a key it has not seen, which is how providers announce rotated keys
+type remoteKeySet struct {
+	uri    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newRemoteKeySet(uri string, client *http.Client) *remoteKeySet {
+	return &remoteKeySet{uri: uri, client: client}
+}
+
+func (s *remoteKeySet) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.key(ctx, kid)
+	}
+}
+
+func (s *remoteKeySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.find(kid); ok {
+		return key, nil
+	}
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < minKeyRefreshInterval {
+		return nil, ErrUnknownSigningKey
+	}
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := s.find(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownSigningKey
+}
+
+// find looks a key up by id; a token without kid matches a single-key set
+func (s *remoteKeySet) find(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *remoteKeySet) fetch(ctx context.Context) error {
+	var set domain.JWKSResponse
+	if err := getJSON(ctx, s.client, s.uri, "", &set); err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			// Skip key types we cannot use instead of failing the whole set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// parseJWK decodes an RSA, EC or Ed25519 public key
+func parseJWK(jwk domain.JWK) (crypto.PublicKey, error) {
+	b64 := base64.RawURLEncoding.DecodeString
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := b64(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+
+	case "EC":
+		var (
+			curve elliptic.Curve
+			ecdhc ecdh.Curve
+		)
+		switch jwk.Crv {
+		case "P-256":
+			curve, ecdhc = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhc = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhc = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := b64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		// Reject points that are not on the curve
+		if _, err := ecdhc.NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := b64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
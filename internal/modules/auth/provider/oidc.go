@@ -0,0 +1,250 @@
+// Package provider implements domain.IdentityProvider for external OAuth2/OIDC
+// login providers.
+package provider
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"golang.org/x/oauth2"
+)
+
+// defaultHTTPTimeout bounds calls to provider endpoints when no client is configured
+const defaultHTTPTimeout = 10 * time.Second
+
+var (
+	ErrMissingIDToken = errors.New("token response has no id_token")
+	ErrInvalidIDToken = errors.New("invalid id_token")
+)
+
+// idTokenAlgorithms are the ID token signatures accepted from providers
+var idTokenAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "ES512", "EdDSA"}
+
+// OIDCConfig configures an OpenID Connect provider found through discovery
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // defaults to openid, email and profile
+	HTTPClient   *http.Client
+}
+
+// OIDCProvider signs users in with an OpenID Connect provider such as Google.
+// The discovery document and signing keys are fetched on first use.
+type OIDCProvider struct {
+	config OIDCConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     *remoteKeySet
+}
+
+// oidcMetadata is the subset of the discovery document the flow needs
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func NewOIDCProvider(config OIDCConfig) *OIDCProvider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &OIDCProvider{config: config, client: httpClient(config.HTTPClient)}
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(metadata).AuthCodeURL(state,
+		oauth2.S256ChallengeOption(codeVerifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.oauth2Config(metadata).Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	claims, err := p.verifyIDToken(ctx, metadata, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &domain.ExternalIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		Username:      claims.PreferredUsername,
+	}
+
+	// Some providers only return profile claims from the userinfo endpoint
+	if identity.Email == "" && metadata.UserinfoEndpoint != "" {
+		var info idTokenClaims
+		if err := getJSON(ctx, p.client, metadata.UserinfoEndpoint, token.AccessToken, &info); err != nil {
+			return nil, err
+		}
+		if info.Subject != claims.Subject {
+			return nil, fmt.Errorf("%w: userinfo subject mismatch", ErrInvalidIDToken)
+		}
+		identity.Email = info.Email
+		identity.EmailVerified = bool(info.EmailVerified)
+		if identity.Name == "" {
+			identity.Name = info.Name
+		}
+		if identity.Username == "" {
+			identity.Username = info.PreferredUsername
+		}
+	}
+	return identity, nil
+}
+
+// idTokenClaims are the ID token and userinfo claims used for sign-in
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty   string   `json:"azp"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, metadata *oidcMetadata, raw, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, p.keys.keyFunc(ctx),
+		jwt.WithValidMethods(idTokenAlgorithms),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party", ErrInvalidIDToken)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// discover loads the provider metadata once; failures are retried on the next call
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	var metadata oidcMetadata
+	if err := getJSON(ctx, p.client, issuer+"/.well-known/openid-configuration", "", &metadata); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", metadata.Issuer, p.config.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: incomplete provider metadata")
+	}
+
+	p.metadata = &metadata
+	p.keys = newRemoteKeySet(metadata.JWKSURI, p.client)
+	return p.metadata, nil
+}
+
+func (p *OIDCProvider) oauth2Config(metadata *oidcMetadata) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+	}
+}
+
+// flexBool accepts both true and "true"; some providers send email_verified as a string
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch x := v.(type) {
+	case bool:
+		*b = flexBool(x)
+	case string:
+		*b = flexBool(x == "true")
+	}
+	return nil
+}
+
+func httpClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
+
+// getJSON decodes a JSON response, authenticating with bearerToken when set
+func getJSON(ctx context.Context, client *http.Client, url, bearerToken string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: %s: %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
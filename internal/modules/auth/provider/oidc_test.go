@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// stubOIDCServer is a minimal OpenID provider: discovery, JWKS and a token
+// endpoint that checks PKCE and returns an RS256 ID token
+type stubOIDCServer struct {
+	*httptest.Server
+	t   *testing.T
+	key *rsa.PrivateKey
+
+	mu        sync.Mutex
+	codes     map[string]stubAuthorization
+	claims    map[string]any // overrides merged into the next ID token
+	userinfo  map[string]any
+	jwksCalls int
+}
+
+type stubAuthorization struct {
+	challenge string
+	nonce     string
+}
+
+func newStubOIDCServer(t *testing.T) *stubOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &stubOIDCServer{t: t, key: key, codes: map[string]stubAuthorization{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/authorize",
+			"token_endpoint":         s.URL + "/token",
+			"userinfo_endpoint":      s.URL + "/userinfo",
+			"jwks_uri":               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.jwksCalls++
+		s.mu.Unlock()
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "stub-key",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer stub-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, s.userinfo)
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// authorize plays the browser: it follows the authorization URL and returns the issued code
+func (s *stubOIDCServer) authorize(authURL string) string {
+	u, err := url.Parse(authURL)
+	require.NoError(s.t, err)
+	q := u.Query()
+	require.Equal(s.t, "S256", q.Get("code_challenge_method"))
+
+	code := "code-" + q.Get("state")
+	s.mu.Lock()
+	s.codes[code] = stubAuthorization{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	s.mu.Unlock()
+	return code
+}
+
+func (s *stubOIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(s.t, r.ParseForm())
+
+	s.mu.Lock()
+	auth, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+
+	clientID, _, _ := r.BasicAuth()
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            "stub-subject",
+		"aud":            clientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          auth.nonce,
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+	}
+	for k, v := range s.claims {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "stub-key"
+	idToken, err := token.SignedString(s.key)
+	require.NoError(s.t, err)
+
+	writeJSON(w, map[string]any{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestOIDCProvider(server *stubOIDCServer) *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Issuer:       server.URL,
+		ClientID:     "client-123",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/auth/oidc/stub/callback",
+		HTTPClient:   server.Client(),
+	})
+}
+
+// login runs the authorization code flow and returns the exchange result
+func login(t *testing.T, server *stubOIDCServer, p *OIDCProvider, nonce string) (*domain.ExternalIdentity, error) {
+	verifier := oauth2.GenerateVerifier()
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", nonce, verifier)
+	require.NoError(t, err)
+	code := server.authorize(authURL)
+	return p.Exchange(context.Background(), code, verifier, nonce)
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	server := newStubOIDCServer(t)
+	p := newTestOIDCProvider(server)
+
+	identity, err := login(t, server, p, "nonce-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ExternalIdentity{
+		Subject:       "stub-subject",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}, identity)
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	server := newStubOIDCServer(t)
+	p := newTestOIDCProvider(server)
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier")
+
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "client-123", q.Get("client_id"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier("verifier"), q.Get("code_challenge"))
+}
+
+func TestOIDCProvider_PKCEMismatch(t *testing.T) {
+	server := newStubOIDCServer(t)
+	p := newTestOIDCProvider(server)
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", oauth2.GenerateVerifier())
+	require.NoError(t, err)
+	code := server.authorize(authURL)
+
+	_, err = p.Exchange(context.Background(), code, oauth2.GenerateVerifier(), "nonce-1")
+
+	assert.ErrorContains(t, err, "invalid_grant")
+}
+
+func TestOIDCProvider_RejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		nonce  string
+	}{
+		{name: "nonce mismatch", nonce: "other-nonce", claims: map[string]any{"nonce": "nonce-1"}},
+		{name: "wrong audience", claims: map[string]any{"aud": "someone-else"}},
+		{name: "wrong issuer", claims: map[string]any{"iss": "https://evil.example.com"}},
+		{name: "expired", claims: map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}},
+		{name: "missing expiry", claims: map[string]any{"exp": nil}},
+		{name: "foreign authorized party", claims: map[string]any{"aud": []string{"client-123", "other"}, "azp": "other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubOIDCServer(t)
+			server.claims = tt.claims
+			p := newTestOIDCProvider(server)
+
+			nonce := tt.nonce
+			if nonce == "" {
+				nonce = "nonce-1"
+			}
+			_, err := login(t, server, p, nonce)
+
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestOIDCProvider_UserinfoFallback(t *testing.T) {
+	server := newStubOIDCServer(t)
+	server.claims = map[string]any{"email": nil, "email_verified": nil, "name": nil}
+	server.userinfo = map[string]any{
+		"sub":                "stub-subject",
+		"email":              "jane@example.com",
+		"email_verified":     "true",
+		"preferred_username": "jane",
+	}
+	p := newTestOIDCProvider(server)
+
+	identity, err := login(t, server, p, "nonce-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "jane", identity.Username)
+}
+
+func TestOIDCProvider_CachesKeys(t *testing.T) {
+	server := newStubOIDCServer(t)
+	p := newTestOIDCProvider(server)
+
+	for i := 0; i < 3; i++ {
+		_, err := login(t, server, p, "nonce-1")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, server.jwksCalls)
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	server := newStubOIDCServer(t)
+	p := NewOIDCProvider(OIDCConfig{Issuer: server.URL + "/tenant", ClientID: "client-123", HTTPClient: server.Client()})
+
+	_, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier")
+
+	assert.Error(t, err)
+}
+
+func TestGitHubProvider_Exchange(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "gh-code", r.PostForm.Get("code"))
+		assert.Equal(t, "verifier", r.PostForm.Get("code_verifier"))
+		writeJSON(w, map[string]any{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/api/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		writeJSON(w, map[string]any{"id": 583231, "login": "octocat", "name": "The Octocat", "email": nil})
+	})
+	mux.HandleFunc("/api/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]any{
+			{"email": "old@example.com", "primary": false, "verified": true},
+			{"email": "octocat@example.com", "primary": true, "verified": true},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewGitHubProvider(GitHubConfig{
+		ClientID:   "client-123",
+		AuthURL:    server.URL + "/login/oauth/authorize",
+		TokenURL:   server.URL + "/login/oauth/access_token",
+		APIURL:     server.URL + "/api",
+		HTTPClient: server.Client(),
+	})
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "", "verifier")
+	require.NoError(t, err)
+	assert.Contains(t, authURL, "code_challenge="+oauth2.S256ChallengeFromVerifier("verifier"))
+
+	identity, err := p.Exchange(context.Background(), "gh-code", "verifier", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ExternalIdentity{
+		Subject:       "583231",
+		Email:         "octocat@example.com",
+		EmailVerified: true,
+		Name:          "The Octocat",
+		Username:      "octocat",
+	}, identity)
+}
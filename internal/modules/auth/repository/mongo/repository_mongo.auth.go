@@ -2,9 +2,11 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,8 +15,16 @@ import (
 )
 
 const (
-	credentialsCollection = "auth_credentials"
-	sessionsCollection    = "auth_sessions"
+	credentialsCollection   = "auth_credentials"
+	sessionsCollection      = "auth_sessions"
+	rolesCollection         = "auth_roles"
+	permissionsCollection   = "auth_permissions"
+	userRolesCollection     = "auth_user_roles"
+	resetTokensCollection   = "auth_password_reset_tokens"
+	mfaCollection           = "auth_mfa_enrollments"
+	recoveryCodesCollection = "auth_mfa_recovery_codes"
+	identitiesCollection    = "auth_identities"
+	clientsCollection       = "auth_clients"
 )
 
 type MongoRepository struct {
@@ -37,16 +47,51 @@ func (r *MongoRepository) getSessionsCollection() *mongo.Collection {
 	return r.client.Database(r.dbName).Collection(sessionsCollection)
 }
 
-func (r *MongoRepository) StartContext(ctx context.Context) context.Context {
-	return ctx
+func (r *MongoRepository) getRolesCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(rolesCollection)
+}
+
+func (r *MongoRepository) getPermissionsCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(permissionsCollection)
+}
+
+func (r *MongoRepository) getUserRolesCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(userRolesCollection)
+}
+
+func (r *MongoRepository) getResetTokensCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(resetTokensCollection)
+}
+
+func (r *MongoRepository) getMFACollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(mfaCollection)
+}
+
+func (r *MongoRepository) getRecoveryCodesCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(recoveryCodesCollection)
+}
+
+func (r *MongoRepository) getIdentitiesCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(identitiesCollection)
+}
+
+func (r *MongoRepository) getClientsCollection() *mongo.Collection {
+	return r.client.Database(r.dbName).Collection(clientsCollection)
 }
 
-func (r *MongoRepository) DeferErrorContext(ctx context.Context, err error) {
+// getSessionContext binds ctx to the unit of work session, if any, so the
+// operation joins its transaction.
+func (r *MongoRepository) getSessionContext(ctx context.Context) context.Context {
+	if session := sharedCtx.GetObjectFromContext[mongo.Session](ctx, sharedCtx.MongoSessionKey); session != nil {
+		return mongo.NewSessionContext(ctx, *session)
+	}
+	return ctx
 }
 
 // Credential operations
 
 func (r *MongoRepository) CreateCredential(ctx context.Context, cred *domain.Credential) error {
+	ctx = r.getSessionContext(ctx)
 	if cred.ID == "" {
 		cred.ID = uuid.NewString()
 	}
@@ -58,6 +103,7 @@ func (r *MongoRepository) CreateCredential(ctx context.Context, cred *domain.Cre
 }
 
 func (r *MongoRepository) GetCredentialByUsername(ctx context.Context, username string) (*domain.Credential, error) {
+	ctx = r.getSessionContext(ctx)
 	var cred domain.Credential
 	filter := bson.M{
 		"username":   username,
@@ -72,6 +118,7 @@ func (r *MongoRepository) GetCredentialByUsername(ctx context.Context, username
 }
 
 func (r *MongoRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error) {
+	ctx = r.getSessionContext(ctx)
 	var cred domain.Credential
 	filter := bson.M{
 		"email":      email,
@@ -86,6 +133,7 @@ func (r *MongoRepository) GetCredentialByEmail(ctx context.Context, email string
 }
 
 func (r *MongoRepository) GetCredentialByUserID(ctx context.Context, userID string) (*domain.Credential, error) {
+	ctx = r.getSessionContext(ctx)
 	var cred domain.Credential
 	filter := bson.M{
 		"user_id":    userID,
@@ -100,6 +148,7 @@ func (r *MongoRepository) GetCredentialByUserID(ctx context.Context, userID stri
 }
 
 func (r *MongoRepository) UpdateCredential(ctx context.Context, cred *domain.Credential) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	cred.UpdatedAt = &now
 
@@ -118,6 +167,7 @@ func (r *MongoRepository) UpdateCredential(ctx context.Context, cred *domain.Cre
 }
 
 func (r *MongoRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	filter := bson.M{"user_id": userID, "deleted_at": bson.M{"$eq": nil}}
 	update := bson.M{
@@ -132,6 +182,7 @@ func (r *MongoRepository) UpdatePassword(ctx context.Context, userID, passwordHa
 }
 
 func (r *MongoRepository) UpdateLastLogin(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	filter := bson.M{"user_id": userID, "deleted_at": bson.M{"$eq": nil}}
 	update := bson.M{
@@ -145,12 +196,35 @@ func (r *MongoRepository) UpdateLastLogin(ctx context.Context, userID string) er
 	return err
 }
 
+func (r *MongoRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	filter := bson.M{
+		"user_id":           userID,
+		"email_verified_at": bson.M{"$eq": nil},
+		"deleted_at":        bson.M{"$eq": nil},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"email_verified_at": now,
+			"updated_at":        now,
+		},
+	}
+
+	_, err := r.getCredentialsCollection().UpdateOne(ctx, filter, update)
+	return err
+}
+
 // Session operations
 
 func (r *MongoRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	ctx = r.getSessionContext(ctx)
 	if session.ID == "" {
 		session.ID = uuid.NewString()
 	}
+	if session.FamilyID == "" {
+		session.FamilyID = session.ID
+	}
 	session.CreatedAt = time.Now().UTC()
 
 	_, err := r.getSessionsCollection().InsertOne(ctx, session)
@@ -158,11 +232,13 @@ func (r *MongoRepository) CreateSession(ctx context.Context, session *domain.Ses
 }
 
 func (r *MongoRepository) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	ctx = r.getSessionContext(ctx)
 	var session domain.Session
 	filter := bson.M{
-		"token":      token,
-		"revoked_at": bson.M{"$eq": nil},
-		"expires_at": bson.M{"$gt": time.Now().UTC()},
+		"token":       token,
+		"revoked_at":  bson.M{"$eq": nil},
+		"consumed_at": bson.M{"$eq": nil},
+		"expires_at":  bson.M{"$gt": time.Now().UTC()},
 	}
 
 	err := r.getSessionsCollection().FindOne(ctx, filter).Decode(&session)
@@ -173,6 +249,7 @@ func (r *MongoRepository) GetSessionByToken(ctx context.Context, token string) (
 }
 
 func (r *MongoRepository) GetSessionByID(ctx context.Context, id string) (*domain.Session, error) {
+	ctx = r.getSessionContext(ctx)
 	var session domain.Session
 	filter := bson.M{"id": id}
 
@@ -184,10 +261,12 @@ func (r *MongoRepository) GetSessionByID(ctx context.Context, id string) (*domai
 }
 
 func (r *MongoRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]domain.Session, error) {
+	ctx = r.getSessionContext(ctx)
 	filter := bson.M{
-		"user_id":    userID,
-		"revoked_at": bson.M{"$eq": nil},
-		"expires_at": bson.M{"$gt": time.Now().UTC()},
+		"user_id":     userID,
+		"revoked_at":  bson.M{"$eq": nil},
+		"consumed_at": bson.M{"$eq": nil},
+		"expires_at":  bson.M{"$gt": time.Now().UTC()},
 	}
 
 	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
@@ -205,6 +284,7 @@ func (r *MongoRepository) GetSessionsByUserID(ctx context.Context, userID string
 }
 
 func (r *MongoRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	filter := bson.M{"id": sessionID}
 	update := bson.M{
@@ -219,6 +299,7 @@ func (r *MongoRepository) RevokeSession(ctx context.Context, sessionID string) e
 }
 
 func (r *MongoRepository) RevokeAllUserSessions(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	filter := bson.M{
 		"user_id":    userID,
@@ -235,7 +316,55 @@ func (r *MongoRepository) RevokeAllUserSessions(ctx context.Context, userID stri
 	return err
 }
 
+func (r *MongoRepository) GetConsumedSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	ctx = r.getSessionContext(ctx)
+	var session domain.Session
+	filter := bson.M{
+		"token":       token,
+		"consumed_at": bson.M{"$ne": nil},
+	}
+
+	if err := r.getSessionsCollection().FindOne(ctx, filter).Decode(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *MongoRepository) ConsumeSession(ctx context.Context, sessionID string) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	filter := bson.M{
+		"id":          sessionID,
+		"consumed_at": bson.M{"$eq": nil},
+		"revoked_at":  bson.M{"$eq": nil},
+	}
+	update := bson.M{"$set": bson.M{"consumed_at": now, "updated_at": now}}
+
+	result, err := r.getSessionsCollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MongoRepository) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	filter := bson.M{
+		"family_id":  familyID,
+		"revoked_at": bson.M{"$eq": nil},
+	}
+	update := bson.M{"$set": bson.M{"revoked_at": now, "updated_at": now}}
+
+	_, err := r.getSessionsCollection().UpdateMany(ctx, filter, update)
+	return err
+}
+
 func (r *MongoRepository) DeleteExpiredSessions(ctx context.Context) error {
+	ctx = r.getSessionContext(ctx)
 	filter := bson.M{
 		"expires_at": bson.M{"$lt": time.Now().UTC()},
 	}
@@ -243,3 +372,413 @@ func (r *MongoRepository) DeleteExpiredSessions(ctx context.Context) error {
 	_, err := r.getSessionsCollection().DeleteMany(ctx, filter)
 	return err
 }
+
+// Role operations
+
+func (r *MongoRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	ctx = r.getSessionContext(ctx)
+	if role.ID == "" {
+		role.ID = uuid.NewString()
+	}
+	role.CreatedAt = time.Now().UTC()
+
+	permissions, err := r.knownPermissions(ctx, role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+
+	_, err = r.getRolesCollection().InsertOne(ctx, role)
+	return err
+}
+
+func (r *MongoRepository) GetRoleByID(ctx context.Context, id string) (*domain.Role, error) {
+	ctx = r.getSessionContext(ctx)
+	var role domain.Role
+	if err := r.getRolesCollection().FindOne(ctx, bson.M{"id": id}).Decode(&role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *MongoRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	ctx = r.getSessionContext(ctx)
+	var role domain.Role
+	if err := r.getRolesCollection().FindOne(ctx, bson.M{"name": name}).Decode(&role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *MongoRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	ctx = r.getSessionContext(ctx)
+	return r.findRoles(ctx, bson.M{})
+}
+
+func (r *MongoRepository) findRoles(ctx context.Context, filter bson.M) ([]domain.Role, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.getRolesCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	roles := []domain.Role{}
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *MongoRepository) DeleteRole(ctx context.Context, id string) error {
+	ctx = r.getSessionContext(ctx)
+	if _, err := r.getUserRolesCollection().DeleteMany(ctx, bson.M{"role_id": id}); err != nil {
+		return err
+	}
+	_, err := r.getRolesCollection().DeleteOne(ctx, bson.M{"id": id})
+	return err
+}
+
+func (r *MongoRepository) SetRolePermissions(ctx context.Context, roleID string, permissions []string) error {
+	ctx = r.getSessionContext(ctx)
+	known, err := r.knownPermissions(ctx, permissions)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"permissions": known,
+			"updated_at":  time.Now().UTC(),
+		},
+	}
+	_, err = r.getRolesCollection().UpdateOne(ctx, bson.M{"id": roleID}, update)
+	return err
+}
+
+// knownPermissions drops names that are not in the permissions collection,
+// matching the SQL repository where unknown names fail the join
+func (r *MongoRepository) knownPermissions(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	values, err := r.getPermissionsCollection().Distinct(ctx, "name", bson.M{"name": bson.M{"$in": names}})
+	if err != nil {
+		return nil, err
+	}
+
+	known := make([]string, 0, len(values))
+	for _, v := range values {
+		if name, ok := v.(string); ok {
+			known = append(known, name)
+		}
+	}
+	return known, nil
+}
+
+func (r *MongoRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	ctx = r.getSessionContext(ctx)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.getPermissionsCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	permissions := []domain.Permission{}
+	if err := cursor.All(ctx, &permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// User-role assignment operations
+
+func (r *MongoRepository) AssignRole(ctx context.Context, userRole *domain.UserRole) error {
+	ctx = r.getSessionContext(ctx)
+	userRole.AssignedAt = time.Now().UTC()
+
+	filter := bson.M{"user_id": userRole.UserID, "role_id": userRole.RoleID}
+	update := bson.M{"$setOnInsert": userRole}
+	_, err := r.getUserRolesCollection().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *MongoRepository) RevokeRole(ctx context.Context, userID, roleID string) error {
+	ctx = r.getSessionContext(ctx)
+	filter := bson.M{"user_id": userID, "role_id": roleID}
+
+	_, err := r.getUserRolesCollection().DeleteOne(ctx, filter)
+	return err
+}
+
+func (r *MongoRepository) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+	ctx = r.getSessionContext(ctx)
+	roleIDs, err := r.getUserRolesCollection().Distinct(ctx, "role_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(roleIDs) == 0 {
+		return []domain.Role{}, nil
+	}
+	return r.findRoles(ctx, bson.M{"id": bson.M{"$in": roleIDs}})
+}
+
+// Password reset token operations
+
+func (r *MongoRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	ctx = r.getSessionContext(ctx)
+	if token.ID == "" {
+		token.ID = uuid.NewString()
+	}
+	token.CreatedAt = time.Now().UTC()
+
+	_, err := r.getResetTokensCollection().InsertOne(ctx, token)
+	return err
+}
+
+func (r *MongoRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	filter := bson.M{
+		"token_hash": tokenHash,
+		"used_at":    bson.M{"$eq": nil},
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"used_at": now}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var token domain.PasswordResetToken
+	if err := r.getResetTokensCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *MongoRepository) InvalidatePasswordResetTokens(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
+	filter := bson.M{
+		"user_id": userID,
+		"used_at": bson.M{"$eq": nil},
+	}
+	update := bson.M{"$set": bson.M{"used_at": time.Now().UTC()}}
+
+	_, err := r.getResetTokensCollection().UpdateMany(ctx, filter, update)
+	return err
+}
+
+// MFA operations
+
+func (r *MongoRepository) SaveMFAEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	enrollment.CreatedAt = now
+
+	filter := bson.M{"user_id": enrollment.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"secret":         enrollment.Secret,
+			"last_used_step": enrollment.LastUsedStep,
+			"enabled_at":     enrollment.EnabledAt,
+			"updated_at":     now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	_, err := r.getMFACollection().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *MongoRepository) GetMFAEnrollment(ctx context.Context, userID string) (*domain.MFAEnrollment, error) {
+	ctx = r.getSessionContext(ctx)
+	var enrollment domain.MFAEnrollment
+	if err := r.getMFACollection().FindOne(ctx, bson.M{"user_id": userID}).Decode(&enrollment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *MongoRepository) EnableMFA(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	update := bson.M{"$set": bson.M{"enabled_at": now, "updated_at": now}}
+
+	_, err := r.getMFACollection().UpdateOne(ctx, bson.M{"user_id": userID}, update)
+	return err
+}
+
+func (r *MongoRepository) DeleteMFAEnrollment(ctx context.Context, userID string) error {
+	ctx = r.getSessionContext(ctx)
+	if _, err := r.getRecoveryCodesCollection().DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		return err
+	}
+	_, err := r.getMFACollection().DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *MongoRepository) UseMFAStep(ctx context.Context, userID string, step int64) error {
+	ctx = r.getSessionContext(ctx)
+	filter := bson.M{
+		"user_id":        userID,
+		"last_used_step": bson.M{"$lt": step},
+	}
+	update := bson.M{"$set": bson.M{"last_used_step": step, "updated_at": time.Now().UTC()}}
+
+	result, err := r.getMFACollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MongoRepository) ReplaceMFARecoveryCodes(ctx context.Context, userID string, codes []domain.MFARecoveryCode) error {
+	ctx = r.getSessionContext(ctx)
+	if _, err := r.getRecoveryCodesCollection().DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	docs := make([]interface{}, len(codes))
+	for i := range codes {
+		if codes[i].ID == "" {
+			codes[i].ID = uuid.NewString()
+		}
+		codes[i].UserID = userID
+		codes[i].CreatedAt = now
+		docs[i] = codes[i]
+	}
+
+	_, err := r.getRecoveryCodesCollection().InsertMany(ctx, docs)
+	return err
+}
+
+func (r *MongoRepository) ConsumeMFARecoveryCode(ctx context.Context, userID, codeHash string) error {
+	ctx = r.getSessionContext(ctx)
+	filter := bson.M{
+		"user_id":   userID,
+		"code_hash": codeHash,
+		"used_at":   bson.M{"$eq": nil},
+	}
+	update := bson.M{"$set": bson.M{"used_at": time.Now().UTC()}}
+
+	result, err := r.getRecoveryCodesCollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// External identity operations
+
+func (r *MongoRepository) CreateIdentity(ctx context.Context, identity *domain.Identity) error {
+	ctx = r.getSessionContext(ctx)
+	if identity.ID == "" {
+		identity.ID = uuid.NewString()
+	}
+	identity.CreatedAt = time.Now().UTC()
+
+	_, err := r.getIdentitiesCollection().InsertOne(ctx, identity)
+	return err
+}
+
+func (r *MongoRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.Identity, error) {
+	ctx = r.getSessionContext(ctx)
+	var identity domain.Identity
+	filter := bson.M{"provider": provider, "subject": subject}
+	if err := r.getIdentitiesCollection().FindOne(ctx, filter).Decode(&identity); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *MongoRepository) GetIdentitiesByUserID(ctx context.Context, userID string) ([]domain.Identity, error) {
+	ctx = r.getSessionContext(ctx)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.getIdentitiesCollection().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var identities []domain.Identity
+	if err := cursor.All(ctx, &identities); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (r *MongoRepository) DeleteIdentity(ctx context.Context, id string) error {
+	ctx = r.getSessionContext(ctx)
+	_, err := r.getIdentitiesCollection().DeleteOne(ctx, bson.M{"id": id})
+	return err
+}
+
+// Machine client operations
+
+func (r *MongoRepository) CreateClient(ctx context.Context, client *domain.Client) error {
+	ctx = r.getSessionContext(ctx)
+	if client.ID == "" {
+		client.ID = uuid.NewString()
+	}
+	client.CreatedAt = time.Now().UTC()
+
+	_, err := r.getClientsCollection().InsertOne(ctx, client)
+	return err
+}
+
+func (r *MongoRepository) GetClientByID(ctx context.Context, id string) (*domain.Client, error) {
+	ctx = r.getSessionContext(ctx)
+	var client domain.Client
+	if err := r.getClientsCollection().FindOne(ctx, bson.M{"id": id}).Decode(&client); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *MongoRepository) ListClients(ctx context.Context) ([]domain.Client, error) {
+	ctx = r.getSessionContext(ctx)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.getClientsCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var clients []domain.Client
+	if err := cursor.All(ctx, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+func (r *MongoRepository) UpdateClientSecret(ctx context.Context, id, secretHash string) error {
+	ctx = r.getSessionContext(ctx)
+	update := bson.M{"$set": bson.M{"secret_hash": secretHash, "updated_at": time.Now().UTC()}}
+	_, err := r.getClientsCollection().UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (r *MongoRepository) DeleteClient(ctx context.Context, id string) error {
+	ctx = r.getSessionContext(ctx)
+	_, err := r.getClientsCollection().DeleteOne(ctx, bson.M{"id": id})
+	return err
+}
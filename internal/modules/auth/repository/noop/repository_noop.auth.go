@@ -2,12 +2,12 @@ package noop
 
 import (
 	"context"
-	"errors"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
-var ErrNotImplemented = errors.New("auth repository not implemented")
+var ErrNotImplemented = sharederrors.ErrNotImplemented.WithMessage("auth repository not implemented")
 
 type NoopRepository struct{}
 
@@ -15,12 +15,6 @@ func NewNoopRepository() *NoopRepository {
 	return &NoopRepository{}
 }
 
-func (r *NoopRepository) StartContext(ctx context.Context) context.Context {
-	return ctx
-}
-
-func (r *NoopRepository) DeferErrorContext(ctx context.Context, err error) {}
-
 // Credential operations
 
 func (r *NoopRepository) CreateCredential(ctx context.Context, cred *domain.Credential) error {
@@ -51,6 +45,10 @@ func (r *NoopRepository) UpdateLastLogin(ctx context.Context, userID string) err
 	return ErrNotImplemented
 }
 
+func (r *NoopRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	return ErrNotImplemented
+}
+
 // Session operations
 
 func (r *NoopRepository) CreateSession(ctx context.Context, session *domain.Session) error {
@@ -77,6 +75,142 @@ func (r *NoopRepository) RevokeAllUserSessions(ctx context.Context, userID strin
 	return ErrNotImplemented
 }
 
+func (r *NoopRepository) GetConsumedSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) ConsumeSession(ctx context.Context, sessionID string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	return ErrNotImplemented
+}
+
 func (r *NoopRepository) DeleteExpiredSessions(ctx context.Context) error {
 	return ErrNotImplemented
 }
+
+// Role operations
+
+func (r *NoopRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) GetRoleByID(ctx context.Context, id string) (*domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) DeleteRole(ctx context.Context, id string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) SetRolePermissions(ctx context.Context, roleID string, permissions []string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	return nil, ErrNotImplemented
+}
+
+// User-role assignment operations
+
+func (r *NoopRepository) AssignRole(ctx context.Context, userRole *domain.UserRole) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) RevokeRole(ctx context.Context, userID, roleID string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+// Password reset token operations
+
+func (r *NoopRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) InvalidatePasswordResetTokens(ctx context.Context, userID string) error {
+	return ErrNotImplemented
+}
+
+// MFA operations
+
+func (r *NoopRepository) SaveMFAEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) GetMFAEnrollment(ctx context.Context, userID string) (*domain.MFAEnrollment, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) EnableMFA(ctx context.Context, userID string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) DeleteMFAEnrollment(ctx context.Context, userID string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) UseMFAStep(ctx context.Context, userID string, step int64) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) ReplaceMFARecoveryCodes(ctx context.Context, userID string, codes []domain.MFARecoveryCode) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) ConsumeMFARecoveryCode(ctx context.Context, userID, codeHash string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) CreateIdentity(ctx context.Context, identity *domain.Identity) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.Identity, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) GetIdentitiesByUserID(ctx context.Context, userID string) ([]domain.Identity, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) DeleteIdentity(ctx context.Context, id string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) CreateClient(ctx context.Context, client *domain.Client) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) GetClientByID(ctx context.Context, id string) (*domain.Client, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) ListClients(ctx context.Context) ([]domain.Client, error) {
+	return nil, ErrNotImplemented
+}
+
+func (r *NoopRepository) UpdateClientSecret(ctx context.Context, id, secretHash string) error {
+	return ErrNotImplemented
+}
+
+func (r *NoopRepository) DeleteClient(ctx context.Context, id string) error {
+	return ErrNotImplemented
+}
@@ -2,55 +2,46 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
-const authDriverName = "AuthPostgreSQL"
-
 type SQLRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	dialect sqldialect.Dialect
+	router  *dbrouter.Router // nil reads from db
 }
 
 func NewSQLRepository(db *sqlx.DB) *SQLRepository {
-	return &SQLRepository{db: db}
-}
-
-func (r *SQLRepository) StartContext(ctx context.Context) context.Context {
-	tx := r.db.MustBeginTx(ctx, nil)
-	return context.WithValue(ctx, authDriverName, tx)
+	return &SQLRepository{db: db, dialect: sqldialect.Of(db)}
 }
 
-func (r *SQLRepository) DeferErrorContext(ctx context.Context, err error) {
-	tx := r.getTxFromContext(ctx)
-	if tx != nil {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			tx.Commit()
-		}
-	}
+// SetRouter sends the role, permission and client listings outside a
+// transaction through router, which may pick a read replica. Credentials and
+// sessions are always read from the primary.
+func (r *SQLRepository) SetRouter(router *dbrouter.Router) {
+	r.router = router
 }
 
 func (r *SQLRepository) getTxFromContext(ctx context.Context) *sqlx.Tx {
-	txVal := ctx.Value(authDriverName)
-	tx, ok := txVal.(*sqlx.Tx)
-	if !ok {
-		return nil
-	}
-	return tx
+	return sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey)
 }
 
 // Credential operations
 
 func (r *SQLRepository) CreateCredential(ctx context.Context, cred *domain.Credential) error {
 	query := `INSERT INTO auth_credentials 
-		(id, user_id, username, email, password_hash, is_active, created_at) 
-		VALUES (:id, :user_id, :username, :email, :password_hash, :is_active, :created_at)`
+		(id, user_id, username, email, password_hash, is_active, email_verified_at, created_at) 
+		VALUES (:id, :user_id, :username, :email, :password_hash, :is_active, :email_verified_at, :created_at)`
 
 	tx := r.getTxFromContext(ctx)
 	if cred.ID == "" {
@@ -70,8 +61,8 @@ func (r *SQLRepository) CreateCredential(ctx context.Context, cred *domain.Crede
 func (r *SQLRepository) GetCredentialByUsername(ctx context.Context, username string) (*domain.Credential, error) {
 	var cred domain.Credential
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, username, email, password_hash, is_active, last_login_at, created_at, updated_at, deleted_at 
-		FROM auth_credentials WHERE username = $1 AND deleted_at IS NULL`
+	query := r.db.Rebind(`SELECT id, user_id, username, email, password_hash, is_active, email_verified_at, last_login_at, created_at, updated_at, deleted_at 
+		FROM auth_credentials WHERE username = ? AND deleted_at IS NULL`)
 
 	if tx != nil {
 		if err := tx.Get(&cred, query, username); err != nil {
@@ -88,8 +79,8 @@ func (r *SQLRepository) GetCredentialByUsername(ctx context.Context, username st
 func (r *SQLRepository) GetCredentialByEmail(ctx context.Context, email string) (*domain.Credential, error) {
 	var cred domain.Credential
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, username, email, password_hash, is_active, last_login_at, created_at, updated_at, deleted_at 
-		FROM auth_credentials WHERE email = $1 AND deleted_at IS NULL`
+	query := r.db.Rebind(`SELECT id, user_id, username, email, password_hash, is_active, email_verified_at, last_login_at, created_at, updated_at, deleted_at 
+		FROM auth_credentials WHERE email = ? AND deleted_at IS NULL`)
 
 	if tx != nil {
 		if err := tx.Get(&cred, query, email); err != nil {
@@ -106,8 +97,8 @@ func (r *SQLRepository) GetCredentialByEmail(ctx context.Context, email string)
 func (r *SQLRepository) GetCredentialByUserID(ctx context.Context, userID string) (*domain.Credential, error) {
 	var cred domain.Credential
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, username, email, password_hash, is_active, last_login_at, created_at, updated_at, deleted_at 
-		FROM auth_credentials WHERE user_id = $1 AND deleted_at IS NULL`
+	query := r.db.Rebind(`SELECT id, user_id, username, email, password_hash, is_active, email_verified_at, last_login_at, created_at, updated_at, deleted_at 
+		FROM auth_credentials WHERE user_id = ? AND deleted_at IS NULL`)
 
 	if tx != nil {
 		if err := tx.Get(&cred, query, userID); err != nil {
@@ -140,7 +131,7 @@ func (r *SQLRepository) UpdateCredential(ctx context.Context, cred *domain.Crede
 func (r *SQLRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE auth_credentials SET password_hash = $1, updated_at = $2 WHERE user_id = $3 AND deleted_at IS NULL`
+	query := r.db.Rebind(`UPDATE auth_credentials SET password_hash = ?, updated_at = ? WHERE user_id = ? AND deleted_at IS NULL`)
 
 	if tx != nil {
 		_, err := tx.Exec(query, passwordHash, now, userID)
@@ -153,13 +144,27 @@ func (r *SQLRepository) UpdatePassword(ctx context.Context, userID, passwordHash
 func (r *SQLRepository) UpdateLastLogin(ctx context.Context, userID string) error {
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE auth_credentials SET last_login_at = $1, updated_at = $1 WHERE user_id = $2 AND deleted_at IS NULL`
+	query := r.db.Rebind(`UPDATE auth_credentials SET last_login_at = ?, updated_at = ? WHERE user_id = ? AND deleted_at IS NULL`)
+
+	if tx != nil {
+		_, err := tx.Exec(query, now, now, userID)
+		return err
+	}
+	_, err := r.db.Exec(query, now, now, userID)
+	return err
+}
+
+func (r *SQLRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	now := time.Now().UTC()
+	tx := r.getTxFromContext(ctx)
+	query := r.db.Rebind(`UPDATE auth_credentials SET email_verified_at = ?, updated_at = ? 
+		WHERE user_id = ? AND email_verified_at IS NULL AND deleted_at IS NULL`)
 
 	if tx != nil {
-		_, err := tx.Exec(query, now, userID)
+		_, err := tx.Exec(query, now, now, userID)
 		return err
 	}
-	_, err := r.db.Exec(query, now, userID)
+	_, err := r.db.Exec(query, now, now, userID)
 	return err
 }
 
@@ -167,13 +172,16 @@ func (r *SQLRepository) UpdateLastLogin(ctx context.Context, userID string) erro
 
 func (r *SQLRepository) CreateSession(ctx context.Context, session *domain.Session) error {
 	query := `INSERT INTO auth_sessions 
-		(id, user_id, token, expires_at, created_at, user_agent, ip_address) 
-		VALUES (:id, :user_id, :token, :expires_at, :created_at, :user_agent, :ip_address)`
+		(id, user_id, family_id, token, expires_at, created_at, user_agent, ip_address) 
+		VALUES (:id, :user_id, :family_id, :token, :expires_at, :created_at, :user_agent, :ip_address)`
 
 	tx := r.getTxFromContext(ctx)
 	if session.ID == "" {
 		session.ID = uuid.NewString()
 	}
+	if session.FamilyID == "" {
+		session.FamilyID = session.ID
+	}
 	session.CreatedAt = time.Now().UTC()
 
 	if tx != nil {
@@ -187,15 +195,16 @@ func (r *SQLRepository) CreateSession(ctx context.Context, session *domain.Sessi
 func (r *SQLRepository) GetSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
 	var session domain.Session
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, token, expires_at, created_at, updated_at, revoked_at, user_agent, ip_address 
-		FROM auth_sessions WHERE token = $1 AND revoked_at IS NULL AND expires_at > NOW()`
+	query := r.db.Rebind(`SELECT id, user_id, family_id, token, expires_at, created_at, updated_at, revoked_at, consumed_at, user_agent, ip_address 
+		FROM auth_sessions WHERE token = ? AND revoked_at IS NULL AND consumed_at IS NULL AND expires_at > ?`)
 
+	now := time.Now().UTC()
 	if tx != nil {
-		if err := tx.Get(&session, query, token); err != nil {
+		if err := tx.Get(&session, query, token, now); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := r.db.Get(&session, query, token); err != nil {
+		if err := r.db.Get(&session, query, token, now); err != nil {
 			return nil, err
 		}
 	}
@@ -205,8 +214,8 @@ func (r *SQLRepository) GetSessionByToken(ctx context.Context, token string) (*d
 func (r *SQLRepository) GetSessionByID(ctx context.Context, id string) (*domain.Session, error) {
 	var session domain.Session
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, token, expires_at, created_at, updated_at, revoked_at, user_agent, ip_address 
-		FROM auth_sessions WHERE id = $1`
+	query := r.db.Rebind(`SELECT id, user_id, family_id, token, expires_at, created_at, updated_at, revoked_at, consumed_at, user_agent, ip_address 
+		FROM auth_sessions WHERE id = ?`)
 
 	if tx != nil {
 		if err := tx.Get(&session, query, id); err != nil {
@@ -223,15 +232,16 @@ func (r *SQLRepository) GetSessionByID(ctx context.Context, id string) (*domain.
 func (r *SQLRepository) GetSessionsByUserID(ctx context.Context, userID string) ([]domain.Session, error) {
 	var sessions []domain.Session
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id, user_id, token, expires_at, created_at, updated_at, revoked_at, user_agent, ip_address 
-		FROM auth_sessions WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW() ORDER BY created_at DESC`
+	query := r.db.Rebind(`SELECT id, user_id, family_id, token, expires_at, created_at, updated_at, revoked_at, consumed_at, user_agent, ip_address 
+		FROM auth_sessions WHERE user_id = ? AND revoked_at IS NULL AND consumed_at IS NULL AND expires_at > ? ORDER BY created_at DESC`)
 
+	now := time.Now().UTC()
 	if tx != nil {
-		if err := tx.Select(&sessions, query, userID); err != nil {
+		if err := tx.Select(&sessions, query, userID, now); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := r.db.Select(&sessions, query, userID); err != nil {
+		if err := r.db.Select(&sessions, query, userID, now); err != nil {
 			return nil, err
 		}
 	}
@@ -241,37 +251,503 @@ func (r *SQLRepository) GetSessionsByUserID(ctx context.Context, userID string)
 func (r *SQLRepository) RevokeSession(ctx context.Context, sessionID string) error {
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE auth_sessions SET revoked_at = $1, updated_at = $1 WHERE id = $2`
+	query := r.db.Rebind(`UPDATE auth_sessions SET revoked_at = ?, updated_at = ? WHERE id = ?`)
 
 	if tx != nil {
-		_, err := tx.Exec(query, now, sessionID)
+		_, err := tx.Exec(query, now, now, sessionID)
 		return err
 	}
-	_, err := r.db.Exec(query, now, sessionID)
+	_, err := r.db.Exec(query, now, now, sessionID)
 	return err
 }
 
 func (r *SQLRepository) RevokeAllUserSessions(ctx context.Context, userID string) error {
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE auth_sessions SET revoked_at = $1, updated_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+	query := r.db.Rebind(`UPDATE auth_sessions SET revoked_at = ?, updated_at = ? WHERE user_id = ? AND revoked_at IS NULL`)
 
 	if tx != nil {
-		_, err := tx.Exec(query, now, userID)
+		_, err := tx.Exec(query, now, now, userID)
 		return err
 	}
-	_, err := r.db.Exec(query, now, userID)
+	_, err := r.db.Exec(query, now, now, userID)
+	return err
+}
+
+func (r *SQLRepository) GetConsumedSessionByToken(ctx context.Context, token string) (*domain.Session, error) {
+	var session domain.Session
+	query := r.db.Rebind(`SELECT id, user_id, family_id, token, expires_at, created_at, updated_at, revoked_at, consumed_at, user_agent, ip_address 
+		FROM auth_sessions WHERE token = ? AND consumed_at IS NOT NULL`)
+
+	if err := sqlx.GetContext(ctx, r.conn(ctx), &session, query, token); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SQLRepository) ConsumeSession(ctx context.Context, sessionID string) error {
+	query := r.db.Rebind(`UPDATE auth_sessions SET consumed_at = ?, updated_at = ?
+		WHERE id = ? AND consumed_at IS NULL AND revoked_at IS NULL`)
+
+	now := time.Now().UTC()
+	return updatedOne(r.conn(ctx).ExecContext(ctx, query, now, now, sessionID))
+}
+
+func (r *SQLRepository) RevokeSessionFamily(ctx context.Context, familyID string) error {
+	query := r.db.Rebind(`UPDATE auth_sessions SET revoked_at = ?, updated_at = ? WHERE family_id = ? AND revoked_at IS NULL`)
+
+	now := time.Now().UTC()
+	_, err := r.conn(ctx).ExecContext(ctx, query, now, now, familyID)
 	return err
 }
 
 func (r *SQLRepository) DeleteExpiredSessions(ctx context.Context) error {
 	tx := r.getTxFromContext(ctx)
-	query := `DELETE FROM auth_sessions WHERE expires_at < NOW()`
+	query := r.db.Rebind(`DELETE FROM auth_sessions WHERE expires_at < ?`)
 
+	now := time.Now().UTC()
 	if tx != nil {
-		_, err := tx.Exec(query)
+		_, err := tx.Exec(query, now)
+		return err
+	}
+	_, err := r.db.Exec(query, now)
+	return err
+}
+
+// Role operations
+
+// conn returns the transaction bound to ctx, if any, otherwise the database
+func (r *SQLRepository) conn(ctx context.Context) sqlx.ExtContext {
+	if tx := r.getTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// reader is conn for reads that may be served by a replica
+func (r *SQLRepository) reader(ctx context.Context) sqlx.QueryerContext {
+	if tx := r.getTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	if r.router != nil {
+		return r.router.Reader(ctx)
+	}
+	return r.db
+}
+
+func (r *SQLRepository) CreateRole(ctx context.Context, role *domain.Role) error {
+	defer r.router.MarkWrite(ctx)
+	query := r.db.Rebind(`INSERT INTO auth_roles (id, name, description, created_at) VALUES (?, ?, ?, ?)`)
+
+	if role.ID == "" {
+		role.ID = uuid.NewString()
+	}
+	role.CreatedAt = time.Now().UTC()
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, role.ID, role.Name, role.Description, role.CreatedAt); err != nil {
+		return err
+	}
+	return r.SetRolePermissions(ctx, role.ID, role.Permissions)
+}
+
+func (r *SQLRepository) GetRoleByID(ctx context.Context, id string) (*domain.Role, error) {
+	return r.getRole(ctx, r.reader(ctx), r.db.Rebind(`SELECT id, name, description, created_at, updated_at FROM auth_roles WHERE id = ?`), id)
+}
+
+func (r *SQLRepository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
+	return r.getRole(ctx, r.conn(ctx), r.db.Rebind(`SELECT id, name, description, created_at, updated_at FROM auth_roles WHERE name = ?`), name)
+}
+
+func (r *SQLRepository) getRole(ctx context.Context, q sqlx.QueryerContext, query string, arg string) (*domain.Role, error) {
+	var role domain.Role
+	if err := sqlx.GetContext(ctx, q, &role, query, arg); err != nil {
+		return nil, err
+	}
+	roles := []domain.Role{role}
+	if err := r.loadPermissions(ctx, q, roles); err != nil {
+		return nil, err
+	}
+	return &roles[0], nil
+}
+
+func (r *SQLRepository) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	var roles []domain.Role
+	query := `SELECT id, name, description, created_at, updated_at FROM auth_roles ORDER BY name`
+
+	q := r.reader(ctx)
+	if err := sqlx.SelectContext(ctx, q, &roles, query); err != nil {
+		return nil, err
+	}
+	if err := r.loadPermissions(ctx, q, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *SQLRepository) DeleteRole(ctx context.Context, id string) error {
+	defer r.router.MarkWrite(ctx)
+	// role_permissions and user_roles rows cascade
+	_, err := r.conn(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_roles WHERE id = ?`), id)
+	return err
+}
+
+func (r *SQLRepository) SetRolePermissions(ctx context.Context, roleID string, permissions []string) error {
+	defer r.router.MarkWrite(ctx)
+	q := r.conn(ctx)
+	if _, err := q.ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_role_permissions WHERE role_id = ?`), roleID); err != nil {
+		return err
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(`INSERT INTO auth_role_permissions (role_id, permission_id)
+		SELECT ?, id FROM auth_permissions WHERE name IN (?)`, roleID, permissions)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx, r.db.Rebind(query), args...)
+	return err
+}
+
+func (r *SQLRepository) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	query := `SELECT id, name, description, created_at FROM auth_permissions ORDER BY name`
+
+	if err := sqlx.SelectContext(ctx, r.reader(ctx), &permissions, query); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// loadPermissions fills in the permission names of each role in place, reading
+// from q so roles and their permissions come from the same database
+func (r *SQLRepository) loadPermissions(ctx context.Context, q sqlx.QueryerContext, roles []domain.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(roles))
+	index := make(map[string]int, len(roles))
+	for i := range roles {
+		ids[i] = roles[i].ID
+		index[roles[i].ID] = i
+		roles[i].Permissions = []string{}
+	}
+
+	var rows []struct {
+		RoleID string `db:"role_id"`
+		Name   string `db:"name"`
+	}
+	query, args, err := sqlx.In(`SELECT rp.role_id, p.name FROM auth_role_permissions rp
+		JOIN auth_permissions p ON p.id = rp.permission_id
+		WHERE rp.role_id IN (?) ORDER BY p.name`, ids)
+	if err != nil {
+		return err
+	}
+	if err := sqlx.SelectContext(ctx, q, &rows, r.db.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		i := index[row.RoleID]
+		roles[i].Permissions = append(roles[i].Permissions, row.Name)
+	}
+	return nil
+}
+
+// User-role assignment operations
+
+func (r *SQLRepository) AssignRole(ctx context.Context, userRole *domain.UserRole) error {
+	query := r.dialect.Upsert(`INSERT INTO auth_user_roles (user_id, role_id, assigned_by, assigned_at)
+		VALUES (:user_id, :role_id, :assigned_by, :assigned_at)`, []string{"user_id", "role_id"})
+
+	userRole.AssignedAt = time.Now().UTC()
+
+	_, err := sqlx.NamedExecContext(ctx, r.conn(ctx), query, userRole)
+	return err
+}
+
+func (r *SQLRepository) RevokeRole(ctx context.Context, userID, roleID string) error {
+	query := r.db.Rebind(`DELETE FROM auth_user_roles WHERE user_id = ? AND role_id = ?`)
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, userID, roleID)
+	return err
+}
+
+func (r *SQLRepository) GetUserRoles(ctx context.Context, userID string) ([]domain.Role, error) {
+	var roles []domain.Role
+	query := r.db.Rebind(`SELECT r.id, r.name, r.description, r.created_at, r.updated_at FROM auth_roles r
+		JOIN auth_user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = ? ORDER BY r.name`)
+
+	q := r.conn(ctx)
+	if err := sqlx.SelectContext(ctx, q, &roles, query, userID); err != nil {
+		return nil, err
+	}
+	if err := r.loadPermissions(ctx, q, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// Password reset token operations
+
+func (r *SQLRepository) CreatePasswordResetToken(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `INSERT INTO auth_password_reset_tokens
+		(id, user_id, token_hash, expires_at, created_at)
+		VALUES (:id, :user_id, :token_hash, :expires_at, :created_at)`
+
+	if token.ID == "" {
+		token.ID = uuid.NewString()
+	}
+	token.CreatedAt = time.Now().UTC()
+
+	_, err := sqlx.NamedExecContext(ctx, r.conn(ctx), query, token)
+	return err
+}
+
+func (r *SQLRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	query := r.db.Rebind(`UPDATE auth_password_reset_tokens SET used_at = ?
+		WHERE token_hash = ? AND used_at IS NULL AND expires_at > ?`)
+
+	// Token hashes are unique, so the row read back is the one just consumed
+	q := r.conn(ctx)
+	now := time.Now().UTC()
+	if err := updatedOne(q.ExecContext(ctx, query, now, tokenHash, now)); err != nil {
+		return nil, err
+	}
+	query = r.db.Rebind(`SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM auth_password_reset_tokens WHERE token_hash = ?`)
+	if err := sqlx.GetContext(ctx, q, &token, query, tokenHash); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *SQLRepository) InvalidatePasswordResetTokens(ctx context.Context, userID string) error {
+	query := r.db.Rebind(`UPDATE auth_password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL`)
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, time.Now().UTC(), userID)
+	return err
+}
+
+// MFA operations
+
+func (r *SQLRepository) SaveMFAEnrollment(ctx context.Context, enrollment *domain.MFAEnrollment) error {
+	query := r.dialect.Upsert(`INSERT INTO auth_mfa_enrollments (user_id, secret, last_used_step, enabled_at, created_at)
+		VALUES (:user_id, :secret, :last_used_step, :enabled_at, :created_at)`,
+		[]string{"user_id"}, "secret", "last_used_step", "enabled_at", "updated_at=created_at")
+
+	enrollment.CreatedAt = time.Now().UTC()
+
+	_, err := sqlx.NamedExecContext(ctx, r.conn(ctx), query, enrollment)
+	return err
+}
+
+func (r *SQLRepository) GetMFAEnrollment(ctx context.Context, userID string) (*domain.MFAEnrollment, error) {
+	var enrollment domain.MFAEnrollment
+	query := r.db.Rebind(`SELECT user_id, secret, last_used_step, enabled_at, created_at, updated_at
+		FROM auth_mfa_enrollments WHERE user_id = ?`)
+
+	if err := sqlx.GetContext(ctx, r.conn(ctx), &enrollment, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *SQLRepository) EnableMFA(ctx context.Context, userID string) error {
+	query := r.db.Rebind(`UPDATE auth_mfa_enrollments SET enabled_at = ?, updated_at = ? WHERE user_id = ?`)
+
+	now := time.Now().UTC()
+	_, err := r.conn(ctx).ExecContext(ctx, query, now, now, userID)
+	return err
+}
+
+func (r *SQLRepository) DeleteMFAEnrollment(ctx context.Context, userID string) error {
+	q := r.conn(ctx)
+	if _, err := q.ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_mfa_recovery_codes WHERE user_id = ?`), userID); err != nil {
+		return err
+	}
+	_, err := q.ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_mfa_enrollments WHERE user_id = ?`), userID)
+	return err
+}
+
+func (r *SQLRepository) UseMFAStep(ctx context.Context, userID string, step int64) error {
+	query := r.db.Rebind(`UPDATE auth_mfa_enrollments SET last_used_step = ?, updated_at = ?
+		WHERE user_id = ? AND last_used_step < ?`)
+
+	return updatedOne(r.conn(ctx).ExecContext(ctx, query, step, time.Now().UTC(), userID, step))
+}
+
+func (r *SQLRepository) ReplaceMFARecoveryCodes(ctx context.Context, userID string, codes []domain.MFARecoveryCode) error {
+	q := r.conn(ctx)
+	if _, err := q.ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_mfa_recovery_codes WHERE user_id = ?`), userID); err != nil {
 		return err
 	}
-	_, err := r.db.Exec(query)
+	if len(codes) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for i := range codes {
+		if codes[i].ID == "" {
+			codes[i].ID = uuid.NewString()
+		}
+		codes[i].UserID = userID
+		codes[i].CreatedAt = now
+	}
+
+	query := `INSERT INTO auth_mfa_recovery_codes (id, user_id, code_hash, created_at)
+		VALUES (:id, :user_id, :code_hash, :created_at)`
+	_, err := sqlx.NamedExecContext(ctx, q, query, codes)
 	return err
 }
+
+func (r *SQLRepository) ConsumeMFARecoveryCode(ctx context.Context, userID, codeHash string) error {
+	query := r.db.Rebind(`UPDATE auth_mfa_recovery_codes SET used_at = ?
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`)
+
+	return updatedOne(r.conn(ctx).ExecContext(ctx, query, time.Now().UTC(), userID, codeHash))
+}
+
+// External identity operations
+
+func (r *SQLRepository) CreateIdentity(ctx context.Context, identity *domain.Identity) error {
+	query := `INSERT INTO auth_identities (id, user_id, provider, subject, email, created_at)
+		VALUES (:id, :user_id, :provider, :subject, :email, :created_at)`
+
+	if identity.ID == "" {
+		identity.ID = uuid.NewString()
+	}
+	identity.CreatedAt = time.Now().UTC()
+
+	_, err := sqlx.NamedExecContext(ctx, r.conn(ctx), query, identity)
+	return err
+}
+
+func (r *SQLRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.Identity, error) {
+	var identity domain.Identity
+	query := r.db.Rebind(`SELECT id, user_id, provider, subject, email, created_at
+		FROM auth_identities WHERE provider = ? AND subject = ?`)
+
+	if err := sqlx.GetContext(ctx, r.conn(ctx), &identity, query, provider, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *SQLRepository) GetIdentitiesByUserID(ctx context.Context, userID string) ([]domain.Identity, error) {
+	var identities []domain.Identity
+	query := r.db.Rebind(`SELECT id, user_id, provider, subject, email, created_at
+		FROM auth_identities WHERE user_id = ? ORDER BY created_at`)
+
+	if err := sqlx.SelectContext(ctx, r.conn(ctx), &identities, query, userID); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (r *SQLRepository) DeleteIdentity(ctx context.Context, id string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_identities WHERE id = ?`), id)
+	return err
+}
+
+// Machine client operations
+
+// clientRow maps the scopes list column, which sqlx cannot scan into
+// []string, and the nullable created_by
+type clientRow struct {
+	domain.Client
+	Scopes    sqldialect.StringArray `db:"scopes"`
+	CreatedBy sql.NullString         `db:"created_by"`
+}
+
+func (row clientRow) client() domain.Client {
+	row.Client.Scopes = row.Scopes
+	row.Client.CreatedBy = row.CreatedBy.String
+	return row.Client
+}
+
+const clientColumns = `id, name, secret_hash, scopes, created_by, created_at, updated_at`
+
+func (r *SQLRepository) CreateClient(ctx context.Context, client *domain.Client) error {
+	defer r.router.MarkWrite(ctx)
+	query := r.db.Rebind(`INSERT INTO auth_clients (id, name, secret_hash, scopes, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`)
+
+	if client.ID == "" {
+		client.ID = uuid.NewString()
+	}
+	client.CreatedAt = time.Now().UTC()
+	createdBy := sql.NullString{String: client.CreatedBy, Valid: client.CreatedBy != ""}
+
+	_, err := r.conn(ctx).ExecContext(ctx, query,
+		client.ID, client.Name, client.SecretHash, r.dialect.Array(client.Scopes), createdBy, client.CreatedAt)
+	return err
+}
+
+func (r *SQLRepository) GetClientByID(ctx context.Context, id string) (*domain.Client, error) {
+	var row clientRow
+	query := `SELECT ` + clientColumns + r.db.Rebind(` FROM auth_clients WHERE id = ?`)
+
+	if err := sqlx.GetContext(ctx, r.conn(ctx), &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	client := row.client()
+	return &client, nil
+}
+
+func (r *SQLRepository) ListClients(ctx context.Context) ([]domain.Client, error) {
+	var rows []clientRow
+	query := `SELECT ` + clientColumns + ` FROM auth_clients ORDER BY created_at`
+
+	if err := sqlx.SelectContext(ctx, r.reader(ctx), &rows, query); err != nil {
+		return nil, err
+	}
+
+	clients := make([]domain.Client, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, row.client())
+	}
+	return clients, nil
+}
+
+func (r *SQLRepository) UpdateClientSecret(ctx context.Context, id, secretHash string) error {
+	defer r.router.MarkWrite(ctx)
+	query := r.db.Rebind(`UPDATE auth_clients SET secret_hash = ?, updated_at = ? WHERE id = ?`)
+	_, err := r.conn(ctx).ExecContext(ctx, query, secretHash, time.Now().UTC(), id)
+	return err
+}
+
+func (r *SQLRepository) DeleteClient(ctx context.Context, id string) error {
+	defer r.router.MarkWrite(ctx)
+	_, err := r.conn(ctx).ExecContext(ctx, r.db.Rebind(`DELETE FROM auth_clients WHERE id = ?`), id)
+	return err
+}
+
+// updatedOne turns the result of a conditional update into sql.ErrNoRows when
+// no row matched
+func updatedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
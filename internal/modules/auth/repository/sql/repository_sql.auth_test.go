@@ -0,0 +1,136 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect/sqltest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteRepository returns a repository over a migrated SQLite database
+// and the ID of a user to attach auth records to
+func newSQLiteRepository(t *testing.T) (*SQLRepository, string) {
+	t.Helper()
+	db := sqltest.OpenSQLite(t)
+	userID := uuid.NewString()
+	_, err := db.Exec(`INSERT INTO users (id, name, email) VALUES (?, ?, ?)`, userID, "Ada", "ada@example.com")
+	require.NoError(t, err)
+	return NewSQLRepository(db), userID
+}
+
+func TestSQLRepository_SQLiteSessions(t *testing.T) {
+	r, userID := newSQLiteRepository(t)
+	ctx := context.Background()
+
+	live := &domain.Session{UserID: userID, Token: "live", ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	require.NoError(t, r.CreateSession(ctx, live))
+	expired := &domain.Session{UserID: userID, Token: "expired", ExpiresAt: time.Now().UTC().Add(-time.Hour)}
+	require.NoError(t, r.CreateSession(ctx, expired))
+
+	got, err := r.GetSessionByToken(ctx, "live")
+	require.NoError(t, err)
+	assert.Equal(t, live.ID, got.FamilyID, "a new session starts its own family")
+	_, err = r.GetSessionByToken(ctx, "expired")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	sessions, err := r.GetSessionsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1)
+
+	require.NoError(t, r.ConsumeSession(ctx, live.ID))
+	assert.ErrorIs(t, r.ConsumeSession(ctx, live.ID), sql.ErrNoRows, "a session is consumed once")
+	_, err = r.GetConsumedSessionByToken(ctx, "live")
+	require.NoError(t, err)
+
+	require.NoError(t, r.DeleteExpiredSessions(ctx))
+	_, err = r.GetSessionByID(ctx, expired.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSQLRepository_SQLiteRoles(t *testing.T) {
+	r, userID := newSQLiteRepository(t)
+	ctx := context.Background()
+
+	role := &domain.Role{Name: "auditor", Permissions: []string{"user:read", "product:read", "unknown:perm"}}
+	require.NoError(t, r.CreateRole(ctx, role))
+
+	got, err := r.GetRoleByName(ctx, "auditor")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"product:read", "user:read"}, got.Permissions)
+
+	assign := &domain.UserRole{UserID: userID, RoleID: role.ID, AssignedBy: "admin"}
+	require.NoError(t, r.AssignRole(ctx, assign))
+	require.NoError(t, r.AssignRole(ctx, assign), "assigning twice is a no-op")
+
+	roles, err := r.GetUserRoles(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "auditor", roles[0].Name)
+
+	roles, err = r.ListRoles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, roles, 3)
+}
+
+func TestSQLRepository_SQLitePasswordResetToken(t *testing.T) {
+	r, userID := newSQLiteRepository(t)
+	ctx := context.Background()
+
+	token := &domain.PasswordResetToken{UserID: userID, TokenHash: "hash", ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	require.NoError(t, r.CreatePasswordResetToken(ctx, token))
+
+	used, err := r.ConsumePasswordResetToken(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, used.ID)
+	assert.NotNil(t, used.UsedAt)
+
+	_, err = r.ConsumePasswordResetToken(ctx, "hash")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSQLRepository_SQLiteMFA(t *testing.T) {
+	r, userID := newSQLiteRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, r.SaveMFAEnrollment(ctx, &domain.MFAEnrollment{UserID: userID, Secret: "first"}))
+	require.NoError(t, r.SaveMFAEnrollment(ctx, &domain.MFAEnrollment{UserID: userID, Secret: "second"}))
+
+	enrollment, err := r.GetMFAEnrollment(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "second", enrollment.Secret, "saving again replaces the enrollment")
+	assert.NotNil(t, enrollment.UpdatedAt)
+
+	require.NoError(t, r.UseMFAStep(ctx, userID, 10))
+	assert.ErrorIs(t, r.UseMFAStep(ctx, userID, 10), sql.ErrNoRows, "a step is accepted once")
+
+	require.NoError(t, r.ReplaceMFARecoveryCodes(ctx, userID, []domain.MFARecoveryCode{{CodeHash: "a"}, {CodeHash: "b"}}))
+	require.NoError(t, r.ConsumeMFARecoveryCode(ctx, userID, "a"))
+	assert.ErrorIs(t, r.ConsumeMFARecoveryCode(ctx, userID, "a"), sql.ErrNoRows)
+}
+
+func TestSQLRepository_SQLiteClients(t *testing.T) {
+	r, userID := newSQLiteRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, r.CreateClient(ctx, &domain.Client{Name: "billing", SecretHash: "x", Scopes: []string{"product:read"}, CreatedBy: userID}))
+	anonymous := &domain.Client{Name: "cron", SecretHash: "y"}
+	require.NoError(t, r.CreateClient(ctx, anonymous))
+
+	got, err := r.GetClientByID(ctx, anonymous.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.CreatedBy)
+	assert.Empty(t, got.Scopes)
+
+	clients, err := r.ListClients(ctx)
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Equal(t, []string{"product:read"}, clients[0].Scopes)
+	assert.Equal(t, userID, clients[0].CreatedBy)
+}
@@ -2,12 +2,12 @@ package noop
 
 import (
 	"context"
-	"errors"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
-var ErrNotImplemented = errors.New("auth service not implemented")
+var ErrNotImplemented = sharederrors.ErrNotImplemented.WithMessage("auth service not implemented")
 
 type NoopService struct{}
 
@@ -35,6 +35,10 @@ func (s *NoopService) ValidateToken(ctx context.Context, token string) (*domain.
 	return nil, ErrNotImplemented
 }
 
+func (s *NoopService) ValidateSession(ctx context.Context, token string) (*domain.ValidateTokenResponse, error) {
+	return nil, ErrNotImplemented
+}
+
 func (s *NoopService) ChangePassword(ctx context.Context, userID string, req *domain.ChangePasswordRequest) error {
 	return ErrNotImplemented
 }
@@ -47,6 +51,30 @@ func (s *NoopService) ConfirmResetPassword(ctx context.Context, req *domain.Conf
 	return ErrNotImplemented
 }
 
+func (s *NoopService) VerifyEmail(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) ResendVerification(ctx context.Context, req *domain.ResendVerificationRequest) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) EnrollMFA(ctx context.Context, userID string) (*domain.MFAEnrollResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) EnableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) (*domain.MFARecoveryCodesResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) DisableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) VerifyMFA(ctx context.Context, req *domain.VerifyMFARequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	return nil, ErrNotImplemented
+}
+
 func (s *NoopService) GetSessions(ctx context.Context, userID string) (*domain.SessionListResponse, error) {
 	return nil, ErrNotImplemented
 }
@@ -59,6 +87,38 @@ func (s *NoopService) RevokeAllSessions(ctx context.Context, userID string) erro
 	return ErrNotImplemented
 }
 
+func (s *NoopService) ListRoles(ctx context.Context) (*domain.RoleListResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) CreateRole(ctx context.Context, req *domain.CreateRoleRequest) (*domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) DeleteRole(ctx context.Context, roleID string) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) SetRolePermissions(ctx context.Context, roleID string, req *domain.SetRolePermissionsRequest) (*domain.Role, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) ListPermissions(ctx context.Context) (*domain.PermissionListResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) GetUserRoles(ctx context.Context, userID string) (*domain.UserRolesResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) AssignRole(ctx context.Context, assignedBy, userID string, req *domain.AssignRoleRequest) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) RevokeRole(ctx context.Context, userID, roleID string) error {
+	return ErrNotImplemented
+}
+
 func (s *NoopService) GenerateAccessToken(claims *domain.TokenClaims) (string, error) {
 	return "", ErrNotImplemented
 }
@@ -78,3 +138,51 @@ func (s *NoopService) HashPassword(password string) (string, error) {
 func (s *NoopService) VerifyPassword(hashedPassword, password string) error {
 	return ErrNotImplemented
 }
+
+func (s *NoopService) JWKS(ctx context.Context) (*domain.JWKSResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) StartOIDCLogin(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) StartOIDCLink(ctx context.Context, userID, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) CompleteOIDCLogin(ctx context.Context, req *domain.OIDCCallbackRequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) ListIdentities(ctx context.Context, userID string) (*domain.IdentityListResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) ListClients(ctx context.Context) (*domain.ClientListResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) RegisterClient(ctx context.Context, createdBy string, req *domain.RegisterClientRequest) (*domain.ClientSecretResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) DeleteClient(ctx context.Context, clientID string) error {
+	return ErrNotImplemented
+}
+
+func (s *NoopService) RotateClientSecret(ctx context.Context, clientID string) (*domain.ClientSecretResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) IssueClientToken(ctx context.Context, req *domain.ClientTokenRequest) (*domain.ClientTokenResponse, error) {
+	return nil, ErrNotImplemented
+}
+
+func (s *NoopService) IntrospectToken(ctx context.Context, req *domain.IntrospectTokenRequest) (*domain.IntrospectionResponse, error) {
+	return nil, ErrNotImplemented
+}
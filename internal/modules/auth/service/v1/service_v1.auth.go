@@ -2,60 +2,175 @@ package v1
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid username or password")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserNotActive      = errors.New("user account is not active")
-	ErrInvalidToken       = errors.New("invalid or expired token")
-	ErrSessionNotFound    = errors.New("session not found")
-	ErrPasswordMismatch   = errors.New("current password is incorrect")
-	ErrUsernameExists     = errors.New("username already exists")
-	ErrEmailExists        = errors.New("email already exists")
+	ErrInvalidCredentials = sharederrors.ErrInvalidCredentials.WithMessage("invalid username or password")
+	ErrUserNotFound       = sharederrors.ErrNotFound.WithMessage("user not found")
+	ErrUserNotActive      = sharederrors.ErrForbidden.WithMessage("user account is not active")
+	ErrInvalidToken       = sharederrors.ErrInvalidToken.WithMessage("invalid or expired token")
+	ErrSessionNotFound    = sharederrors.ErrNotFound.WithMessage("session not found")
+	ErrPasswordMismatch   = sharederrors.ErrInvalidCredentials.WithMessage("current password is incorrect")
+	ErrUsernameExists     = sharederrors.ErrAlreadyExists.WithMessage("username already exists")
+	ErrEmailExists        = sharederrors.ErrAlreadyExists.WithMessage("email already exists")
+	ErrInvalidResetToken  = sharederrors.ErrInvalidToken.WithMessage("invalid or expired password reset token")
+	ErrEmailNotVerified   = sharederrors.ErrForbidden.WithMessage("email address is not verified")
+	ErrRefreshTokenReused = sharederrors.ErrInvalidToken.WithMessage("refresh token reuse detected, please log in again")
+
+	ErrInvalidVerificationToken = sharederrors.ErrInvalidToken.WithMessage("invalid or expired verification token")
+
+	ErrRoleNotFound      = sharederrors.ErrNotFound.WithMessage("role not found")
+	ErrRoleExists        = sharederrors.ErrAlreadyExists.WithMessage("role already exists")
+	ErrRoleProtected     = sharederrors.ErrForbidden.WithMessage("built-in admin role cannot be deleted")
+	ErrUnknownPermission = sharederrors.ErrInvalidInput.WithMessage("unknown permission")
+	ErrAssigneeNotFound  = sharederrors.ErrNotFound.WithMessage("user not found")
+
+	ErrVerificationRateLimited = sharederrors.ErrRateLimited.WithMessage("verification email was sent recently, try again later")
+
+	ErrMFAAlreadyEnabled   = sharederrors.ErrConflict.WithMessage("MFA is already enabled")
+	ErrMFANotEnrolled      = sharederrors.ErrBusinessRule.WithMessage("MFA enrollment has not been started")
+	ErrMFANotEnabled       = sharederrors.ErrBusinessRule.WithMessage("MFA is not enabled")
+	ErrInvalidMFACode      = sharederrors.ErrInvalidCredentials.WithMessage("invalid MFA code")
+	ErrInvalidMFAChallenge = sharederrors.ErrInvalidToken.WithMessage("invalid or expired MFA challenge")
+	ErrMFAAttemptsExceeded = sharederrors.ErrRateLimited.WithMessage("too many MFA attempts, try again later")
+	ErrMFAUnavailable      = sharederrors.ErrExternalService.WithMessage("MFA attempts cannot be counted, try again later")
+
+	ErrUnknownIdentityProvider = sharederrors.ErrNotFound.WithMessage("unknown identity provider")
+	ErrInvalidOIDCState        = sharederrors.ErrInvalidToken.WithMessage("invalid or expired login state")
+	ErrOIDCLoginFailed         = sharederrors.ErrInvalidCredentials.WithMessage("identity provider login failed")
+	ErrOIDCEmailNotVerified    = sharederrors.ErrBusinessRule.WithMessage("identity provider did not return a verified email address")
+	ErrOIDCEmailInUse          = sharederrors.ErrConflict.WithMessage("an account with this email already exists, sign in and link the provider instead")
+	ErrIdentityLinked          = sharederrors.ErrConflict.WithMessage("this provider account is linked to another user")
+	ErrProviderAlreadyLinked   = sharederrors.ErrConflict.WithMessage("an account of this provider is already linked")
+	ErrIdentityNotLinked       = sharederrors.ErrNotFound.WithMessage("provider is not linked")
+	ErrOIDCLinkUserMismatch    = sharederrors.ErrForbidden.WithMessage("sign in as the account that started linking to complete it")
+
+	ErrClientNotFound = sharederrors.ErrNotFound.WithMessage("client not found")
+	ErrInvalidClient  = sharederrors.ErrInvalidCredentials.WithMessage("invalid client credentials")
+	ErrInvalidScope   = sharederrors.ErrInvalidInput.WithMessage("requested scope is not allowed for this client")
+)
+
+const (
+	verificationResendKeyPrefix = "auth:verification_resend:"
+	mfaAttemptsKeyPrefix        = "auth:mfa_attempts:"
+	oidcStateKeyPrefix          = "auth:oidc_state:"
+
+	// maxMFAAttempts bounds code guesses per user within a challenge lifetime
+	maxMFAAttempts = 5
+)
+
+// Token purposes; each signs with its own key derived from the JWT secret
+const (
+	purposeEmailVerification = "email_verification"
+	purposeMFAChallenge      = "mfa_challenge"
 )
 
 type AuthConfig struct {
-	JWTSecret            string
-	AccessTokenDuration  time.Duration
-	RefreshTokenDuration time.Duration
-	SessionDuration      time.Duration
-	BcryptCost           int
+	JWTSecret                  string // signs access tokens with HS256 when SigningKeys is empty, and always signs internal single-purpose tokens
+	AccessTokenDuration        time.Duration
+	RefreshTokenDuration       time.Duration
+	SessionDuration            time.Duration
+	PasswordResetTokenDuration time.Duration
+	PasswordResetURL           string // reset token is appended as the "token" query parameter
+	BcryptCost                 int
+	DefaultRole                string // assigned on registration; empty disables
+
+	RequireEmailVerification       bool // new accounts must verify their email before Login succeeds
+	EmailVerificationTokenDuration time.Duration
+	EmailVerificationURL           string        // verification token is appended as the "token" query parameter
+	VerificationResendInterval     time.Duration // minimum gap between verification emails to one address
+
+	MFAIssuer            string // shown by authenticator apps next to the account
+	MFAChallengeDuration time.Duration
+
+	IdentityProviders map[string]domain.IdentityProvider // keyed by the provider name used in routes
+	OIDCStateDuration time.Duration                      // how long a started provider login stays valid
+
+	SigningKeys            []SigningKey  // asymmetric access token keys; rotation follows their ActiveFrom
+	KeyRotationGracePeriod time.Duration // how long a replaced key keeps verifying; never shorter than AccessTokenDuration
 }
 
+// DefaultJWTSecret is the placeholder secret of DefaultAuthConfig. It is
+// public, so anyone could forge MFA challenges and verification links signed
+// with it; the application refuses to start until a real secret is configured.
+const DefaultJWTSecret = "supersecretkey"
+
 func DefaultAuthConfig() AuthConfig {
 	return AuthConfig{
-		JWTSecret:            "supersecretkey",
-		AccessTokenDuration:  15 * time.Minute,
-		RefreshTokenDuration: 7 * 24 * time.Hour,
-		SessionDuration:      24 * time.Hour,
-		BcryptCost:           bcrypt.DefaultCost,
+		JWTSecret:                  DefaultJWTSecret,
+		AccessTokenDuration:        15 * time.Minute,
+		RefreshTokenDuration:       7 * 24 * time.Hour,
+		SessionDuration:            24 * time.Hour,
+		PasswordResetTokenDuration: time.Hour,
+		PasswordResetURL:           "http://localhost:8080/reset-password",
+		BcryptCost:                 bcrypt.DefaultCost,
+		DefaultRole:                domain.RoleUser,
+
+		EmailVerificationTokenDuration: 24 * time.Hour,
+		EmailVerificationURL:           "http://localhost:8080/verify-email",
+		VerificationResendInterval:     time.Minute,
+
+		MFAIssuer:            "go-pste-monolith",
+		MFAChallengeDuration: 5 * time.Minute,
+
+		OIDCStateDuration: 10 * time.Minute,
 	}
 }
 
 type ServiceV1 struct {
-	repo        domain.Repository
-	userCreator domain.UserCreator // ACL interface instead of direct user repo
-	config      AuthConfig
+	repo          domain.Repository
+	uow           uow.UnitOfWork
+	userCreator   domain.UserCreator           // ACL interface instead of direct user repo
+	resetNotifier domain.PasswordResetNotifier // ACL interface for reset email delivery
+	eventBus      events.EventBus
+	emailService  email.EmailService
+	cache         cache.Cache
+	config        AuthConfig
+	keys          *keyRing // nil when access tokens use HS256
 }
 
-func NewServiceV1(repo domain.Repository, userCreator domain.UserCreator, config AuthConfig) *ServiceV1 {
-	return &ServiceV1{
-		repo:        repo,
-		userCreator: userCreator,
-		config:      config,
+func NewServiceV1(repo domain.Repository, u uow.UnitOfWork, userCreator domain.UserCreator, resetNotifier domain.PasswordResetNotifier, eb events.EventBus, es email.EmailService, c cache.Cache, config AuthConfig) *ServiceV1 {
+	s := &ServiceV1{
+		repo:          repo,
+		uow:           u,
+		userCreator:   userCreator,
+		resetNotifier: resetNotifier,
+		eventBus:      eb,
+		emailService:  es,
+		cache:         c,
+		config:        config,
 	}
+	if len(config.SigningKeys) > 0 {
+		// Tokens signed just before a rotation must stay verifiable until they expire
+		grace := max(config.KeyRotationGracePeriod, config.AccessTokenDuration)
+		s.keys = newKeyRing(config.SigningKeys, grace)
+	}
+	return s
 }
 
 func (s *ServiceV1) Login(ctx context.Context, req *domain.LoginRequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
@@ -75,14 +190,36 @@ func (s *ServiceV1) Login(ctx context.Context, req *domain.LoginRequest, userAge
 		return nil, ErrInvalidCredentials
 	}
 
-	_ = s.repo.UpdateLastLogin(ctx, cred.UserID)
+	if s.config.RequireEmailVerification && cred.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+
+	return s.finishLogin(ctx, cred, userAgent, ipAddress)
+}
+
+// finishLogin continues a login whose first factor succeeded: it answers with
+// an MFA challenge when the account has MFA enabled, otherwise with tokens
+func (s *ServiceV1) finishLogin(ctx context.Context, cred *domain.Credential, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	enrollment, err := s.repo.GetMFAEnrollment(ctx, cred.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment != nil && enrollment.EnabledAt != nil {
+		return s.mfaChallenge(cred)
+	}
 
-	claims := &domain.TokenClaims{
-		UserID:   cred.UserID,
-		Username: cred.Username,
-		Email:    cred.Email,
+	return s.completeLogin(ctx, cred, userAgent, ipAddress)
+}
+
+// completeLogin issues the access token and refresh session for an authenticated credential
+func (s *ServiceV1) completeLogin(ctx context.Context, cred *domain.Credential, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	claims, err := s.buildClaims(ctx, cred)
+	if err != nil {
+		return nil, err
 	}
 
+	_ = s.repo.UpdateLastLogin(ctx, cred.UserID)
+
 	accessToken, err := s.GenerateAccessToken(claims)
 	if err != nil {
 		return nil, err
@@ -114,9 +251,11 @@ func (s *ServiceV1) Login(ctx context.Context, req *domain.LoginRequest, userAge
 		ExpiresIn:    int64(s.config.AccessTokenDuration.Seconds()),
 		ExpiresAt:    expiresAt,
 		User: &domain.UserInfo{
-			ID:       cred.UserID,
-			Username: cred.Username,
-			Email:    cred.Email,
+			ID:          cred.UserID,
+			Username:    cred.Username,
+			Email:       cred.Email,
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
 		},
 	}, nil
 }
@@ -145,50 +284,83 @@ func (s *ServiceV1) Register(ctx context.Context, req *domain.RegisterRequest) (
 		CreatedBy: userID,
 	}
 
-	ctx = s.repo.StartContext(ctx)
-	defer s.repo.DeferErrorContext(ctx, err)
 	defer func() {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
 
-	if err = s.userCreator.CreateUser(ctx, newUser); err != nil {
-		return nil, err
-	}
+	var cred *domain.Credential
+	var roles []string
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userCreator.CreateUser(ctx, newUser); err != nil {
+			return err
+		}
 
-	cred := &domain.Credential{
-		ID:           uuid.NewString(),
-		UserID:       userID,
-		Username:     req.Username,
-		Email:        req.Email,
-		PasswordHash: hashedPassword,
-		IsActive:     true,
-	}
+		cred = &domain.Credential{
+			ID:           uuid.NewString(),
+			UserID:       userID,
+			Username:     req.Username,
+			Email:        req.Email,
+			PasswordHash: hashedPassword,
+			IsActive:     true,
+		}
+		if err := s.repo.CreateCredential(ctx, cred); err != nil {
+			return err
+		}
 
-	if err = s.repo.CreateCredential(ctx, cred); err != nil {
+		var err error
+		roles, err = s.assignDefaultRole(ctx, userID)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// Sent after the commit, so a rolled back registration sends no link and
+	// a slow mail server does not hold the transaction open
+	message := "Registration successful"
+	if s.config.RequireEmailVerification {
+		// A failed send is not fatal; the user can ask for a new link via ResendVerification
+		_ = s.sendVerification(ctx, cred)
+		message = "Registration successful, check your email to verify your account"
+	}
+
 	resp = &domain.RegisterResponse{
 		User: &domain.UserInfo{
 			ID:       userID,
 			Username: req.Username,
 			Email:    req.Email,
 			Name:     req.Name,
+			Roles:    roles,
 		},
-		Message: "Registration successful",
+		Message: message,
 	}
 	return
 }
 
+// assignDefaultRole gives a new user the configured default role and returns the assigned role names
+func (s *ServiceV1) assignDefaultRole(ctx context.Context, userID string) ([]string, error) {
+	if s.config.DefaultRole == "" {
+		return nil, nil
+	}
+	role, err := s.repo.GetRoleByName(ctx, s.config.DefaultRole)
+	if err != nil {
+		return nil, sharederrors.ErrInternal.WithError(fmt.Errorf("default role %q: %w", s.config.DefaultRole, err))
+	}
+	if err := s.repo.AssignRole(ctx, &domain.UserRole{UserID: userID, RoleID: role.ID, AssignedBy: userID}); err != nil {
+		return nil, err
+	}
+	return []string{role.Name}, nil
+}
+
 func (s *ServiceV1) Logout(ctx context.Context, userID string, req *domain.LogoutRequest) error {
 	if req.AllDevices {
 		return s.repo.RevokeAllUserSessions(ctx, userID)
@@ -205,10 +377,12 @@ func (s *ServiceV1) Logout(ctx context.Context, userID string, req *domain.Logou
 	return nil
 }
 
+// RefreshToken issues a new access token and rotates the refresh token.
+// Presenting a refresh token that was already rotated revokes its whole family.
 func (s *ServiceV1) RefreshToken(ctx context.Context, refreshToken string) (*domain.RefreshTokenResponse, error) {
 	session, err := s.repo.GetSessionByToken(ctx, refreshToken)
 	if err != nil {
-		return nil, ErrInvalidToken
+		return nil, s.detectTokenReuse(ctx, refreshToken)
 	}
 
 	cred, err := s.repo.GetCredentialByUserID(ctx, session.UserID)
@@ -220,10 +394,10 @@ func (s *ServiceV1) RefreshToken(ctx context.Context, refreshToken string) (*dom
 		return nil, ErrUserNotActive
 	}
 
-	claims := &domain.TokenClaims{
-		UserID:   cred.UserID,
-		Username: cred.Username,
-		Email:    cred.Email,
+	// Roles are re-read so that assignment changes apply on the next refresh
+	claims, err := s.buildClaims(ctx, cred)
+	if err != nil {
+		return nil, err
 	}
 
 	accessToken, err := s.GenerateAccessToken(claims)
@@ -231,18 +405,91 @@ func (s *ServiceV1) RefreshToken(ctx context.Context, refreshToken string) (*dom
 		return nil, err
 	}
 
+	next, err := s.rotateSession(ctx, session)
+	if err != nil {
+		// A concurrent refresh may have rotated the same token first
+		if reuseErr := s.detectTokenReuse(ctx, refreshToken); reuseErr == ErrRefreshTokenReused {
+			return nil, reuseErr
+		}
+		return nil, err
+	}
+
 	expiresAt := time.Now().UTC().Add(s.config.AccessTokenDuration)
 	return &domain.RefreshTokenResponse{
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: next.Token,
 		TokenType:    "Bearer",
 		ExpiresIn:    int64(s.config.AccessTokenDuration.Seconds()),
 		ExpiresAt:    expiresAt,
 	}, nil
 }
 
+// rotateSession consumes session and stores its successor in the same family.
+// The successor keeps the family's expiry, so rotation does not extend a login.
+func (s *ServiceV1) rotateSession(ctx context.Context, session *domain.Session) (next *domain.Session, err error) {
+	token, err := s.GenerateRefreshToken(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	familyID := session.FamilyID
+	if familyID == "" {
+		familyID = session.ID
+	}
+
+	next = &domain.Session{
+		UserID:    session.UserID,
+		FamilyID:  familyID,
+		Token:     token,
+		ExpiresAt: session.ExpiresAt,
+		UserAgent: session.UserAgent,
+		IPAddress: session.IPAddress,
+	}
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.ConsumeSession(ctx, session.ID); err != nil {
+			return err
+		}
+		next.ID = uuid.NewString()
+		return s.repo.CreateSession(ctx, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// detectTokenReuse revokes the token family of an already rotated refresh token.
+// It returns ErrRefreshTokenReused on reuse and ErrInvalidToken for unknown tokens.
+func (s *ServiceV1) detectTokenReuse(ctx context.Context, refreshToken string) error {
+	consumed, err := s.repo.GetConsumedSessionByToken(ctx, refreshToken)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	// The family was already revoked by an earlier reuse or logout
+	if consumed.RevokedAt != nil {
+		return ErrRefreshTokenReused
+	}
+
+	if err := s.repo.RevokeSessionFamily(ctx, consumed.FamilyID); err != nil {
+		return err
+	}
+
+	if s.eventBus != nil {
+		_ = s.eventBus.Publish(ctx, domain.SessionRevokedEvent{
+			UserID:    consumed.UserID,
+			SessionID: consumed.ID,
+			FamilyID:  consumed.FamilyID,
+			Reason:    domain.SessionRevokedReasonTokenReuse,
+			RevokedAt: time.Now().UTC(),
+		})
+	}
+
+	return ErrRefreshTokenReused
+}
+
 func (s *ServiceV1) ValidateToken(ctx context.Context, token string) (*domain.ValidateTokenResponse, error) {
-	claims, err := s.ParseToken(token)
+	claims, err := s.parseAccessToken(token)
 	if err != nil {
 		return &domain.ValidateTokenResponse{
 			Valid:  false,
@@ -250,7 +497,67 @@ func (s *ServiceV1) ValidateToken(ctx context.Context, token string) (*domain.Va
 		}, nil
 	}
 
+	user, reason := s.tokenSubject(ctx, claims)
+	if user == nil {
+		return &domain.ValidateTokenResponse{
+			Valid:  false,
+			Reason: reason,
+		}, nil
+	}
+
+	return &domain.ValidateTokenResponse{
+		Valid: true,
+		User:  user,
+	}, nil
+}
+
+// tokenSubject resolves the user or machine client an access token was
+// issued to and checks that it may still use the token; reason explains a nil result
+func (s *ServiceV1) tokenSubject(ctx context.Context, claims *jwtClaims) (*domain.UserInfo, string) {
+	if claims.ClientID != "" {
+		client, err := s.repo.GetClientByID(ctx, claims.ClientID)
+		if err != nil || client == nil {
+			return nil, "client not found"
+		}
+		return &domain.UserInfo{
+			ID:       client.ID,
+			Username: client.Name,
+			// Scopes removed from the client since the token was issued no longer apply
+			Permissions: intersectScopes(claims.Permissions, client.Scopes),
+			ClientID:    client.ID,
+		}, ""
+	}
+
 	cred, err := s.repo.GetCredentialByUserID(ctx, claims.UserID)
+	if err != nil {
+		return nil, "user not found"
+	}
+
+	if !cred.IsActive {
+		return nil, "user account is inactive"
+	}
+
+	return &domain.UserInfo{
+		ID:          claims.UserID,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+	}, ""
+}
+
+// ValidateSession validates an opaque session token and resolves the user's
+// current roles and permissions
+func (s *ServiceV1) ValidateSession(ctx context.Context, token string) (*domain.ValidateTokenResponse, error) {
+	session, err := s.repo.GetSessionByToken(ctx, token)
+	if err != nil {
+		return &domain.ValidateTokenResponse{
+			Valid:  false,
+			Reason: ErrSessionNotFound.Error(),
+		}, nil
+	}
+
+	cred, err := s.repo.GetCredentialByUserID(ctx, session.UserID)
 	if err != nil {
 		return &domain.ValidateTokenResponse{
 			Valid:  false,
@@ -265,17 +572,39 @@ func (s *ServiceV1) ValidateToken(ctx context.Context, token string) (*domain.Va
 		}, nil
 	}
 
+	claims, err := s.buildClaims(ctx, cred)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.ValidateTokenResponse{
 		Valid: true,
 		User: &domain.UserInfo{
-			ID:       claims.UserID,
-			Username: claims.Username,
-			Email:    claims.Email,
-			Roles:    claims.Roles,
+			ID:          claims.UserID,
+			Username:    claims.Username,
+			Email:       claims.Email,
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
 		},
 	}, nil
 }
 
+// buildClaims loads the roles assigned to cred's user and flattens them into token claims
+func (s *ServiceV1) buildClaims(ctx context.Context, cred *domain.Credential) (*domain.TokenClaims, error) {
+	roles, err := s.repo.GetUserRoles(ctx, cred.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenClaims{
+		UserID:      cred.UserID,
+		Username:    cred.Username,
+		Email:       cred.Email,
+		Roles:       domain.RoleNames(roles),
+		Permissions: domain.RolePermissions(roles),
+	}, nil
+}
+
 func (s *ServiceV1) ChangePassword(ctx context.Context, userID string, req *domain.ChangePasswordRequest) error {
 	cred, err := s.repo.GetCredentialByUserID(ctx, userID)
 	if err != nil {
@@ -294,105 +623,1165 @@ func (s *ServiceV1) ChangePassword(ctx context.Context, userID string, req *doma
 	return s.repo.UpdatePassword(ctx, userID, hashedPassword)
 }
 
+// ResetPassword issues a single-use reset token and enqueues the reset email.
+// Unknown or inactive accounts are ignored so the caller cannot probe for registered emails.
 func (s *ServiceV1) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) error {
-	return nil
+	cred, err := s.repo.GetCredentialByEmail(ctx, req.Email)
+	if err != nil || !cred.IsActive {
+		return nil
+	}
+
+	token, err := s.GenerateRefreshToken(cred.UserID)
+	if err != nil {
+		return err
+	}
+
+	resetToken := &domain.PasswordResetToken{
+		UserID:    cred.UserID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(s.config.PasswordResetTokenDuration),
+	}
+	if err := s.repo.CreatePasswordResetToken(ctx, resetToken); err != nil {
+		return err
+	}
+
+	return s.resetNotifier.SendPasswordReset(ctx, &domain.PasswordResetNotice{
+		UserID:    cred.UserID,
+		Email:     cred.Email,
+		ResetLink: tokenLink(s.config.PasswordResetURL, token),
+	})
 }
 
+// ConfirmResetPassword consumes a reset token, sets the new password and
+// revokes every session of the account
 func (s *ServiceV1) ConfirmResetPassword(ctx context.Context, req *domain.ConfirmResetPasswordRequest) error {
-	return nil
+	hashedPassword, err := s.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		resetToken, err := s.repo.ConsumePasswordResetToken(ctx, hashToken(req.Token))
+		if err != nil {
+			return ErrInvalidResetToken
+		}
+
+		if err := s.repo.UpdatePassword(ctx, resetToken.UserID, hashedPassword); err != nil {
+			return err
+		}
+
+		if err := s.repo.InvalidatePasswordResetTokens(ctx, resetToken.UserID); err != nil {
+			return err
+		}
+
+		return s.repo.RevokeAllUserSessions(ctx, resetToken.UserID)
+	})
 }
 
-func (s *ServiceV1) GetSessions(ctx context.Context, userID string) (*domain.SessionListResponse, error) {
-	sessions, err := s.repo.GetSessionsByUserID(ctx, userID)
+// VerifyEmail marks the credential named by a verification token as verified.
+// Verifying an already verified address is not an error.
+func (s *ServiceV1) VerifyEmail(ctx context.Context, req *domain.VerifyEmailRequest) error {
+	claims, err := s.parseVerificationToken(req.Token)
 	if err != nil {
-		return nil, err
+		return ErrInvalidVerificationToken
 	}
 
-	sessionInfos := make([]domain.SessionInfo, len(sessions))
-	for i, sess := range sessions {
-		sessionInfos[i] = domain.SessionInfo{
-			ID:        sess.ID,
-			UserAgent: sess.UserAgent,
-			IPAddress: sess.IPAddress,
-			CreatedAt: sess.CreatedAt,
-			ExpiresAt: sess.ExpiresAt,
+	cred, err := s.repo.GetCredentialByUserID(ctx, claims.Subject)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	// Tokens issued before an email change must not verify the new address
+	if !strings.EqualFold(cred.Email, claims.Email) {
+		return ErrInvalidVerificationToken
+	}
+
+	if cred.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return s.repo.MarkEmailVerified(ctx, cred.UserID)
+}
+
+// ResendVerification sends a fresh verification link, at most once per
+// VerificationResendInterval for each address. Unknown, inactive and already
+// verified accounts are ignored so the caller cannot probe for registered emails.
+func (s *ServiceV1) ResendVerification(ctx context.Context, req *domain.ResendVerificationRequest) error {
+	if s.cache != nil && s.config.VerificationResendInterval > 0 {
+		key := verificationResendKeyPrefix + strings.ToLower(req.Email)
+		if ok, err := s.cache.SetNX(ctx, key, 1, s.config.VerificationResendInterval); err == nil && !ok {
+			return ErrVerificationRateLimited
 		}
 	}
 
-	return &domain.SessionListResponse{Sessions: sessionInfos}, nil
+	cred, err := s.repo.GetCredentialByEmail(ctx, req.Email)
+	if err != nil || !cred.IsActive || cred.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return s.sendVerification(ctx, cred)
 }
 
-func (s *ServiceV1) RevokeSession(ctx context.Context, userID, sessionID string) error {
-	session, err := s.repo.GetSessionByID(ctx, sessionID)
-	if err != nil {
-		return ErrSessionNotFound
+// sendVerification emails a signed verification link through the "verification" template
+func (s *ServiceV1) sendVerification(ctx context.Context, cred *domain.Credential) error {
+	if s.emailService == nil {
+		return nil
 	}
 
-	if session.UserID != userID {
-		return ErrSessionNotFound
+	token, err := s.generateVerificationToken(cred)
+	if err != nil {
+		return err
 	}
 
-	return s.repo.RevokeSession(ctx, sessionID)
+	return s.emailService.SendTemplate(ctx, []string{cred.Email}, string(email.EmailTypeVerification), map[string]interface{}{
+		"username":          cred.Username,
+		"verification_link": tokenLink(s.config.EmailVerificationURL, token),
+	})
 }
 
-func (s *ServiceV1) RevokeAllSessions(ctx context.Context, userID string) error {
-	return s.repo.RevokeAllUserSessions(ctx, userID)
+// tokenLink appends token to base as the "token" query parameter
+func tokenLink(base, token string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
-type jwtClaims struct {
-	jwt.RegisteredClaims
-	UserID   string   `json:"user_id"`
-	Username string   `json:"username"`
-	Email    string   `json:"email"`
-	Roles    []string `json:"roles,omitempty"`
+// hashToken returns the hex SHA-256 of an opaque token for storage
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
-func (s *ServiceV1) GenerateAccessToken(claims *domain.TokenClaims) (string, error) {
-	now := time.Now().UTC()
-	jwtClaims := jwtClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.AccessTokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			ID:        uuid.NewString(),
-		},
-		UserID:   claims.UserID,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Roles:    claims.Roles,
+// EnrollMFA starts (or restarts) TOTP enrollment with a fresh secret.
+// MFA is not enforced until EnableMFA confirms a code from the authenticator.
+func (s *ServiceV1) EnrollMFA(ctx context.Context, userID string) (*domain.MFAEnrollResponse, error) {
+	cred, err := s.repo.GetCredentialByUserID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	enrollment, err := s.repo.GetMFAEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment != nil && enrollment.EnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveMFAEnrollment(ctx, &domain.MFAEnrollment{UserID: userID, Secret: secret}); err != nil {
+		return nil, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
-	return token.SignedString([]byte(s.config.JWTSecret))
+	return &domain.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totpURI(s.config.MFAIssuer, cred.Email, secret),
+	}, nil
 }
 
-func (s *ServiceV1) GenerateRefreshToken(userID string) (string, error) {
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
+// EnableMFA confirms enrollment with a TOTP code and returns a new set of recovery codes
+func (s *ServiceV1) EnableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) (*domain.MFARecoveryCodesResponse, error) {
+	enrollment, err := s.repo.GetMFAEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
-	return base64.URLEncoding.EncodeToString(bytes), nil
+	if enrollment == nil {
+		return nil, ErrMFANotEnrolled
+	}
+	if enrollment.EnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkMFACode(ctx, enrollment, req.Code, false); err != nil {
+			return err
+		}
+
+		if err := s.repo.EnableMFA(ctx, userID); err != nil {
+			return err
+		}
+
+		records := make([]domain.MFARecoveryCode, len(codes))
+		for i, code := range codes {
+			records[i] = domain.MFARecoveryCode{CodeHash: hashToken(normalizeRecoveryCode(code))}
+		}
+		return s.repo.ReplaceMFARecoveryCodes(ctx, userID, records)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
 }
 
-func (s *ServiceV1) ParseToken(tokenString string) (*domain.TokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
+// DisableMFA removes the enrollment after checking a TOTP or recovery code
+func (s *ServiceV1) DisableMFA(ctx context.Context, userID string, req *domain.MFACodeRequest) error {
+	enrollment, err := s.repo.GetMFAEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil || enrollment.EnabledAt == nil {
+		return ErrMFANotEnabled
+	}
+
+	return s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkMFACode(ctx, enrollment, req.Code, true); err != nil {
+			return err
 		}
-		return []byte(s.config.JWTSecret), nil
+		return s.repo.DeleteMFAEnrollment(ctx, userID)
 	})
+}
+
+// VerifyMFA completes a login that Login answered with an MFA challenge
+func (s *ServiceV1) VerifyMFA(ctx context.Context, req *domain.VerifyMFARequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	claims := &jwt.RegisteredClaims{}
+	token, err := jwt.ParseWithClaims(req.MFAToken, claims, s.purposeKeyFunc(purposeMFAChallenge))
+	if err != nil || !token.Valid || claims.Subject == "" {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	if err := s.countMFAAttempt(ctx, claims.Subject); err != nil {
+		return nil, err
+	}
+
+	cred, err := s.repo.GetCredentialByUserID(ctx, claims.Subject)
+	if err != nil || !cred.IsActive {
+		return nil, ErrInvalidMFAChallenge
+	}
 
+	enrollment, err := s.repo.GetMFAEnrollment(ctx, cred.UserID)
 	if err != nil {
-		return nil, ErrInvalidToken
+		return nil, err
+	}
+	if enrollment == nil || enrollment.EnabledAt == nil {
+		return nil, ErrInvalidMFAChallenge
 	}
 
-	if claims, ok := token.Claims.(*jwtClaims); ok && token.Valid {
-		return &domain.TokenClaims{
-			UserID:   claims.UserID,
-			Username: claims.Username,
-			Email:    claims.Email,
-			Roles:    claims.Roles,
-		}, nil
+	if err := s.checkMFACode(ctx, enrollment, req.Code, true); err != nil {
+		return nil, err
+	}
+	_ = s.cache.Delete(ctx, mfaAttemptsKeyPrefix+cred.UserID)
+
+	return s.completeLogin(ctx, cred, userAgent, ipAddress)
+}
+
+// mfaChallenge answers a correct password for an MFA-enabled account
+func (s *ServiceV1) mfaChallenge(cred *domain.Credential) (*domain.LoginResponse, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(s.config.MFAChallengeDuration)
+	claims := jwt.RegisteredClaims{
+		Subject:   cred.UserID,
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        uuid.NewString(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.purposeKey(purposeMFAChallenge))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(s.config.MFAChallengeDuration.Seconds()),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// checkMFACode accepts a TOTP code once per time step, or an unused recovery code when allowed
+func (s *ServiceV1) checkMFACode(ctx context.Context, enrollment *domain.MFAEnrollment, code string, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		step, ok := validateTOTP(enrollment.Secret, code, time.Now())
+		if !ok {
+			return ErrInvalidMFACode
+		}
+		// Fails when this or a later step was already used, so a code cannot be replayed
+		if err := s.repo.UseMFAStep(ctx, enrollment.UserID, step); err != nil {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	if !allowRecovery {
+		return ErrInvalidMFACode
+	}
+	if err := s.repo.ConsumeMFARecoveryCode(ctx, enrollment.UserID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// countMFAAttempt rejects code checks for userID after maxMFAAttempts within
+// a challenge lifetime. The count is per user so a fresh login does not reset
+// it, and an attempt that cannot be counted is refused rather than let through.
+func (s *ServiceV1) countMFAAttempt(ctx context.Context, userID string) error {
+	if s.cache == nil {
+		return ErrMFAUnavailable
+	}
+
+	key := mfaAttemptsKeyPrefix + userID
+	attempts, err := s.cache.Increment(ctx, key, 1)
+	if err != nil {
+		return ErrMFAUnavailable.WithError(err)
+	}
+	if attempts == 1 {
+		_ = s.cache.Expire(ctx, key, s.config.MFAChallengeDuration)
+	}
+	if attempts > maxMFAAttempts {
+		return ErrMFAAttemptsExceeded
+	}
+	return nil
+}
+
+// oidcState is kept in the cache between the start of a provider login and its callback
+type oidcState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+	LinkUserID   string `json:"link_user_id,omitempty"` // set when linking to a signed-in user
+}
+
+func (s *ServiceV1) StartOIDCLogin(ctx context.Context, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	return s.startOIDC(ctx, provider, "")
+}
+
+func (s *ServiceV1) StartOIDCLink(ctx context.Context, userID, provider string) (*domain.OIDCAuthorizationResponse, error) {
+	return s.startOIDC(ctx, provider, userID)
+}
+
+func (s *ServiceV1) startOIDC(ctx context.Context, providerName, linkUserID string) (*domain.OIDCAuthorizationResponse, error) {
+	provider, ok := s.config.IdentityProviders[providerName]
+	if !ok {
+		return nil, ErrUnknownIdentityProvider
+	}
+
+	stateToken, err := s.GenerateRefreshToken(linkUserID)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.GenerateRefreshToken(linkUserID)
+	if err != nil {
+		return nil, err
+	}
+	state := oidcState{
+		Provider:     providerName,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, oidcStateKeyPrefix+stateToken, string(data), s.config.OIDCStateDuration); err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, stateToken, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OIDCAuthorizationResponse{AuthorizationURL: authURL, State: stateToken}, nil
+}
+
+// CompleteOIDCLogin handles the provider callback. A flow started by
+// StartOIDCLink links the provider account to that user, must be completed by
+// the same signed-in user and issues no tokens. Otherwise the provider account
+// signs in the user it is linked to, the account with the same verified email,
+// or a newly created user. MFA still applies afterwards.
+func (s *ServiceV1) CompleteOIDCLogin(ctx context.Context, req *domain.OIDCCallbackRequest, userAgent, ipAddress string) (*domain.LoginResponse, error) {
+	state, err := s.consumeOIDCState(ctx, req.State)
+	if err != nil || state.Provider != req.Provider {
+		return nil, ErrInvalidOIDCState
+	}
+	// Whoever follows a link URL must be its owner; anyone else would attach
+	// their provider account to the starter's user and sign in to it
+	if state.LinkUserID != "" && req.UserID != state.LinkUserID {
+		return nil, ErrOIDCLinkUserMismatch
+	}
+	provider, ok := s.config.IdentityProviders[state.Provider]
+	if !ok {
+		return nil, ErrUnknownIdentityProvider
+	}
+
+	external, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, ErrOIDCLoginFailed.WithError(err)
+	}
+
+	cred, err := s.resolveIdentity(ctx, state, external)
+	if err != nil {
+		return nil, err
+	}
+	if !cred.IsActive {
+		return nil, ErrUserNotActive
+	}
+
+	if state.LinkUserID != "" {
+		return &domain.LoginResponse{
+			Linked: true,
+			User: &domain.UserInfo{
+				ID:       cred.UserID,
+				Username: cred.Username,
+				Email:    cred.Email,
+			},
+		}, nil
+	}
+
+	return s.finishLogin(ctx, cred, userAgent, ipAddress)
+}
+
+// consumeOIDCState loads a login state and deletes it so it can be used only once
+func (s *ServiceV1) consumeOIDCState(ctx context.Context, stateToken string) (*oidcState, error) {
+	if stateToken == "" {
+		return nil, ErrInvalidOIDCState
+	}
+	key := oidcStateKeyPrefix + stateToken
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	var state oidcState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// resolveIdentity returns the credential an external account signs in to,
+// linking or creating it first when needed
+func (s *ServiceV1) resolveIdentity(ctx context.Context, state *oidcState, external *domain.ExternalIdentity) (*domain.Credential, error) {
+	identity, err := s.repo.GetIdentity(ctx, state.Provider, external.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		if state.LinkUserID != "" && identity.UserID != state.LinkUserID {
+			return nil, ErrIdentityLinked
+		}
+		return s.repo.GetCredentialByUserID(ctx, identity.UserID)
+	}
+
+	if state.LinkUserID != "" {
+		cred, err := s.repo.GetCredentialByUserID(ctx, state.LinkUserID)
+		if err != nil {
+			return nil, ErrUserNotFound
+		}
+		return cred, s.linkIdentity(ctx, cred.UserID, state.Provider, external)
+	}
+
+	// An email address is only trusted once the provider has verified it
+	if external.Email == "" || !external.EmailVerified {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	if cred, err := s.repo.GetCredentialByEmail(ctx, external.Email); err == nil {
+		// Link automatically only when the local account has proven the address too
+		if cred.EmailVerifiedAt == nil {
+			return nil, ErrOIDCEmailInUse
+		}
+		return cred, s.linkIdentity(ctx, cred.UserID, state.Provider, external)
+	}
+
+	return s.registerExternal(ctx, state.Provider, external)
+}
+
+// linkIdentity links an external account; a user can link one account per provider
+func (s *ServiceV1) linkIdentity(ctx context.Context, userID, provider string, external *domain.ExternalIdentity) error {
+	identities, err := s.repo.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return ErrProviderAlreadyLinked
+		}
+	}
+
+	return s.repo.CreateIdentity(ctx, &domain.Identity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  external.Subject,
+		Email:    external.Email,
+	})
+}
+
+// registerExternal creates a user, credential and identity for a new provider account.
+// The credential gets a random password; the user can set one through password reset.
+func (s *ServiceV1) registerExternal(ctx context.Context, provider string, external *domain.ExternalIdentity) (*domain.Credential, error) {
+	username, err := s.availableUsername(ctx, external)
+	if err != nil {
+		return nil, err
+	}
+	password, err := s.GenerateRefreshToken("")
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := s.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := uuid.NewString()
+	name := external.Name
+	if name == "" {
+		name = username
+	}
+
+	verifiedAt := time.Now().UTC()
+	cred := &domain.Credential{
+		UserID:          userID,
+		Username:        username,
+		Email:           external.Email,
+		PasswordHash:    hashedPassword,
+		IsActive:        true,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userCreator.CreateUser(ctx, &domain.NewUser{ID: userID, Name: name, Email: external.Email, CreatedBy: userID}); err != nil {
+			return err
+		}
+
+		cred.ID = uuid.NewString()
+		if err := s.repo.CreateCredential(ctx, cred); err != nil {
+			return err
+		}
+
+		if _, err := s.assignDefaultRole(ctx, userID); err != nil {
+			return err
+		}
+
+		return s.linkIdentity(ctx, userID, provider, external)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+// maxUsernameAttempts bounds the search for a free username for provider sign-ups
+const maxUsernameAttempts = 5
+
+// availableUsername derives a free username from the provider username or the
+// email local part, adding a random suffix on collision
+func (s *ServiceV1) availableUsername(ctx context.Context, external *domain.ExternalIdentity) (string, error) {
+	base := external.Username
+	if base == "" {
+		base, _, _ = strings.Cut(external.Email, "@")
+	}
+	base = sanitizeUsername(base)
+
+	candidate := base
+	for i := 0; i < maxUsernameAttempts; i++ {
+		if _, err := s.repo.GetCredentialByUsername(ctx, candidate); err != nil {
+			return candidate, nil
+		}
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		candidate = base + "-" + hex.EncodeToString(suffix)
+	}
+	return "", ErrUsernameExists
+}
+
+// sanitizeUsername keeps letters, digits, '.', '_' and '-' and enforces the registration length limits
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	if len(username) > 40 {
+		username = username[:40]
+	}
+	if len(username) < 3 {
+		username = "user"
+	}
+	return username
+}
+
+func (s *ServiceV1) ListIdentities(ctx context.Context, userID string) (*domain.IdentityListResponse, error) {
+	identities, err := s.repo.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]domain.IdentityInfo, 0, len(identities))
+	for _, identity := range identities {
+		infos = append(infos, domain.IdentityInfo{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+	return &domain.IdentityListResponse{Identities: infos}, nil
+}
+
+func (s *ServiceV1) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	identities, err := s.repo.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return s.repo.DeleteIdentity(ctx, identity.ID)
+		}
+	}
+	return ErrIdentityNotLinked
+}
+
+func (s *ServiceV1) GetSessions(ctx context.Context, userID string) (*domain.SessionListResponse, error) {
+	sessions, err := s.repo.GetSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionInfos := make([]domain.SessionInfo, len(sessions))
+	for i, sess := range sessions {
+		sessionInfos[i] = domain.SessionInfo{
+			ID:        sess.ID,
+			UserAgent: sess.UserAgent,
+			IPAddress: sess.IPAddress,
+			CreatedAt: sess.CreatedAt,
+			ExpiresAt: sess.ExpiresAt,
+		}
+	}
+
+	return &domain.SessionListResponse{Sessions: sessionInfos}, nil
+}
+
+func (s *ServiceV1) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.repo.RevokeSession(ctx, sessionID)
+}
+
+func (s *ServiceV1) RevokeAllSessions(ctx context.Context, userID string) error {
+	return s.repo.RevokeAllUserSessions(ctx, userID)
+}
+
+func (s *ServiceV1) ListRoles(ctx context.Context) (*domain.RoleListResponse, error) {
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RoleListResponse{Roles: roles}, nil
+}
+
+func (s *ServiceV1) CreateRole(ctx context.Context, req *domain.CreateRoleRequest) (*domain.Role, error) {
+	if _, err := s.repo.GetRoleByName(ctx, req.Name); err == nil {
+		return nil, ErrRoleExists
+	}
+
+	if err := s.validatePermissions(ctx, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	if err := s.repo.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *ServiceV1) DeleteRole(ctx context.Context, roleID string) error {
+	role, err := s.repo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+
+	if role.Name == domain.RoleAdmin {
+		return ErrRoleProtected
+	}
+
+	return s.repo.DeleteRole(ctx, roleID)
+}
+
+func (s *ServiceV1) SetRolePermissions(ctx context.Context, roleID string, req *domain.SetRolePermissionsRequest) (*domain.Role, error) {
+	if _, err := s.repo.GetRoleByID(ctx, roleID); err != nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := s.validatePermissions(ctx, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetRolePermissions(ctx, roleID, req.Permissions); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRoleByID(ctx, roleID)
+}
+
+func (s *ServiceV1) ListPermissions(ctx context.Context) (*domain.PermissionListResponse, error) {
+	permissions, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PermissionListResponse{Permissions: permissions}, nil
+}
+
+func (s *ServiceV1) GetUserRoles(ctx context.Context, userID string) (*domain.UserRolesResponse, error) {
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserRolesResponse{
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: domain.RolePermissions(roles),
+	}, nil
+}
+
+func (s *ServiceV1) AssignRole(ctx context.Context, assignedBy, userID string, req *domain.AssignRoleRequest) error {
+	if _, err := s.repo.GetCredentialByUserID(ctx, userID); err != nil {
+		return ErrAssigneeNotFound
+	}
+
+	role, err := s.repo.GetRoleByName(ctx, req.Role)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+
+	return s.repo.AssignRole(ctx, &domain.UserRole{
+		UserID:     userID,
+		RoleID:     role.ID,
+		AssignedBy: assignedBy,
+	})
+}
+
+func (s *ServiceV1) RevokeRole(ctx context.Context, userID, roleID string) error {
+	if _, err := s.repo.GetRoleByID(ctx, roleID); err != nil {
+		return ErrRoleNotFound
+	}
+
+	return s.repo.RevokeRole(ctx, userID, roleID)
+}
+
+// ListClients returns the registered machine clients
+func (s *ServiceV1) ListClients(ctx context.Context) (*domain.ClientListResponse, error) {
+	clients, err := s.repo.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if clients == nil {
+		clients = []domain.Client{}
+	}
+	return &domain.ClientListResponse{Clients: clients}, nil
+}
+
+// RegisterClient registers a machine client allowed to request the given
+// permissions as scopes. The generated secret is returned only here.
+func (s *ServiceV1) RegisterClient(ctx context.Context, createdBy string, req *domain.RegisterClientRequest) (*domain.ClientSecretResponse, error) {
+	if err := s.validatePermissions(ctx, req.Scopes); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.GenerateRefreshToken("")
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := slices.Compact(slices.Sorted(slices.Values(req.Scopes)))
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	client := &domain.Client{
+		Name:       req.Name,
+		SecretHash: hashToken(secret),
+		Scopes:     scopes,
+		CreatedBy:  createdBy,
+	}
+	if err := s.repo.CreateClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	return &domain.ClientSecretResponse{Client: client, ClientSecret: secret}, nil
+}
+
+// DeleteClient removes a machine client; tokens already issued to it stop validating
+func (s *ServiceV1) DeleteClient(ctx context.Context, clientID string) error {
+	if _, err := s.getClient(ctx, clientID); err != nil {
+		return err
+	}
+	return s.repo.DeleteClient(ctx, clientID)
+}
+
+// RotateClientSecret replaces a client's secret. Tokens issued with the old
+// secret stay valid until they expire.
+func (s *ServiceV1) RotateClientSecret(ctx context.Context, clientID string) (*domain.ClientSecretResponse, error) {
+	client, err := s.getClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.GenerateRefreshToken("")
+	if err != nil {
+		return nil, err
+	}
+
+	client.SecretHash = hashToken(secret)
+	if err := s.repo.UpdateClientSecret(ctx, client.ID, client.SecretHash); err != nil {
+		return nil, err
+	}
+
+	return &domain.ClientSecretResponse{Client: client, ClientSecret: secret}, nil
+}
+
+func (s *ServiceV1) getClient(ctx context.Context, clientID string) (*domain.Client, error) {
+	if _, err := uuid.Parse(clientID); err != nil {
+		return nil, ErrClientNotFound
+	}
+	client, err := s.repo.GetClientByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientNotFound
+	}
+	return client, nil
+}
+
+// authenticateClient checks client credentials; unknown clients and wrong
+// secrets are indistinguishable to the caller
+func (s *ServiceV1) authenticateClient(ctx context.Context, clientID, secret string) (*domain.Client, error) {
+	if clientID == "" || secret == "" {
+		return nil, ErrInvalidClient
+	}
+
+	client, err := s.getClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, ErrClientNotFound) {
+			return nil, ErrInvalidClient
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(hashToken(secret))) != 1 {
+		return nil, ErrInvalidClient
+	}
+	return client, nil
+}
+
+// IssueClientToken implements the OAuth2 client_credentials grant. The access
+// token carries the granted scopes as permissions and has no refresh token.
+func (s *ServiceV1) IssueClientToken(ctx context.Context, req *domain.ClientTokenRequest) (*domain.ClientTokenResponse, error) {
+	client, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := grantScopes(req.Scope, client.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.GenerateAccessToken(&domain.TokenClaims{
+		UserID:      client.ID,
+		Username:    client.Name,
+		Permissions: scopes,
+		ClientID:    client.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ClientTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.config.AccessTokenDuration.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// IntrospectToken describes an access token to an authenticated client.
+// Invalid, expired and revoked tokens are reported as inactive, not as errors.
+func (s *ServiceV1) IntrospectToken(ctx context.Context, req *domain.IntrospectTokenRequest) (*domain.IntrospectionResponse, error) {
+	if _, err := s.authenticateClient(ctx, req.ClientID, req.ClientSecret); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.parseAccessToken(req.Token)
+	if err != nil {
+		return &domain.IntrospectionResponse{Active: false}, nil
+	}
+	user, _ := s.tokenSubject(ctx, claims)
+	if user == nil {
+		return &domain.IntrospectionResponse{Active: false}, nil
+	}
+
+	resp := &domain.IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(user.Permissions, " "),
+		ClientID:  user.ClientID,
+		Username:  user.Username,
+		TokenType: "Bearer",
+		Subject:   user.ID,
+	}
+	if claims.ExpiresAt != nil {
+		resp.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return resp, nil
+}
+
+// grantScopes resolves a space-delimited scope request against the client's
+// scopes; an empty request grants all of them
+func grantScopes(requested string, allowed []string) ([]string, error) {
+	fields := strings.Fields(requested)
+	if len(fields) == 0 {
+		return allowed, nil
+	}
+
+	for _, scope := range fields {
+		if !slices.Contains(allowed, scope) {
+			return nil, ErrInvalidScope.WithMessage("scope not allowed for this client: " + scope)
+		}
+	}
+	return intersectScopes(fields, allowed), nil
+}
+
+// intersectScopes returns the de-duplicated scopes that are also in allowed, keeping their order
+func intersectScopes(scopes, allowed []string) []string {
+	set := make(map[string]bool, len(allowed))
+	for _, a := range allowed {
+		set[a] = true
+	}
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if set[scope] {
+			out = append(out, scope)
+			delete(set, scope)
+		}
+	}
+	return out
+}
+
+// validatePermissions rejects permission names that are not registered
+func (s *ServiceV1) validatePermissions(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	known, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	set := make(map[string]bool, len(known))
+	for _, p := range known {
+		set[p.Name] = true
+	}
+	for _, name := range names {
+		if !set[name] {
+			return ErrUnknownPermission.WithMessage("unknown permission: " + name)
+		}
+	}
+	return nil
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	UserID      string   `json:"user_id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	Scope       string   `json:"scope,omitempty"` // granted scopes of a machine client token (RFC 9068)
+}
+
+func (s *ServiceV1) GenerateAccessToken(claims *domain.TokenClaims) (string, error) {
+	now := time.Now().UTC()
+	jwtClaims := jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.AccessTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+		UserID:      claims.UserID,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		ClientID:    claims.ClientID,
+	}
+	if claims.ClientID != "" {
+		jwtClaims.Subject = claims.ClientID
+		jwtClaims.Scope = strings.Join(claims.Permissions, " ")
+	}
+
+	if s.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
+		return token.SignedString([]byte(s.config.JWTSecret))
+	}
+
+	key := s.keys.signing(now)
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), jwtClaims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// accessTokenKey resolves the verification key of an access token. With
+// signing keys configured the key is selected by the "kid" header and must
+// match the token's algorithm; HS256 tokens are then rejected.
+func (s *ServiceV1) accessTokenKey(token *jwt.Token) (interface{}, error) {
+	if s.keys == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return []byte(s.config.JWTSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys.lookup(kid, time.Now().UTC())
+	if !ok || token.Method.Alg() != key.Algorithm {
+		return nil, ErrInvalidToken
+	}
+	return key.PrivateKey.Public(), nil
+}
+
+// JWKS returns the public keys that currently verify access tokens. The set
+// is empty when tokens are signed with the shared HS256 secret.
+func (s *ServiceV1) JWKS(ctx context.Context) (*domain.JWKSResponse, error) {
+	resp := &domain.JWKSResponse{Keys: []domain.JWK{}}
+	if s.keys == nil {
+		return resp, nil
+	}
+
+	for _, k := range s.keys.verifying(time.Now().UTC()) {
+		jwk, err := publicJWK(k)
+		if err != nil {
+			return nil, err
+		}
+		resp.Keys = append(resp.Keys, jwk)
+	}
+	return resp, nil
+}
+
+// verificationClaims identify the credential by subject and pin the address being verified
+type verificationClaims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+}
+
+func (s *ServiceV1) generateVerificationToken(cred *domain.Credential) (string, error) {
+	now := time.Now().UTC()
+	claims := verificationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   cred.UserID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.EmailVerificationTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+		Email: cred.Email,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.purposeKey(purposeEmailVerification))
+}
+
+func (s *ServiceV1) parseVerificationToken(tokenString string) (*verificationClaims, error) {
+	claims := &verificationClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.purposeKeyFunc(purposeEmailVerification))
+	if err != nil || !token.Valid || claims.Subject == "" {
+		return nil, ErrInvalidVerificationToken
+	}
+	return claims, nil
+}
+
+// purposeKey derives a key from the JWT secret for one token purpose so that
+// access, verification and MFA challenge tokens cannot stand in for each other
+func (s *ServiceV1) purposeKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.JWTSecret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func (s *ServiceV1) purposeKeyFunc(purpose string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return s.purposeKey(purpose), nil
+	}
+}
+
+func (s *ServiceV1) GenerateRefreshToken(userID string) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(bytes), nil
+}
+
+func (s *ServiceV1) ParseToken(tokenString string) (*domain.TokenClaims, error) {
+	claims, err := s.parseAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenClaims{
+		UserID:      claims.UserID,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		ClientID:    claims.ClientID,
+	}, nil
+}
+
+func (s *ServiceV1) parseAccessToken(tokenString string) (*jwtClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, s.accessTokenKey)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims, ok := token.Claims.(*jwtClaims); ok && token.Valid {
+		return claims, nil
 	}
 
 	return nil, ErrInvalidToken
@@ -3,6 +3,8 @@ package v1
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +14,14 @@ import (
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain/mocks"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+	cachemocks "github.com/kamil5b/go-pste-monolith/internal/shared/cache/mocks"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
+	emailmocks "github.com/kamil5b/go-pste-monolith/internal/shared/email/mocks"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	eventmocks "github.com/kamil5b/go-pste-monolith/internal/shared/events/mocks"
+	uowmocks "github.com/kamil5b/go-pste-monolith/internal/shared/uow/mocks"
 )
 
 // contextKey is a custom context key type
@@ -19,6 +29,18 @@ type contextKey struct{}
 
 var txContextKey = contextKey{}
 
+// expectTransaction makes the unit of work run the service's work with txCtx,
+// failing like the commit with commitErr when the work succeeds
+func expectTransaction(mockUOW *uowmocks.MockUnitOfWork, ctx, txCtx context.Context, commitErr error) *gomock.Call {
+	return mockUOW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(context.Context) error) error {
+			if err := fn(txCtx); err != nil {
+				return err
+			}
+			return commitErr
+		})
+}
+
 func TestServiceV1_Login_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -27,7 +49,7 @@ func TestServiceV1_Login_Success(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	hashedPassword, _ := service.HashPassword("password123")
@@ -46,7 +68,14 @@ func TestServiceV1_Login_Success(t *testing.T) {
 		Password: "password123",
 	}
 
+	roles := []domain.Role{
+		{ID: "role1", Name: domain.RoleUser, Permissions: []string{domain.PermissionProductRead, domain.PermissionUserRead}},
+		{ID: "role2", Name: "editor", Permissions: []string{domain.PermissionProductRead, domain.PermissionProductWrite}},
+	}
+
 	mockRepo.EXPECT().GetCredentialByUsername(ctx, req.Username).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, cred.UserID).Return(roles, nil).Times(1)
 	mockRepo.EXPECT().UpdateLastLogin(ctx, cred.UserID).Return(nil).Times(1)
 	mockRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
 
@@ -60,6 +89,14 @@ func TestServiceV1_Login_Success(t *testing.T) {
 	assert.Equal(t, cred.UserID, resp.User.ID)
 	assert.Equal(t, cred.Username, resp.User.Username)
 	assert.Equal(t, cred.Email, resp.User.Email)
+	assert.Equal(t, []string{domain.RoleUser, "editor"}, resp.User.Roles)
+	assert.Equal(t, []string{domain.PermissionProductRead, domain.PermissionUserRead, domain.PermissionProductWrite}, resp.User.Permissions)
+
+	// Roles and permissions are embedded in the access token
+	claims, err := service.ParseToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, resp.User.Roles, claims.Roles)
+	assert.Equal(t, resp.User.Permissions, claims.Permissions)
 }
 
 func TestServiceV1_Login_InvalidCredentials(t *testing.T) {
@@ -70,7 +107,7 @@ func TestServiceV1_Login_InvalidCredentials(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	req := &domain.LoginRequest{
@@ -96,7 +133,7 @@ func TestServiceV1_Login_InactiveUser(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	hashedPassword, _ := service.HashPassword("password123")
@@ -129,10 +166,11 @@ func TestServiceV1_Register_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, mockUOW, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	txCtx := context.WithValue(ctx, txContextKey, "transaction")
@@ -146,10 +184,15 @@ func TestServiceV1_Register_Success(t *testing.T) {
 
 	mockRepo.EXPECT().GetCredentialByUsername(ctx, req.Username).Return(nil, errors.New("not found")).Times(1)
 	mockRepo.EXPECT().GetCredentialByEmail(ctx, req.Email).Return(nil, errors.New("not found")).Times(1)
-	mockRepo.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
 	mockUserCreator.EXPECT().CreateUser(txCtx, gomock.Any()).Return(nil).Times(1)
 	mockRepo.EXPECT().CreateCredential(txCtx, gomock.Any()).Return(nil).Times(1)
-	mockRepo.EXPECT().DeferErrorContext(txCtx, nil).Times(1)
+	mockRepo.EXPECT().GetRoleByName(txCtx, domain.RoleUser).Return(&domain.Role{ID: "role1", Name: domain.RoleUser}, nil).Times(1)
+	mockRepo.EXPECT().AssignRole(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, ur *domain.UserRole) error {
+		assert.Equal(t, "role1", ur.RoleID)
+		assert.NotEmpty(t, ur.UserID)
+		return nil
+	}).Times(1)
 
 	resp, err := service.Register(ctx, req)
 
@@ -158,9 +201,73 @@ func TestServiceV1_Register_Success(t *testing.T) {
 	assert.Equal(t, req.Username, resp.User.Username)
 	assert.Equal(t, req.Email, resp.User.Email)
 	assert.Equal(t, req.Name, resp.User.Name)
+	assert.Equal(t, []string{domain.RoleUser}, resp.User.Roles)
 	assert.Equal(t, "Registration successful", resp.Message)
 }
 
+func TestServiceV1_Register_RollsBackWhenRoleAssignmentFails(t *testing.T) {
+	assignErr := errors.New("assign failed")
+
+	tests := []struct {
+		name    string
+		setup   func(mockRepo *mocks.MockRepository, txCtx context.Context)
+		wantErr error
+	}{
+		{
+			name: "default role missing",
+			setup: func(mockRepo *mocks.MockRepository, txCtx context.Context) {
+				mockRepo.EXPECT().GetRoleByName(txCtx, domain.RoleUser).Return(nil, errors.New("not found")).Times(1)
+			},
+		},
+		{
+			name: "assignment fails",
+			setup: func(mockRepo *mocks.MockRepository, txCtx context.Context) {
+				mockRepo.EXPECT().GetRoleByName(txCtx, domain.RoleUser).Return(&domain.Role{ID: "role1", Name: domain.RoleUser}, nil).Times(1)
+				mockRepo.EXPECT().AssignRole(txCtx, gomock.Any()).Return(assignErr).Times(1)
+			},
+			wantErr: assignErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+			mockUserCreator := mocks.NewMockUserCreator(ctrl)
+			service := NewServiceV1(mockRepo, mockUOW, mockUserCreator, nil, nil, nil, nil, DefaultAuthConfig())
+
+			ctx := context.Background()
+			txCtx := context.WithValue(ctx, txContextKey, "transaction")
+			req := &domain.RegisterRequest{Username: "newuser", Email: "newuser@example.com", Password: "password123", Name: "New User"}
+
+			mockRepo.EXPECT().GetCredentialByUsername(ctx, req.Username).Return(nil, errors.New("not found")).Times(1)
+			mockRepo.EXPECT().GetCredentialByEmail(ctx, req.Email).Return(nil, errors.New("not found")).Times(1)
+			// The unit of work must see the failure so the user and credential are rolled back
+			var deferred error
+			mockUOW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, fn func(context.Context) error) error {
+					deferred = fn(txCtx)
+					return deferred
+				})
+			mockUserCreator.EXPECT().CreateUser(txCtx, gomock.Any()).Return(nil).Times(1)
+			mockRepo.EXPECT().CreateCredential(txCtx, gomock.Any()).Return(nil).Times(1)
+			tt.setup(mockRepo, txCtx)
+
+			resp, err := service.Register(ctx, req)
+
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			assert.Equal(t, err, deferred)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestServiceV1_Register_UsernameExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -169,7 +276,7 @@ func TestServiceV1_Register_UsernameExists(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	req := &domain.RegisterRequest{
@@ -202,7 +309,7 @@ func TestServiceV1_Register_EmailExists(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	req := &domain.RegisterRequest{
@@ -236,7 +343,7 @@ func TestServiceV1_Logout_SingleDevice(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -269,7 +376,7 @@ func TestServiceV1_Logout_AllDevices(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -290,19 +397,24 @@ func TestServiceV1_RefreshToken_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, mockUOW, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	refreshToken := "refresh_token_123"
 
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+
 	session := &domain.Session{
 		ID:        "session123",
 		UserID:    "user123",
+		FamilyID:  "family123",
 		Token:     refreshToken,
 		ExpiresAt: time.Now().Add(24 * time.Hour),
+		UserAgent: "Mozilla/5.0",
 	}
 
 	cred := &domain.Credential{
@@ -313,16 +425,39 @@ func TestServiceV1_RefreshToken_Success(t *testing.T) {
 		IsActive: true,
 	}
 
+	var rotated *domain.Session
 	mockRepo.EXPECT().GetSessionByToken(ctx, refreshToken).Return(session, nil).Times(1)
 	mockRepo.EXPECT().GetCredentialByUserID(ctx, session.UserID).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, session.UserID).Return([]domain.Role{
+		{ID: "role1", Name: domain.RoleAdmin, Permissions: []string{domain.PermissionRBACManage}},
+	}, nil).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().ConsumeSession(txCtx, session.ID).Return(nil).Times(1)
+	mockRepo.EXPECT().CreateSession(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, next *domain.Session) error {
+		rotated = next
+		return nil
+	}).Times(1)
 
 	resp, err := service.RefreshToken(ctx, refreshToken)
 
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 	assert.NotEmpty(t, resp.AccessToken)
-	assert.Equal(t, refreshToken, resp.RefreshToken)
+	assert.NotEqual(t, refreshToken, resp.RefreshToken)
 	assert.Equal(t, "Bearer", resp.TokenType)
+
+	// The successor joins the family and keeps its expiry
+	require.NotNil(t, rotated)
+	assert.Equal(t, resp.RefreshToken, rotated.Token)
+	assert.Equal(t, session.FamilyID, rotated.FamilyID)
+	assert.Equal(t, session.ExpiresAt, rotated.ExpiresAt)
+	assert.Equal(t, session.UserAgent, rotated.UserAgent)
+	assert.NotEqual(t, session.ID, rotated.ID)
+
+	claims, err := service.ParseToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.RoleAdmin}, claims.Roles)
+	assert.Equal(t, []string{domain.PermissionRBACManage}, claims.Permissions)
 }
 
 func TestServiceV1_RefreshToken_InvalidToken(t *testing.T) {
@@ -333,12 +468,13 @@ func TestServiceV1_RefreshToken_InvalidToken(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	refreshToken := "invalid_token"
 
 	mockRepo.EXPECT().GetSessionByToken(ctx, refreshToken).Return(nil, errors.New("not found")).Times(1)
+	mockRepo.EXPECT().GetConsumedSessionByToken(ctx, refreshToken).Return(nil, errors.New("not found")).Times(1)
 
 	resp, err := service.RefreshToken(ctx, refreshToken)
 
@@ -347,6 +483,68 @@ func TestServiceV1_RefreshToken_InvalidToken(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestServiceV1_RefreshToken_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockEventBus := eventmocks.NewMockEventBus(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, mockEventBus, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	consumedAt := time.Now().UTC()
+	consumed := &domain.Session{ID: "session123", UserID: "user123", FamilyID: "family123", Token: "rotated_token", ConsumedAt: &consumedAt}
+
+	mockRepo.EXPECT().GetSessionByToken(ctx, consumed.Token).Return(nil, errors.New("not found")).Times(1)
+	mockRepo.EXPECT().GetConsumedSessionByToken(ctx, consumed.Token).Return(consumed, nil).Times(1)
+	mockRepo.EXPECT().RevokeSessionFamily(ctx, consumed.FamilyID).Return(nil).Times(1)
+	mockEventBus.EXPECT().Publish(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event events.Event) error {
+		revoked, ok := event.(domain.SessionRevokedEvent)
+		require.True(t, ok)
+		assert.Equal(t, consumed.UserID, revoked.UserID)
+		assert.Equal(t, consumed.ID, revoked.SessionID)
+		assert.Equal(t, consumed.FamilyID, revoked.FamilyID)
+		assert.Equal(t, domain.SessionRevokedReasonTokenReuse, revoked.Reason)
+		return nil
+	}).Times(1)
+
+	resp, err := service.RefreshToken(ctx, consumed.Token)
+
+	assert.Equal(t, ErrRefreshTokenReused, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_RefreshToken_ConcurrentRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	session := &domain.Session{ID: "session123", UserID: "user123", FamilyID: "family123", Token: "refresh_token_123"}
+	consumeErr := errors.New("no rows")
+	revokedAt := time.Now().UTC()
+	consumed := *session
+	consumed.ConsumedAt = &revokedAt
+	consumed.RevokedAt = &revokedAt
+
+	// Another request rotated and reused the token between the lookup and the rotation
+	mockRepo.EXPECT().GetSessionByToken(ctx, session.Token).Return(session, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, session.UserID).Return(&domain.Credential{UserID: session.UserID, IsActive: true}, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, session.UserID).Return(nil, nil).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().ConsumeSession(txCtx, session.ID).Return(consumeErr).Times(1)
+	mockRepo.EXPECT().GetConsumedSessionByToken(ctx, session.Token).Return(&consumed, nil).Times(1)
+
+	resp, err := service.RefreshToken(ctx, session.Token)
+
+	assert.Equal(t, ErrRefreshTokenReused, err)
+	assert.Nil(t, resp)
+}
+
 func TestServiceV1_ValidateToken_Valid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -355,7 +553,7 @@ func TestServiceV1_ValidateToken_Valid(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 
@@ -397,7 +595,7 @@ func TestServiceV1_ValidateToken_Invalid(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	invalidToken := "invalid.token.string"
@@ -410,6 +608,57 @@ func TestServiceV1_ValidateToken_Invalid(t *testing.T) {
 	assert.NotEmpty(t, resp.Reason)
 }
 
+func TestServiceV1_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUserCreator := mocks.NewMockUserCreator(ctrl)
+
+	config := DefaultAuthConfig()
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
+
+	ctx := context.Background()
+	session := &domain.Session{ID: "session123", UserID: "user123", Token: "session_token"}
+	cred := &domain.Credential{UserID: "user123", Username: "testuser", Email: "test@example.com", IsActive: true}
+
+	mockRepo.EXPECT().GetSessionByToken(ctx, session.Token).Return(session, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, session.UserID).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, session.UserID).Return([]domain.Role{
+		{ID: "role1", Name: domain.RoleUser, Permissions: []string{domain.PermissionProductRead}},
+	}, nil).Times(1)
+
+	resp, err := service.ValidateSession(ctx, session.Token)
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, []string{domain.RoleUser}, resp.User.Roles)
+	assert.Equal(t, []string{domain.PermissionProductRead}, resp.User.Permissions)
+}
+
+func TestServiceV1_ValidateSession_Unknown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUserCreator := mocks.NewMockUserCreator(ctrl)
+
+	config := DefaultAuthConfig()
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetSessionByToken(ctx, "missing").Return(nil, errors.New("not found")).Times(1)
+
+	resp, err := service.ValidateSession(ctx, "missing")
+
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.False(t, resp.Valid)
+	assert.Nil(t, resp.User)
+}
+
 func TestServiceV1_ChangePassword_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -418,7 +667,7 @@ func TestServiceV1_ChangePassword_Success(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -454,7 +703,7 @@ func TestServiceV1_ChangePassword_WrongOldPassword(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -488,7 +737,7 @@ func TestServiceV1_GetSessions(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -531,7 +780,7 @@ func TestServiceV1_RevokeSession(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -558,7 +807,7 @@ func TestServiceV1_RevokeAllSessions(t *testing.T) {
 	mockUserCreator := mocks.NewMockUserCreator(ctrl)
 
 	config := DefaultAuthConfig()
-	service := NewServiceV1(mockRepo, mockUserCreator, config)
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, nil, config)
 
 	ctx := context.Background()
 	userID := "user123"
@@ -572,7 +821,7 @@ func TestServiceV1_RevokeAllSessions(t *testing.T) {
 
 func TestServiceV1_HashAndVerifyPassword(t *testing.T) {
 	config := DefaultAuthConfig()
-	service := NewServiceV1(nil, nil, config)
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
 
 	password := "mypassword123"
 
@@ -593,7 +842,7 @@ func TestServiceV1_HashAndVerifyPassword(t *testing.T) {
 
 func TestServiceV1_TokenGeneration(t *testing.T) {
 	config := DefaultAuthConfig()
-	service := NewServiceV1(nil, nil, config)
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
 
 	claims := &domain.TokenClaims{
 		UserID:   "user123",
@@ -624,7 +873,7 @@ func TestServiceV1_TokenGeneration(t *testing.T) {
 // Benchmark tests
 func BenchmarkServiceV1_HashPassword(b *testing.B) {
 	config := DefaultAuthConfig()
-	service := NewServiceV1(nil, nil, config)
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -634,7 +883,7 @@ func BenchmarkServiceV1_HashPassword(b *testing.B) {
 
 func BenchmarkServiceV1_VerifyPassword(b *testing.B) {
 	config := DefaultAuthConfig()
-	service := NewServiceV1(nil, nil, config)
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
 
 	hash, _ := service.HashPassword("password123")
 
@@ -646,7 +895,7 @@ func BenchmarkServiceV1_VerifyPassword(b *testing.B) {
 
 func BenchmarkServiceV1_GenerateAccessToken(b *testing.B) {
 	config := DefaultAuthConfig()
-	service := NewServiceV1(nil, nil, config)
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
 
 	claims := &domain.TokenClaims{
 		UserID:   "user123",
@@ -659,3 +908,1230 @@ func BenchmarkServiceV1_GenerateAccessToken(b *testing.B) {
 		service.GenerateAccessToken(claims)
 	}
 }
+
+func TestServiceV1_CreateRole(t *testing.T) {
+	permissions := []domain.Permission{
+		{ID: "p1", Name: domain.PermissionProductRead},
+		{ID: "p2", Name: domain.PermissionProductWrite},
+	}
+
+	tests := []struct {
+		name    string
+		req     *domain.CreateRoleRequest
+		setup   func(repo *mocks.MockRepository)
+		wantErr *sharederrors.DomainError
+	}{
+		{
+			name: "success",
+			req:  &domain.CreateRoleRequest{Name: "editor", Permissions: []string{domain.PermissionProductWrite}},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetRoleByName(gomock.Any(), "editor").Return(nil, errors.New("not found"))
+				repo.EXPECT().ListPermissions(gomock.Any()).Return(permissions, nil)
+				repo.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "role already exists",
+			req:  &domain.CreateRoleRequest{Name: domain.RoleAdmin},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetRoleByName(gomock.Any(), domain.RoleAdmin).Return(&domain.Role{ID: "r1", Name: domain.RoleAdmin}, nil)
+			},
+			wantErr: ErrRoleExists,
+		},
+		{
+			name: "unknown permission",
+			req:  &domain.CreateRoleRequest{Name: "editor", Permissions: []string{"product:destroy"}},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetRoleByName(gomock.Any(), "editor").Return(nil, errors.New("not found"))
+				repo.EXPECT().ListPermissions(gomock.Any()).Return(permissions, nil)
+			},
+			wantErr: ErrUnknownPermission,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			tt.setup(mockRepo)
+
+			service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+			role, err := service.CreateRole(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.True(t, sharederrors.Is(err, tt.wantErr))
+				assert.Nil(t, role)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.Name, role.Name)
+			assert.Equal(t, tt.req.Permissions, role.Permissions)
+		})
+	}
+}
+
+func TestServiceV1_DeleteRole_Admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetRoleByID(ctx, "r1").Return(&domain.Role{ID: "r1", Name: domain.RoleAdmin}, nil).Times(1)
+
+	err := service.DeleteRole(ctx, "r1")
+
+	assert.Equal(t, ErrRoleProtected, err)
+}
+
+func TestServiceV1_AssignRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(&domain.Credential{UserID: "user123"}, nil).Times(1)
+	mockRepo.EXPECT().GetRoleByName(ctx, domain.RoleAdmin).Return(&domain.Role{ID: "r1", Name: domain.RoleAdmin}, nil).Times(1)
+	mockRepo.EXPECT().AssignRole(ctx, &domain.UserRole{UserID: "user123", RoleID: "r1", AssignedBy: "admin1"}).Return(nil).Times(1)
+
+	err := service.AssignRole(ctx, "admin1", "user123", &domain.AssignRoleRequest{Role: domain.RoleAdmin})
+
+	assert.NoError(t, err)
+}
+
+func TestServiceV1_AssignRole_RoleNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(&domain.Credential{UserID: "user123"}, nil).Times(1)
+	mockRepo.EXPECT().GetRoleByName(ctx, "ghost").Return(nil, errors.New("not found")).Times(1)
+
+	err := service.AssignRole(ctx, "admin1", "user123", &domain.AssignRoleRequest{Role: "ghost"})
+
+	assert.Equal(t, ErrRoleNotFound, err)
+}
+
+func TestServiceV1_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockNotifier := mocks.NewMockPasswordResetNotifier(ctrl)
+
+	config := DefaultAuthConfig()
+	config.PasswordResetURL = "https://app.example.com/reset?lang=en"
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), mockNotifier, nil, nil, nil, config)
+
+	ctx := context.Background()
+	cred := &domain.Credential{UserID: "user123", Email: "test@example.com", IsActive: true}
+
+	var stored *domain.PasswordResetToken
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, cred.Email).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().CreatePasswordResetToken(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *domain.PasswordResetToken) error {
+		stored = token
+		return nil
+	}).Times(1)
+	mockNotifier.EXPECT().SendPasswordReset(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, notice *domain.PasswordResetNotice) error {
+		require.NotNil(t, stored)
+		u, err := url.Parse(notice.ResetLink)
+		require.NoError(t, err)
+		token := u.Query().Get("token")
+
+		assert.Equal(t, "en", u.Query().Get("lang"))
+		assert.NotEmpty(t, token)
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+		assert.NotEqual(t, token, stored.TokenHash)
+		assert.Equal(t, cred.Email, notice.Email)
+		return nil
+	}).Times(1)
+
+	err := service.ResetPassword(ctx, &domain.ResetPasswordRequest{Email: cred.Email})
+
+	require.NoError(t, err)
+	assert.Equal(t, cred.UserID, stored.UserID)
+	assert.WithinDuration(t, time.Now().Add(config.PasswordResetTokenDuration), stored.ExpiresAt, time.Minute)
+}
+
+func TestServiceV1_ResetPassword_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockNotifier := mocks.NewMockPasswordResetNotifier(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), mockNotifier, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, "nobody@example.com").Return(nil, errors.New("not found")).Times(1)
+
+	err := service.ResetPassword(ctx, &domain.ResetPasswordRequest{Email: "nobody@example.com"})
+
+	assert.NoError(t, err)
+}
+
+func TestServiceV1_ConfirmResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	req := &domain.ConfirmResetPasswordRequest{Token: "reset_token", NewPassword: "newpassword123"}
+
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().ConsumePasswordResetToken(txCtx, hashToken(req.Token)).Return(&domain.PasswordResetToken{ID: "t1", UserID: "user123"}, nil).Times(1)
+	mockRepo.EXPECT().UpdatePassword(txCtx, "user123", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, hash string) error {
+		assert.NoError(t, service.VerifyPassword(hash, req.NewPassword))
+		return nil
+	}).Times(1)
+	mockRepo.EXPECT().InvalidatePasswordResetTokens(txCtx, "user123").Return(nil).Times(1)
+	mockRepo.EXPECT().RevokeAllUserSessions(txCtx, "user123").Return(nil).Times(1)
+
+	err := service.ConfirmResetPassword(ctx, req)
+
+	assert.NoError(t, err)
+}
+
+func TestServiceV1_ConfirmResetPassword_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	req := &domain.ConfirmResetPasswordRequest{Token: "used_token", NewPassword: "newpassword123"}
+
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().ConsumePasswordResetToken(txCtx, hashToken(req.Token)).Return(nil, errors.New("no rows")).Times(1)
+
+	err := service.ConfirmResetPassword(ctx, req)
+
+	assert.Equal(t, ErrInvalidResetToken, err)
+}
+
+func TestServiceV1_Login_EmailNotVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	config := DefaultAuthConfig()
+	config.RequireEmailVerification = true
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, config)
+
+	ctx := context.Background()
+	hashedPassword, _ := service.HashPassword("password123")
+	cred := &domain.Credential{UserID: "user123", Username: "testuser", PasswordHash: hashedPassword, IsActive: true}
+
+	mockRepo.EXPECT().GetCredentialByUsername(ctx, cred.Username).Return(cred, nil).Times(1)
+
+	resp, err := service.Login(ctx, &domain.LoginRequest{Username: cred.Username, Password: "password123"}, "Mozilla/5.0", "192.168.1.1")
+
+	assert.Equal(t, ErrEmailNotVerified, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_Register_NoVerificationWhenCommitFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	mockUserCreator := mocks.NewMockUserCreator(ctrl)
+	mockEmail := emailmocks.NewMockEmailService(ctrl)
+
+	config := DefaultAuthConfig()
+	config.RequireEmailVerification = true
+	config.DefaultRole = ""
+	service := NewServiceV1(mockRepo, mockUOW, mockUserCreator, nil, nil, mockEmail, nil, config)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	req := &domain.RegisterRequest{Username: "newuser", Email: "newuser@example.com", Password: "password123", Name: "New User"}
+	commitErr := errors.New("commit failed")
+
+	mockRepo.EXPECT().GetCredentialByUsername(ctx, req.Username).Return(nil, errors.New("not found")).Times(1)
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, req.Email).Return(nil, errors.New("not found")).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, commitErr)
+	mockUserCreator.EXPECT().CreateUser(txCtx, gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().CreateCredential(txCtx, gomock.Any()).Return(nil).Times(1)
+	mockEmail.EXPECT().SendTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	resp, err := service.Register(ctx, req)
+
+	assert.Equal(t, commitErr, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_Register_SendsVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	mockUserCreator := mocks.NewMockUserCreator(ctrl)
+	mockEmail := emailmocks.NewMockEmailService(ctrl)
+
+	config := DefaultAuthConfig()
+	config.RequireEmailVerification = true
+	config.DefaultRole = ""
+	service := NewServiceV1(mockRepo, mockUOW, mockUserCreator, nil, nil, mockEmail, nil, config)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	req := &domain.RegisterRequest{
+		Username: "newuser",
+		Email:    "newuser@example.com",
+		Password: "password123",
+		Name:     "New User",
+	}
+
+	var cred *domain.Credential
+	var link string
+	mockRepo.EXPECT().GetCredentialByUsername(ctx, req.Username).Return(nil, errors.New("not found")).Times(1)
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, req.Email).Return(nil, errors.New("not found")).Times(1)
+	tx := expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockUserCreator.EXPECT().CreateUser(txCtx, gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().CreateCredential(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, c *domain.Credential) error {
+		assert.Nil(t, c.EmailVerifiedAt)
+		cred = c
+		return nil
+	}).Times(1)
+	// The link goes out once the registration is committed
+	mockEmail.EXPECT().SendTemplate(ctx, []string{req.Email}, string(email.EmailTypeVerification), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ []string, _ string, data map[string]interface{}) error {
+			link, _ = data["verification_link"].(string)
+			return nil
+		}).After(tx).Times(1)
+
+	resp, err := service.Register(ctx, req)
+
+	require.NoError(t, err)
+	assert.Contains(t, resp.Message, "verify")
+
+	// The emailed link verifies the new account
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().MarkEmailVerified(ctx, cred.UserID).Return(nil).Times(1)
+
+	err = service.VerifyEmail(ctx, &domain.VerifyEmailRequest{Token: u.Query().Get("token")})
+
+	assert.NoError(t, err)
+}
+
+func TestServiceV1_VerifyEmail_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	cred := &domain.Credential{UserID: "user123", Email: "test@example.com"}
+
+	accessToken, err := service.GenerateAccessToken(&domain.TokenClaims{UserID: cred.UserID, Email: cred.Email})
+	require.NoError(t, err)
+	verificationToken, err := service.generateVerificationToken(cred)
+	require.NoError(t, err)
+
+	// Access tokens are signed with a different key
+	assert.Equal(t, ErrInvalidVerificationToken, service.VerifyEmail(ctx, &domain.VerifyEmailRequest{Token: accessToken}))
+	_, err = service.ParseToken(verificationToken)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// The address changed after the token was issued
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(&domain.Credential{UserID: cred.UserID, Email: "other@example.com"}, nil).Times(1)
+	assert.Equal(t, ErrInvalidVerificationToken, service.VerifyEmail(ctx, &domain.VerifyEmailRequest{Token: verificationToken}))
+}
+
+func TestServiceV1_ResendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockEmail := emailmocks.NewMockEmailService(ctrl)
+	mockCache := cachemocks.NewMockCache(ctrl)
+
+	config := DefaultAuthConfig()
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, mockEmail, mockCache, config)
+
+	ctx := context.Background()
+	cred := &domain.Credential{UserID: "user123", Username: "testuser", Email: "test@example.com", IsActive: true}
+	key := verificationResendKeyPrefix + cred.Email
+
+	mockCache.EXPECT().SetNX(ctx, key, gomock.Any(), config.VerificationResendInterval).Return(true, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, "Test@Example.com").Return(cred, nil).Times(1)
+	mockEmail.EXPECT().SendTemplate(ctx, []string{cred.Email}, string(email.EmailTypeVerification), gomock.Any()).Return(nil).Times(1)
+
+	err := service.ResendVerification(ctx, &domain.ResendVerificationRequest{Email: "Test@Example.com"})
+	require.NoError(t, err)
+
+	// A second request inside the interval is rejected before any lookup
+	mockCache.EXPECT().SetNX(ctx, key, gomock.Any(), config.VerificationResendInterval).Return(false, nil).Times(1)
+
+	err = service.ResendVerification(ctx, &domain.ResendVerificationRequest{Email: cred.Email})
+	assert.Equal(t, ErrVerificationRateLimited, err)
+	assert.True(t, sharederrors.Is(err, sharederrors.ErrRateLimited))
+}
+
+func TestServiceV1_ResendVerification_AlreadyVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockEmail := emailmocks.NewMockEmailService(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, mockEmail, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	verifiedAt := time.Now().UTC()
+	cred := &domain.Credential{UserID: "user123", Email: "test@example.com", IsActive: true, EmailVerifiedAt: &verifiedAt}
+
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, cred.Email).Return(cred, nil).Times(1)
+
+	err := service.ResendVerification(ctx, &domain.ResendVerificationRequest{Email: cred.Email})
+
+	assert.NoError(t, err)
+}
+
+func TestServiceV1_Login_MFAChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	config := DefaultAuthConfig()
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, cache.NewInMemoryCache(), config)
+
+	ctx := context.Background()
+	hashedPassword, _ := service.HashPassword("password123")
+	cred := &domain.Credential{UserID: "user123", Username: "testuser", PasswordHash: hashedPassword, IsActive: true}
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	enabledAt := time.Now().UTC()
+	enrollment := &domain.MFAEnrollment{UserID: cred.UserID, Secret: secret, EnabledAt: &enabledAt}
+
+	mockRepo.EXPECT().GetCredentialByUsername(ctx, cred.Username).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(enrollment, nil).Times(2)
+
+	resp, err := service.Login(ctx, &domain.LoginRequest{Username: cred.Username, Password: "password123"}, "Mozilla/5.0", "192.168.1.1")
+
+	require.NoError(t, err)
+	assert.True(t, resp.MFARequired)
+	assert.NotEmpty(t, resp.MFAToken)
+	assert.Empty(t, resp.AccessToken)
+	assert.Empty(t, resp.RefreshToken)
+
+	// The challenge is not an access token
+	_, err = service.ParseToken(resp.MFAToken)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// Exchanging the challenge with a current code completes the login
+	step := totpStep(time.Now())
+	code, err := totpCode(secret, step)
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().UseMFAStep(ctx, cred.UserID, step).Return(nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, cred.UserID).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().UpdateLastLogin(ctx, cred.UserID).Return(nil).Times(1)
+	mockRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
+
+	loginResp, err := service.VerifyMFA(ctx, &domain.VerifyMFARequest{MFAToken: resp.MFAToken, Code: code}, "Mozilla/5.0", "192.168.1.1")
+
+	require.NoError(t, err)
+	assert.False(t, loginResp.MFARequired)
+	assert.NotEmpty(t, loginResp.AccessToken)
+	assert.NotEmpty(t, loginResp.RefreshToken)
+}
+
+func TestServiceV1_VerifyMFA_Codes(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	enabledAt := time.Now().UTC()
+	enrollment := &domain.MFAEnrollment{UserID: "user123", Secret: secret, EnabledAt: &enabledAt}
+	cred := &domain.Credential{UserID: "user123", IsActive: true}
+	step := totpStep(time.Now())
+	code, err := totpCode(secret, step)
+	require.NoError(t, err)
+	wrongCode := "000000"
+	for _, candidate := range []string{"000000", "111111", "222222", "333333"} {
+		if _, ok := validateTOTP(secret, candidate, time.Now()); !ok {
+			wrongCode = candidate
+			break
+		}
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func(ctx context.Context, repo *mocks.MockRepository)
+		wantErr error
+	}{
+		{
+			name: "replayed totp code",
+			code: code,
+			setup: func(ctx context.Context, repo *mocks.MockRepository) {
+				repo.EXPECT().UseMFAStep(ctx, cred.UserID, step).Return(errors.New("no rows")).Times(1)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name:    "wrong totp code",
+			code:    wrongCode,
+			setup:   func(ctx context.Context, repo *mocks.MockRepository) {},
+			wantErr: ErrInvalidMFACode,
+		},
+		{
+			name: "used recovery code",
+			code: "abcde-fghij",
+			setup: func(ctx context.Context, repo *mocks.MockRepository) {
+				repo.EXPECT().ConsumeMFARecoveryCode(ctx, cred.UserID, hashToken("abcdefghij")).Return(errors.New("no rows")).Times(1)
+			},
+			wantErr: ErrInvalidMFACode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, cache.NewInMemoryCache(), DefaultAuthConfig())
+			ctx := context.Background()
+
+			challenge, err := service.mfaChallenge(cred)
+			require.NoError(t, err)
+
+			mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(cred, nil).Times(1)
+			mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(enrollment, nil).Times(1)
+			tt.setup(ctx, mockRepo)
+
+			resp, err := service.VerifyMFA(ctx, &domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: tt.code}, "", "")
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+func TestServiceV1_VerifyMFA_AttemptsExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := cachemocks.NewMockCache(ctrl)
+	service := NewServiceV1(mocks.NewMockRepository(ctrl), nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, mockCache, DefaultAuthConfig())
+
+	ctx := context.Background()
+	challenge, err := service.mfaChallenge(&domain.Credential{UserID: "user123"})
+	require.NoError(t, err)
+
+	mockCache.EXPECT().Increment(ctx, mfaAttemptsKeyPrefix+"user123", int64(1)).Return(int64(maxMFAAttempts+1), nil).Times(1)
+
+	resp, err := service.VerifyMFA(ctx, &domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "123456"}, "", "")
+
+	assert.Equal(t, ErrMFAAttemptsExceeded, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_VerifyMFA_AttemptsCountedPerUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	enabledAt := time.Now().UTC()
+	cred := &domain.Credential{UserID: "user123", IsActive: true}
+	enrollment := &domain.MFAEnrollment{UserID: cred.UserID, Secret: secret, EnabledAt: &enabledAt}
+	wrongCode := "000000"
+	for _, candidate := range []string{"000000", "111111", "222222", "333333"} {
+		if _, ok := validateTOTP(secret, candidate, time.Now()); !ok {
+			wrongCode = candidate
+			break
+		}
+	}
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, cache.NewInMemoryCache(), DefaultAuthConfig())
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(cred, nil).Times(maxMFAAttempts)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(enrollment, nil).Times(maxMFAAttempts)
+
+	// A new login for every guess must not start a new count
+	for i := 0; i < maxMFAAttempts; i++ {
+		challenge, err := service.mfaChallenge(cred)
+		require.NoError(t, err)
+		_, err = service.VerifyMFA(ctx, &domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: wrongCode}, "", "")
+		require.Equal(t, ErrInvalidMFACode, err)
+	}
+
+	challenge, err := service.mfaChallenge(cred)
+	require.NoError(t, err)
+	resp, err := service.VerifyMFA(ctx, &domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: wrongCode}, "", "")
+
+	assert.Equal(t, ErrMFAAttemptsExceeded, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_VerifyMFA_FailsClosedWithoutCache(t *testing.T) {
+	cacheErr := errors.New("cache down")
+
+	tests := []struct {
+		name  string
+		cache func(ctrl *gomock.Controller) cache.Cache
+	}{
+		{
+			name:  "no cache",
+			cache: func(ctrl *gomock.Controller) cache.Cache { return nil },
+		},
+		{
+			name: "cache error",
+			cache: func(ctrl *gomock.Controller) cache.Cache {
+				mockCache := cachemocks.NewMockCache(ctrl)
+				mockCache.EXPECT().Increment(gomock.Any(), mfaAttemptsKeyPrefix+"user123", int64(1)).Return(int64(0), cacheErr).Times(1)
+				return mockCache
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := NewServiceV1(mocks.NewMockRepository(ctrl), nil, mocks.NewMockUserCreator(ctrl), nil, nil, nil, tt.cache(ctrl), DefaultAuthConfig())
+			challenge, err := service.mfaChallenge(&domain.Credential{UserID: "user123"})
+			require.NoError(t, err)
+
+			resp, err := service.VerifyMFA(context.Background(), &domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "123456"}, "", "")
+
+			assert.True(t, sharederrors.Is(err, ErrMFAUnavailable))
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+func TestServiceV1_EnrollAndEnableMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	cred := &domain.Credential{UserID: "user123", Email: "test@example.com"}
+
+	var saved *domain.MFAEnrollment
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, cred.UserID).Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().SaveMFAEnrollment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *domain.MFAEnrollment) error {
+		saved = e
+		return nil
+	}).Times(1)
+
+	enrollResp, err := service.EnrollMFA(ctx, cred.UserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, saved.Secret, enrollResp.Secret)
+	assert.Nil(t, saved.EnabledAt)
+	u, err := url.Parse(enrollResp.OTPAuthURI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, enrollResp.Secret, u.Query().Get("secret"))
+
+	// Confirming with a current code enables MFA and returns recovery codes
+	step := totpStep(time.Now())
+	code, err := totpCode(saved.Secret, step)
+	require.NoError(t, err)
+
+	var stored []domain.MFARecoveryCode
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, cred.UserID).Return(saved, nil).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().UseMFAStep(txCtx, cred.UserID, step).Return(nil).Times(1)
+	mockRepo.EXPECT().EnableMFA(txCtx, cred.UserID).Return(nil).Times(1)
+	mockRepo.EXPECT().ReplaceMFARecoveryCodes(txCtx, cred.UserID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, codes []domain.MFARecoveryCode) error {
+		stored = codes
+		return nil
+	}).Times(1)
+
+	codesResp, err := service.EnableMFA(ctx, cred.UserID, &domain.MFACodeRequest{Code: code})
+
+	require.NoError(t, err)
+	require.Len(t, codesResp.RecoveryCodes, recoveryCodeCount)
+	require.Len(t, stored, recoveryCodeCount)
+	for i, c := range codesResp.RecoveryCodes {
+		assert.Equal(t, hashToken(normalizeRecoveryCode(c)), stored[i].CodeHash)
+	}
+}
+
+func TestServiceV1_EnableMFA_RejectsRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, "user123").Return(&domain.MFAEnrollment{UserID: "user123", Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}, nil).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+
+	resp, err := service.EnableMFA(ctx, "user123", &domain.MFACodeRequest{Code: "abcde-fghij"})
+
+	assert.Equal(t, ErrInvalidMFACode, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_DisableMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service := NewServiceV1(mockRepo, mockUOW, mocks.NewMockUserCreator(ctrl), nil, nil, nil, nil, DefaultAuthConfig())
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	enabledAt := time.Now().UTC()
+
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, "user123").Return(&domain.MFAEnrollment{UserID: "user123", EnabledAt: &enabledAt}, nil).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockRepo.EXPECT().ConsumeMFARecoveryCode(txCtx, "user123", hashToken("abcdefghij")).Return(nil).Times(1)
+	mockRepo.EXPECT().DeleteMFAEnrollment(txCtx, "user123").Return(nil).Times(1)
+
+	err := service.DisableMFA(ctx, "user123", &domain.MFACodeRequest{Code: "ABCDE-FGHIJ"})
+
+	assert.NoError(t, err)
+}
+
+// startOIDCTest wires a service with one mocked identity provider named "stub"
+// and returns it with the state of a started login
+func startOIDCTest(t *testing.T, ctrl *gomock.Controller, linkUserID string) (*ServiceV1, *mocks.MockRepository, *mocks.MockUserCreator, *mocks.MockIdentityProvider, string) {
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUserCreator := mocks.NewMockUserCreator(ctrl)
+	mockProvider := mocks.NewMockIdentityProvider(ctrl)
+
+	config := DefaultAuthConfig()
+	config.BcryptCost = bcryptMinCostForTests
+	config.IdentityProviders = map[string]domain.IdentityProvider{"stub": mockProvider}
+	service := NewServiceV1(mockRepo, nil, mockUserCreator, nil, nil, nil, cache.NewInMemoryCache(), config)
+
+	var state string
+	mockProvider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s, nonce, verifier string) (string, error) {
+			state = s
+			return "https://idp.example.com/authorize?state=" + s, nil
+		}).Times(1)
+
+	var resp *domain.OIDCAuthorizationResponse
+	var err error
+	if linkUserID != "" {
+		resp, err = service.StartOIDCLink(context.Background(), linkUserID, "stub")
+	} else {
+		resp, err = service.StartOIDCLogin(context.Background(), "stub")
+	}
+	require.NoError(t, err)
+	require.Equal(t, state, resp.State)
+	assert.Contains(t, resp.AuthorizationURL, state)
+
+	return service, mockRepo, mockUserCreator, mockProvider, state
+}
+
+// bcryptMinCostForTests keeps the random password hash of provider sign-ups fast
+const bcryptMinCostForTests = 4
+
+func TestServiceV1_StartOIDCLogin_UnknownProvider(t *testing.T) {
+	service := NewServiceV1(nil, nil, nil, nil, nil, nil, cache.NewInMemoryCache(), DefaultAuthConfig())
+
+	resp, err := service.StartOIDCLogin(context.Background(), "nope")
+
+	assert.Equal(t, ErrUnknownIdentityProvider, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_CompleteOIDCLogin_LinkedIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockProvider, state := startOIDCTest(t, ctrl, "")
+	ctx := context.Background()
+	cred := &domain.Credential{UserID: "user123", Username: "jane", Email: "jane@example.com", IsActive: true}
+	external := &domain.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true}
+
+	mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(external, nil).Times(1)
+	mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(&domain.Identity{UserID: "user123", Provider: "stub", Subject: "sub-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, "user123").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, "user123").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().UpdateLastLogin(ctx, "user123").Return(nil).Times(1)
+	mockRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
+
+	resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state}, "Mozilla/5.0", "127.0.0.1")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, "user123", resp.User.ID)
+
+	// The state is single-use
+	_, err = service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state}, "", "")
+	assert.Equal(t, ErrInvalidOIDCState, err)
+}
+
+func TestServiceV1_CompleteOIDCLogin_MFAStillRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockProvider, state := startOIDCTest(t, ctrl, "")
+	ctx := context.Background()
+	enabledAt := time.Now().UTC()
+
+	mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(&domain.ExternalIdentity{Subject: "sub-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(&domain.Identity{UserID: "user123"}, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(&domain.Credential{UserID: "user123", IsActive: true}, nil).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, "user123").Return(&domain.MFAEnrollment{UserID: "user123", EnabledAt: &enabledAt}, nil).Times(1)
+
+	resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state}, "", "")
+
+	require.NoError(t, err)
+	assert.True(t, resp.MFARequired)
+	assert.Empty(t, resp.AccessToken)
+}
+
+func TestServiceV1_CompleteOIDCLogin_RegistersNewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, mockUserCreator, mockProvider, state := startOIDCTest(t, ctrl, "")
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	service.uow = mockUOW
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	external := &domain.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true, Name: "Jane Doe", Username: "Jane.Doe"}
+
+	var created *domain.Credential
+	mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(external, nil).Times(1)
+	mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByEmail(ctx, "jane@example.com").Return(nil, errors.New("not found")).Times(1)
+	gomock.InOrder(
+		mockRepo.EXPECT().GetCredentialByUsername(ctx, "jane.doe").Return(&domain.Credential{}, nil),
+		mockRepo.EXPECT().GetCredentialByUsername(ctx, gomock.Any()).Return(nil, errors.New("not found")),
+	)
+	expectTransaction(mockUOW, ctx, txCtx, nil)
+	mockUserCreator.EXPECT().CreateUser(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.NewUser) error {
+		assert.Equal(t, "Jane Doe", u.Name)
+		assert.Equal(t, "jane@example.com", u.Email)
+		return nil
+	}).Times(1)
+	mockRepo.EXPECT().CreateCredential(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, c *domain.Credential) error {
+		created = c
+		return nil
+	}).Times(1)
+	mockRepo.EXPECT().GetRoleByName(txCtx, domain.RoleUser).Return(&domain.Role{ID: "role-user", Name: domain.RoleUser}, nil).Times(1)
+	mockRepo.EXPECT().AssignRole(txCtx, gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().GetIdentitiesByUserID(txCtx, gomock.Any()).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().CreateIdentity(txCtx, gomock.Any()).DoAndReturn(func(_ context.Context, identity *domain.Identity) error {
+		assert.Equal(t, "stub", identity.Provider)
+		assert.Equal(t, "sub-1", identity.Subject)
+		assert.Equal(t, created.UserID, identity.UserID)
+		return nil
+	}).Times(1)
+	mockRepo.EXPECT().GetMFAEnrollment(ctx, gomock.Any()).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserRoles(ctx, gomock.Any()).Return([]domain.Role{{Name: domain.RoleUser}}, nil).Times(1)
+	mockRepo.EXPECT().UpdateLastLogin(ctx, gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
+
+	resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state}, "", "")
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Regexp(t, `^jane\.doe-[0-9a-f]{6}$`, created.Username)
+	assert.NotNil(t, created.EmailVerifiedAt)
+	assert.NotEmpty(t, created.PasswordHash)
+	assert.Equal(t, created.UserID, resp.User.ID)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestServiceV1_CompleteOIDCLogin_EmailMatch(t *testing.T) {
+	verifiedAt := time.Now().UTC()
+
+	tests := []struct {
+		name     string
+		external *domain.ExternalIdentity
+		local    *domain.Credential
+		wantErr  error
+	}{
+		{
+			name:     "links verified account",
+			external: &domain.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true},
+			local:    &domain.Credential{UserID: "user123", Email: "jane@example.com", IsActive: true, EmailVerifiedAt: &verifiedAt},
+		},
+		{
+			name:     "refuses unverified local account",
+			external: &domain.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com", EmailVerified: true},
+			local:    &domain.Credential{UserID: "user123", Email: "jane@example.com", IsActive: true},
+			wantErr:  ErrOIDCEmailInUse,
+		},
+		{
+			name:     "refuses unverified provider email",
+			external: &domain.ExternalIdentity{Subject: "sub-1", Email: "jane@example.com"},
+			wantErr:  ErrOIDCEmailNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service, mockRepo, _, mockProvider, state := startOIDCTest(t, ctrl, "")
+			ctx := context.Background()
+
+			mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(tt.external, nil).Times(1)
+			mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(nil, nil).Times(1)
+			if tt.local != nil {
+				mockRepo.EXPECT().GetCredentialByEmail(ctx, tt.local.Email).Return(tt.local, nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().GetIdentitiesByUserID(ctx, "user123").Return(nil, nil).Times(1)
+				mockRepo.EXPECT().CreateIdentity(ctx, gomock.Any()).Return(nil).Times(1)
+				mockRepo.EXPECT().GetMFAEnrollment(ctx, "user123").Return(nil, nil).Times(1)
+				mockRepo.EXPECT().GetUserRoles(ctx, "user123").Return(nil, nil).Times(1)
+				mockRepo.EXPECT().UpdateLastLogin(ctx, "user123").Return(nil).Times(1)
+				mockRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
+			}
+
+			resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state}, "", "")
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user123", resp.User.ID)
+		})
+	}
+}
+
+func TestServiceV1_CompleteOIDCLogin_Link(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockProvider, state := startOIDCTest(t, ctrl, "user123")
+	ctx := context.Background()
+	cred := &domain.Credential{UserID: "user123", Username: "jane", Email: "jane@example.com", IsActive: true}
+
+	mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(&domain.ExternalIdentity{Subject: "sub-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(cred, nil).Times(1)
+	mockRepo.EXPECT().GetIdentitiesByUserID(ctx, "user123").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().CreateIdentity(ctx, gomock.Any()).Return(nil).Times(1)
+
+	resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state, UserID: "user123"}, "", "")
+
+	require.NoError(t, err)
+	assert.True(t, resp.Linked)
+	assert.Equal(t, "user123", resp.User.ID)
+	assert.Empty(t, resp.AccessToken, "a link completion is not a login")
+	assert.Empty(t, resp.RefreshToken)
+}
+
+func TestServiceV1_CompleteOIDCLogin_LinkByOtherUser(t *testing.T) {
+	for _, callerID := range []string{"", "user456"} {
+		t.Run("caller "+callerID, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The provider code is never redeemed for someone who did not start the link
+			service, _, _, _, state := startOIDCTest(t, ctrl, "user123")
+
+			resp, err := service.CompleteOIDCLogin(context.Background(), &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state, UserID: callerID}, "", "")
+
+			assert.Equal(t, ErrOIDCLinkUserMismatch, err)
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+func TestServiceV1_CompleteOIDCLogin_LinkTakenIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockProvider, state := startOIDCTest(t, ctrl, "user123")
+	ctx := context.Background()
+
+	// The provider account already belongs to someone else
+	mockProvider.EXPECT().Exchange(ctx, "code-1", gomock.Any(), gomock.Any()).Return(&domain.ExternalIdentity{Subject: "sub-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetIdentity(ctx, "stub", "sub-1").Return(&domain.Identity{UserID: "user456"}, nil).Times(1)
+
+	resp, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "code-1", State: state, UserID: "user123"}, "", "")
+
+	assert.Equal(t, ErrIdentityLinked, err)
+	assert.Nil(t, resp)
+}
+
+func TestServiceV1_CompleteOIDCLogin_InvalidCallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _, mockProvider, state := startOIDCTest(t, ctrl, "")
+	ctx := context.Background()
+
+	// State issued for a different provider route
+	_, err := service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "other", Code: "code-1", State: state}, "", "")
+	assert.Equal(t, ErrInvalidOIDCState, err)
+
+	service, _, _, mockProvider, state = startOIDCTest(t, ctrl, "")
+	mockProvider.EXPECT().Exchange(ctx, "bad-code", gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid_grant")).Times(1)
+
+	_, err = service.CompleteOIDCLogin(ctx, &domain.OIDCCallbackRequest{Provider: "stub", Code: "bad-code", State: state}, "", "")
+	assert.True(t, sharederrors.Is(err, ErrOIDCLoginFailed))
+	assert.Equal(t, 401, sharederrors.HTTPStatusCode(err))
+}
+
+func TestServiceV1_UnlinkIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+	ctx := context.Background()
+	identities := []domain.Identity{{ID: "id-1", UserID: "user123", Provider: "google"}}
+
+	mockRepo.EXPECT().GetIdentitiesByUserID(ctx, "user123").Return(identities, nil).Times(2)
+	mockRepo.EXPECT().DeleteIdentity(ctx, "id-1").Return(nil).Times(1)
+
+	assert.NoError(t, service.UnlinkIdentity(ctx, "user123", "google"))
+	assert.Equal(t, ErrIdentityNotLinked, service.UnlinkIdentity(ctx, "user123", "github"))
+}
+
+func TestServiceV1_RegisterClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+	ctx := context.Background()
+
+	permissions := []domain.Permission{
+		{ID: "p1", Name: domain.PermissionProductRead},
+		{ID: "p2", Name: domain.PermissionProductWrite},
+	}
+	mockRepo.EXPECT().ListPermissions(ctx).Return(permissions, nil).Times(2)
+
+	var stored *domain.Client
+	mockRepo.EXPECT().CreateClient(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, client *domain.Client) error {
+		client.ID = "3f1c2a9e-8f6b-4d2e-9a57-0c1d2e3f4a5b"
+		stored = client
+		return nil
+	})
+
+	resp, err := service.RegisterClient(ctx, "admin123", &domain.RegisterClientRequest{
+		Name:   "billing-service",
+		Scopes: []string{domain.PermissionProductWrite, domain.PermissionProductRead, domain.PermissionProductWrite},
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ClientSecret)
+	assert.Equal(t, stored.ID, resp.Client.ID)
+	assert.Equal(t, hashToken(resp.ClientSecret), stored.SecretHash)
+	assert.Equal(t, []string{domain.PermissionProductRead, domain.PermissionProductWrite}, stored.Scopes)
+	assert.Equal(t, "admin123", stored.CreatedBy)
+
+	_, err = service.RegisterClient(ctx, "admin123", &domain.RegisterClientRequest{Name: "billing-service", Scopes: []string{"product:destroy"}})
+	assert.True(t, sharederrors.Is(err, ErrUnknownPermission))
+}
+
+func newTestClient() (*domain.Client, string) {
+	secret := "s3cr3t-client-secret"
+	return &domain.Client{
+		ID:         "3f1c2a9e-8f6b-4d2e-9a57-0c1d2e3f4a5b",
+		Name:       "billing-service",
+		SecretHash: hashToken(secret),
+		Scopes:     []string{domain.PermissionProductRead, domain.PermissionProductWrite},
+	}, secret
+}
+
+func TestServiceV1_IssueClientToken(t *testing.T) {
+	client, secret := newTestClient()
+
+	tests := []struct {
+		name       string
+		req        *domain.ClientTokenRequest
+		setup      func(repo *mocks.MockRepository)
+		wantScopes []string
+		wantErr    *sharederrors.DomainError
+	}{
+		{
+			name: "all scopes by default",
+			req:  &domain.ClientTokenRequest{ClientID: client.ID, ClientSecret: secret},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetClientByID(gomock.Any(), client.ID).Return(client, nil)
+			},
+			wantScopes: client.Scopes,
+		},
+		{
+			name: "requested subset",
+			req:  &domain.ClientTokenRequest{ClientID: client.ID, ClientSecret: secret, Scope: " product:read  product:read "},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetClientByID(gomock.Any(), client.ID).Return(client, nil)
+			},
+			wantScopes: []string{domain.PermissionProductRead},
+		},
+		{
+			name: "scope not allowed",
+			req:  &domain.ClientTokenRequest{ClientID: client.ID, ClientSecret: secret, Scope: "product:read rbac:manage"},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetClientByID(gomock.Any(), client.ID).Return(client, nil)
+			},
+			wantErr: ErrInvalidScope,
+		},
+		{
+			name: "wrong secret",
+			req:  &domain.ClientTokenRequest{ClientID: client.ID, ClientSecret: "guess"},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetClientByID(gomock.Any(), client.ID).Return(client, nil)
+			},
+			wantErr: ErrInvalidClient,
+		},
+		{
+			name: "unknown client",
+			req:  &domain.ClientTokenRequest{ClientID: client.ID, ClientSecret: secret},
+			setup: func(repo *mocks.MockRepository) {
+				repo.EXPECT().GetClientByID(gomock.Any(), client.ID).Return(nil, nil)
+			},
+			wantErr: ErrInvalidClient,
+		},
+		{
+			name:    "malformed client id",
+			req:     &domain.ClientTokenRequest{ClientID: "billing", ClientSecret: secret},
+			setup:   func(repo *mocks.MockRepository) {},
+			wantErr: ErrInvalidClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			tt.setup(mockRepo)
+
+			service := NewServiceV1(mockRepo, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+			resp, err := service.IssueClientToken(context.Background(), tt.req)
+
+			if tt.wantErr != nil {
+				assert.True(t, sharederrors.Is(err, tt.wantErr), err)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer", resp.TokenType)
+			assert.Equal(t, strings.Join(tt.wantScopes, " "), resp.Scope)
+
+			claims, err := service.ParseToken(resp.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, client.ID, claims.ClientID)
+			assert.Equal(t, client.ID, claims.UserID)
+			assert.Equal(t, tt.wantScopes, claims.Permissions)
+		})
+	}
+}
+
+func TestServiceV1_ValidateToken_ClientToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+	ctx := context.Background()
+
+	client, _ := newTestClient()
+	token, err := service.GenerateAccessToken(&domain.TokenClaims{
+		UserID:      client.ID,
+		Username:    client.Name,
+		Permissions: client.Scopes,
+		ClientID:    client.ID,
+	})
+	require.NoError(t, err)
+
+	// The client lost product:write after the token was issued
+	narrowed := *client
+	narrowed.Scopes = []string{domain.PermissionProductRead}
+	mockRepo.EXPECT().GetClientByID(ctx, client.ID).Return(&narrowed, nil)
+
+	resp, err := service.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	require.True(t, resp.Valid)
+	assert.Equal(t, client.ID, resp.User.ID)
+	assert.Equal(t, client.ID, resp.User.ClientID)
+	assert.Equal(t, []string{domain.PermissionProductRead}, resp.User.Permissions)
+
+	// Deleted clients can no longer use their tokens
+	mockRepo.EXPECT().GetClientByID(ctx, client.ID).Return(nil, nil)
+
+	resp, err = service.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+}
+
+func TestServiceV1_IntrospectToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewServiceV1(mockRepo, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+	ctx := context.Background()
+
+	caller, secret := newTestClient()
+	token, err := service.GenerateAccessToken(&domain.TokenClaims{
+		UserID:      "user123",
+		Username:    "testuser",
+		Permissions: []string{domain.PermissionProductRead},
+	})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().GetClientByID(ctx, caller.ID).Return(caller, nil).Times(3)
+	mockRepo.EXPECT().GetCredentialByUserID(ctx, "user123").Return(&domain.Credential{UserID: "user123", IsActive: true}, nil)
+
+	resp, err := service.IntrospectToken(ctx, &domain.IntrospectTokenRequest{ClientID: caller.ID, ClientSecret: secret, Token: token})
+	require.NoError(t, err)
+	assert.True(t, resp.Active)
+	assert.Equal(t, "user123", resp.Subject)
+	assert.Equal(t, "testuser", resp.Username)
+	assert.Equal(t, domain.PermissionProductRead, resp.Scope)
+	assert.Empty(t, resp.ClientID)
+	assert.Greater(t, resp.ExpiresAt, resp.IssuedAt)
+
+	resp, err = service.IntrospectToken(ctx, &domain.IntrospectTokenRequest{ClientID: caller.ID, ClientSecret: secret, Token: "not-a-token"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.IntrospectionResponse{Active: false}, resp)
+
+	_, err = service.IntrospectToken(ctx, &domain.IntrospectTokenRequest{ClientID: caller.ID, ClientSecret: "guess", Token: token})
+	assert.True(t, sharederrors.Is(err, ErrInvalidClient))
+}
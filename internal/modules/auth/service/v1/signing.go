@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing
+const minRSAKeyBits = 2048
+
+// SigningKey is an asymmetric key that signs access tokens.
+// Keys take over signing in ActiveFrom order; a replaced key keeps verifying
+// tokens for the rotation grace period and is then dropped from the JWKS.
+type SigningKey struct {
+	ID         string        // published as the JWT "kid" header
+	Algorithm  string        // RS256, ES256, ES384, ES512 or EdDSA
+	PrivateKey crypto.Signer // *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey
+	ActiveFrom time.Time     // zero means active immediately
+}
+
+// NewSigningKey parses a PEM encoded private key (PKCS#1, SEC 1 or PKCS#8)
+// and picks the JWT algorithm matching its type
+func NewSigningKey(id string, pemData []byte, activeFrom time.Time) (SigningKey, error) {
+	if id == "" {
+		return SigningKey{}, errors.New("signing key id is required")
+	}
+
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("signing key %q: no PEM block found", id)
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("signing key %q: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("signing key %q: %w", id, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, fmt.Errorf("signing key %q: unsupported key type %T", id, key)
+	}
+	alg, err := signingAlgorithm(signer)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("signing key %q: %w", id, err)
+	}
+
+	return SigningKey{ID: id, Algorithm: alg, PrivateKey: signer, ActiveFrom: activeFrom}, nil
+}
+
+// signingAlgorithm returns the JWT algorithm used with a private key
+func signingAlgorithm(key crypto.Signer) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return "", fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256.Alg(), nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256.Alg(), nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384.Alg(), nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512.Alg(), nil
+		}
+		return "", errors.New("unsupported ECDSA curve")
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA.Alg(), nil
+	}
+	return "", fmt.Errorf("unsupported key type %T", key)
+}
+
+// keyRing selects the signing key and the verification keys at a point in time
+type keyRing struct {
+	keys  []SigningKey // sorted by ActiveFrom
+	grace time.Duration
+}
+
+func newKeyRing(keys []SigningKey, grace time.Duration) *keyRing {
+	sorted := make([]SigningKey, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+	return &keyRing{keys: sorted, grace: grace}
+}
+
+// signing returns the most recently activated key, or the earliest key when
+// none is active yet
+func (r *keyRing) signing(now time.Time) SigningKey {
+	current := r.keys[0]
+	for _, k := range r.keys[1:] {
+		if k.ActiveFrom.After(now) {
+			break
+		}
+		current = k
+	}
+	return current
+}
+
+// verifying returns the keys accepted at now: the signing key, keys scheduled
+// to take over later, and replaced keys still inside the grace period
+func (r *keyRing) verifying(now time.Time) []SigningKey {
+	keys := make([]SigningKey, 0, len(r.keys))
+	for i, k := range r.keys {
+		if i+1 < len(r.keys) && !now.Before(r.keys[i+1].ActiveFrom.Add(r.grace)) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// lookup returns the verification key with the given kid
+func (r *keyRing) lookup(kid string, now time.Time) (SigningKey, bool) {
+	for _, k := range r.verifying(now) {
+		if k.ID == kid {
+			return k, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+// publicJWK encodes the public half of a signing key
+func publicJWK(k SigningKey) (domain.JWK, error) {
+	jwk := domain.JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	switch pub := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return jwk, err
+		}
+		// Uncompressed point: 0x04 || X || Y
+		point := ecdhKey.Bytes()[1:]
+		size := len(point) / 2
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64(point[:size])
+		jwk.Y = b64(point[size:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = b64(pub)
+	default:
+		return jwk, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return jwk, nil
+}
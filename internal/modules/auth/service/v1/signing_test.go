@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pkcs8PEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func testSigningKey(t *testing.T, id string, activeFrom time.Time) SigningKey {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := NewSigningKey(id, pkcs8PEM(t, priv), activeFrom)
+	require.NoError(t, err)
+	return key
+}
+
+func newSigningService(keys ...SigningKey) *ServiceV1 {
+	config := DefaultAuthConfig()
+	config.SigningKeys = keys
+	config.KeyRotationGracePeriod = time.Hour
+	return NewServiceV1(nil, nil, nil, nil, nil, nil, nil, config)
+}
+
+func TestNewSigningKey_Algorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		alg     string
+		kty     string
+		jwkKeys func(domain.JWK) []string
+	}{
+		{
+			name:    "RSA PKCS#1",
+			pem:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			alg:     "RS256",
+			kty:     "RSA",
+			jwkKeys: func(j domain.JWK) []string { return []string{j.N, j.E} },
+		},
+		{
+			name:    "ECDSA SEC 1",
+			pem:     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			alg:     "ES256",
+			kty:     "EC",
+			jwkKeys: func(j domain.JWK) []string { return []string{j.Crv, j.X, j.Y} },
+		},
+		{
+			name:    "Ed25519 PKCS#8",
+			pem:     pkcs8PEM(t, edKey),
+			alg:     "EdDSA",
+			kty:     "OKP",
+			jwkKeys: func(j domain.JWK) []string { return []string{j.Crv, j.X} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewSigningKey("k1", tt.pem, time.Time{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, key.Algorithm)
+
+			svc := newSigningService(key)
+			token, err := svc.GenerateAccessToken(&domain.TokenClaims{UserID: "user123", Username: "testuser"})
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwtClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "k1", parsed.Header["kid"])
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+
+			claims, err := svc.ParseToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user123", claims.UserID)
+
+			jwks, err := svc.JWKS(context.Background())
+			require.NoError(t, err)
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, "k1", jwks.Keys[0].Kid)
+			assert.Equal(t, tt.alg, jwks.Keys[0].Alg)
+			for _, v := range tt.jwkKeys(jwks.Keys[0]) {
+				assert.NotEmpty(t, v)
+			}
+		})
+	}
+}
+
+func TestNewSigningKey_Invalid(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = NewSigningKey("", pkcs8PEM(t, weak), time.Time{})
+	assert.Error(t, err)
+
+	_, err = NewSigningKey("k1", []byte("not a pem"), time.Time{})
+	assert.Error(t, err)
+
+	_, err = NewSigningKey("k1", pkcs8PEM(t, weak), time.Time{})
+	assert.ErrorContains(t, err, "2048")
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	now := time.Now().UTC()
+	oldKey := testSigningKey(t, "old", now.Add(-48*time.Hour))
+	newKey := testSigningKey(t, "new", now.Add(-30*time.Minute))
+	nextKey := testSigningKey(t, "next", now.Add(24*time.Hour))
+
+	ring := newKeyRing([]SigningKey{nextKey, newKey, oldKey}, time.Hour)
+
+	assert.Equal(t, "new", ring.signing(now).ID)
+	assert.Equal(t, "next", ring.signing(now.Add(25*time.Hour)).ID)
+
+	ids := func(keys []SigningKey) []string {
+		out := make([]string, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, k.ID)
+		}
+		return out
+	}
+
+	// The replaced key verifies during the grace period; the scheduled key is pre-published
+	assert.Equal(t, []string{"old", "new", "next"}, ids(ring.verifying(now)))
+	assert.Equal(t, []string{"new", "next"}, ids(ring.verifying(now.Add(time.Hour))))
+	assert.Equal(t, []string{"next"}, ids(ring.verifying(now.Add(26*time.Hour))))
+}
+
+func TestParseToken_RotatedKeys(t *testing.T) {
+	now := time.Now().UTC()
+	oldKey := testSigningKey(t, "old", now.Add(-48*time.Hour))
+	retiredKey := testSigningKey(t, "retired", now.Add(-72*time.Hour))
+	newKey := testSigningKey(t, "new", now.Add(-30*time.Minute))
+
+	claims := &domain.TokenClaims{UserID: "user123"}
+	oldToken, err := newSigningService(oldKey).GenerateAccessToken(claims)
+	require.NoError(t, err)
+	retiredToken, err := newSigningService(retiredKey).GenerateAccessToken(claims)
+	require.NoError(t, err)
+	hsToken, err := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, DefaultAuthConfig()).GenerateAccessToken(claims)
+	require.NoError(t, err)
+
+	svc := newSigningService(retiredKey, oldKey, newKey)
+
+	// Still inside the grace period of the last rotation
+	_, err = svc.ParseToken(oldToken)
+	assert.NoError(t, err)
+
+	// Replaced two rotations ago
+	_, err = svc.ParseToken(retiredToken)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// The shared secret no longer verifies once signing keys are configured
+	_, err = svc.ParseToken(hsToken)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// A kid pointing at a key of a different type is rejected
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{UserID: "user123"})
+	forged.Header["kid"] = "new"
+	forgedToken, err := forged.SignedString([]byte("whatever"))
+	require.NoError(t, err)
+	_, err = svc.ParseToken(forgedToken)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestJWKS_HMAC(t *testing.T) {
+	svc := NewServiceV1(nil, nil, nil, nil, nil, nil, nil, DefaultAuthConfig())
+
+	jwks, err := svc.JWKS(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, jwks.Keys)
+}
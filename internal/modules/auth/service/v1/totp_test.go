@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B vectors for the SHA-1 secret "12345678901234567890", truncated to 6 digits
+func TestTOTPCode_RFC6238(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := totpCode(secret, totpStep(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, "t=%d", tt.unix)
+	}
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	now := time.Now()
+	step := totpStep(now)
+
+	previous, err := totpCode(secret, step-1)
+	require.NoError(t, err)
+	got, ok := validateTOTP(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, got)
+
+	stale, err := totpCode(secret, step-3)
+	require.NoError(t, err)
+	if _, ok := validateTOTP(secret, stale, now); ok {
+		// A stale code can only pass by colliding with a code in the window
+		current, _ := totpCode(secret, step)
+		assert.Equal(t, current, stale)
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	u, err := url.Parse(totpURI("Acme Corp", "jane@example.com", "SECRET"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Acme Corp:jane@example.com", u.Path)
+	assert.Equal(t, "SECRET", u.Query().Get("secret"))
+	assert.Equal(t, "Acme Corp", u.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, c := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, c)
+		assert.False(t, isTOTPCode(c))
+		assert.False(t, seen[c])
+		seen[c] = true
+	}
+	assert.Equal(t, "abcdefghij", normalizeRecoveryCode(" ABCDE-fghij "))
+}
@@ -0,0 +1,6 @@
+package domain
+
+import sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+
+// ErrProductNotFound is returned by repositories when no product has the requested ID
+var ErrProductNotFound = sharederrors.ErrNotFound.WithMessage("product not found")
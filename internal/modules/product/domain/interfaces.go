@@ -19,7 +19,7 @@ type Handler interface {
 type Service interface {
 	Create(ctx context.Context, req *CreateProductRequest, createdBy string) (*Product, error)
 	Get(ctx context.Context, id string) (*Product, error)
-	List(ctx context.Context) ([]Product, error)
+	List(ctx context.Context, req *ListProductRequest) (*ProductPage, error)
 	Update(ctx context.Context, req *UpdateProductRequest, updatedBy string) (*Product, error)
 	Delete(ctx context.Context, id, deletedBy string) error
 }
@@ -28,7 +28,9 @@ type Service interface {
 type Repository interface {
 	Create(ctx context.Context, p *Product) error
 	GetByID(ctx context.Context, id string) (*Product, error)
-	List(ctx context.Context) ([]Product, error)
+	// List returns one page of products matching req along with the total
+	// number of matches ignoring pagination
+	List(ctx context.Context, req *ListProductRequest) ([]Product, int, error)
 	Update(ctx context.Context, p *Product) error
 	SoftDelete(ctx context.Context, id, deletedBy string) error
 }
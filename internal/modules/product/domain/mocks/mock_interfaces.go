@@ -174,18 +174,18 @@ func (mr *MockServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockService) List(ctx context.Context) ([]domain.Product, error) {
+func (m *MockService) List(ctx context.Context, req *domain.ListProductRequest) (*domain.ProductPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
-	ret0, _ := ret[0].([]domain.Product)
+	ret := m.ctrl.Call(m, "List", ctx, req)
+	ret0, _ := ret[0].(*domain.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) List(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, req)
 }
 
 // Update mocks base method.
@@ -256,18 +256,19 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call
 }
 
 // List mocks base method.
-func (m *MockRepository) List(ctx context.Context) ([]domain.Product, error) {
+func (m *MockRepository) List(ctx context.Context, req *domain.ListProductRequest) ([]domain.Product, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
+	ret := m.ctrl.Call(m, "List", ctx, req)
 	ret0, _ := ret[0].([]domain.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // List indicates an expected call of List.
-func (mr *MockRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) List(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, req)
 }
 
 // SoftDelete mocks base method.
@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"time"
+
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+)
+
 // CreateProductRequest represents the request to create a product
 type CreateProductRequest struct {
 	Name        string `json:"name" binding:"required" validate:"required,min=1,max=255"`
@@ -12,3 +19,67 @@ type UpdateProductRequest struct {
 	Name        string `json:"name" validate:"omitempty,min=1,max=255"`
 	Description string `json:"description" validate:"omitempty,max=1000"`
 }
+
+// Sort keys accepted by ListProductRequest
+const (
+	ProductSortName      = "name"
+	ProductSortCreatedAt = "created_at"
+
+	defaultProductSort = "-" + ProductSortCreatedAt
+)
+
+// ListProductRequest represents the query for listing products
+type ListProductRequest struct {
+	model.PaginationRequest
+	Name        string     `json:"name,omitempty"` // case-insensitive substring match
+	CreatedBy   string     `json:"created_by,omitempty"`
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	Sort        string     `json:"sort,omitempty"` // name or created_at, prefixed with '-' for descending
+}
+
+// Normalize applies pagination defaults and rejects unknown sort keys
+func (r *ListProductRequest) Normalize() error {
+	r.PaginationRequest.Normalize()
+	if r.Sort == "" {
+		r.Sort = defaultProductSort
+	}
+	switch r.SortOrder().Field {
+	case ProductSortName, ProductSortCreatedAt:
+		return nil
+	default:
+		return sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + r.Sort)
+	}
+}
+
+// SortOrder returns the parsed sort key
+func (r *ListProductRequest) SortOrder() model.SortRequest {
+	return model.ParseSort(r.Sort)
+}
+
+// DecodeCursor returns the position to continue after, or nil for offset paging
+func (r *ListProductRequest) DecodeCursor() (*model.Cursor, error) {
+	if !r.UsesCursor() {
+		return nil, nil
+	}
+	c, err := model.DecodeCursor(r.Cursor, r.SortOrder())
+	if err != nil {
+		return nil, err
+	}
+	if r.SortOrder().Field == ProductSortCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+			return nil, model.ErrInvalidCursor.WithError(err)
+		}
+	}
+	return &c, nil
+}
+
+// NextCursor builds the cursor pointing just after p in the requested order
+func (r *ListProductRequest) NextCursor(p *Product) string {
+	sort := r.SortOrder()
+	value := p.Name
+	if sort.Field == ProductSortCreatedAt {
+		value = p.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return model.EncodeCursor(model.Cursor{Sort: sort.String(), Value: value, ID: p.ID})
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+)
 
 // ProductResponse represents the product response payload
 type ProductResponse struct {
@@ -20,6 +24,9 @@ type ProductListResponse struct {
 	Products []ProductResponse `json:"products"`
 }
 
+// ProductPage is one page of a product listing
+type ProductPage = model.PaginatedResponse[Product]
+
 // ToResponse converts a Product to ProductResponse
 func (p *Product) ToResponse() ProductResponse {
 	return ProductResponse{
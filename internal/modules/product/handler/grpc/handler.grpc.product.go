@@ -6,6 +6,7 @@ import (
 	productDomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/proto/adapters"
 	productv1 "github.com/kamil5b/go-pste-monolith/internal/modules/product/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 	grpcAdapter "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -24,10 +25,8 @@ func NewGRPCHandler(service productDomain.Service) *GRPCHandler {
 
 // Create creates a new product
 func (h *GRPCHandler) Create(ctx context.Context, req *productv1.CreateProductRequest) (*productv1.CreateProductResponse, error) {
-	createdBy := ""
-	if uid := ctx.Value("user_id"); uid != nil {
-		createdBy = uid.(string)
-	}
+	// Set by the gRPC auth interceptor
+	createdBy, _ := sharedctx.GetUserID(ctx)
 
 	createReq := adapters.PBCreateProductRequestToDomainRequest(req)
 
@@ -53,29 +52,19 @@ func (h *GRPCHandler) Get(ctx context.Context, req *productv1.GetProductRequest)
 	}, nil
 }
 
-// List retrieves all products
-func (h *GRPCHandler) List(ctx context.Context, _ *emptypb.Empty) (*productv1.ListProductResponse, error) {
-	products, err := h.service.List(ctx)
+// List retrieves a page of products
+func (h *GRPCHandler) List(ctx context.Context, req *productv1.ListProductRequest) (*productv1.ListProductResponse, error) {
+	page, err := h.service.List(ctx, adapters.PBListProductRequestToDomainRequest(req))
 	if err != nil {
 		return nil, err
 	}
 
-	pbProducts := make([]*productv1.Product, len(products))
-	for i, p := range products {
-		pbProducts[i] = adapters.DomainProductToPBProduct(&p)
-	}
-
-	return &productv1.ListProductResponse{
-		Products: pbProducts,
-	}, nil
+	return adapters.DomainProductPageToPBListResponse(page), nil
 }
 
 // Update updates an existing product
 func (h *GRPCHandler) Update(ctx context.Context, req *productv1.UpdateProductRequest) (*productv1.UpdateProductResponse, error) {
-	updatedBy := ""
-	if uid := ctx.Value("user_id"); uid != nil {
-		updatedBy = uid.(string)
-	}
+	updatedBy, _ := sharedctx.GetUserID(ctx)
 
 	updateReq := adapters.PBUpdateProductRequestToDomainRequest(req)
 
@@ -91,10 +80,7 @@ func (h *GRPCHandler) Update(ctx context.Context, req *productv1.UpdateProductRe
 
 // Delete deletes a product
 func (h *GRPCHandler) Delete(ctx context.Context, req *productv1.DeleteProductRequest) (*emptypb.Empty, error) {
-	deletedBy := ""
-	if uid := ctx.Value("user_id"); uid != nil {
-		deletedBy = uid.(string)
-	}
+	deletedBy, _ := sharedctx.GetUserID(ctx)
 
 	err := h.service.Delete(ctx, req.GetId(), deletedBy)
 	if err != nil {
@@ -8,8 +8,11 @@ import (
 	productDomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	mockdomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain/mocks"
 	productv1 "github.com/kamil5b/go-pste-monolith/internal/modules/product/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 
 	gomock "github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TestGRPCHandler_Create tests the Create method
@@ -31,7 +34,7 @@ func TestGRPCHandler_Create(t *testing.T) {
 		}, nil)
 
 	handler := NewGRPCHandler(mockService)
-	ctx := context.WithValue(context.Background(), "user_id", "user-123")
+	ctx := sharedctx.WithUserID(context.Background(), "user-123")
 
 	resp, err := handler.Create(ctx, &productv1.CreateProductRequest{
 		Name:        "Test Product",
@@ -91,8 +94,8 @@ func TestGRPCHandler_List(t *testing.T) {
 	now := time.Now()
 
 	mockService.EXPECT().
-		List(gomock.Any()).
-		Return([]productDomain.Product{
+		List(gomock.Any(), gomock.Any()).
+		Return(&productDomain.ProductPage{Data: []productDomain.Product{
 			{
 				ID:          "product-1",
 				Name:        "Product 1",
@@ -107,7 +110,7 @@ func TestGRPCHandler_List(t *testing.T) {
 				CreatedAt:   now,
 				CreatedBy:   "user-123",
 			},
-		}, nil)
+		}, Metadata: model.PaginationMetadata{TotalItems: 12, TotalPages: 6, Page: 1, Limit: 2, NextCursor: "next"}}, nil)
 
 	handler := NewGRPCHandler(mockService)
 
@@ -120,6 +123,45 @@ func TestGRPCHandler_List(t *testing.T) {
 	if len(resp.Products) != 2 {
 		t.Errorf("expected 2 products, got %d", len(resp.Products))
 	}
+
+	if resp.TotalItems != 12 || resp.TotalPages != 6 || resp.NextCursor != "next" {
+		t.Errorf("unexpected pagination metadata: %+v", resp)
+	}
+}
+
+// TestGRPCHandler_List_Filters tests that request filters reach the service
+func TestGRPCHandler_List_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *productDomain.ListProductRequest) (*productDomain.ProductPage, error) {
+			if req.Limit != 10 || req.Cursor != "abc" || req.Name != "chair" || req.Sort != "-name" {
+				t.Errorf("unexpected request: %+v", req)
+			}
+			if req.CreatedFrom == nil || !req.CreatedFrom.Equal(from) {
+				t.Errorf("expected created_from %v, got %v", from, req.CreatedFrom)
+			}
+			return &productDomain.ProductPage{}, nil
+		})
+
+	handler := NewGRPCHandler(mockService)
+
+	_, err := handler.List(context.Background(), &productv1.ListProductRequest{
+		Limit:       10,
+		Cursor:      "abc",
+		Name:        "chair",
+		Sort:        "-name",
+		CreatedFrom: timestamppb.New(from),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
 }
 
 // TestGRPCHandler_Update tests the Update method
@@ -145,7 +187,7 @@ func TestGRPCHandler_Update(t *testing.T) {
 		}, nil)
 
 	handler := NewGRPCHandler(mockService)
-	ctx := context.WithValue(context.Background(), "user_id", "user-456")
+	ctx := sharedctx.WithUserID(context.Background(), "user-456")
 
 	resp, err := handler.Update(ctx, &productv1.UpdateProductRequest{
 		Id:   "product-1",
@@ -176,7 +218,7 @@ func TestGRPCHandler_Delete(t *testing.T) {
 		Return(nil)
 
 	handler := NewGRPCHandler(mockService)
-	ctx := context.WithValue(context.Background(), "user_id", "user-123")
+	ctx := sharedctx.WithUserID(context.Background(), "user-123")
 
 	_, err := handler.Delete(ctx, &productv1.DeleteProductRequest{Id: "product-1"})
 
@@ -1,11 +1,12 @@
 package noop
 
 import (
-	"net/http"
-
 	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
+var errNotImplemented = sharederrors.ErrNotImplemented.WithMessage("product not implemented")
+
 type Handler struct{}
 
 func NewUnimplementedHandler() *Handler {
@@ -13,21 +14,21 @@ func NewUnimplementedHandler() *Handler {
 }
 
 func (h *Handler) Create(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"message": "unimplemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *Handler) Get(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"message": "unimplemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *Handler) List(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"message": "unimplemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *Handler) Update(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"message": "unimplemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
 
 func (h *Handler) Delete(c sharedctx.Context) error {
-	return c.JSON(http.StatusNotImplemented, map[string]string{"message": "unimplemented"})
+	return sharederrors.WriteProblem(c, errNotImplemented)
 }
@@ -9,10 +9,18 @@ import (
 	domain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	mockdomain "github.com/kamil5b/go-pste-monolith/internal/modules/product/domain/mocks"
 	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// expectProblem expects an application/problem+json response with status
+func expectProblem(mc *ctxmocks.MockContext, status int) {
+	mc.EXPECT().GetContext().Return(context.Background())
+	mc.EXPECT().SetHeader("Content-Type", sharederrors.ProblemContentType)
+	mc.EXPECT().JSON(status, gomock.AssignableToTypeOf(sharederrors.Problem{})).Return(nil)
+}
+
 func TestHandler_Create(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -33,7 +41,7 @@ func TestHandler_Create(t *testing.T) {
 			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
 				mc.EXPECT().GetContext().Return(context.Background())
 				mc.EXPECT().Bind(gomock.AssignableToTypeOf(&domain.CreateProductRequest{})).Return(errors.New("bad input"))
-				mc.EXPECT().JSON(http.StatusBadRequest, gomock.Any()).Return(nil)
+				expectProblem(mc, http.StatusBadRequest)
 			},
 		},
 		{
@@ -43,7 +51,7 @@ func TestHandler_Create(t *testing.T) {
 				mc.EXPECT().GetContext().Return(context.Background())
 				mc.EXPECT().GetUserID().Return("user1")
 				svc.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&domain.CreateProductRequest{}), "user1").Return(nil, errors.New("boom"))
-				mc.EXPECT().JSON(http.StatusInternalServerError, gomock.Any()).Return(nil)
+				expectProblem(mc, http.StatusInternalServerError)
 			},
 		},
 	}
@@ -85,8 +93,8 @@ func TestHandler_Get(t *testing.T) {
 			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
 				mc.EXPECT().GetContext().Return(context.Background())
 				mc.EXPECT().Param("id").Return("p1")
-				svc.EXPECT().Get(gomock.Any(), "p1").Return(nil, errors.New("not found"))
-				mc.EXPECT().JSON(http.StatusNotFound, gomock.Any()).Return(nil)
+				svc.EXPECT().Get(gomock.Any(), "p1").Return(nil, domain.ErrProductNotFound)
+				expectProblem(mc, http.StatusNotFound)
 			},
 		},
 	}
@@ -118,16 +126,55 @@ func TestHandler_List(t *testing.T) {
 			name: "ok",
 			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
 				mc.EXPECT().GetContext().Return(context.Background())
-				svc.EXPECT().List(gomock.Any()).Return([]domain.Product{{ID: "p1"}}, nil)
+				mc.EXPECT().QueryParam(gomock.Any()).Return("").AnyTimes()
+				svc.EXPECT().List(gomock.Any(), gomock.Any()).Return(&domain.ProductPage{Data: []domain.Product{{ID: "p1"}}}, nil)
 				mc.EXPECT().JSON(http.StatusOK, gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "query params",
+			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
+				query := map[string]string{"page": "2", "limit": "50", "name": "chair", "created_from": "2024-01-01T00:00:00Z", "sort": "-name"}
+				mc.EXPECT().GetContext().Return(context.Background())
+				mc.EXPECT().QueryParam(gomock.Any()).DoAndReturn(func(n string) string { return query[n] }).AnyTimes()
+				svc.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.ListProductRequest) (*domain.ProductPage, error) {
+					if req.Page != 2 || req.Limit != 50 || req.Name != "chair" || req.Sort != "-name" || req.CreatedFrom == nil {
+						t.Errorf("unexpected request: %+v", req)
+					}
+					return &domain.ProductPage{}, nil
+				})
+				mc.EXPECT().JSON(http.StatusOK, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "invalid query param",
+			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
+				mc.EXPECT().GetContext().Return(context.Background())
+				mc.EXPECT().QueryParam(gomock.Any()).DoAndReturn(func(n string) string {
+					if n == "limit" {
+						return "many"
+					}
+					return ""
+				}).AnyTimes()
+				expectProblem(mc, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "invalid sort",
+			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
+				mc.EXPECT().GetContext().Return(context.Background())
+				mc.EXPECT().QueryParam(gomock.Any()).Return("").AnyTimes()
+				svc.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, sharederrors.ErrInvalidInput)
+				expectProblem(mc, http.StatusBadRequest)
+			},
+		},
 		{
 			name: "service error",
 			setup: func(svc *mockdomain.MockService, mc *ctxmocks.MockContext) {
 				mc.EXPECT().GetContext().Return(context.Background())
-				svc.EXPECT().List(gomock.Any()).Return(nil, errors.New("boom"))
-				mc.EXPECT().JSON(http.StatusInternalServerError, gomock.Any()).Return(nil)
+				mc.EXPECT().QueryParam(gomock.Any()).Return("").AnyTimes()
+				svc.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+				expectProblem(mc, http.StatusInternalServerError)
 			},
 		},
 	}
@@ -172,7 +219,7 @@ func TestHandler_Update(t *testing.T) {
 				mc.EXPECT().GetContext().Return(context.Background())
 				mc.EXPECT().Param("id").Return("p1")
 				mc.EXPECT().Bind(gomock.AssignableToTypeOf(&domain.UpdateProductRequest{})).Return(errors.New("bad"))
-				mc.EXPECT().JSON(http.StatusBadRequest, gomock.Any()).Return(nil)
+				expectProblem(mc, http.StatusBadRequest)
 			},
 		},
 		{
@@ -183,7 +230,7 @@ func TestHandler_Update(t *testing.T) {
 				mc.EXPECT().GetContext().Return(context.Background())
 				mc.EXPECT().GetUserID().Return("user1")
 				svc.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&domain.UpdateProductRequest{}), "user1").Return(nil, errors.New("boom"))
-				mc.EXPECT().JSON(http.StatusInternalServerError, gomock.Any()).Return(nil)
+				expectProblem(mc, http.StatusInternalServerError)
 			},
 		},
 	}
@@ -238,7 +285,7 @@ func TestHandler_Delete(t *testing.T) {
 				mc.EXPECT().Param("id").Return("p1")
 				mc.EXPECT().Get("user_id").Return("user1")
 				svc.EXPECT().Delete(gomock.Any(), "p1", "user1").Return(errors.New("boom"))
-				mc.EXPECT().JSON(http.StatusInternalServerError, gomock.Any()).Return(nil)
+				expectProblem(mc, http.StatusInternalServerError)
 			},
 		},
 	}
@@ -121,3 +121,51 @@ func PBUpdateProductRequestToDomainRequest(pb *productv1.UpdateProductRequest) *
 
 	return req
 }
+
+// PBListProductRequestToDomainRequest converts protobuf request to domain request
+func PBListProductRequestToDomainRequest(pb *productv1.ListProductRequest) *productDomain.ListProductRequest {
+	req := &productDomain.ListProductRequest{}
+	if pb == nil {
+		return req
+	}
+
+	req.Page = int(pb.GetPage())
+	req.Limit = int(pb.GetLimit())
+	req.Cursor = pb.GetCursor()
+	req.Name = pb.GetName()
+	req.CreatedBy = pb.GetCreatedBy()
+	req.Sort = pb.GetSort()
+
+	if pb.CreatedFrom != nil {
+		createdFrom := pb.CreatedFrom.AsTime()
+		req.CreatedFrom = &createdFrom
+	}
+
+	if pb.CreatedTo != nil {
+		createdTo := pb.CreatedTo.AsTime()
+		req.CreatedTo = &createdTo
+	}
+
+	return req
+}
+
+// DomainProductPageToPBListResponse converts a page of domain Products to a protobuf list response
+func DomainProductPageToPBListResponse(page *productDomain.ProductPage) *productv1.ListProductResponse {
+	if page == nil {
+		return &productv1.ListProductResponse{}
+	}
+
+	pbProducts := make([]*productv1.Product, len(page.Data))
+	for i := range page.Data {
+		pbProducts[i] = DomainProductToPBProduct(&page.Data[i])
+	}
+
+	return &productv1.ListProductResponse{
+		Products:   pbProducts,
+		TotalItems: int32(page.Metadata.TotalItems),
+		TotalPages: int32(page.Metadata.TotalPages),
+		Page:       int32(page.Metadata.Page),
+		Limit:      int32(page.Metadata.Limit),
+		NextCursor: page.Metadata.NextCursor,
+	}
+}
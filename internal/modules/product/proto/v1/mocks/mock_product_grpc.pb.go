@@ -98,7 +98,7 @@ func (mr *MockProductServiceClientMockRecorder) Get(ctx, in interface{}, opts ..
 }
 
 // List mocks base method.
-func (m *MockProductServiceClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*productv1.ListProductResponse, error) {
+func (m *MockProductServiceClient) List(ctx context.Context, in *productv1.ListProductRequest, opts ...grpc.CallOption) (*productv1.ListProductResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
@@ -206,7 +206,7 @@ func (mr *MockProductServiceServerMockRecorder) Get(arg0, arg1 interface{}) *gom
 }
 
 // List mocks base method.
-func (m *MockProductServiceServer) List(arg0 context.Context, arg1 *emptypb.Empty) (*productv1.ListProductResponse, error) {
+func (m *MockProductServiceServer) List(arg0 context.Context, arg1 *productv1.ListProductRequest) (*productv1.ListProductResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", arg0, arg1)
 	ret0, _ := ret[0].(*productv1.ListProductResponse)
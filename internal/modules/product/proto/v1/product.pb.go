@@ -321,17 +321,128 @@ func (x *GetProductResponse) GetProduct() *Product {
 	return nil
 }
 
-// ListProductResponse returns a list of products
+// ListProductRequest selects a page of products. All fields are optional;
+// an empty request returns the first page, newest first.
+type ListProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Opaque cursor from a previous response; takes precedence over page
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Case-insensitive substring match on the product name
+	Name        string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy   string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3,oneof" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3,oneof" json:"created_to,omitempty"`
+	// "name" or "created_at", prefixed with "-" for descending
+	Sort          string `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductRequest) Reset() {
+	*x = ListProductRequest{}
+	mi := &file_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductRequest) ProtoMessage() {}
+
+func (x *ListProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductRequest.ProtoReflect.Descriptor instead.
+func (*ListProductRequest) Descriptor() ([]byte, []int) {
+	return file_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProductRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListProductRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListProductRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListProductRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListProductRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// ListProductResponse returns a page of products
 type ListProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Products   []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	TotalItems int32                  `protobuf:"varint,2,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages int32                  `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Page       int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit      int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor for the next page; empty when the page was not full
+	NextCursor    string `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProductResponse) Reset() {
 	*x = ListProductResponse{}
-	mi := &file_v1_product_proto_msgTypes[5]
+	mi := &file_v1_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -343,7 +454,7 @@ func (x *ListProductResponse) String() string {
 func (*ListProductResponse) ProtoMessage() {}
 
 func (x *ListProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_product_proto_msgTypes[5]
+	mi := &file_v1_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,7 +467,7 @@ func (x *ListProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductResponse.ProtoReflect.Descriptor instead.
 func (*ListProductResponse) Descriptor() ([]byte, []int) {
-	return file_v1_product_proto_rawDescGZIP(), []int{5}
+	return file_v1_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListProductResponse) GetProducts() []*Product {
@@ -366,6 +477,41 @@ func (x *ListProductResponse) GetProducts() []*Product {
 	return nil
 }
 
+func (x *ListProductResponse) GetTotalItems() int32 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *ListProductResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListProductResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // UpdateProductRequest represents the request to update a product
 type UpdateProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -378,7 +524,7 @@ type UpdateProductRequest struct {
 
 func (x *UpdateProductRequest) Reset() {
 	*x = UpdateProductRequest{}
-	mi := &file_v1_product_proto_msgTypes[6]
+	mi := &file_v1_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -390,7 +536,7 @@ func (x *UpdateProductRequest) String() string {
 func (*UpdateProductRequest) ProtoMessage() {}
 
 func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_product_proto_msgTypes[6]
+	mi := &file_v1_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -403,7 +549,7 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_v1_product_proto_rawDescGZIP(), []int{6}
+	return file_v1_product_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateProductRequest) GetId() string {
@@ -437,7 +583,7 @@ type UpdateProductResponse struct {
 
 func (x *UpdateProductResponse) Reset() {
 	*x = UpdateProductResponse{}
-	mi := &file_v1_product_proto_msgTypes[7]
+	mi := &file_v1_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +595,7 @@ func (x *UpdateProductResponse) String() string {
 func (*UpdateProductResponse) ProtoMessage() {}
 
 func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_product_proto_msgTypes[7]
+	mi := &file_v1_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +608,7 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
 func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_v1_product_proto_rawDescGZIP(), []int{7}
+	return file_v1_product_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateProductResponse) GetProduct() *Product {
@@ -482,7 +628,7 @@ type DeleteProductRequest struct {
 
 func (x *DeleteProductRequest) Reset() {
 	*x = DeleteProductRequest{}
-	mi := &file_v1_product_proto_msgTypes[8]
+	mi := &file_v1_product_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -494,7 +640,7 @@ func (x *DeleteProductRequest) String() string {
 func (*DeleteProductRequest) ProtoMessage() {}
 
 func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_product_proto_msgTypes[8]
+	mi := &file_v1_product_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +653,7 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
 func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_v1_product_proto_rawDescGZIP(), []int{8}
+	return file_v1_product_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteProductRequest) GetId() string {
@@ -551,9 +697,30 @@ const file_v1_product_proto_rawDesc = "" +
 	"\x11GetProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
 	"\x12GetProductResponse\x12-\n" +
-	"\aproduct\x18\x01 \x01(\v2\x13.product.v1.ProductR\aproduct\"F\n" +
+	"\aproduct\x18\x01 \x01(\v2\x13.product.v1.ProductR\aproduct\"\xc1\x02\n" +
+	"\x12ListProductRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12B\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\vcreatedFrom\x88\x01\x01\x12>\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tcreatedTo\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sortB\x0f\n" +
+	"\r_created_fromB\r\n" +
+	"\v_created_to\"\xd3\x01\n" +
 	"\x13ListProductResponse\x12/\n" +
-	"\bproducts\x18\x01 \x03(\v2\x13.product.v1.ProductR\bproducts\"\x7f\n" +
+	"\bproducts\x18\x01 \x03(\v2\x13.product.v1.ProductR\bproducts\x12\x1f\n" +
+	"\vtotal_items\x18\x02 \x01(\x05R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x03 \x01(\x05R\n" +
+	"totalPages\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\"\x7f\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
@@ -563,11 +730,11 @@ const file_v1_product_proto_rawDesc = "" +
 	"\x15UpdateProductResponse\x12-\n" +
 	"\aproduct\x18\x01 \x01(\v2\x13.product.v1.ProductR\aproduct\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xf9\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\x81\x03\n" +
 	"\x0eProductService\x12M\n" +
 	"\x06Create\x12 .product.v1.CreateProductRequest\x1a!.product.v1.CreateProductResponse\x12D\n" +
-	"\x03Get\x12\x1d.product.v1.GetProductRequest\x1a\x1e.product.v1.GetProductResponse\x12?\n" +
-	"\x04List\x12\x16.google.protobuf.Empty\x1a\x1f.product.v1.ListProductResponse\x12M\n" +
+	"\x03Get\x12\x1d.product.v1.GetProductRequest\x1a\x1e.product.v1.GetProductResponse\x12G\n" +
+	"\x04List\x12\x1e.product.v1.ListProductRequest\x1a\x1f.product.v1.ListProductResponse\x12M\n" +
 	"\x06Update\x12 .product.v1.UpdateProductRequest\x1a!.product.v1.UpdateProductResponse\x12B\n" +
 	"\x06Delete\x12 .product.v1.DeleteProductRequest\x1a\x16.google.protobuf.EmptyBNZLgithub.com/kamil5b/go-pste-monolith/internal/modules/product/proto;productv1b\x06proto3"
 
@@ -583,43 +750,46 @@ func file_v1_product_proto_rawDescGZIP() []byte {
 	return file_v1_product_proto_rawDescData
 }
 
-var file_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_v1_product_proto_goTypes = []any{
 	(*Product)(nil),               // 0: product.v1.Product
 	(*CreateProductRequest)(nil),  // 1: product.v1.CreateProductRequest
 	(*CreateProductResponse)(nil), // 2: product.v1.CreateProductResponse
 	(*GetProductRequest)(nil),     // 3: product.v1.GetProductRequest
 	(*GetProductResponse)(nil),    // 4: product.v1.GetProductResponse
-	(*ListProductResponse)(nil),   // 5: product.v1.ListProductResponse
-	(*UpdateProductRequest)(nil),  // 6: product.v1.UpdateProductRequest
-	(*UpdateProductResponse)(nil), // 7: product.v1.UpdateProductResponse
-	(*DeleteProductRequest)(nil),  // 8: product.v1.DeleteProductRequest
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+	(*ListProductRequest)(nil),    // 5: product.v1.ListProductRequest
+	(*ListProductResponse)(nil),   // 6: product.v1.ListProductResponse
+	(*UpdateProductRequest)(nil),  // 7: product.v1.UpdateProductRequest
+	(*UpdateProductResponse)(nil), // 8: product.v1.UpdateProductResponse
+	(*DeleteProductRequest)(nil),  // 9: product.v1.DeleteProductRequest
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
 }
 var file_v1_product_proto_depIdxs = []int32{
-	9,  // 0: product.v1.Product.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 1: product.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: product.v1.Product.deleted_at:type_name -> google.protobuf.Timestamp
+	10, // 0: product.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: product.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 2: product.v1.Product.deleted_at:type_name -> google.protobuf.Timestamp
 	0,  // 3: product.v1.CreateProductResponse.product:type_name -> product.v1.Product
 	0,  // 4: product.v1.GetProductResponse.product:type_name -> product.v1.Product
-	0,  // 5: product.v1.ListProductResponse.products:type_name -> product.v1.Product
-	0,  // 6: product.v1.UpdateProductResponse.product:type_name -> product.v1.Product
-	1,  // 7: product.v1.ProductService.Create:input_type -> product.v1.CreateProductRequest
-	3,  // 8: product.v1.ProductService.Get:input_type -> product.v1.GetProductRequest
-	10, // 9: product.v1.ProductService.List:input_type -> google.protobuf.Empty
-	6,  // 10: product.v1.ProductService.Update:input_type -> product.v1.UpdateProductRequest
-	8,  // 11: product.v1.ProductService.Delete:input_type -> product.v1.DeleteProductRequest
-	2,  // 12: product.v1.ProductService.Create:output_type -> product.v1.CreateProductResponse
-	4,  // 13: product.v1.ProductService.Get:output_type -> product.v1.GetProductResponse
-	5,  // 14: product.v1.ProductService.List:output_type -> product.v1.ListProductResponse
-	7,  // 15: product.v1.ProductService.Update:output_type -> product.v1.UpdateProductResponse
-	10, // 16: product.v1.ProductService.Delete:output_type -> google.protobuf.Empty
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	10, // 5: product.v1.ListProductRequest.created_from:type_name -> google.protobuf.Timestamp
+	10, // 6: product.v1.ListProductRequest.created_to:type_name -> google.protobuf.Timestamp
+	0,  // 7: product.v1.ListProductResponse.products:type_name -> product.v1.Product
+	0,  // 8: product.v1.UpdateProductResponse.product:type_name -> product.v1.Product
+	1,  // 9: product.v1.ProductService.Create:input_type -> product.v1.CreateProductRequest
+	3,  // 10: product.v1.ProductService.Get:input_type -> product.v1.GetProductRequest
+	5,  // 11: product.v1.ProductService.List:input_type -> product.v1.ListProductRequest
+	7,  // 12: product.v1.ProductService.Update:input_type -> product.v1.UpdateProductRequest
+	9,  // 13: product.v1.ProductService.Delete:input_type -> product.v1.DeleteProductRequest
+	2,  // 14: product.v1.ProductService.Create:output_type -> product.v1.CreateProductResponse
+	4,  // 15: product.v1.ProductService.Get:output_type -> product.v1.GetProductResponse
+	6,  // 16: product.v1.ProductService.List:output_type -> product.v1.ListProductResponse
+	8,  // 17: product.v1.ProductService.Update:output_type -> product.v1.UpdateProductResponse
+	11, // 18: product.v1.ProductService.Delete:output_type -> google.protobuf.Empty
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_v1_product_proto_init() }
@@ -628,14 +798,15 @@ func file_v1_product_proto_init() {
 		return
 	}
 	file_v1_product_proto_msgTypes[0].OneofWrappers = []any{}
-	file_v1_product_proto_msgTypes[6].OneofWrappers = []any{}
+	file_v1_product_proto_msgTypes[5].OneofWrappers = []any{}
+	file_v1_product_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_product_proto_rawDesc), len(file_v1_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -37,8 +37,8 @@ type ProductServiceClient interface {
 	Create(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
 	// Get a product by ID
 	Get(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
-	// List all products
-	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProductResponse, error)
+	// List products page by page, with optional filters and sort
+	List(ctx context.Context, in *ListProductRequest, opts ...grpc.CallOption) (*ListProductResponse, error)
 	// Update an existing product
 	Update(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error)
 	// Delete a product
@@ -73,7 +73,7 @@ func (c *productServiceClient) Get(ctx context.Context, in *GetProductRequest, o
 	return out, nil
 }
 
-func (c *productServiceClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListProductResponse, error) {
+func (c *productServiceClient) List(ctx context.Context, in *ListProductRequest, opts ...grpc.CallOption) (*ListProductResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListProductResponse)
 	err := c.cc.Invoke(ctx, ProductService_List_FullMethodName, in, out, cOpts...)
@@ -113,8 +113,8 @@ type ProductServiceServer interface {
 	Create(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
 	// Get a product by ID
 	Get(context.Context, *GetProductRequest) (*GetProductResponse, error)
-	// List all products
-	List(context.Context, *emptypb.Empty) (*ListProductResponse, error)
+	// List products page by page, with optional filters and sort
+	List(context.Context, *ListProductRequest) (*ListProductResponse, error)
 	// Update an existing product
 	Update(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
 	// Delete a product
@@ -135,7 +135,7 @@ func (UnimplementedProductServiceServer) Create(context.Context, *CreateProductR
 func (UnimplementedProductServiceServer) Get(context.Context, *GetProductRequest) (*GetProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedProductServiceServer) List(context.Context, *emptypb.Empty) (*ListProductResponse, error) {
+func (UnimplementedProductServiceServer) List(context.Context, *ListProductRequest) (*ListProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
 func (UnimplementedProductServiceServer) Update(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
@@ -202,7 +202,7 @@ func _ProductService_Get_Handler(srv interface{}, ctx context.Context, dec func(
 }
 
 func _ProductService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -214,7 +214,7 @@ func _ProductService_List_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: ProductService_List_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).List(ctx, req.(*emptypb.Empty))
+		return srv.(ProductServiceServer).List(ctx, req.(*ListProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
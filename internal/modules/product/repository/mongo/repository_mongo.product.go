@@ -3,9 +3,13 @@ package mongo
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -29,7 +33,17 @@ func NewMongoRepository(client *mongo.Client, dbName string) *MongoRepository {
 	return &MongoRepository{col: col}
 }
 
+// getSessionContext binds ctx to the unit of work session, if any, so the
+// operation joins its transaction.
+func (r *MongoRepository) getSessionContext(ctx context.Context) context.Context {
+	if session := sharedCtx.GetObjectFromContext[mongo.Session](ctx, sharedCtx.MongoSessionKey); session != nil {
+		return mongo.NewSessionContext(ctx, *session)
+	}
+	return ctx
+}
+
 func (r *MongoRepository) Create(ctx context.Context, p *domain.Product) error {
+	ctx = r.getSessionContext(ctx)
 	if p.ID == "" {
 		p.ID = uuid.NewString()
 	}
@@ -39,34 +53,97 @@ func (r *MongoRepository) Create(ctx context.Context, p *domain.Product) error {
 }
 
 func (r *MongoRepository) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	ctx = r.getSessionContext(ctx)
 	var p domain.Product
 	if err := r.col.FindOne(ctx, bson.M{"id": id}).Decode(&p); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, err
+			return nil, domain.ErrProductNotFound
 		}
 		return nil, err
 	}
 	return &p, nil
 }
 
-func (r *MongoRepository) List(ctx context.Context) ([]domain.Product, error) {
-	cur, err := r.col.Find(ctx, bson.M{"deleted_at": bson.M{"$exists": false}})
+func (r *MongoRepository) List(ctx context.Context, req *domain.ListProductRequest) ([]domain.Product, int, error) {
+	ctx = r.getSessionContext(ctx)
+	sort := req.SortOrder()
+	if sort.Field != domain.ProductSortName && sort.Field != domain.ProductSortCreatedAt {
+		return nil, 0, sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + req.Sort)
+	}
+	after, err := req.DecodeCursor()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	filter := bson.M{"deleted_at": bson.M{"$exists": false}}
+	if req.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Name), "$options": "i"}
+	}
+	if req.CreatedBy != "" {
+		filter["created_by"] = req.CreatedBy
+	}
+	if req.CreatedFrom != nil || req.CreatedTo != nil {
+		created := bson.M{}
+		if req.CreatedFrom != nil {
+			created["$gte"] = *req.CreatedFrom
+		}
+		if req.CreatedTo != nil {
+			created["$lte"] = *req.CreatedTo
+		}
+		filter["created_at"] = created
+	}
+
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dir, cmp := 1, "$gt"
+	if sort.Direction == model.SortDesc {
+		dir, cmp = -1, "$lt"
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: sort.Field, Value: dir}, {Key: "id", Value: dir}}).
+		SetLimit(int64(req.Limit))
+	if after != nil {
+		var value any = after.Value
+		if sort.Field == domain.ProductSortCreatedAt {
+			createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, 0, model.ErrInvalidCursor.WithError(err)
+			}
+			value = createdAt
+		}
+		// Keyset condition, ANDed with the created_at range filter if present
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{sort.Field: bson.M{cmp: value}},
+			bson.M{sort.Field: value, "id": bson.M{cmp: after.ID}},
+		}}}}
+	} else {
+		opts.SetSkip(int64(req.Offset()))
+	}
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer cur.Close(ctx)
-	var res []domain.Product
+	res := []domain.Product{}
 	for cur.Next(ctx) {
 		var p domain.Product
 		if err := cur.Decode(&p); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		res = append(res, p)
 	}
-	return res, nil
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+	return res, int(total), nil
 }
 
 func (r *MongoRepository) Update(ctx context.Context, p *domain.Product) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	p.UpdatedAt = &now
 	upd := bson.M{"$set": bson.M{"name": p.Name, "description": p.Description, "updated_at": p.UpdatedAt, "updated_by": p.UpdatedBy}}
@@ -75,6 +152,7 @@ func (r *MongoRepository) Update(ctx context.Context, p *domain.Product) error {
 }
 
 func (r *MongoRepository) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	ctx = r.getSessionContext(ctx)
 	now := time.Now().UTC()
 	upd := bson.M{"$set": bson.M{"deleted_at": now, "deleted_by": deletedBy}}
 	_, err := r.col.UpdateOne(ctx, bson.M{"id": id}, upd)
@@ -2,11 +2,13 @@ package noop
 
 import (
 	"context"
-	"errors"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
+var errNotImplemented = sharederrors.ErrNotImplemented.WithMessage("product repository not implemented")
+
 type UnimplementedRepository struct{}
 
 func NewUnimplementedRepository() *UnimplementedRepository {
@@ -14,17 +16,17 @@ func NewUnimplementedRepository() *UnimplementedRepository {
 }
 
 func (s *UnimplementedRepository) Create(_ context.Context, _ *domain.Product) error {
-	return errors.New("not implemented")
+	return errNotImplemented
 }
 func (s *UnimplementedRepository) GetByID(_ context.Context, _ string) (*domain.Product, error) {
-	return nil, errors.New("not implemented")
+	return nil, errNotImplemented
 }
-func (s *UnimplementedRepository) List(_ context.Context) ([]domain.Product, error) {
-	return nil, errors.New("not implemented")
+func (s *UnimplementedRepository) List(_ context.Context, _ *domain.ListProductRequest) ([]domain.Product, int, error) {
+	return nil, 0, errNotImplemented
 }
 func (s *UnimplementedRepository) Update(_ context.Context, _ *domain.Product) error {
-	return errors.New("not implemented")
+	return errNotImplemented
 }
 func (s *UnimplementedRepository) SoftDelete(_ context.Context, _, _ string) error {
-	return errors.New("not implemented")
+	return errNotImplemented
 }
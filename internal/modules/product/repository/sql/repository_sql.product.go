@@ -2,28 +2,57 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
 type SQLRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	dialect sqldialect.Dialect
+	router  *dbrouter.Router // nil reads from db
 }
 
 func NewSQLRepository(db *sqlx.DB) *SQLRepository {
-	return &SQLRepository{db: db}
+	return &SQLRepository{db: db, dialect: sqldialect.Of(db)}
+}
+
+// SetRouter sends reads outside a transaction through router, which may pick
+// a read replica
+func (r *SQLRepository) SetRouter(router *dbrouter.Router) {
+	r.router = router
 }
 
 func (r *SQLRepository) getTxFromContext(ctx context.Context) *sqlx.Tx {
 	return sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey)
 }
 
+// reader returns the transaction bound to ctx, if any, otherwise the
+// database the router picks for reads
+func (r *SQLRepository) reader(ctx context.Context) sqlx.QueryerContext {
+	if tx := r.getTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	if r.router != nil {
+		return r.router.Reader(ctx)
+	}
+	return r.db
+}
+
 func (r *SQLRepository) Create(ctx context.Context, p *domain.Product) error {
+	defer r.router.MarkWrite(ctx)
 	query := `INSERT INTO products (id,name,description,created_at,created_by) VALUES (:id,:name,:description,:created_at,:created_by)`
 	tx := r.getTxFromContext(ctx)
 	if p.ID == "" {
@@ -40,37 +69,99 @@ func (r *SQLRepository) Create(ctx context.Context, p *domain.Product) error {
 
 func (r *SQLRepository) GetByID(ctx context.Context, id string) (*domain.Product, error) {
 	var p domain.Product
-	tx := r.getTxFromContext(ctx)
-	query := `SELECT id,name,description,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM products WHERE id=$1`
-	if tx != nil {
-		if err := tx.Get(&p, query, id); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := r.db.Get(&p, query, id); err != nil {
-			return nil, err
-		}
+	query := r.db.Rebind(`SELECT id,name,description,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM products WHERE id=?`)
+	err := sqlx.GetContext(ctx, r.reader(ctx), &p, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 	return &p, nil
 }
 
-func (r *SQLRepository) List(ctx context.Context) ([]domain.Product, error) {
-	var lst []domain.Product
-	tx := r.getTxFromContext(ctx)
-	query := `SELECT id,name,description,created_at,created_by,updated_at,updated_by FROM products WHERE deleted_at IS NULL ORDER BY created_at DESC`
-	if tx != nil {
-		if err := tx.Select(&lst, query); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := r.db.Select(&lst, query); err != nil {
-			return nil, err
+// productSortColumns maps public sort keys to columns; anything else is rejected
+// so user input never reaches the ORDER BY clause
+var productSortColumns = map[string]string{
+	domain.ProductSortName:      "name",
+	domain.ProductSortCreatedAt: "created_at",
+}
+
+func (r *SQLRepository) List(ctx context.Context, req *domain.ListProductRequest) ([]domain.Product, int, error) {
+	sort := req.SortOrder()
+	column, ok := productSortColumns[sort.Field]
+	if !ok {
+		return nil, 0, sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + req.Sort)
+	}
+	after, err := req.DecodeCursor()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := r.reader(ctx)
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return "?"
+	}
+	conds := []string{"deleted_at IS NULL"}
+	if req.Name != "" {
+		conds = append(conds, r.dialect.ILike("name", arg("%"+escapeLike(req.Name)+"%")))
+	}
+	if req.CreatedBy != "" {
+		conds = append(conds, "created_by = "+arg(req.CreatedBy))
+	}
+	if req.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*req.CreatedFrom))
+	}
+	if req.CreatedTo != nil {
+		conds = append(conds, "created_at <= "+arg(*req.CreatedTo))
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM products WHERE ` + strings.Join(conds, " AND ")
+	if err := sqlx.GetContext(ctx, q, &total, r.db.Rebind(countQuery), args...); err != nil {
+		return nil, 0, err
+	}
+
+	cmp, dir := ">", "ASC"
+	if sort.Direction == model.SortDesc {
+		cmp, dir = "<", "DESC"
+	}
+	if after != nil {
+		var value any = after.Value
+		if sort.Field == domain.ProductSortCreatedAt {
+			createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, 0, model.ErrInvalidCursor.WithError(err)
+			}
+			value = createdAt
 		}
+		conds = append(conds, fmt.Sprintf("(%s, id) %s (%s, %s)", column, cmp, arg(value), arg(after.ID)))
+	}
+
+	query := `SELECT id,name,description,created_at,created_by,updated_at,updated_by FROM products WHERE ` +
+		strings.Join(conds, " AND ") +
+		fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT %s", column, dir, dir, arg(req.Limit))
+	if after == nil {
+		query += " OFFSET " + arg(req.Offset())
 	}
-	return lst, nil
+
+	lst := []domain.Product{}
+	if err := sqlx.SelectContext(ctx, q, &lst, r.db.Rebind(query), args...); err != nil {
+		return nil, 0, err
+	}
+	return lst, total, nil
+}
+
+// escapeLike escapes LIKE wildcards so filters match them literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 func (r *SQLRepository) Update(ctx context.Context, p *domain.Product) error {
+	defer r.router.MarkWrite(ctx)
 	now := time.Now().UTC()
 	p.UpdatedAt = &now
 	tx := r.getTxFromContext(ctx)
@@ -84,9 +175,10 @@ func (r *SQLRepository) Update(ctx context.Context, p *domain.Product) error {
 }
 
 func (r *SQLRepository) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	defer r.router.MarkWrite(ctx)
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE products SET deleted_at=$1, deleted_by=$2 WHERE id=$3`
+	query := r.db.Rebind(`UPDATE products SET deleted_at=?, deleted_by=? WHERE id=?`)
 	if tx != nil {
 		_, err := tx.Exec(query, now, deletedBy, id)
 		return err
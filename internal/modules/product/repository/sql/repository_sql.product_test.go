@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect/sqltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRepository_SQLiteCRUD(t *testing.T) {
+	r := NewSQLRepository(sqltest.OpenSQLite(t))
+	ctx := context.Background()
+
+	p := &domain.Product{Name: "Keyboard", Description: "mechanical", CreatedBy: "user-1"}
+	require.NoError(t, r.Create(ctx, p))
+	require.NotEmpty(t, p.ID)
+
+	got, err := r.GetByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Keyboard", got.Name)
+	assert.Equal(t, "user-1", got.CreatedBy)
+
+	updatedBy := "user-2"
+	got.Name, got.UpdatedBy = "Split keyboard", &updatedBy
+	require.NoError(t, r.Update(ctx, got))
+	got, err = r.GetByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Split keyboard", got.Name)
+	require.NotNil(t, got.UpdatedAt)
+
+	require.NoError(t, r.SoftDelete(ctx, p.ID, "user-2"))
+	got, err = r.GetByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.DeletedAt)
+}
+
+func TestSQLRepository_SQLiteList(t *testing.T) {
+	r := NewSQLRepository(sqltest.OpenSQLite(t))
+	ctx := context.Background()
+
+	for _, name := range []string{"Apple", "apricot", "Banana", "100% juice"} {
+		require.NoError(t, r.Create(ctx, &domain.Product{Name: name, CreatedBy: "user-1"}))
+	}
+
+	req := &domain.ListProductRequest{Name: "AP", Sort: "name"}
+	require.NoError(t, req.Normalize())
+	lst, total, err := r.List(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total, "the name filter ignores case")
+	require.Len(t, lst, 2)
+	assert.Equal(t, "Apple", lst[0].Name)
+
+	req = &domain.ListProductRequest{Name: "%", Sort: "name"}
+	require.NoError(t, req.Normalize())
+	_, total, err = r.List(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total, "wildcards in the filter match literally")
+
+	req = &domain.ListProductRequest{PaginationRequest: model.PaginationRequest{Limit: 2}, Sort: "name"}
+	require.NoError(t, req.Normalize())
+	first, _, err := r.List(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	req.Cursor = req.NextCursor(&first[1])
+	second, _, err := r.List(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, second, 2)
+	assert.NotEqual(t, first[1].ID, second[0].ID, "the cursor continues after the last item")
+
+	req = &domain.ListProductRequest{}
+	require.NoError(t, req.Normalize())
+	req.Cursor = model.EncodeCursor(model.Cursor{Sort: req.SortOrder().String(), Value: "yesterday", ID: first[0].ID})
+	_, _, err = r.List(ctx, req)
+	var domainErr *sharederrors.DomainError
+	require.ErrorAs(t, err, &domainErr, "a created_at cursor must hold a timestamp")
+	assert.Equal(t, model.ErrInvalidCursor.Message, domainErr.Message)
+}
@@ -2,11 +2,13 @@ package noop
 
 import (
 	"context"
-	"errors"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
+var errNotImplemented = sharederrors.ErrNotImplemented.WithMessage("product service not implemented")
+
 type UnimplementedService struct{}
 
 func NewUnimplementedService() *UnimplementedService {
@@ -14,17 +16,17 @@ func NewUnimplementedService() *UnimplementedService {
 }
 
 func (s *UnimplementedService) Create(_ context.Context, _ *domain.CreateProductRequest, _ string) (*domain.Product, error) {
-	return nil, errors.New("not implemented")
+	return nil, errNotImplemented
 }
 func (s *UnimplementedService) Get(_ context.Context, _ string) (*domain.Product, error) {
-	return nil, errors.New("not implemented")
+	return nil, errNotImplemented
 }
-func (s *UnimplementedService) List(_ context.Context) ([]domain.Product, error) {
-	return nil, errors.New("not implemented")
+func (s *UnimplementedService) List(_ context.Context, _ *domain.ListProductRequest) (*domain.ProductPage, error) {
+	return nil, errNotImplemented
 }
 func (s *UnimplementedService) Update(_ context.Context, _ *domain.UpdateProductRequest, _ string) (*domain.Product, error) {
-	return nil, errors.New("not implemented")
+	return nil, errNotImplemented
 }
 func (s *UnimplementedService) Delete(_ context.Context, _ string, _ string) error {
-	return errors.New("not implemented")
+	return errNotImplemented
 }
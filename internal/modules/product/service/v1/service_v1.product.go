@@ -8,7 +8,10 @@ import (
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
 )
 
@@ -29,44 +32,53 @@ func NewServiceV1(r domain.Repository, u uow.UnitOfWork, eb events.EventBus, c c
 }
 
 func (s *ServiceV1) Create(ctx context.Context, req *domain.CreateProductRequest, createdBy string) (product *domain.Product, err error) {
-	ctx = s.uow.StartContext(ctx)
-	defer s.uow.DeferErrorContext(ctx, err)
 	defer func() {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
 
-	var p domain.Product
-	p.Name = req.Name
-	p.Description = req.Description
-	p.CreatedAt = time.Now().UTC()
-	p.CreatedBy = createdBy
+	// The work may run again when the transaction hits a serialization
+	// failure, so it starts from the request every time
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		var p domain.Product
+		p.Name = req.Name
+		p.Description = req.Description
+		p.CreatedAt = time.Now().UTC()
+		p.CreatedBy = createdBy
 
-	if err = s.repo.Create(ctx, &p); err != nil {
-		return nil, err
-	}
+		if err := s.repo.Create(ctx, &p); err != nil {
+			return err
+		}
 
-	// Publish event for inter-module communication
-	if s.eventBus != nil {
-		_ = s.eventBus.Publish(ctx, domain.ProductCreatedEvent{
-			ProductID:   p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			CreatedBy:   createdBy,
-			CreatedAt:   p.CreatedAt,
-		})
-	}
+		// Publish event for inter-module communication; inside the unit of work
+		// this lands in the outbox and only goes out if the transaction commits
+		if s.eventBus != nil {
+			if err := s.eventBus.Publish(ctx, domain.ProductCreatedEvent{
+				ProductID:   p.ID,
+				Name:        p.Name,
+				Description: p.Description,
+				CreatedBy:   createdBy,
+				CreatedAt:   p.CreatedAt,
+			}); err != nil {
+				return err
+			}
+		}
 
-	product = &p
-	return
+		product = &p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 func (s *ServiceV1) Get(ctx context.Context, id string) (*domain.Product, error) {
 	// Try to get from cache first
@@ -96,98 +108,124 @@ func (s *ServiceV1) Get(ctx context.Context, id string) (*domain.Product, error)
 
 	return product, nil
 }
-func (s *ServiceV1) List(ctx context.Context) ([]domain.Product, error) {
-	return s.repo.List(ctx)
+func (s *ServiceV1) List(ctx context.Context, req *domain.ListProductRequest) (*domain.ProductPage, error) {
+	if req == nil {
+		req = &domain.ListProductRequest{}
+	}
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+
+	products, total, err := s.repo.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page may have more behind it; hand out a cursor to continue from
+	meta := model.PaginationMetadata{Page: req.Page, Limit: req.Limit}
+	if len(products) == req.Limit {
+		meta.NextCursor = req.NextCursor(&products[len(products)-1])
+	}
+	if req.UsesCursor() {
+		// Page numbers are meaningless when walking by cursor
+		meta.Page = 0
+	}
+
+	requestID, _ := sharedctx.GetRequestID(ctx)
+	return model.NewPaginatedResponse(requestID, products, total, meta), nil
 }
 func (s *ServiceV1) Update(ctx context.Context, req *domain.UpdateProductRequest, updatedBy string) (product *domain.Product, err error) {
-	ctx = s.uow.StartContext(ctx)
-	defer s.uow.DeferErrorContext(ctx, err)
 	defer func() {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
 
-	p, err := s.repo.GetByID(ctx, req.ID)
-	if err != nil {
-		return nil, err
-	}
-	if req.Name != "" {
-		p.Name = req.Name
-	}
-	if req.Description != "" {
-		p.Description = req.Description
-	}
-	now := time.Now().UTC()
-	p.UpdatedAt = &now
-	p.UpdatedBy = &updatedBy
-	err = s.repo.Update(ctx, p)
-	if err != nil {
-		return nil, err
-	}
+	err = s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.repo.GetByID(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if req.Name != "" {
+			p.Name = req.Name
+		}
+		if req.Description != "" {
+			p.Description = req.Description
+		}
+		now := time.Now().UTC()
+		p.UpdatedAt = &now
+		p.UpdatedBy = &updatedBy
+		if err := s.repo.Update(ctx, p); err != nil {
+			return err
+		}
 
-	// Invalidate cache after update
-	if s.cache != nil {
-		cacheKey := productCacheKeyPrefix + p.ID
-		_ = s.cache.Delete(ctx, cacheKey)
-	}
+		// Invalidate cache after update
+		if s.cache != nil {
+			cacheKey := productCacheKeyPrefix + p.ID
+			_ = s.cache.Delete(ctx, cacheKey)
+		}
 
-	// Publish event for inter-module communication
-	if s.eventBus != nil {
-		_ = s.eventBus.Publish(ctx, domain.ProductUpdatedEvent{
-			ProductID:   p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			UpdatedBy:   updatedBy,
-			UpdatedAt:   now,
-		})
-	}
+		// Publish event for inter-module communication
+		if s.eventBus != nil {
+			if err := s.eventBus.Publish(ctx, domain.ProductUpdatedEvent{
+				ProductID:   p.ID,
+				Name:        p.Name,
+				Description: p.Description,
+				UpdatedBy:   updatedBy,
+				UpdatedAt:   now,
+			}); err != nil {
+				return err
+			}
+		}
 
-	product = p
-	return
+		product = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 func (s *ServiceV1) Delete(ctx context.Context, id, by string) (err error) {
-	ctx = s.uow.StartContext(ctx)
-	defer s.uow.DeferErrorContext(ctx, err)
 	defer func() {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
 
-	err = s.repo.SoftDelete(ctx, id, by)
-	if err != nil {
-		return err
-	}
-
-	// Invalidate cache after delete
-	if s.cache != nil {
-		cacheKey := productCacheKeyPrefix + id
-		_ = s.cache.Delete(ctx, cacheKey)
-	}
+	return s.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SoftDelete(ctx, id, by); err != nil {
+			return err
+		}
 
-	// Publish event for inter-module communication
-	if s.eventBus != nil {
-		_ = s.eventBus.Publish(ctx, domain.ProductDeletedEvent{
-			ProductID: id,
-			DeletedBy: by,
-			DeletedAt: time.Now().UTC(),
-		})
-	}
+		// Invalidate cache after delete
+		if s.cache != nil {
+			cacheKey := productCacheKeyPrefix + id
+			_ = s.cache.Delete(ctx, cacheKey)
+		}
 
-	return nil
+		// Publish event for inter-module communication
+		if s.eventBus != nil {
+			return s.eventBus.Publish(ctx, domain.ProductDeletedEvent{
+				ProductID: id,
+				DeletedBy: by,
+				DeletedAt: time.Now().UTC(),
+			})
+		}
+		return nil
+	})
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/modules/product/domain/mocks"
 	cachemocks "github.com/kamil5b/go-pste-monolith/internal/shared/cache/mocks"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 	eventmocks "github.com/kamil5b/go-pste-monolith/internal/shared/events/mocks"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 	uowmocks "github.com/kamil5b/go-pste-monolith/internal/shared/uow/mocks"
 )
 
@@ -22,6 +24,18 @@ type contextKey struct{}
 
 var txContextKey = contextKey{}
 
+// expectTransaction makes the unit of work run the service's work with txCtx,
+// failing like the commit with commitErr when the work succeeds
+func expectTransaction(mockUOW *uowmocks.MockUnitOfWork, ctx, txCtx context.Context, commitErr error) *gomock.Call {
+	return mockUOW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(context.Context) error) error {
+			if err := fn(txCtx); err != nil {
+				return err
+			}
+			return commitErr
+		})
+}
+
 // TestServiceV1_Create tests the Create method with table-driven tests
 func TestServiceV1_Create(t *testing.T) {
 	tests := []struct {
@@ -68,13 +82,12 @@ func TestServiceV1_Create(t *testing.T) {
 			ctx := context.Background()
 			txCtx := context.WithValue(ctx, txContextKey, "transaction")
 
-			mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+			expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 			mockCache.EXPECT().GetBytes(gomock.Any(), gomock.Any()).Return(nil, errors.New("cache miss")).AnyTimes()
 			mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 			if tt.repoErr != nil {
 				mockRepo.EXPECT().Create(txCtx, gomock.Any()).Return(tt.repoErr).Times(1)
-				mockUOW.EXPECT().DeferErrorContext(txCtx, gomock.Any()).Return(nil).Times(1)
 			} else {
 				mockRepo.EXPECT().Create(txCtx, gomock.Any()).DoAndReturn(func(c context.Context, p *domain.Product) error {
 					assert.Equal(t, tt.req.Name, p.Name)
@@ -84,7 +97,6 @@ func TestServiceV1_Create(t *testing.T) {
 					return nil
 				}).Times(1)
 				mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Times(1)
-				mockUOW.EXPECT().DeferErrorContext(txCtx, nil).Return(nil).Times(1)
 			}
 
 			service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
@@ -105,6 +117,57 @@ func TestServiceV1_Create(t *testing.T) {
 	}
 }
 
+// TestServiceV1_Create_PublishErrorRollsBack verifies that a failed publish
+// (e.g. the outbox insert) fails the transaction and is returned
+func TestServiceV1_Create_PublishErrorRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	mockEventBus := eventmocks.NewMockEventBus(ctrl)
+	mockCache := cachemocks.NewMockCache(ctrl)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	publishErr := errors.New("outbox insert failed")
+
+	expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
+	mockRepo.EXPECT().Create(txCtx, gomock.Any()).Return(nil).Times(1)
+	mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Return(publishErr).Times(1)
+
+	service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
+	product, err := service.Create(ctx, &domain.CreateProductRequest{Name: "Test Product"}, "user123")
+
+	assert.ErrorIs(t, err, publishErr)
+	assert.Nil(t, product)
+}
+
+// TestServiceV1_Delete_CommitError verifies that a failed commit fails the call
+func TestServiceV1_Delete_CommitError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockUOW := uowmocks.NewMockUnitOfWork(ctrl)
+	mockEventBus := eventmocks.NewMockEventBus(ctrl)
+	mockCache := cachemocks.NewMockCache(ctrl)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, txContextKey, "transaction")
+	commitErr := errors.New("commit failed")
+
+	expectTransaction(mockUOW, ctx, txCtx, commitErr).Times(1)
+	mockRepo.EXPECT().SoftDelete(txCtx, "prod123", "user123").Return(nil).Times(1)
+	mockCache.EXPECT().Delete(txCtx, productCacheKeyPrefix+"prod123").Return(nil).Times(1)
+	mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Return(nil).Times(1)
+
+	service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
+	err := service.Delete(ctx, "prod123", "user123")
+
+	assert.ErrorIs(t, err, commitErr)
+}
+
 // Test Get method with table-driven tests
 func TestServiceV1_Get(t *testing.T) {
 	tests := []struct {
@@ -217,26 +280,83 @@ func TestServiceV1_List(t *testing.T) {
 			ctx := context.Background()
 
 			mockCache.EXPECT().GetBytes(ctx, gomock.Any()).Return(nil, errors.New("cache miss")).AnyTimes()
-			mockRepo.EXPECT().List(ctx).Return(tt.want, tt.repoErr).Times(1)
+			mockRepo.EXPECT().List(ctx, gomock.Any()).Return(tt.want, len(tt.want), tt.repoErr).Times(1)
 
 			service := NewServiceV1(mockRepo, mockUOW, nil, mockCache)
-			products, err := service.List(ctx)
+			page, err := service.List(ctx, &domain.ListProductRequest{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				assert.Nil(t, products)
+				assert.Nil(t, page)
 			} else {
 				require.NoError(t, err)
-				require.NotNil(t, products)
-				assert.Equal(t, len(tt.want), len(products))
+				require.NotNil(t, page)
+				assert.Equal(t, len(tt.want), len(page.Data))
+				assert.Equal(t, len(tt.want), page.Metadata.TotalItems)
+				assert.Equal(t, 1, page.Metadata.Page)
+				assert.Equal(t, model.DefaultPageLimit, page.Metadata.Limit)
 				if len(tt.want) > 0 {
-					assert.Equal(t, tt.want, products)
+					assert.Equal(t, tt.want, page.Data)
 				}
 			}
 		})
 	}
 }
 
+func TestServiceV1_List_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	ctx := context.Background()
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	products := []domain.Product{
+		{ID: "prod1", Name: "Product 1", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: "prod2", Name: "Product 2", CreatedAt: createdAt},
+	}
+
+	mockRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.ListProductRequest) ([]domain.Product, int, error) {
+		assert.Equal(t, 2, req.Limit)
+		assert.Equal(t, "-created_at", req.Sort)
+		return products, 5, nil
+	})
+
+	service := NewServiceV1(mockRepo, nil, nil, nil)
+	req := &domain.ListProductRequest{PaginationRequest: model.PaginationRequest{Limit: 2}}
+	page, err := service.List(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Metadata.TotalItems)
+	assert.Equal(t, 3, page.Metadata.TotalPages)
+	require.NotEmpty(t, page.Metadata.NextCursor)
+
+	// The cursor resumes after the last product of the page
+	next := &domain.ListProductRequest{Sort: req.Sort}
+	next.Cursor = page.Metadata.NextCursor
+	after, err := next.DecodeCursor()
+	require.NoError(t, err)
+	assert.Equal(t, "prod2", after.ID)
+	assert.Equal(t, createdAt.Format(time.RFC3339Nano), after.Value)
+
+	// A cursor issued for one sort order is rejected for another
+	next.Sort = "name"
+	_, err = next.DecodeCursor()
+	assert.True(t, sharederrors.Is(err, sharederrors.ErrInvalidInput))
+}
+
+func TestServiceV1_List_InvalidSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := NewServiceV1(mockRepo, nil, nil, nil)
+	page, err := service.List(context.Background(), &domain.ListProductRequest{Sort: "description"})
+
+	assert.Nil(t, page)
+	assert.True(t, sharederrors.Is(err, sharederrors.ErrInvalidInput))
+}
+
 func TestServiceV1_Update_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -265,7 +385,7 @@ func TestServiceV1_Update_Success(t *testing.T) {
 	}
 
 	// Expectations
-	mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 	mockRepo.EXPECT().GetByID(txCtx, req.ID).Return(existingProduct, nil).Times(1)
 	mockRepo.EXPECT().Update(txCtx, gomock.Any()).DoAndReturn(func(c context.Context, p *domain.Product) error {
 		assert.Equal(t, req.Name, p.Name)
@@ -283,7 +403,6 @@ func TestServiceV1_Update_Success(t *testing.T) {
 		return nil
 	}).Times(1)
 	mockCache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	mockUOW.EXPECT().DeferErrorContext(txCtx, nil).Return(nil).Times(1)
 
 	service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
 	product, err := service.Update(ctx, req, updatedBy)
@@ -324,7 +443,7 @@ func TestServiceV1_Update_PartialFields(t *testing.T) {
 	}
 
 	// Expectations
-	mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 	mockRepo.EXPECT().GetByID(txCtx, req.ID).Return(existingProduct, nil).Times(1)
 	mockRepo.EXPECT().Update(txCtx, gomock.Any()).DoAndReturn(func(c context.Context, p *domain.Product) error {
 		assert.Equal(t, "Updated Name", p.Name)
@@ -333,7 +452,6 @@ func TestServiceV1_Update_PartialFields(t *testing.T) {
 	}).Times(1)
 	mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Times(1)
 	mockCache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	mockUOW.EXPECT().DeferErrorContext(txCtx, nil).Return(nil).Times(1)
 
 	service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
 	product, err := service.Update(ctx, req, updatedBy)
@@ -364,9 +482,8 @@ func TestServiceV1_Update_ProductNotFound(t *testing.T) {
 	expectedErr := errors.New("product not found")
 
 	// Expectations
-	mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 	mockRepo.EXPECT().GetByID(txCtx, req.ID).Return(nil, expectedErr).Times(1)
-	mockUOW.EXPECT().DeferErrorContext(txCtx, gomock.Any()).Return(nil).Times(1)
 
 	service := NewServiceV1(mockRepo, mockUOW, nil, mockCache)
 	product, err := service.Update(ctx, req, updatedBy)
@@ -405,10 +522,9 @@ func TestServiceV1_Update_RepositoryError(t *testing.T) {
 	expectedErr := errors.New("update failed")
 
 	// Expectations
-	mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+	expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 	mockRepo.EXPECT().GetByID(txCtx, req.ID).Return(existingProduct, nil).Times(1)
 	mockRepo.EXPECT().Update(txCtx, gomock.Any()).Return(expectedErr).Times(1)
-	mockUOW.EXPECT().DeferErrorContext(txCtx, gomock.Any()).Return(nil).Times(1)
 
 	service := NewServiceV1(mockRepo, mockUOW, nil, mockCache)
 	product, err := service.Update(ctx, req, updatedBy)
@@ -462,16 +578,14 @@ func TestServiceV1_Delete(t *testing.T) {
 			ctx := context.Background()
 			txCtx := context.WithValue(ctx, txContextKey, "transaction")
 
-			mockUOW.EXPECT().StartContext(ctx).Return(txCtx).Times(1)
+			expectTransaction(mockUOW, ctx, txCtx, nil).Times(1)
 
 			if tt.repoErr != nil {
 				mockRepo.EXPECT().SoftDelete(txCtx, tt.args.id, tt.args.deletedBy).Return(tt.repoErr).Times(1)
-				mockUOW.EXPECT().DeferErrorContext(txCtx, gomock.Any()).Return(nil).Times(1)
 			} else {
 				mockRepo.EXPECT().SoftDelete(txCtx, tt.args.id, tt.args.deletedBy).Return(nil).Times(1)
 				mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Times(1)
 				mockCache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-				mockUOW.EXPECT().DeferErrorContext(txCtx, nil).Return(nil).Times(1)
 			}
 
 			service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
@@ -504,11 +618,10 @@ func BenchmarkServiceV1_Create(b *testing.B) {
 	ctx := context.Background()
 	txCtx := context.WithValue(ctx, txContextKey, "transaction")
 
-	mockUOW.EXPECT().StartContext(ctx).Return(txCtx).AnyTimes()
+	expectTransaction(mockUOW, ctx, txCtx, nil).AnyTimes()
 	mockRepo.EXPECT().Create(txCtx, gomock.Any()).Return(nil).AnyTimes()
 	mockEventBus.EXPECT().Publish(txCtx, gomock.Any()).Return(nil).AnyTimes()
 	mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-	mockUOW.EXPECT().DeferErrorContext(txCtx, nil).Return(nil).AnyTimes()
 
 	service := NewServiceV1(mockRepo, mockUOW, mockEventBus, mockCache)
 
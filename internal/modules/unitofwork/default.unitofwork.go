@@ -2,20 +2,22 @@ package unitofwork
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jmoiron/sqlx"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// DefaultUnitOfWork spans the SQL and MongoDB transactions of the backends it
+// was given. Pass nil for a backend no active repository uses so no
+// transaction is opened on it; with neither, it only runs the work.
 type DefaultUnitOfWork struct {
-	db       *sqlx.DB
-	mongo    *mongo.Client
 	uowSQL   *SQLUnitOfWork
 	uowMongo *MongoUnitOfWork
 }
 
 func NewDefaultUnitOfWork(db *sqlx.DB, mongo *mongo.Client) *DefaultUnitOfWork {
-	result := &DefaultUnitOfWork{db: db, mongo: mongo}
+	result := &DefaultUnitOfWork{}
 	if db != nil {
 		result.uowSQL = NewSQLUnitOfWork(db)
 	}
@@ -25,22 +27,50 @@ func NewDefaultUnitOfWork(db *sqlx.DB, mongo *mongo.Client) *DefaultUnitOfWork {
 	return result
 }
 
-func (r *DefaultUnitOfWork) StartContext(ctx context.Context) context.Context {
+func (r *DefaultUnitOfWork) StartContext(ctx context.Context) (context.Context, error) {
+	txCtx := ctx
+	var err error
 	if r.uowSQL != nil {
-		ctx = r.uowSQL.StartContext(ctx)
+		if txCtx, err = r.uowSQL.StartContext(txCtx); err != nil {
+			return ctx, err
+		}
 	}
 	if r.uowMongo != nil {
-		ctx = r.uowMongo.StartContext(ctx)
+		mongoCtx, err := r.uowMongo.StartContext(txCtx)
+		if err != nil {
+			if r.uowSQL != nil {
+				_ = r.uowSQL.DeferErrorContext(txCtx, err)
+			}
+			return ctx, err
+		}
+		txCtx = mongoCtx
 	}
-	return ctx
+	return txCtx, nil
 }
 
 func (r *DefaultUnitOfWork) DeferErrorContext(ctx context.Context, err error) error {
+	var mongoErr, sqlErr error
 	if r.uowMongo != nil {
-		err = r.uowMongo.DeferErrorContext(ctx, err)
+		mongoErr = r.uowMongo.DeferErrorContext(ctx, err)
+	}
+	if err == nil {
+		// A failed MongoDB commit rolls back the SQL transaction as well
+		err = mongoErr
 	}
 	if r.uowSQL != nil {
-		err = r.uowSQL.DeferErrorContext(ctx, err)
+		sqlErr = r.uowSQL.DeferErrorContext(ctx, err)
 	}
-	return err
+	return errors.Join(mongoErr, sqlErr)
+}
+
+func (r *DefaultUnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, r, fn)
+}
+
+func (r *DefaultUnitOfWork) active(ctx context.Context) bool {
+	return (r.uowSQL != nil && r.uowSQL.active(ctx)) || (r.uowMongo != nil && r.uowMongo.active(ctx))
+}
+
+func (r *DefaultUnitOfWork) retryable(err error) bool {
+	return (r.uowSQL != nil && r.uowSQL.retryable(err)) || (r.uowMongo != nil && r.uowMongo.retryable(err))
 }
@@ -2,38 +2,101 @@ package unitofwork
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 
 	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// transientTransactionError labels errors after which the whole MongoDB
+// transaction can be retried
+const transientTransactionError = "TransientTransactionError"
+
+// MongoUnitOfWork runs a MongoDB transaction in a session, which requires a
+// replica set or sharded cluster. MongoDB has no savepoints, so a nested unit
+// of work joins the outer transaction and a failure inside it marks the
+// whole transaction rollback-only.
 type MongoUnitOfWork struct {
 	client *mongo.Client
 }
 
+// mongoFrame is one level of a possibly nested MongoDB unit of work
+type mongoFrame struct {
+	session      mongo.Session
+	nested       bool
+	rollbackOnly *atomic.Bool // shared by every level of the transaction
+}
+
+type mongoFrameKey struct{}
+
 func NewMongoUnitOfWork(client *mongo.Client) *MongoUnitOfWork {
 	return &MongoUnitOfWork{client: client}
 }
 
-func (u *MongoUnitOfWork) StartContext(ctx context.Context) context.Context {
+func (u *MongoUnitOfWork) StartContext(ctx context.Context) (context.Context, error) {
+	if parent := mongoFrameFrom(ctx); parent != nil {
+		frame := &mongoFrame{session: parent.session, nested: true, rollbackOnly: parent.rollbackOnly}
+		return context.WithValue(ctx, mongoFrameKey{}, frame), nil
+	}
+
 	session, err := u.client.StartSession()
 	if err != nil {
-		return ctx
+		return ctx, fmt.Errorf("start session: %w", err)
+	}
+	if err := session.StartTransaction(); err != nil {
+		session.EndSession(ctx)
+		return ctx, fmt.Errorf("start transaction: %w", err)
 	}
-	return context.WithValue(ctx, sharedCtx.MongoSessionKey, session)
+	ctx = context.WithValue(ctx, sharedCtx.MongoSessionKey, &session)
+	return context.WithValue(ctx, mongoFrameKey{}, &mongoFrame{session: session, rollbackOnly: new(atomic.Bool)}), nil
 }
 
 func (u *MongoUnitOfWork) DeferErrorContext(ctx context.Context, err error) error {
-	session := *sharedCtx.GetObjectFromContext[mongo.Session](ctx, sharedCtx.MongoSessionKey)
-	if session != nil {
-		defer session.EndSession(ctx)
+	frame := mongoFrameFrom(ctx)
+	if frame == nil {
+		return nil
+	}
+	if frame.nested {
 		if err != nil {
-			// abort transaction
-			return session.AbortTransaction(ctx)
-		} else {
-			return session.CommitTransaction(ctx)
+			frame.rollbackOnly.Store(true)
 		}
+		return nil
+	}
+
+	// Abort and end the session even if the request was cancelled, so the
+	// server does not keep the transaction open until it times out
+	ctx = context.WithoutCancel(ctx)
+	defer frame.session.EndSession(ctx)
+	if err == nil && !frame.rollbackOnly.Load() {
+		return frame.session.CommitTransaction(ctx)
+	}
+	if abortErr := frame.session.AbortTransaction(ctx); abortErr != nil {
+		return abortErr
+	}
+	if err == nil {
+		return uow.ErrRollbackOnly
 	}
 	return nil
 }
+
+func (u *MongoUnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, u, fn)
+}
+
+func (u *MongoUnitOfWork) active(ctx context.Context) bool {
+	return mongoFrameFrom(ctx) != nil
+}
+
+func (u *MongoUnitOfWork) retryable(err error) bool {
+	var labeled interface{ HasErrorLabel(string) bool }
+	return errors.As(err, &labeled) && labeled.HasErrorLabel(transientTransactionError)
+}
+
+func mongoFrameFrom(ctx context.Context) *mongoFrame {
+	frame, _ := ctx.Value(mongoFrameKey{}).(*mongoFrame)
+	return frame
+}
@@ -2,33 +2,113 @@ package unitofwork
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Postgres SQLSTATE codes after which a transaction can succeed when re-run
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+// MySQL error numbers after which a transaction can succeed when re-run
+const (
+	mysqlLockWaitTimeout = 1205
+	mysqlDeadlock        = 1213
 )
 
 type SQLUnitOfWork struct {
 	db *sqlx.DB
 }
 
+// sqlFrame is one level of a possibly nested SQL unit of work
+type sqlFrame struct {
+	tx        *sqlx.Tx
+	depth     int
+	savepoint string // empty for the outermost transaction
+}
+
+type sqlFrameKey struct{}
+
 func NewSQLUnitOfWork(db *sqlx.DB) *SQLUnitOfWork {
 	return &SQLUnitOfWork{db: db}
 }
 
-func (r *SQLUnitOfWork) StartContext(ctx context.Context) context.Context {
-	tx := r.db.MustBeginTx(ctx, nil)
-	return context.WithValue(ctx, sharedCtx.PostgresTxKey, &tx)
+func (r *SQLUnitOfWork) StartContext(ctx context.Context) (context.Context, error) {
+	if parent := sqlFrameFrom(ctx); parent != nil {
+		frame := &sqlFrame{tx: parent.tx, depth: parent.depth + 1}
+		frame.savepoint = fmt.Sprintf("uow_savepoint_%d", frame.depth)
+		if _, err := parent.tx.ExecContext(ctx, "SAVEPOINT "+frame.savepoint); err != nil {
+			return ctx, fmt.Errorf("create savepoint: %w", err)
+		}
+		return context.WithValue(ctx, sqlFrameKey{}, frame), nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ctx, fmt.Errorf("begin transaction: %w", err)
+	}
+	ctx = context.WithValue(ctx, sharedCtx.PostgresTxKey, tx)
+	return context.WithValue(ctx, sqlFrameKey{}, &sqlFrame{tx: tx}), nil
 }
 
 func (r *SQLUnitOfWork) DeferErrorContext(ctx context.Context, err error) error {
-	tx := sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey)
-	if tx != nil {
+	frame := sqlFrameFrom(ctx)
+	if frame == nil {
+		return nil
+	}
+	if frame.savepoint == "" {
 		if err != nil {
-			return tx.Rollback()
-		} else {
-			return tx.Commit()
+			return frame.tx.Rollback()
 		}
+		return frame.tx.Commit()
 	}
-	return nil
+
+	// The error may come from a cancelled ctx; the savepoint must be
+	// resolved either way so the outer transaction can continue
+	ctx = context.WithoutCancel(ctx)
+	statement := "RELEASE SAVEPOINT "
+	if err != nil {
+		statement = "ROLLBACK TO SAVEPOINT "
+	}
+	_, execErr := frame.tx.ExecContext(ctx, statement+frame.savepoint)
+	return execErr
+}
+
+func (r *SQLUnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, r, fn)
+}
+
+func (r *SQLUnitOfWork) active(ctx context.Context) bool {
+	return sqlFrameFrom(ctx) != nil
+}
+
+func (r *SQLUnitOfWork) retryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock || mysqlErr.Number == mysqlLockWaitTimeout
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		// another connection held the write lock past the busy timeout
+		return sqliteErr.Code == sqlite3.ErrBusy
+	}
+	return false
+}
+
+func sqlFrameFrom(ctx context.Context) *sqlFrame {
+	frame, _ := ctx.Value(sqlFrameKey{}).(*sqlFrame)
+	return frame
 }
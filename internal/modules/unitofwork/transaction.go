@@ -0,0 +1,69 @@
+package unitofwork
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/uow"
+)
+
+// MaxAttempts bounds how often WithinTransaction runs fn when the
+// transaction keeps failing with retryable errors
+const MaxAttempts = 3
+
+// retryBackoff is the pause before the second attempt; it doubles per attempt
+var retryBackoff = 20 * time.Millisecond
+
+// transactor is a unit of work that knows whether ctx already carries its
+// transaction and which errors are worth re-running the transaction for
+type transactor interface {
+	uow.UnitOfWork
+	active(ctx context.Context) bool
+	retryable(err error) bool
+}
+
+// withinTransaction runs fn in a unit of work of u. Only the outermost
+// transaction retries: a nested one cannot recover from a serialization
+// failure since the database aborted the enclosing transaction, so the error
+// is returned to let the outermost level re-run everything.
+func withinTransaction(ctx context.Context, u transactor, fn func(ctx context.Context) error) error {
+	attempts := MaxAttempts
+	if u.active(ctx) {
+		attempts = 1
+	}
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := runTransaction(ctx, u, fn)
+		if err == nil || attempt >= attempts || !u.retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// runTransaction runs fn once between StartContext and DeferErrorContext. A
+// panic in fn rolls back before it propagates.
+func runTransaction(ctx context.Context, u uow.UnitOfWork, fn func(ctx context.Context) error) (err error) {
+	txCtx, err := u.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = u.DeferErrorContext(txCtx, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+	}()
+
+	err = fn(txCtx)
+	if endErr := u.DeferErrorContext(txCtx, err); err == nil {
+		err = endErr
+	}
+	return err
+}
@@ -0,0 +1,284 @@
+package unitofwork
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDriver is a database/sql driver that records the transaction
+// statements it receives instead of talking to a database
+type recordingDriver struct {
+	mu       sync.Mutex
+	log      []string
+	beginErr error
+}
+
+func (d *recordingDriver) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, s)
+}
+
+func (d *recordingDriver) statements() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.log...)
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d: d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recordingConn) Close() error                        { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	if c.d.beginErr != nil {
+		return nil, c.d.beginErr
+	}
+	c.d.record("BEGIN")
+	return &recordingTx{d: c.d}, nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+type recordingTx struct{ d *recordingDriver }
+
+func (t *recordingTx) Commit() error   { t.d.record("COMMIT"); return nil }
+func (t *recordingTx) Rollback() error { t.d.record("ROLLBACK"); return nil }
+
+var driverSeq int
+
+func newRecordingDB(t *testing.T) (*sqlx.DB, *recordingDriver) {
+	t.Helper()
+	d := &recordingDriver{}
+	driverSeq++
+	name := fmt.Sprintf("recording-%d", driverSeq)
+	sql.Register(name, d)
+	db, err := sqlx.Open(name, "")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestSQLUnitOfWork_CommitAndRollback(t *testing.T) {
+	db, d := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	ctx, err := u.StartContext(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey))
+	require.NoError(t, u.DeferErrorContext(ctx, nil))
+
+	ctx, err = u.StartContext(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, u.DeferErrorContext(ctx, errors.New("boom")))
+
+	assert.Equal(t, []string{"BEGIN", "COMMIT", "BEGIN", "ROLLBACK"}, d.statements())
+}
+
+func TestSQLUnitOfWork_NestedSavepoints(t *testing.T) {
+	db, d := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	err := u.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		// A failing nested unit only undoes its own work
+		nestedErr := u.WithinTransaction(ctx, func(ctx context.Context) error {
+			return u.WithinTransaction(ctx, func(context.Context) error { return nil })
+		})
+		require.NoError(t, nestedErr)
+		return u.WithinTransaction(ctx, func(context.Context) error { return errors.New("ignored by caller") })
+	})
+	require.Error(t, err, "the outer work returned the nested error")
+
+	assert.Equal(t, []string{
+		"BEGIN",
+		"SAVEPOINT uow_savepoint_1",
+		"SAVEPOINT uow_savepoint_2",
+		"RELEASE SAVEPOINT uow_savepoint_2",
+		"RELEASE SAVEPOINT uow_savepoint_1",
+		"SAVEPOINT uow_savepoint_1",
+		"ROLLBACK TO SAVEPOINT uow_savepoint_1",
+		"ROLLBACK",
+	}, d.statements())
+}
+
+func TestSQLUnitOfWork_BeginError(t *testing.T) {
+	db, d := newRecordingDB(t)
+	d.beginErr = errors.New("too many connections")
+	u := NewSQLUnitOfWork(db)
+
+	ctx := context.Background()
+	txCtx, err := u.StartContext(ctx)
+	assert.ErrorIs(t, err, d.beginErr)
+	assert.Equal(t, ctx, txCtx)
+
+	called := false
+	err = u.WithinTransaction(ctx, func(context.Context) error { called = true; return nil })
+	assert.ErrorIs(t, err, d.beginErr)
+	assert.False(t, called)
+}
+
+func TestWithinTransaction_RetriesSerializationFailures(t *testing.T) {
+	retryBackoff = time.Millisecond
+	db, d := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	runs := 0
+	err := u.WithinTransaction(context.Background(), func(context.Context) error {
+		runs++
+		if runs < 3 {
+			return &pq.Error{Code: pqSerializationFailure}
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"}, d.statements())
+}
+
+func TestWithinTransaction_GivesUp(t *testing.T) {
+	retryBackoff = time.Millisecond
+	db, _ := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	runs := 0
+	err := u.WithinTransaction(context.Background(), func(context.Context) error {
+		runs++
+		return &pq.Error{Code: pqDeadlockDetected}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, MaxAttempts, runs)
+
+	runs = 0
+	err = u.WithinTransaction(context.Background(), func(context.Context) error {
+		runs++
+		return &pq.Error{Code: "23505"} // unique violation is not retried
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, runs)
+}
+
+func TestSQLUnitOfWork_Retryable(t *testing.T) {
+	u := NewSQLUnitOfWork(nil)
+	assert.True(t, u.retryable(fmt.Errorf("insert: %w", &pq.Error{Code: pqSerializationFailure})))
+	assert.True(t, u.retryable(&mysql.MySQLError{Number: mysqlDeadlock}))
+	assert.True(t, u.retryable(&mysql.MySQLError{Number: mysqlLockWaitTimeout}))
+	assert.False(t, u.retryable(&mysql.MySQLError{Number: 1062})) // duplicate entry
+	assert.True(t, u.retryable(sqlite3.Error{Code: sqlite3.ErrBusy}))
+	assert.False(t, u.retryable(sqlite3.Error{Code: sqlite3.ErrConstraint}))
+}
+
+func TestWithinTransaction_NestedDoesNotRetry(t *testing.T) {
+	retryBackoff = time.Millisecond
+	db, _ := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	outerRuns, innerRuns := 0, 0
+	err := u.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		outerRuns++
+		return u.WithinTransaction(ctx, func(context.Context) error {
+			innerRuns++
+			if outerRuns == 1 {
+				return &pq.Error{Code: pqSerializationFailure}
+			}
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, outerRuns)
+	assert.Equal(t, 2, innerRuns)
+}
+
+func TestWithinTransaction_PanicRollsBack(t *testing.T) {
+	db, d := newRecordingDB(t)
+	u := NewSQLUnitOfWork(db)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = u.WithinTransaction(context.Background(), func(context.Context) error { panic("boom") })
+	})
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, d.statements())
+}
+
+func TestDefaultUnitOfWork_NoBackends(t *testing.T) {
+	u := NewDefaultUnitOfWork(nil, nil)
+
+	ctx := context.Background()
+	txCtx, err := u.StartContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, txCtx)
+	assert.NoError(t, u.DeferErrorContext(txCtx, nil))
+
+	fnErr := errors.New("boom")
+	assert.ErrorIs(t, u.WithinTransaction(ctx, func(context.Context) error { return fnErr }), fnErr)
+}
+
+func TestDefaultUnitOfWork_SQLOnly(t *testing.T) {
+	db, d := newRecordingDB(t)
+	u := NewDefaultUnitOfWork(db, nil)
+
+	err := u.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		assert.Nil(t, sharedCtx.GetObjectFromContext[any](ctx, sharedCtx.MongoSessionKey))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BEGIN", "COMMIT"}, d.statements())
+}
+
+// labeledError mimics a MongoDB server error carrying error labels
+type labeledError struct{ labels []string }
+
+func (e labeledError) Error() string { return "mongo error" }
+
+func (e labeledError) HasErrorLabel(label string) bool {
+	for _, l := range e.labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMongoUnitOfWork_Retryable(t *testing.T) {
+	u := NewMongoUnitOfWork(nil)
+	assert.True(t, u.retryable(labeledError{labels: []string{transientTransactionError}}))
+	assert.False(t, u.retryable(labeledError{labels: []string{"RetryableWriteError"}}))
+	assert.False(t, u.retryable(errors.New("duplicate key")))
+}
+
+func TestMongoUnitOfWork_NestedMarksRollbackOnly(t *testing.T) {
+	u := NewMongoUnitOfWork(nil)
+	outer := &mongoFrame{rollbackOnly: new(atomic.Bool)}
+	ctx := context.WithValue(context.Background(), mongoFrameKey{}, outer)
+
+	nestedCtx, err := u.StartContext(ctx)
+	require.NoError(t, err)
+	require.NoError(t, u.DeferErrorContext(nestedCtx, nil))
+	assert.False(t, outer.rollbackOnly.Load())
+
+	nestedCtx, err = u.StartContext(ctx)
+	require.NoError(t, err)
+	require.NoError(t, u.DeferErrorContext(nestedCtx, errors.New("boom")))
+	assert.True(t, outer.rollbackOnly.Load(), "the outer transaction can no longer commit")
+}
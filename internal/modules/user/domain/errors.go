@@ -0,0 +1,9 @@
+package domain
+
+import sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+
+// ErrUserNotFound is returned by repositories when no user has the requested ID
+var ErrUserNotFound = sharederrors.ErrNotFound.WithMessage("user not found")
+
+// ErrEmailExists is returned by repositories when another user has the email
+var ErrEmailExists = sharederrors.ErrAlreadyExists.WithMessage("email already exists")
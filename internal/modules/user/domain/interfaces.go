@@ -25,7 +25,7 @@ type EmailSender interface {
 type Service interface {
 	Create(ctx context.Context, req *CreateUserRequest, createdBy string) (*User, error)
 	Get(ctx context.Context, id string) (*User, error)
-	List(ctx context.Context) ([]User, error)
+	List(ctx context.Context, req *ListUserRequest) (*UserPage, error)
 	Update(ctx context.Context, req *UpdateUserRequest, updatedBy string) (*User, error)
 	Delete(ctx context.Context, id, deletedBy string) error
 }
@@ -38,7 +38,9 @@ type Repository interface {
 	Create(ctx context.Context, u *User) error
 	GetByID(ctx context.Context, id string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
-	List(ctx context.Context) ([]User, error)
+	// List returns one page of users matching req along with the total
+	// number of matches ignoring pagination
+	List(ctx context.Context, req *ListUserRequest) ([]User, int, error)
 	Update(ctx context.Context, u *User) error
 	SoftDelete(ctx context.Context, id, deletedBy string) error
 }
@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"time"
+
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+)
+
 // CreateUserRequest represents the request to create a user
 type CreateUserRequest struct {
 	Name  string `json:"name" binding:"required" validate:"required,min=1,max=255"`
@@ -12,3 +19,73 @@ type UpdateUserRequest struct {
 	Name  string `json:"name" validate:"omitempty,min=1,max=255"`
 	Email string `json:"email" binding:"omitempty,email" validate:"omitempty,email"`
 }
+
+// Sort keys accepted by ListUserRequest
+const (
+	UserSortName      = "name"
+	UserSortEmail     = "email"
+	UserSortCreatedAt = "created_at"
+
+	defaultUserSort = "-" + UserSortCreatedAt
+)
+
+// ListUserRequest represents the query for listing users
+type ListUserRequest struct {
+	model.PaginationRequest
+	Name        string     `json:"name,omitempty"` // case-insensitive substring match
+	CreatedBy   string     `json:"created_by,omitempty"`
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	Sort        string     `json:"sort,omitempty"` // name, email or created_at, prefixed with '-' for descending
+}
+
+// Normalize applies pagination defaults and rejects unknown sort keys
+func (r *ListUserRequest) Normalize() error {
+	r.PaginationRequest.Normalize()
+	if r.Sort == "" {
+		r.Sort = defaultUserSort
+	}
+	switch r.SortOrder().Field {
+	case UserSortName, UserSortEmail, UserSortCreatedAt:
+		return nil
+	default:
+		return sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + r.Sort)
+	}
+}
+
+// SortOrder returns the parsed sort key
+func (r *ListUserRequest) SortOrder() model.SortRequest {
+	return model.ParseSort(r.Sort)
+}
+
+// DecodeCursor returns the position to continue after, or nil for offset paging
+func (r *ListUserRequest) DecodeCursor() (*model.Cursor, error) {
+	if !r.UsesCursor() {
+		return nil, nil
+	}
+	c, err := model.DecodeCursor(r.Cursor, r.SortOrder())
+	if err != nil {
+		return nil, err
+	}
+	if r.SortOrder().Field == UserSortCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+			return nil, model.ErrInvalidCursor.WithError(err)
+		}
+	}
+	return &c, nil
+}
+
+// NextCursor builds the cursor pointing just after u in the requested order
+func (r *ListUserRequest) NextCursor(u *User) string {
+	sort := r.SortOrder()
+	var value string
+	switch sort.Field {
+	case UserSortName:
+		value = u.Name
+	case UserSortEmail:
+		value = u.Email
+	default:
+		value = u.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return model.EncodeCursor(model.Cursor{Sort: sort.String(), Value: value, ID: u.ID})
+}
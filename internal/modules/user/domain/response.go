@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+)
 
 // UserResponse represents the user response payload
 type UserResponse struct {
@@ -20,6 +24,9 @@ type UserListResponse struct {
 	Users []UserResponse `json:"users"`
 }
 
+// UserPage is one page of a user listing
+type UserPage = model.PaginatedResponse[User]
+
 // ToResponse converts a User to UserResponse
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+
+	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/adapters"
+	userv1 "github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	grpcAdapter "github.com/kamil5b/go-pste-monolith/internal/transports/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GRPCHandler implements the User gRPC service
+type GRPCHandler struct {
+	service userDomain.Service
+	userv1.UnimplementedUserServiceServer
+}
+
+// NewGRPCHandler creates a new GRPCHandler
+func NewGRPCHandler(service userDomain.Service) *GRPCHandler {
+	return &GRPCHandler{service: service}
+}
+
+// Create creates a new user
+func (h *GRPCHandler) Create(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	// Set by the gRPC auth interceptor
+	createdBy, _ := sharedctx.GetUserID(ctx)
+
+	createReq := adapters.PBCreateUserRequestToDomainRequest(req)
+
+	user, err := h.service.Create(ctx, createReq, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.CreateUserResponse{
+		User: adapters.DomainUserToPBUser(user),
+	}, nil
+}
+
+// Get retrieves a user by ID
+func (h *GRPCHandler) Get(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	user, err := h.service.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.GetUserResponse{
+		User: adapters.DomainUserToPBUser(user),
+	}, nil
+}
+
+// List retrieves a page of users
+func (h *GRPCHandler) List(ctx context.Context, req *userv1.ListUserRequest) (*userv1.ListUserResponse, error) {
+	page, err := h.service.List(ctx, adapters.PBListUserRequestToDomainRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return adapters.DomainUserPageToPBListResponse(page), nil
+}
+
+// Update updates an existing user
+func (h *GRPCHandler) Update(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	updatedBy, _ := sharedctx.GetUserID(ctx)
+
+	updateReq := adapters.PBUpdateUserRequestToDomainRequest(req)
+
+	user, err := h.service.Update(ctx, updateReq, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.UpdateUserResponse{
+		User: adapters.DomainUserToPBUser(user),
+	}, nil
+}
+
+// Delete deletes a user
+func (h *GRPCHandler) Delete(ctx context.Context, req *userv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	deletedBy, _ := sharedctx.GetUserID(ctx)
+
+	err := h.service.Delete(ctx, req.GetId(), deletedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RegisterService registers the User service with the gRPC server
+func RegisterService(h *GRPCHandler) grpcAdapter.ServiceRegistrar {
+	return func(s *grpc.Server) {
+		userv1.RegisterUserServiceServer(s, h)
+	}
+}
@@ -0,0 +1,228 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	mockdomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain/mocks"
+	userv1 "github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/v1"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+
+	gomock "github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestGRPCHandler_Create tests the Create method
+func TestGRPCHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	now := time.Now()
+
+	mockService.EXPECT().
+		Create(gomock.Any(), gomock.AssignableToTypeOf(&userDomain.CreateUserRequest{}), "user-123").
+		Return(&userDomain.User{
+			ID:        "user-1",
+			Name:      "Test User",
+			Email:     "test@example.com",
+			CreatedAt: now,
+			CreatedBy: "user-123",
+		}, nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := sharedctx.WithUserID(context.Background(), "user-123")
+
+	resp, err := handler.Create(ctx, &userv1.CreateUserRequest{
+		Name:  "Test User",
+		Email: "test@example.com",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.User.Name != "Test User" {
+		t.Errorf("expected name 'Test User', got %q", resp.User.Name)
+	}
+
+	if resp.User.CreatedBy != "user-123" {
+		t.Errorf("expected createdBy 'user-123', got %q", resp.User.CreatedBy)
+	}
+}
+
+// TestGRPCHandler_Get tests the Get method
+func TestGRPCHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	now := time.Now()
+
+	mockService.EXPECT().
+		Get(gomock.Any(), "user-1").
+		Return(&userDomain.User{
+			ID:        "user-1",
+			Name:      "Test User",
+			Email:     "test@example.com",
+			CreatedAt: now,
+			CreatedBy: "user-123",
+		}, nil)
+
+	handler := NewGRPCHandler(mockService)
+
+	resp, err := handler.Get(context.Background(), &userv1.GetUserRequest{Id: "user-1"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.User.Id != "user-1" {
+		t.Errorf("expected Id 'user-1', got %q", resp.User.Id)
+	}
+}
+
+// TestGRPCHandler_List tests the List method
+func TestGRPCHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	now := time.Now()
+
+	mockService.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(&userDomain.UserPage{Data: []userDomain.User{
+			{
+				ID:        "user-1",
+				Name:      "User 1",
+				Email:     "first@example.com",
+				CreatedAt: now,
+				CreatedBy: "user-123",
+			},
+			{
+				ID:        "user-2",
+				Name:      "User 2",
+				Email:     "second@example.com",
+				CreatedAt: now,
+				CreatedBy: "user-123",
+			},
+		}, Metadata: model.PaginationMetadata{TotalItems: 12, TotalPages: 6, Page: 1, Limit: 2, NextCursor: "next"}}, nil)
+
+	handler := NewGRPCHandler(mockService)
+
+	resp, err := handler.List(context.Background(), nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(resp.Users) != 2 {
+		t.Errorf("expected 2 users, got %d", len(resp.Users))
+	}
+
+	if resp.TotalItems != 12 || resp.TotalPages != 6 || resp.NextCursor != "next" {
+		t.Errorf("unexpected pagination metadata: %+v", resp)
+	}
+}
+
+// TestGRPCHandler_List_Filters tests that request filters reach the service
+func TestGRPCHandler_List_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *userDomain.ListUserRequest) (*userDomain.UserPage, error) {
+			if req.Limit != 10 || req.Cursor != "abc" || req.Name != "alice" || req.Sort != "-name" {
+				t.Errorf("unexpected request: %+v", req)
+			}
+			if req.CreatedFrom == nil || !req.CreatedFrom.Equal(from) {
+				t.Errorf("expected created_from %v, got %v", from, req.CreatedFrom)
+			}
+			return &userDomain.UserPage{}, nil
+		})
+
+	handler := NewGRPCHandler(mockService)
+
+	_, err := handler.List(context.Background(), &userv1.ListUserRequest{
+		Limit:       10,
+		Cursor:      "abc",
+		Name:        "alice",
+		Sort:        "-name",
+		CreatedFrom: timestamppb.New(from),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestGRPCHandler_Update tests the Update method
+func TestGRPCHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+	now := time.Now()
+	updatedName := "Updated User"
+	updatedBy := "user-456"
+
+	mockService.EXPECT().
+		Update(gomock.Any(), gomock.AssignableToTypeOf(&userDomain.UpdateUserRequest{}), "user-456").
+		Return(&userDomain.User{
+			ID:        "user-1",
+			Name:      updatedName,
+			Email:     "test@example.com",
+			CreatedAt: now,
+			CreatedBy: "user-123",
+			UpdatedAt: &now,
+			UpdatedBy: &updatedBy,
+		}, nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := sharedctx.WithUserID(context.Background(), "user-456")
+
+	resp, err := handler.Update(ctx, &userv1.UpdateUserRequest{
+		Id:   "user-1",
+		Name: &updatedName,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.User.Name != updatedName {
+		t.Errorf("expected name %q, got %q", updatedName, resp.User.Name)
+	}
+
+	if resp.User.UpdatedBy == nil || *resp.User.UpdatedBy != "user-456" {
+		t.Errorf("expected updatedBy 'user-456'")
+	}
+}
+
+// TestGRPCHandler_Delete tests the Delete method
+func TestGRPCHandler_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockdomain.NewMockService(ctrl)
+
+	mockService.EXPECT().
+		Delete(gomock.Any(), "user-1", "user-123").
+		Return(nil)
+
+	handler := NewGRPCHandler(mockService)
+	ctx := sharedctx.WithUserID(context.Background(), "user-123")
+
+	_, err := handler.Delete(ctx, &userv1.DeleteUserRequest{Id: "user-1"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -1,10 +1,14 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
 	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 )
 
 type Handler struct {
@@ -17,12 +21,12 @@ func (h *Handler) Create(c sharedctx.Context) error {
 	var req domain.CreateUserRequest
 	ctx := c.GetContext()
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 	createdBy := c.GetUserID()
 	u, err := h.svc.Create(ctx, &req, createdBy)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return sharederrors.WriteProblem(c, err)
 	}
 	return c.JSON(http.StatusCreated, u)
 }
@@ -32,18 +36,72 @@ func (h *Handler) Get(c sharedctx.Context) error {
 	id := c.Param("id")
 	u, err := h.svc.Get(ctx, id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, err.Error())
+		return sharederrors.WriteProblem(c, err)
 	}
 	return c.JSON(http.StatusOK, u)
 }
 
 func (h *Handler) List(c sharedctx.Context) error {
 	ctx := c.GetContext()
-	lst, err := h.svc.List(ctx)
+	req, err := parseListRequest(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return sharederrors.WriteProblem(c, err)
 	}
-	return c.JSON(http.StatusOK, lst)
+	res, err := h.svc.List(ctx, req)
+	if err != nil {
+		return sharederrors.WriteProblem(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// parseListRequest reads paging, filter and sort options from the query string,
+// e.g. ?page=2&limit=50&name=ann&sort=email
+func parseListRequest(c sharedctx.Context) (*domain.ListUserRequest, error) {
+	req := &domain.ListUserRequest{
+		Name:      c.QueryParam("name"),
+		CreatedBy: c.QueryParam("created_by"),
+		Sort:      c.QueryParam("sort"),
+	}
+	req.Cursor = c.QueryParam("cursor")
+
+	var err error
+	if req.Page, err = queryInt(c, "page"); err != nil {
+		return nil, err
+	}
+	if req.Limit, err = queryInt(c, "limit"); err != nil {
+		return nil, err
+	}
+	if req.CreatedFrom, err = queryTime(c, "created_from"); err != nil {
+		return nil, err
+	}
+	if req.CreatedTo, err = queryTime(c, "created_to"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func queryInt(c sharedctx.Context, name string) (int, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, sharederrors.ErrInvalidInput.WithMessage(fmt.Sprintf("invalid %s: %q", name, v))
+	}
+	return n, nil
+}
+
+func queryTime(c sharedctx.Context, name string) (*time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, sharederrors.ErrInvalidInput.WithMessage(fmt.Sprintf("invalid %s: expected RFC 3339 timestamp", name))
+	}
+	return &t, nil
 }
 
 func (h *Handler) Update(c sharedctx.Context) error {
@@ -51,13 +109,13 @@ func (h *Handler) Update(c sharedctx.Context) error {
 	id := c.Param("id")
 	var req domain.UpdateUserRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return sharederrors.WriteProblem(c, sharederrors.ErrInvalidInput.WithMessage(err.Error()))
 	}
 	req.ID = id
 	updatedBy := c.GetUserID()
 	u, err := h.svc.Update(ctx, &req, updatedBy)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return sharederrors.WriteProblem(c, err)
 	}
 	return c.JSON(http.StatusOK, u)
 }
@@ -72,7 +130,7 @@ func (h *Handler) Delete(c sharedctx.Context) error {
 		}
 	}
 	if err := h.svc.Delete(ctx, id, by); err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return sharederrors.WriteProblem(c, err)
 	}
 	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
 }
@@ -0,0 +1,171 @@
+package adapters
+
+import (
+	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	userv1 "github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/v1"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DomainUserToPBUser converts a domain User to a protobuf User
+func DomainUserToPBUser(domain *userDomain.User) *userv1.User {
+	if domain == nil {
+		return nil
+	}
+
+	pb := &userv1.User{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		Email:     domain.Email,
+		CreatedBy: domain.CreatedBy,
+	}
+
+	if !domain.CreatedAt.IsZero() {
+		pb.CreatedAt = &timestamppb.Timestamp{
+			Seconds: domain.CreatedAt.Unix(),
+			Nanos:   int32(domain.CreatedAt.Nanosecond()),
+		}
+	}
+
+	if domain.UpdatedAt != nil && !domain.UpdatedAt.IsZero() {
+		pb.UpdatedAt = &timestamppb.Timestamp{
+			Seconds: domain.UpdatedAt.Unix(),
+			Nanos:   int32(domain.UpdatedAt.Nanosecond()),
+		}
+	}
+
+	if domain.UpdatedBy != nil {
+		pb.UpdatedBy = domain.UpdatedBy
+	}
+
+	if domain.DeletedAt != nil && !domain.DeletedAt.IsZero() {
+		pb.DeletedAt = &timestamppb.Timestamp{
+			Seconds: domain.DeletedAt.Unix(),
+			Nanos:   int32(domain.DeletedAt.Nanosecond()),
+		}
+	}
+
+	if domain.DeletedBy != nil {
+		pb.DeletedBy = domain.DeletedBy
+	}
+
+	return pb
+}
+
+// PBUserToDomainUser converts a protobuf User to a domain User
+func PBUserToDomainUser(pb *userv1.User) *userDomain.User {
+	if pb == nil {
+		return nil
+	}
+
+	user := &userDomain.User{
+		ID:        pb.GetId(),
+		Name:      pb.GetName(),
+		Email:     pb.GetEmail(),
+		CreatedBy: pb.GetCreatedBy(),
+	}
+
+	if pb.CreatedAt != nil {
+		user.CreatedAt = pb.CreatedAt.AsTime()
+	}
+
+	if pb.UpdatedAt != nil {
+		updatedAt := pb.UpdatedAt.AsTime()
+		user.UpdatedAt = &updatedAt
+	}
+
+	if pb.UpdatedBy != nil {
+		user.UpdatedBy = pb.UpdatedBy
+	}
+
+	if pb.DeletedAt != nil {
+		deletedAt := pb.DeletedAt.AsTime()
+		user.DeletedAt = &deletedAt
+	}
+
+	if pb.DeletedBy != nil {
+		user.DeletedBy = pb.DeletedBy
+	}
+
+	return user
+}
+
+// PBCreateUserRequestToDomainRequest converts protobuf request to domain request
+func PBCreateUserRequestToDomainRequest(pb *userv1.CreateUserRequest) *userDomain.CreateUserRequest {
+	if pb == nil {
+		return nil
+	}
+
+	return &userDomain.CreateUserRequest{
+		Name:  pb.GetName(),
+		Email: pb.GetEmail(),
+	}
+}
+
+// PBUpdateUserRequestToDomainRequest converts protobuf request to domain request
+func PBUpdateUserRequestToDomainRequest(pb *userv1.UpdateUserRequest) *userDomain.UpdateUserRequest {
+	if pb == nil {
+		return nil
+	}
+
+	req := &userDomain.UpdateUserRequest{
+		ID: pb.GetId(),
+	}
+
+	if pb.Name != nil {
+		req.Name = *pb.Name
+	}
+
+	if pb.Email != nil {
+		req.Email = *pb.Email
+	}
+
+	return req
+}
+
+// PBListUserRequestToDomainRequest converts protobuf request to domain request
+func PBListUserRequestToDomainRequest(pb *userv1.ListUserRequest) *userDomain.ListUserRequest {
+	req := &userDomain.ListUserRequest{}
+	if pb == nil {
+		return req
+	}
+
+	req.Page = int(pb.GetPage())
+	req.Limit = int(pb.GetLimit())
+	req.Cursor = pb.GetCursor()
+	req.Name = pb.GetName()
+	req.CreatedBy = pb.GetCreatedBy()
+	req.Sort = pb.GetSort()
+
+	if pb.CreatedFrom != nil {
+		createdFrom := pb.CreatedFrom.AsTime()
+		req.CreatedFrom = &createdFrom
+	}
+
+	if pb.CreatedTo != nil {
+		createdTo := pb.CreatedTo.AsTime()
+		req.CreatedTo = &createdTo
+	}
+
+	return req
+}
+
+// DomainUserPageToPBListResponse converts a page of domain Users to a protobuf list response
+func DomainUserPageToPBListResponse(page *userDomain.UserPage) *userv1.ListUserResponse {
+	if page == nil {
+		return &userv1.ListUserResponse{}
+	}
+
+	pbUsers := make([]*userv1.User, len(page.Data))
+	for i := range page.Data {
+		pbUsers[i] = DomainUserToPBUser(&page.Data[i])
+	}
+
+	return &userv1.ListUserResponse{
+		Users:      pbUsers,
+		TotalItems: int32(page.Metadata.TotalItems),
+		TotalPages: int32(page.Metadata.TotalPages),
+		Page:       int32(page.Metadata.Page),
+		Limit:      int32(page.Metadata.Limit),
+		NextCursor: page.Metadata.NextCursor,
+	}
+}
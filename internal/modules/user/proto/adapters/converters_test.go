@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	userDomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	userv1 "github.com/kamil5b/go-pste-monolith/internal/modules/user/proto/v1"
+	"github.com/stretchr/testify/assert"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDomainUserToPBUser(t *testing.T) {
+	now := time.Now()
+
+	domainUser := &userDomain.User{
+		ID:        "usr-123",
+		Name:      "Test User",
+		Email:     "test@example.com",
+		CreatedBy: "user-1",
+		CreatedAt: now,
+		UpdatedBy: ptr("user-2"),
+		UpdatedAt: ptr(now.Add(1 * time.Hour)),
+	}
+
+	pbUser := DomainUserToPBUser(domainUser)
+
+	assert.NotNil(t, pbUser)
+	assert.Equal(t, "usr-123", pbUser.GetId())
+	assert.Equal(t, "Test User", pbUser.GetName())
+	assert.Equal(t, "test@example.com", pbUser.GetEmail())
+	assert.Equal(t, "user-1", pbUser.GetCreatedBy())
+	assert.NotNil(t, pbUser.GetCreatedAt())
+	assert.NotNil(t, pbUser.GetUpdatedAt())
+	assert.Equal(t, "user-2", pbUser.GetUpdatedBy())
+}
+
+func TestDomainUserToPBUserNil(t *testing.T) {
+	pbUser := DomainUserToPBUser(nil)
+	assert.Nil(t, pbUser)
+}
+
+func TestPBUserToDomainUser(t *testing.T) {
+	now := timestamppb.Now()
+
+	pbUser := &userv1.User{
+		Id:        "usr-123",
+		Name:      "Test User",
+		Email:     "test@example.com",
+		CreatedBy: "user-1",
+		CreatedAt: now,
+		UpdatedBy: ptr("user-2"),
+		UpdatedAt: now,
+	}
+
+	domainUser := PBUserToDomainUser(pbUser)
+
+	assert.NotNil(t, domainUser)
+	assert.Equal(t, "usr-123", domainUser.ID)
+	assert.Equal(t, "Test User", domainUser.Name)
+	assert.Equal(t, "test@example.com", domainUser.Email)
+	assert.Equal(t, "user-1", domainUser.CreatedBy)
+	assert.NotZero(t, domainUser.CreatedAt)
+	assert.NotNil(t, domainUser.UpdatedAt)
+	assert.Equal(t, "user-2", *domainUser.UpdatedBy)
+}
+
+func TestPBUserToDomainUserNil(t *testing.T) {
+	domainUser := PBUserToDomainUser(nil)
+	assert.Nil(t, domainUser)
+}
+
+func TestPBCreateUserRequestToDomainRequest(t *testing.T) {
+	pbReq := &userv1.CreateUserRequest{
+		Name:  "New User",
+		Email: "new@example.com",
+	}
+
+	domainReq := PBCreateUserRequestToDomainRequest(pbReq)
+
+	assert.NotNil(t, domainReq)
+	assert.Equal(t, "New User", domainReq.Name)
+	assert.Equal(t, "new@example.com", domainReq.Email)
+}
+
+func TestPBCreateUserRequestToDomainRequestNil(t *testing.T) {
+	domainReq := PBCreateUserRequestToDomainRequest(nil)
+	assert.Nil(t, domainReq)
+}
+
+func TestPBUpdateUserRequestToDomainRequest(t *testing.T) {
+	newName := "Updated Name"
+	pbReq := &userv1.UpdateUserRequest{
+		Id:   "usr-123",
+		Name: &newName,
+	}
+
+	domainReq := PBUpdateUserRequestToDomainRequest(pbReq)
+
+	assert.NotNil(t, domainReq)
+	assert.Equal(t, "usr-123", domainReq.ID)
+	assert.Equal(t, "Updated Name", domainReq.Name)
+}
+
+func TestPBUpdateUserRequestToDomainRequestNil(t *testing.T) {
+	domainReq := PBUpdateUserRequestToDomainRequest(nil)
+	assert.Nil(t, domainReq)
+}
+
+// Helper function for pointer conversion
+func ptr[T any](v T) *T {
+	return &v
+}
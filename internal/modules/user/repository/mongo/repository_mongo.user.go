@@ -0,0 +1,175 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const usersCollection = "users"
+
+type MongoRepository struct {
+	col *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, dbName string) *MongoRepository {
+	return &MongoRepository{col: client.Database(dbName).Collection(usersCollection)}
+}
+
+// StartContext is a no-op: MongoDB transactions need a replica set, so the
+// repository only joins the unit of work session already in ctx
+func (r *MongoRepository) StartContext(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (r *MongoRepository) DeferErrorContext(ctx context.Context, err error) {
+}
+
+// getSessionContext binds ctx to the unit of work session, if any, so the
+// operation joins its transaction.
+func (r *MongoRepository) getSessionContext(ctx context.Context) context.Context {
+	if session := sharedCtx.GetObjectFromContext[mongo.Session](ctx, sharedCtx.MongoSessionKey); session != nil {
+		return mongo.NewSessionContext(ctx, *session)
+	}
+	return ctx
+}
+
+func (r *MongoRepository) Create(ctx context.Context, u *domain.User) error {
+	ctx = r.getSessionContext(ctx)
+	if u.ID == "" {
+		u.ID = uuid.NewString()
+	}
+	u.CreatedAt = time.Now().UTC()
+	_, err := r.col.InsertOne(ctx, u)
+	return duplicateEmail(err)
+}
+
+func (r *MongoRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.findOne(ctx, bson.M{"id": id})
+}
+
+func (r *MongoRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.findOne(ctx, bson.M{"email": email, "deleted_at": bson.M{"$exists": false}})
+}
+
+func (r *MongoRepository) findOne(ctx context.Context, filter bson.M) (*domain.User, error) {
+	ctx = r.getSessionContext(ctx)
+	var u domain.User
+	if err := r.col.FindOne(ctx, filter).Decode(&u); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *MongoRepository) List(ctx context.Context, req *domain.ListUserRequest) ([]domain.User, int, error) {
+	ctx = r.getSessionContext(ctx)
+	sort := req.SortOrder()
+	switch sort.Field {
+	case domain.UserSortName, domain.UserSortEmail, domain.UserSortCreatedAt:
+	default:
+		return nil, 0, sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + req.Sort)
+	}
+	after, err := req.DecodeCursor()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{"deleted_at": bson.M{"$exists": false}}
+	if req.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(req.Name), "$options": "i"}
+	}
+	if req.CreatedBy != "" {
+		filter["created_by"] = req.CreatedBy
+	}
+	if req.CreatedFrom != nil || req.CreatedTo != nil {
+		created := bson.M{}
+		if req.CreatedFrom != nil {
+			created["$gte"] = *req.CreatedFrom
+		}
+		if req.CreatedTo != nil {
+			created["$lte"] = *req.CreatedTo
+		}
+		filter["created_at"] = created
+	}
+
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dir, cmp := 1, "$gt"
+	if sort.Direction == model.SortDesc {
+		dir, cmp = -1, "$lt"
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: sort.Field, Value: dir}, {Key: "id", Value: dir}}).
+		SetLimit(int64(req.Limit))
+	if after != nil {
+		var value any = after.Value
+		if sort.Field == domain.UserSortCreatedAt {
+			createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, 0, model.ErrInvalidCursor.WithError(err)
+			}
+			value = createdAt
+		}
+		// Keyset condition, ANDed with the created_at range filter if present
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{sort.Field: bson.M{cmp: value}},
+			bson.M{sort.Field: value, "id": bson.M{cmp: after.ID}},
+		}}}}
+	} else {
+		opts.SetSkip(int64(req.Offset()))
+	}
+
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+	res := []domain.User{}
+	if err := cur.All(ctx, &res); err != nil {
+		return nil, 0, err
+	}
+	return res, int(total), nil
+}
+
+func (r *MongoRepository) Update(ctx context.Context, u *domain.User) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	u.UpdatedAt = &now
+	upd := bson.M{"$set": bson.M{"name": u.Name, "email": u.Email, "updated_at": u.UpdatedAt, "updated_by": u.UpdatedBy}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"id": u.ID}, upd)
+	return duplicateEmail(err)
+}
+
+func (r *MongoRepository) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	ctx = r.getSessionContext(ctx)
+	now := time.Now().UTC()
+	upd := bson.M{"$set": bson.M{"deleted_at": now, "deleted_by": deletedBy}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"id": id}, upd)
+	return err
+}
+
+// duplicateEmail reports a violation of the unique email index as
+// ErrEmailExists
+func duplicateEmail(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrEmailExists
+	}
+	return err
+}
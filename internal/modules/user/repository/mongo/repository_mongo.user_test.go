@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockRepository(mt *mtest.T) *MongoRepository {
+	return &MongoRepository{col: mt.Coll}
+}
+
+func TestMongoRepository(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ns := "appdb." + usersCollection
+
+	mt.Run("create assigns an ID", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		u := &domain.User{Name: "Ada", Email: "ada@example.com"}
+
+		require.NoError(t, newMockRepository(mt).Create(context.Background(), u))
+		assert.NotEmpty(t, u.ID)
+		assert.False(t, u.CreatedAt.IsZero())
+	})
+
+	mt.Run("create with a taken email", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Code:    11000,
+			Message: "E11000 duplicate key error collection: users index: email_1",
+		}))
+
+		err := newMockRepository(mt).Create(context.Background(), &domain.User{Email: "ada@example.com"})
+		assert.ErrorIs(t, err, domain.ErrEmailExists)
+	})
+
+	mt.Run("get by email", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "id", Value: "user-1"},
+			{Key: "name", Value: "Ada"},
+			{Key: "email", Value: "ada@example.com"},
+		}))
+
+		u, err := newMockRepository(mt).GetByEmail(context.Background(), "ada@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", u.ID)
+	})
+
+	mt.Run("get by ID not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+
+		_, err := newMockRepository(mt).GetByID(context.Background(), "missing")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	mt.Run("list", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "n", Value: int32(2)}}),
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{Key: "id", Value: "user-1"}, {Key: "name", Value: "Ada"}},
+				bson.D{{Key: "id", Value: "user-2"}, {Key: "name", Value: "Alan"}},
+			),
+		)
+		req := &domain.ListUserRequest{Sort: "name"}
+		require.NoError(t, req.Normalize())
+
+		users, total, err := newMockRepository(mt).List(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, users, 2)
+	})
+
+	mt.Run("list rejects unknown sort keys", func(mt *mtest.T) {
+		_, _, err := newMockRepository(mt).List(context.Background(), &domain.ListUserRequest{Sort: "password"})
+		var domainErr *sharederrors.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, sharederrors.ErrInvalidInput.Code, domainErr.Code)
+	})
+}
+
+func TestDuplicateEmail(t *testing.T) {
+	assert.NoError(t, duplicateEmail(nil))
+	assert.ErrorIs(t, duplicateEmail(mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}), domain.ErrEmailExists)
+	assert.NotErrorIs(t, duplicateEmail(mongo.ErrClientDisconnected), domain.ErrEmailExists)
+}
@@ -2,25 +2,40 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/dbrouter"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
-const userDriverName = "UserPostgreSQL"
-
 type SQLRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	dialect sqldialect.Dialect
+	router  *dbrouter.Router // nil reads from db
+}
+
+func NewSQLRepository(db *sqlx.DB) *SQLRepository {
+	return &SQLRepository{db: db, dialect: sqldialect.Of(db)}
 }
 
-func NewSQLRepository(db *sqlx.DB) *SQLRepository { return &SQLRepository{db: db} }
+// SetRouter sends reads outside a transaction through router, which may pick
+// a read replica
+func (r *SQLRepository) SetRouter(router *dbrouter.Router) { r.router = router }
 
 func (r *SQLRepository) StartContext(ctx context.Context) context.Context {
 	tx := r.db.MustBeginTx(ctx, nil)
-	return context.WithValue(ctx, userDriverName, tx)
+	return context.WithValue(ctx, sharedCtx.PostgresTxKey, tx)
 }
 
 func (r *SQLRepository) DeferErrorContext(ctx context.Context, err error) {
@@ -34,16 +49,27 @@ func (r *SQLRepository) DeferErrorContext(ctx context.Context, err error) {
 	}
 }
 
+// getTxFromContext returns the transaction bound to ctx under the shared key,
+// so writes made for another module's unit of work, such as the user created
+// by registration, join its transaction
 func (r *SQLRepository) getTxFromContext(ctx context.Context) *sqlx.Tx {
-	txVal := ctx.Value(userDriverName)
-	tx, ok := txVal.(*sqlx.Tx)
-	if !ok {
-		return nil
+	return sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey)
+}
+
+// reader returns the transaction bound to ctx, if any, otherwise the
+// database the router picks for reads
+func (r *SQLRepository) reader(ctx context.Context) sqlx.QueryerContext {
+	if tx := r.getTxFromContext(ctx); tx != nil {
+		return tx
 	}
-	return tx
+	if r.router != nil {
+		return r.router.Reader(ctx)
+	}
+	return r.db
 }
 
 func (r *SQLRepository) Create(ctx context.Context, u *domain.User) error {
+	defer r.router.MarkWrite(ctx)
 	query := `INSERT INTO users (id,name,email,created_at,created_by) VALUES (:id,:name,:email,:created_at,:created_by)`
 	tx := r.getTxFromContext(ctx)
 	if u.ID == "" {
@@ -60,16 +86,13 @@ func (r *SQLRepository) Create(ctx context.Context, u *domain.User) error {
 
 func (r *SQLRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	var u domain.User
-	tx := r.getTxFromContext(ctx)
-	query := `SELECT id,name,email,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM users WHERE id=$1`
-	if tx != nil {
-		if err := tx.Get(&u, query, id); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := r.db.Get(&u, query, id); err != nil {
-			return nil, err
-		}
+	query := r.db.Rebind(`SELECT id,name,email,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM users WHERE id=?`)
+	err := sqlx.GetContext(ctx, r.reader(ctx), &u, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 	return &u, nil
 }
@@ -77,36 +100,105 @@ func (r *SQLRepository) GetByID(ctx context.Context, id string) (*domain.User, e
 func (r *SQLRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var u domain.User
 	tx := r.getTxFromContext(ctx)
-	query := `SELECT id,name,email,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM users WHERE email=$1 AND deleted_at IS NULL`
+	query := r.db.Rebind(`SELECT id,name,email,created_at,created_by,updated_at,updated_by,deleted_at,deleted_by FROM users WHERE email=? AND deleted_at IS NULL`)
+	var err error
 	if tx != nil {
-		if err := tx.Get(&u, query, email); err != nil {
-			return nil, err
-		}
+		err = tx.Get(&u, query, email)
 	} else {
-		if err := r.db.Get(&u, query, email); err != nil {
-			return nil, err
-		}
+		err = r.db.Get(&u, query, email)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
 	return &u, nil
 }
 
-func (r *SQLRepository) List(ctx context.Context) ([]domain.User, error) {
-	var lst []domain.User
-	tx := r.getTxFromContext(ctx)
-	query := `SELECT id,name,email,created_at,created_by,updated_at,updated_by FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC`
-	if tx != nil {
-		if err := tx.Select(&lst, query); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := r.db.Select(&lst, query); err != nil {
-			return nil, err
+// userSortColumns maps public sort keys to columns; anything else is rejected
+// so user input never reaches the ORDER BY clause
+var userSortColumns = map[string]string{
+	domain.UserSortName:      "name",
+	domain.UserSortEmail:     "email",
+	domain.UserSortCreatedAt: "created_at",
+}
+
+func (r *SQLRepository) List(ctx context.Context, req *domain.ListUserRequest) ([]domain.User, int, error) {
+	sort := req.SortOrder()
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return nil, 0, sharederrors.ErrInvalidInput.WithMessage("unsupported sort key: " + req.Sort)
+	}
+	after, err := req.DecodeCursor()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := r.reader(ctx)
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return "?"
+	}
+	conds := []string{"deleted_at IS NULL"}
+	if req.Name != "" {
+		conds = append(conds, r.dialect.ILike("name", arg("%"+escapeLike(req.Name)+"%")))
+	}
+	if req.CreatedBy != "" {
+		conds = append(conds, "created_by = "+arg(req.CreatedBy))
+	}
+	if req.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*req.CreatedFrom))
+	}
+	if req.CreatedTo != nil {
+		conds = append(conds, "created_at <= "+arg(*req.CreatedTo))
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users WHERE ` + strings.Join(conds, " AND ")
+	if err := sqlx.GetContext(ctx, q, &total, r.db.Rebind(countQuery), args...); err != nil {
+		return nil, 0, err
+	}
+
+	cmp, dir := ">", "ASC"
+	if sort.Direction == model.SortDesc {
+		cmp, dir = "<", "DESC"
+	}
+	if after != nil {
+		var value any = after.Value
+		if sort.Field == domain.UserSortCreatedAt {
+			createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, 0, model.ErrInvalidCursor.WithError(err)
+			}
+			value = createdAt
 		}
+		conds = append(conds, fmt.Sprintf("(%s, id) %s (%s, %s)", column, cmp, arg(value), arg(after.ID)))
+	}
+
+	query := `SELECT id,name,email,created_at,created_by,updated_at,updated_by FROM users WHERE ` +
+		strings.Join(conds, " AND ") +
+		fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT %s", column, dir, dir, arg(req.Limit))
+	if after == nil {
+		query += " OFFSET " + arg(req.Offset())
+	}
+
+	lst := []domain.User{}
+	if err := sqlx.SelectContext(ctx, q, &lst, r.db.Rebind(query), args...); err != nil {
+		return nil, 0, err
 	}
-	return lst, nil
+	return lst, total, nil
+}
+
+// escapeLike escapes LIKE wildcards so filters match them literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 func (r *SQLRepository) Update(ctx context.Context, u *domain.User) error {
+	defer r.router.MarkWrite(ctx)
 	now := time.Now().UTC()
 	u.UpdatedAt = &now
 	tx := r.getTxFromContext(ctx)
@@ -120,9 +212,10 @@ func (r *SQLRepository) Update(ctx context.Context, u *domain.User) error {
 }
 
 func (r *SQLRepository) SoftDelete(ctx context.Context, id, deletedBy string) error {
+	defer r.router.MarkWrite(ctx)
 	now := time.Now().UTC()
 	tx := r.getTxFromContext(ctx)
-	query := `UPDATE users SET deleted_at=$1, deleted_by=$2 WHERE id=$3`
+	query := r.db.Rebind(`UPDATE users SET deleted_at=?, deleted_by=? WHERE id=?`)
 	if tx != nil {
 		_, err := tx.Exec(query, now, deletedBy, id)
 		return err
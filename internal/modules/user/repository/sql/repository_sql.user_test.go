@@ -0,0 +1,49 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/sqldialect/sqltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRepository_SQLite(t *testing.T) {
+	r := NewSQLRepository(sqltest.OpenSQLite(t))
+	ctx := context.Background()
+
+	ada := &domain.User{Name: "Ada Lovelace", Email: "ada@example.com"}
+	require.NoError(t, r.Create(ctx, ada))
+	require.NoError(t, r.Create(ctx, &domain.User{Name: "Alan Turing", Email: "alan@example.com"}))
+
+	got, err := r.GetByEmail(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ada.ID, got.ID)
+
+	req := &domain.ListUserRequest{Name: "lovelace", Sort: "-created_at"}
+	require.NoError(t, req.Normalize())
+	lst, total, err := r.List(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, lst, 1)
+	assert.Equal(t, ada.ID, lst[0].ID)
+
+	require.NoError(t, r.SoftDelete(ctx, ada.ID, "admin"))
+	_, err = r.GetByEmail(ctx, "ada@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestSQLRepository_SQLiteTransaction(t *testing.T) {
+	r := NewSQLRepository(sqltest.OpenSQLite(t))
+
+	ctx := r.StartContext(context.Background())
+	u := &domain.User{Name: "Grace Hopper", Email: "grace@example.com"}
+	require.NoError(t, r.Create(ctx, u))
+	r.DeferErrorContext(ctx, assert.AnError)
+
+	_, err := r.GetByID(context.Background(), u.ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "the rolled back insert is gone")
+}
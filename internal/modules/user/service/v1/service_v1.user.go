@@ -8,8 +8,11 @@ import (
 
 	"github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 )
 
 const (
@@ -35,11 +38,11 @@ func (s *ServiceV1) Create(ctx context.Context, req *domain.CreateUserRequest, c
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
@@ -107,8 +110,31 @@ func (s *ServiceV1) Get(ctx context.Context, id string) (*domain.User, error) {
 	return user, nil
 }
 
-func (s *ServiceV1) List(ctx context.Context) ([]domain.User, error) {
-	return s.repo.List(ctx)
+func (s *ServiceV1) List(ctx context.Context, req *domain.ListUserRequest) (*domain.UserPage, error) {
+	if req == nil {
+		req = &domain.ListUserRequest{}
+	}
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.repo.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page may have more behind it; hand out a cursor to continue from
+	meta := model.PaginationMetadata{Page: req.Page, Limit: req.Limit}
+	if len(users) == req.Limit {
+		meta.NextCursor = req.NextCursor(&users[len(users)-1])
+	}
+	if req.UsesCursor() {
+		// Page numbers are meaningless when walking by cursor
+		meta.Page = 0
+	}
+
+	requestID, _ := sharedctx.GetRequestID(ctx)
+	return model.NewPaginatedResponse(requestID, users, total, meta), nil
 }
 
 func (s *ServiceV1) Update(ctx context.Context, req *domain.UpdateUserRequest, updatedBy string) (user *domain.User, err error) {
@@ -118,11 +144,11 @@ func (s *ServiceV1) Update(ctx context.Context, req *domain.UpdateUserRequest, u
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
@@ -172,11 +198,11 @@ func (s *ServiceV1) Delete(ctx context.Context, id, by string) (err error) {
 		if r := recover(); r != nil {
 			switch x := r.(type) {
 			case string:
-				err = fmt.Errorf("panic: %s", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %s", x))
 			case error:
-				err = fmt.Errorf("panic: %w", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %w", x))
 			default:
-				err = fmt.Errorf("panic: %v", x)
+				err = sharederrors.ErrInternal.WithError(fmt.Errorf("panic: %v", x))
 			}
 		}
 	}()
@@ -218,23 +218,68 @@ func TestServiceV1_List(t *testing.T) {
 			mockRepo := mocks.NewMockRepository(ctrl)
 			ctx := context.Background()
 
-			mockRepo.EXPECT().List(ctx).Return(tt.want, tt.repoErr).Times(1)
+			mockRepo.EXPECT().List(ctx, gomock.Any()).Return(tt.want, len(tt.want), tt.repoErr).Times(1)
 
 			service := NewServiceV1(mockRepo, nil, nil, nil)
-			users, err := service.List(ctx)
+			page, err := service.List(ctx, &domain.ListUserRequest{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				assert.Nil(t, users)
+				assert.Nil(t, page)
 			} else {
 				require.NoError(t, err)
-				require.NotNil(t, users)
-				assert.Equal(t, len(tt.want), len(users))
+				require.NotNil(t, page)
+				assert.Equal(t, len(tt.want), len(page.Data))
+				assert.Equal(t, len(tt.want), page.Metadata.TotalItems)
 			}
 		})
 	}
 }
 
+// TestServiceV1_List_Cursor tests that a full page hands out a cursor for the next one
+func TestServiceV1_List_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	ctx := context.Background()
+	users := []domain.User{
+		{ID: "user1", Name: "Ann", Email: "ann@example.com"},
+		{ID: "user2", Name: "Bob", Email: "bob@example.com"},
+	}
+
+	mockRepo.EXPECT().List(ctx, gomock.Any()).Return(users, 7, nil)
+
+	service := NewServiceV1(mockRepo, nil, nil, nil)
+	req := &domain.ListUserRequest{Sort: "email"}
+	req.Limit = 2
+	req.Cursor = (&domain.ListUserRequest{Sort: "email"}).NextCursor(&users[0])
+	page, err := service.List(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, page.Metadata.Page)
+	assert.Equal(t, 4, page.Metadata.TotalPages)
+
+	next := &domain.ListUserRequest{Sort: "email"}
+	next.Cursor = page.Metadata.NextCursor
+	after, err := next.DecodeCursor()
+	require.NoError(t, err)
+	assert.Equal(t, "user2", after.ID)
+	assert.Equal(t, "bob@example.com", after.Value)
+}
+
+// TestServiceV1_List_InvalidSort tests that unknown sort keys are rejected
+func TestServiceV1_List_InvalidSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewServiceV1(mocks.NewMockRepository(ctrl), nil, nil, nil)
+	page, err := service.List(context.Background(), &domain.ListUserRequest{Sort: "-password"})
+
+	assert.Nil(t, page)
+	assert.Error(t, err)
+}
+
 // TestServiceV1_Update tests the Update method
 func TestServiceV1_Update(t *testing.T) {
 	tests := []struct {
@@ -9,6 +9,7 @@ import (
 	logger "github.com/kamil5b/go-pste-monolith/internal/logger"
 	userdomain "github.com/kamil5b/go-pste-monolith/internal/modules/user/domain"
 	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/model"
 	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
 )
 
@@ -229,30 +230,28 @@ func (h *UserWorkerHandler) HandleSendMonthlyEmail(ctx context.Context, payload
 
 	logger.WithField("message", p.Message).Info("Starting monthly email task")
 
-	// Get all users
-	users, err := h.userRepository.List(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch users: %w", err)
-	}
-
-	if len(users) == 0 {
-		logger.Info("No users found, skipping email send")
-		return nil
-	}
-
-	logger.WithField("user_count", len(users)).Info("Found users to send emails to")
-
-	// Send email to each user
+	// Walk users page by page so large tables are never loaded at once
 	successCount := 0
 	failureCount := 0
+	req := &userdomain.ListUserRequest{Sort: userdomain.UserSortCreatedAt}
+	req.Limit = model.MaxPageLimit
+	if err := req.Normalize(); err != nil {
+		return err
+	}
 
-	for _, user := range users {
-		emailMsg := &email.Email{
-			To:      []string{user.Email},
-			Subject: "Monthly Notification - Today is Special",
-			TextBody: fmt.Sprintf("Hello %s,\n\n%s\n\nBest regards,\nThe Team",
-				user.Name, p.Message),
-			HTMLBody: fmt.Sprintf(`
+	for {
+		users, _, err := h.userRepository.List(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch users: %w", err)
+		}
+
+		for _, user := range users {
+			emailMsg := &email.Email{
+				To:      []string{user.Email},
+				Subject: "Monthly Notification - Today is Special",
+				TextBody: fmt.Sprintf("Hello %s,\n\n%s\n\nBest regards,\nThe Team",
+					user.Name, p.Message),
+				HTMLBody: fmt.Sprintf(`
 				<html>
 					<body>
 						<h2>Hello %s,</h2>
@@ -261,29 +260,40 @@ func (h *UserWorkerHandler) HandleSendMonthlyEmail(ctx context.Context, payload
 					</body>
 				</html>
 			`, user.Name, p.Message),
-		}
+			}
+
+			if err := h.emailService.Send(ctx, emailMsg); err != nil {
+				logger.WithFields(map[string]interface{}{
+					"user_id": user.ID,
+					"email":   user.Email,
+					"name":    user.Name,
+					"error":   err.Error(),
+				}).Error("Failed to send monthly email")
+				failureCount++
+				continue
+			}
 
-		if err := h.emailService.Send(ctx, emailMsg); err != nil {
 			logger.WithFields(map[string]interface{}{
 				"user_id": user.ID,
 				"email":   user.Email,
 				"name":    user.Name,
-				"error":   err.Error(),
-			}).Error("Failed to send monthly email")
-			failureCount++
-			continue
+			}).Debug("Monthly email sent")
+			successCount++
+		}
+
+		if len(users) < req.Limit {
+			break
 		}
+		req.Cursor = req.NextCursor(&users[len(users)-1])
+	}
 
-		logger.WithFields(map[string]interface{}{
-			"user_id": user.ID,
-			"email":   user.Email,
-			"name":    user.Name,
-		}).Debug("Monthly email sent")
-		successCount++
+	if successCount == 0 && failureCount == 0 {
+		logger.Info("No users found, skipping email send")
+		return nil
 	}
 
-	if successCount == 0 && len(users) > 0 {
-		return fmt.Errorf("failed to send email to any users: attempted %d, failed %d", len(users), failureCount)
+	if successCount == 0 {
+		return fmt.Errorf("failed to send email to any users: attempted %d, failed %d", failureCount, failureCount)
 	}
 
 	logger.WithFields(map[string]interface{}{
@@ -17,6 +17,8 @@ type Context interface {
 
 	// Request methods
 	Param(name string) string
+	QueryParam(name string) string
+	FormValue(name string) string // url-encoded or multipart body field
 	GetUserID() string
 	Get(key string) any
 	Set(key string, value any)
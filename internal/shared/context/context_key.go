@@ -9,4 +9,5 @@ const (
 	SessionKey      ContextKey = "session"
 	PostgresTxKey   ContextKey = "postgres_tx"
 	MongoSessionKey ContextKey = "mongo_session"
+	EventIDKey      ContextKey = "event_id"
 )
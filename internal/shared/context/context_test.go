@@ -158,6 +158,7 @@ func TestContextKeyValues(t *testing.T) {
 		{"SessionKey", SessionKey, "session"},
 		{"PostgresTxKey", PostgresTxKey, "postgres_tx"},
 		{"MongoSessionKey", MongoSessionKey, "mongo_session"},
+		{"EventIDKey", EventIDKey, "event_id"},
 	}
 
 	for _, tt := range tests {
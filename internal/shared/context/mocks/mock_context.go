@@ -104,6 +104,20 @@ func (mr *MockContextMockRecorder) BindURI(obj interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindURI", reflect.TypeOf((*MockContext)(nil).BindURI), obj)
 }
 
+// FormValue mocks base method.
+func (m *MockContext) FormValue(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormValue", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// FormValue indicates an expected call of FormValue.
+func (mr *MockContextMockRecorder) FormValue(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormValue", reflect.TypeOf((*MockContext)(nil).FormValue), name)
+}
+
 // Get mocks base method.
 func (m *MockContext) Get(key string) any {
 	m.ctrl.T.Helper()
@@ -231,6 +245,20 @@ func (mr *MockContextMockRecorder) Param(name interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Param", reflect.TypeOf((*MockContext)(nil).Param), name)
 }
 
+// QueryParam mocks base method.
+func (m *MockContext) QueryParam(name string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryParam", name)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// QueryParam indicates an expected call of QueryParam.
+func (mr *MockContextMockRecorder) QueryParam(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryParam", reflect.TypeOf((*MockContext)(nil).QueryParam), name)
+}
+
 // RemoveCookie mocks base method.
 func (m *MockContext) RemoveCookie(name string) {
 	m.ctrl.T.Helper()
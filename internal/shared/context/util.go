@@ -25,6 +25,18 @@ func GetRequestID(ctx context.Context) (string, bool) {
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, RequestIDKey, requestID)
 }
+
+// GetEventID extracts the event (deduplication) ID from context
+func GetEventID(ctx context.Context) (string, bool) {
+	eventID, ok := ctx.Value(EventIDKey).(string)
+	return eventID, ok
+}
+
+// WithEventID adds the event (deduplication) ID to context
+func WithEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, EventIDKey, eventID)
+}
+
 func GetObjectFromContext[T any](ctx context.Context, key any) *T {
 	val := ctx.Value(key)
 	obj, ok := val.(*T)
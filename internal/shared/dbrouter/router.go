@@ -0,0 +1,255 @@
+// Package dbrouter sends SQL reads to read replicas and everything else to
+// the primary. Reads stay on the primary inside a unit of work transaction and
+// for a while after the same user wrote, so users always see their own writes.
+package dbrouter
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Policy decides which healthy replica serves a read
+type Policy string
+
+const (
+	PolicyRoundRobin Policy = "round_robin"
+	PolicyRandom     Policy = "random"
+	PolicyLeastConn  Policy = "least_conn" // fewest connections in use
+)
+
+// Config holds the routing and replica health settings
+type Config struct {
+	Policy Policy
+	// ReadYourWritesWindow keeps the reads of a user on the primary after
+	// they wrote; it should exceed the usual replication lag
+	ReadYourWritesWindow time.Duration
+	HealthCheckInterval  time.Duration
+	// FailureThreshold is the number of consecutive failed pings after which
+	// a replica is ejected; one successful ping brings it back
+	FailureThreshold int
+}
+
+// DefaultConfig returns sensible routing defaults
+func DefaultConfig() Config {
+	return Config{
+		Policy:               PolicyRoundRobin,
+		ReadYourWritesWindow: 5 * time.Second,
+		HealthCheckInterval:  5 * time.Second,
+		FailureThreshold:     3,
+	}
+}
+
+// pruneThreshold is the number of tracked writers above which expired
+// read-your-writes entries are swept
+const pruneThreshold = 1024
+
+type replica struct {
+	db       *sqlx.DB
+	failures int // consecutive failed pings, only touched by CheckReplicas
+	healthy  atomic.Bool
+}
+
+// Router picks the database a query runs on
+type Router struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	config   Config
+	next     atomic.Uint64
+
+	checkMu sync.Mutex // serializes CheckReplicas
+
+	mu     sync.Mutex
+	writes map[string]time.Time // user ID -> end of their read-your-writes window
+}
+
+type primaryKey struct{}
+
+// New creates a router over primary and replicas. Replicas start healthy; a
+// router without replicas sends everything to the primary.
+func New(primary *sqlx.DB, replicas []*sqlx.DB, config Config) *Router {
+	defaults := DefaultConfig()
+	if config.Policy == "" {
+		config.Policy = defaults.Policy
+	}
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaults.HealthCheckInterval
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaults.FailureThreshold
+	}
+	if config.ReadYourWritesWindow < 0 {
+		config.ReadYourWritesWindow = 0
+	}
+
+	r := &Router{primary: primary, config: config, writes: make(map[string]time.Time)}
+	for _, db := range replicas {
+		rep := &replica{db: db}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	return r
+}
+
+// WithPrimary pins the reads made with the returned context to the primary,
+// e.g. for a read that decides a write outside a unit of work
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// Primary returns the database for writes and transactions
+func (r *Router) Primary() *sqlx.DB {
+	return r.primary
+}
+
+// Reader returns the database a read made with ctx should use: a healthy
+// replica, or the primary when ctx is inside a transaction, pinned, within the
+// user's read-your-writes window or no replica is healthy
+func (r *Router) Reader(ctx context.Context) *sqlx.DB {
+	if len(r.replicas) == 0 || r.onPrimary(ctx) {
+		return r.primary
+	}
+	if rep := r.pick(); rep != nil {
+		return rep.db
+	}
+	return r.primary
+}
+
+// MarkWrite opens the read-your-writes window of the user in ctx. Anonymous
+// writes are not tracked; pin follow-up reads with WithPrimary instead.
+func (r *Router) MarkWrite(ctx context.Context) {
+	if r == nil || len(r.replicas) == 0 || r.config.ReadYourWritesWindow == 0 {
+		return
+	}
+	userID, ok := sharedCtx.GetUserID(ctx)
+	if !ok || userID == "" {
+		return
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes[userID] = now.Add(r.config.ReadYourWritesWindow)
+	if len(r.writes) > pruneThreshold {
+		for id, until := range r.writes {
+			if now.After(until) {
+				delete(r.writes, id)
+			}
+		}
+	}
+}
+
+// Healthy returns the number of replicas currently serving reads
+func (r *Router) Healthy() int {
+	n := 0
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+// Replicas returns every replica, including ejected ones
+func (r *Router) Replicas() []*sqlx.DB {
+	dbs := make([]*sqlx.DB, len(r.replicas))
+	for i, rep := range r.replicas {
+		dbs[i] = rep.db
+	}
+	return dbs
+}
+
+// Run checks the replicas every HealthCheckInterval until ctx is canceled
+func (r *Router) Run(ctx context.Context) error {
+	if len(r.replicas) == 0 {
+		return nil
+	}
+	ticker := time.NewTicker(r.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.CheckReplicas(ctx)
+		}
+	}
+}
+
+// CheckReplicas pings every replica once, ejecting those that failed
+// FailureThreshold times in a row and restoring those that answer again
+func (r *Router) CheckReplicas(ctx context.Context) {
+	r.checkMu.Lock()
+	defer r.checkMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, rep := range r.replicas {
+		wg.Add(1)
+		go func(rep *replica) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, r.config.HealthCheckInterval)
+			defer cancel()
+			if err := rep.db.PingContext(pingCtx); err != nil {
+				rep.failures++
+				if rep.failures >= r.config.FailureThreshold {
+					rep.healthy.Store(false)
+				}
+				return
+			}
+			rep.failures = 0
+			rep.healthy.Store(true)
+		}(rep)
+	}
+	wg.Wait()
+}
+
+func (r *Router) onPrimary(ctx context.Context) bool {
+	if sharedCtx.GetObjectFromContext[sqlx.Tx](ctx, sharedCtx.PostgresTxKey) != nil {
+		return true
+	}
+	if pinned, _ := ctx.Value(primaryKey{}).(bool); pinned {
+		return true
+	}
+	userID, ok := sharedCtx.GetUserID(ctx)
+	if !ok || userID == "" {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	until, ok := r.writes[userID]
+	return ok && time.Now().Before(until)
+}
+
+// pick returns a healthy replica chosen by the policy, or nil if none is
+func (r *Router) pick() *replica {
+	healthy := make([]*replica, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			healthy = append(healthy, rep)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	switch r.config.Policy {
+	case PolicyRandom:
+		return healthy[rand.IntN(len(healthy))]
+	case PolicyLeastConn:
+		best := healthy[0]
+		for _, rep := range healthy[1:] {
+			if rep.db.Stats().InUse < best.db.Stats().InUse {
+				best = rep
+			}
+		}
+		return best
+	default:
+		return healthy[(r.next.Add(1)-1)%uint64(len(healthy))]
+	}
+}
@@ -0,0 +1,158 @@
+package dbrouter
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sharedCtx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver accepts connections until it is marked down
+type fakeDriver struct{ down atomic.Bool }
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	if d.down.Load() {
+		return nil, errors.New("connection refused")
+	}
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+var driverSeq atomic.Int64
+
+func newFakeDB(t *testing.T) (*sqlx.DB, *fakeDriver) {
+	t.Helper()
+	d := &fakeDriver{}
+	name := fmt.Sprintf("dbrouter-fake-%d", driverSeq.Add(1))
+	sql.Register(name, d)
+	db, err := sqlx.Open(name, "")
+	require.NoError(t, err)
+	// Without idle connections every ping dials the driver
+	db.SetMaxIdleConns(0)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestReader_NoReplicas(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	r := New(primary, nil, Config{})
+
+	assert.Same(t, primary, r.Reader(context.Background()))
+	assert.Same(t, primary, r.Primary())
+}
+
+func TestReader_RoundRobin(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	a, _ := newFakeDB(t)
+	b, _ := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{a, b}, Config{Policy: PolicyRoundRobin})
+
+	ctx := context.Background()
+	assert.Same(t, a, r.Reader(ctx))
+	assert.Same(t, b, r.Reader(ctx))
+	assert.Same(t, a, r.Reader(ctx))
+}
+
+func TestReader_StaysOnPrimary(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	replica, _ := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{replica}, Config{ReadYourWritesWindow: time.Minute})
+
+	t.Run("inside a transaction", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), sharedCtx.PostgresTxKey, &sqlx.Tx{})
+		assert.Same(t, primary, r.Reader(ctx))
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		assert.Same(t, primary, r.Reader(WithPrimary(context.Background())))
+	})
+
+	t.Run("read your writes", func(t *testing.T) {
+		writer := sharedCtx.WithUserID(context.Background(), "user-1")
+		other := sharedCtx.WithUserID(context.Background(), "user-2")
+		assert.Same(t, replica, r.Reader(writer))
+
+		r.MarkWrite(writer)
+		assert.Same(t, primary, r.Reader(writer))
+		assert.Same(t, replica, r.Reader(other))
+	})
+}
+
+func TestReader_ReadYourWritesWindowExpires(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	replica, _ := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{replica}, Config{ReadYourWritesWindow: 10 * time.Millisecond})
+
+	ctx := sharedCtx.WithUserID(context.Background(), "user-1")
+	r.MarkWrite(ctx)
+	assert.Same(t, primary, r.Reader(ctx))
+
+	assert.Eventually(t, func() bool { return r.Reader(ctx) == replica }, time.Second, 5*time.Millisecond)
+}
+
+func TestMarkWrite_NilRouter(t *testing.T) {
+	var r *Router
+	assert.NotPanics(t, func() { r.MarkWrite(sharedCtx.WithUserID(context.Background(), "user-1")) })
+}
+
+func TestCheckReplicas_EjectsAndRestores(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	healthy, _ := newFakeDB(t)
+	flaky, flakyDriver := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{healthy, flaky}, Config{FailureThreshold: 2, HealthCheckInterval: time.Second})
+	ctx := context.Background()
+
+	flakyDriver.down.Store(true)
+	r.CheckReplicas(ctx)
+	assert.Equal(t, 2, r.Healthy(), "one failed ping is below the threshold")
+
+	r.CheckReplicas(ctx)
+	assert.Equal(t, 1, r.Healthy())
+	for range 4 {
+		assert.Same(t, healthy, r.Reader(ctx))
+	}
+
+	flakyDriver.down.Store(false)
+	r.CheckReplicas(ctx)
+	assert.Equal(t, 2, r.Healthy())
+}
+
+func TestReader_FallsBackToPrimary(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	replica, d := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{replica}, Config{FailureThreshold: 1, HealthCheckInterval: time.Second})
+
+	d.down.Store(true)
+	r.CheckReplicas(context.Background())
+
+	assert.Equal(t, 0, r.Healthy())
+	assert.Same(t, primary, r.Reader(context.Background()))
+}
+
+func TestReader_LeastConn(t *testing.T) {
+	primary, _ := newFakeDB(t)
+	busy, _ := newFakeDB(t)
+	idle, _ := newFakeDB(t)
+	r := New(primary, []*sqlx.DB{busy, idle}, Config{Policy: PolicyLeastConn})
+
+	conn, err := busy.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Same(t, idle, r.Reader(context.Background()))
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
 )
 
+var emailLog = logger.Module("email")
+
 // NoOpEmailService is a no-op implementation that doesn't send emails
 // Used for testing and development without a real email provider
 type NoOpEmailService struct{}
@@ -20,7 +24,10 @@ func (s *NoOpEmailService) Send(ctx context.Context, email *Email) error {
 	if email == nil {
 		return fmt.Errorf("email cannot be nil")
 	}
-	fmt.Printf("[NoOp] Would send email to %v with subject: %s\n", email.To, email.Subject)
+	emailLog.WithContext(ctx).WithFields(map[string]interface{}{
+		"to":      email.To,
+		"subject": email.Subject,
+	}).Debug("NoOp email service would send email")
 	return nil
 }
 
@@ -29,7 +36,7 @@ func (s *NoOpEmailService) SendBatch(ctx context.Context, emails []*Email) error
 	if emails == nil {
 		return fmt.Errorf("emails cannot be nil")
 	}
-	fmt.Printf("[NoOp] Would send %d emails in batch\n", len(emails))
+	emailLog.WithContext(ctx).WithField("count", len(emails)).Debug("NoOp email service would send email batch")
 	return nil
 }
 
@@ -38,7 +45,10 @@ func (s *NoOpEmailService) SendTemplate(ctx context.Context, to []string, templa
 	if len(to) == 0 {
 		return fmt.Errorf("to cannot be empty")
 	}
-	fmt.Printf("[NoOp] Would send template email to %v (template: %s)\n", to, templateID)
+	emailLog.WithContext(ctx).WithFields(map[string]interface{}{
+		"to":       to,
+		"template": templateID,
+	}).Debug("NoOp email service would send template email")
 	return nil
 }
 
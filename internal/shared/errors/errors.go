@@ -73,12 +73,14 @@ var (
 	// Business logic errors
 	ErrBusinessRule    = NewDomainError("BUSINESS_RULE_VIOLATION", "business rule violation")
 	ErrOperationFailed = NewDomainError("OPERATION_FAILED", "operation failed")
+	ErrRateLimited     = NewDomainError("RATE_LIMITED", "too many requests")
 
 	// Infrastructure errors
 	ErrInternal        = NewDomainError("INTERNAL_ERROR", "internal server error")
 	ErrDatabaseError   = NewDomainError("DATABASE_ERROR", "database operation failed")
 	ErrExternalService = NewDomainError("EXTERNAL_SERVICE_ERROR", "external service error")
 	ErrTimeout         = NewDomainError("TIMEOUT", "operation timed out")
+	ErrNotImplemented  = NewDomainError("NOT_IMPLEMENTED", "not implemented")
 )
 
 // Is checks if the target error matches the domain error code
@@ -76,6 +76,7 @@ func TestPredefinedErrors(t *testing.T) {
 		{"DatabaseError", ErrDatabaseError, "DATABASE_ERROR"},
 		{"ExternalService", ErrExternalService, "EXTERNAL_SERVICE_ERROR"},
 		{"Timeout", ErrTimeout, "TIMEOUT"},
+		{"NotImplemented", ErrNotImplemented, "NOT_IMPLEMENTED"},
 	}
 
 	for _, tt := range tests {
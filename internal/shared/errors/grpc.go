@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCCode returns the appropriate gRPC status code for a domain error
+func GRPCCode(err error) codes.Code {
+	var validationErr *ValidationError
+	if As(err, &validationErr) {
+		return codes.InvalidArgument
+	}
+
+	var domainErr *DomainError
+	if !As(err, &domainErr) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return codes.Canceled
+		case errors.Is(err, context.DeadlineExceeded):
+			return codes.DeadlineExceeded
+		}
+		return codes.Internal
+	}
+
+	switch domainErr.Code {
+	case ErrNotFound.Code:
+		return codes.NotFound
+	case ErrAlreadyExists.Code:
+		return codes.AlreadyExists
+	case ErrConflict.Code:
+		return codes.Aborted
+	case ErrUnauthorized.Code, ErrInvalidToken.Code, ErrInvalidCredentials.Code:
+		return codes.Unauthenticated
+	case ErrForbidden.Code:
+		return codes.PermissionDenied
+	case ErrValidation.Code, ErrInvalidInput.Code, ErrMissingField.Code, ErrInvalidFormat.Code:
+		return codes.InvalidArgument
+	case ErrBusinessRule.Code:
+		return codes.FailedPrecondition
+	case ErrRateLimited.Code:
+		return codes.ResourceExhausted
+	case ErrTimeout.Code:
+		return codes.DeadlineExceeded
+	case ErrExternalService.Code:
+		return codes.Unavailable
+	case ErrNotImplemented.Code:
+		return codes.Unimplemented
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCStatus converts an error into a gRPC status error. Errors that already
+// carry a gRPC status are returned unchanged; the message follows ToErrorResponse,
+// so unexpected errors do not leak their details.
+func GRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := GRPCCode(err)
+	if code == codes.Canceled || code == codes.DeadlineExceeded {
+		return status.Error(code, err.Error())
+	}
+	return status.Error(code, ToErrorResponse(err).Message)
+}
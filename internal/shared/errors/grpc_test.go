@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"NotFound", ErrNotFound, codes.NotFound},
+		{"AlreadyExists", ErrAlreadyExists, codes.AlreadyExists},
+		{"Conflict", ErrConflict, codes.Aborted},
+		{"Unauthorized", ErrUnauthorized, codes.Unauthenticated},
+		{"Forbidden", ErrForbidden, codes.PermissionDenied},
+		{"InvalidToken", ErrInvalidToken, codes.Unauthenticated},
+		{"InvalidCredentials", ErrInvalidCredentials, codes.Unauthenticated},
+		{"Validation", NewValidationError().AddFieldError("name", "required"), codes.InvalidArgument},
+		{"InvalidInput", ErrInvalidInput, codes.InvalidArgument},
+		{"MissingField", ErrMissingField, codes.InvalidArgument},
+		{"BusinessRule", ErrBusinessRule, codes.FailedPrecondition},
+		{"RateLimited", ErrRateLimited, codes.ResourceExhausted},
+		{"Timeout", ErrTimeout, codes.DeadlineExceeded},
+		{"ExternalService", ErrExternalService, codes.Unavailable},
+		{"NotImplemented", ErrNotImplemented, codes.Unimplemented},
+		{"DatabaseError", ErrDatabaseError, codes.Internal},
+		{"Wrapped", ErrNotFound.WithError(errors.New("details")), codes.NotFound},
+		{"ContextCanceled", context.Canceled, codes.Canceled},
+		{"UnknownError", errors.New("unknown"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, GRPCCode(tt.err))
+		})
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	assert.NoError(t, GRPCStatus(nil))
+
+	st := status.Convert(GRPCStatus(ErrNotFound.WithMessage("product not found")))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "product not found", st.Message())
+
+	// Unexpected errors are not exposed to clients
+	st = status.Convert(GRPCStatus(errors.New("pq: connection refused")))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.NotContains(t, st.Message(), "pq")
+
+	// Existing statuses pass through
+	original := status.Error(codes.PermissionDenied, "denied")
+	assert.Equal(t, original, GRPCStatus(original))
+}
@@ -4,6 +4,11 @@ import "net/http"
 
 // HTTPStatusCode returns the appropriate HTTP status code for a domain error
 func HTTPStatusCode(err error) int {
+	var validationErr *ValidationError
+	if As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+
 	var domainErr *DomainError
 	if !As(err, &domainErr) {
 		return http.StatusInternalServerError
@@ -34,8 +39,14 @@ func HTTPStatusCode(err error) int {
 		return http.StatusBadRequest
 	case ErrBusinessRule.Code:
 		return http.StatusUnprocessableEntity
+	case ErrRateLimited.Code:
+		return http.StatusTooManyRequests
 	case ErrTimeout.Code:
 		return http.StatusGatewayTimeout
+	case ErrExternalService.Code:
+		return http.StatusBadGateway
+	case ErrNotImplemented.Code:
+		return http.StatusNotImplemented
 	default:
 		return http.StatusInternalServerError
 	}
@@ -71,9 +82,10 @@ func As(err error, target any) bool {
 
 // ErrorResponse represents the standard error response format
 type ErrorResponse struct {
-	Code    string         `json:"code"`
-	Message string         `json:"message"`
-	Details map[string]any `json:"details,omitempty"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"requestId,omitempty"`
 }
 
 // ToErrorResponse converts a domain error to an error response
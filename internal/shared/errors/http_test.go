@@ -26,7 +26,11 @@ func TestHTTPStatusCode(t *testing.T) {
 		{"MissingField", ErrMissingField, http.StatusBadRequest},
 		{"InvalidFormat", ErrInvalidFormat, http.StatusBadRequest},
 		{"BusinessRule", ErrBusinessRule, http.StatusUnprocessableEntity},
+		{"RateLimited", ErrRateLimited, http.StatusTooManyRequests},
 		{"Timeout", ErrTimeout, http.StatusGatewayTimeout},
+		{"ExternalService", ErrExternalService, http.StatusBadGateway},
+		{"NotImplemented", ErrNotImplemented, http.StatusNotImplemented},
+		{"ValidationWithFields", NewValidationError().AddFieldError("name", "required"), http.StatusBadRequest},
 		{"Internal", ErrInternal, http.StatusInternalServerError},
 		{"DatabaseError", ErrDatabaseError, http.StatusInternalServerError},
 		{"UnknownError", errors.New("unknown"), http.StatusInternalServerError},
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"net/http"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Code is the stable,
+// machine-readable error code clients should branch on; Title and Detail are
+// for humans and may change.
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Code      string              `json:"code"`
+	Errors    map[string][]string `json:"errors,omitempty"` // field name -> validation messages
+	RequestID string              `json:"requestId,omitempty"`
+}
+
+// NewProblem converts err into problem details. Like ToErrorResponse, errors
+// that are not domain errors are reported as INTERNAL_ERROR without details.
+func NewProblem(err error) Problem {
+	status := HTTPStatusCode(err)
+	resp := ToErrorResponse(err)
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: resp.Message,
+		Code:   resp.Code,
+	}
+
+	var validationErr *ValidationError
+	if As(err, &validationErr) && validationErr.HasErrors() {
+		problem.Errors = validationErr.Fields
+	}
+	return problem
+}
+
+// WriteProblem renders err as an application/problem+json response carrying
+// the request ID of the request context. Handlers return its result directly:
+//
+//	if err != nil {
+//		return sharederrors.WriteProblem(c, err)
+//	}
+func WriteProblem(c sharedctx.Context, err error) error {
+	problem := NewProblem(err)
+	problem.RequestID, _ = sharedctx.GetRequestID(c.GetContext())
+	c.SetHeader("Content-Type", ProblemContentType)
+	return c.JSON(problem.Status, problem)
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "domain error",
+			err:  ErrNotFound.WithMessage("product not found"),
+			want: Problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "product not found", Code: "NOT_FOUND"},
+		},
+		{
+			name: "validation error",
+			err:  NewValidationError().AddFieldError("email", "is required"),
+			want: Problem{
+				Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "validation failed", Code: "VALIDATION_ERROR",
+				Errors: map[string][]string{"email": {"is required"}},
+			},
+		},
+		{
+			name: "unexpected error hides details",
+			err:  errors.New("pq: connection refused"),
+			want: Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError, Detail: "An unexpected error occurred", Code: "INTERNAL_ERROR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewProblem(tt.err))
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	c := ctxmocks.NewMockContext(ctrl)
+	c.EXPECT().GetContext().Return(sharedctx.WithRequestID(context.Background(), "req-123"))
+	c.EXPECT().SetHeader("Content-Type", ProblemContentType)
+
+	var got Problem
+	c.EXPECT().JSON(http.StatusConflict, gomock.Any()).DoAndReturn(func(_ int, v any) error {
+		got = v.(Problem)
+		return nil
+	})
+
+	require.NoError(t, WriteProblem(c, ErrAlreadyExists.WithMessage("email already exists")))
+	assert.Equal(t, "ALREADY_EXISTS", got.Code)
+	assert.Equal(t, "email already exists", got.Detail)
+	assert.Equal(t, "req-123", got.RequestID)
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"github.com/google/uuid"
+)
+
+// Envelope is the wire format used by broker-backed event buses.
+// Payload holds the JSON encoding of Event.Payload(); the event name is used
+// to look the concrete type back up in a Registry on the consuming side.
+// TraceContext carries the publisher's trace so consumers continue it, and
+// RequestID the request that caused the event so consumer logs correlate.
+type Envelope struct {
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Payload      json.RawMessage `json:"payload"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	TraceContext tracing.Carrier `json:"trace_context,omitempty"`
+	RequestID    string          `json:"request_id,omitempty"`
+}
+
+// NewEnvelope wraps an event for transport. The envelope ID is taken from the
+// event ID in ctx (set by the outbox relay) so deduplication IDs survive the
+// hop to the broker; otherwise a new one is generated.
+func NewEnvelope(ctx context.Context, event Event) (*Envelope, error) {
+	payload, err := json.Marshal(event.Payload())
+	if err != nil {
+		return nil, err
+	}
+	id, ok := sharedctx.GetEventID(ctx)
+	if !ok || id == "" {
+		id = uuid.NewString()
+	}
+	requestID, _ := sharedctx.GetRequestID(ctx)
+	return &Envelope{
+		ID:           id,
+		Name:         event.EventName(),
+		Payload:      payload,
+		OccurredAt:   time.Now().UTC(),
+		TraceContext: tracing.Inject(ctx),
+		RequestID:    requestID,
+	}, nil
+}
+
+// Marshal encodes the envelope as JSON
+func (e *Envelope) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// UnmarshalEnvelope decodes a JSON envelope
+func UnmarshalEnvelope(data []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// Decode rebuilds the typed event through registry and returns a context
+// carrying the envelope ID as the event ID for handler-side deduplication,
+// the originating request ID and continuing the publisher's trace.
+func (e *Envelope) Decode(ctx context.Context, registry *Registry) (context.Context, Event, error) {
+	if registry == nil {
+		registry = NewRegistry()
+	}
+	event, err := registry.Decode(e.Name, e.Payload)
+	if err != nil {
+		return ctx, nil, err
+	}
+	ctx = tracing.Extract(ctx, e.TraceContext)
+	if e.RequestID != "" {
+		ctx = sharedctx.WithRequestID(ctx, e.RequestID)
+	}
+	return sharedctx.WithEventID(ctx, e.ID), event, nil
+}
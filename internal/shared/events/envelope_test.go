@@ -0,0 +1,91 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+)
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	registry := NewRegistry()
+	RegisterType[orderPlacedEvent](registry)
+
+	env, err := NewEnvelope(context.Background(), orderPlacedEvent{OrderID: "o-1"})
+	if err != nil {
+		t.Fatalf("NewEnvelope() error = %v", err)
+	}
+	if env.ID == "" {
+		t.Error("expected generated envelope ID")
+	}
+
+	data, err := env.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	decoded, err := UnmarshalEnvelope(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEnvelope() error = %v", err)
+	}
+
+	ctx, event, err := decoded.Decode(context.Background(), registry)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	typed, ok := event.(orderPlacedEvent)
+	if !ok {
+		t.Fatalf("Decode() = %T, want orderPlacedEvent", event)
+	}
+	if typed.OrderID != "o-1" {
+		t.Errorf("OrderID = %q, want o-1", typed.OrderID)
+	}
+	if id, _ := sharedctx.GetEventID(ctx); id != env.ID {
+		t.Errorf("event ID in context = %q, want %q", id, env.ID)
+	}
+}
+
+func TestNewEnvelope_ReusesEventID(t *testing.T) {
+	ctx := sharedctx.WithEventID(context.Background(), "outbox-id")
+
+	env, err := NewEnvelope(ctx, orderPlacedEvent{OrderID: "o-1"})
+	if err != nil {
+		t.Fatalf("NewEnvelope() error = %v", err)
+	}
+	if env.ID != "outbox-id" {
+		t.Errorf("ID = %q, want outbox-id", env.ID)
+	}
+}
+
+func TestEnvelope_DecodeUnregistered(t *testing.T) {
+	env, _ := NewEnvelope(context.Background(), orderPlacedEvent{OrderID: "o-1"})
+
+	_, event, err := env.Decode(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, ok := event.(RawEvent); !ok {
+		t.Errorf("Decode() = %T, want RawEvent", event)
+	}
+}
+
+func TestEnvelope_CarriesRequestID(t *testing.T) {
+	ctx := sharedctx.WithRequestID(context.Background(), "req-123")
+
+	env, err := NewEnvelope(ctx, orderPlacedEvent{OrderID: "o-1"})
+	if err != nil {
+		t.Fatalf("NewEnvelope() error = %v", err)
+	}
+	data, _ := env.Marshal()
+	decoded, err := UnmarshalEnvelope(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEnvelope() error = %v", err)
+	}
+
+	got, _, err := decoded.Decode(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if id, _ := sharedctx.GetRequestID(got); id != "req-123" {
+		t.Errorf("request ID in context = %q, want req-123", id)
+	}
+}
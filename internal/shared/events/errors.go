@@ -5,4 +5,8 @@ import "errors"
 var (
 	// ErrEventBusClosed is returned when trying to publish to a closed event bus
 	ErrEventBusClosed = errors.New("event bus is closed")
+
+	// ErrNoTransaction is returned by an OutboxStore when the context does not
+	// carry a transaction the outbox record can be written in
+	ErrNoTransaction = errors.New("no transaction in context")
 )
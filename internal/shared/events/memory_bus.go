@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 )
@@ -19,7 +20,8 @@ type handlerEntry struct {
 //
 // Features:
 //   - Thread-safe concurrent operations with RWMutex
-//   - Synchronous handler execution (handlers can spawn goroutines for async work)
+//   - Synchronous handler execution by default
+//   - Optional async mode with per-event worker pools (see NewAsyncInMemoryEventBus)
 //   - Panic recovery in event handlers to prevent cascade failures
 //   - Handler identification for reliable unsubscription
 //   - Graceful shutdown support
@@ -37,6 +39,7 @@ type InMemoryEventBus struct {
 	mu       sync.RWMutex
 	handlers map[string][]*handlerEntry // eventName -> list of handler entries
 	closed   bool
+	async    *asyncDispatcher // nil in synchronous mode
 }
 
 // NewInMemoryEventBus creates and initializes a new in-memory event bus.
@@ -61,15 +64,15 @@ func NewInMemoryEventBus() *InMemoryEventBus {
 
 // Publish sends an event to all registered handlers for the event type.
 // Handlers are executed synchronously in the order they were registered.
+// In async mode the event is only enqueued; see NewAsyncInMemoryEventBus.
 //
 // Behavior:
 //   - Returns ErrEventBusClosed if the bus has been closed
 //   - Returns nil if no handlers are registered (not an error)
 //   - Continues executing remaining handlers even if one fails
-//   - Returns the last error encountered (if any)
+//   - Returns the handler errors joined with errors.Join (a single error is returned as is)
 //   - Recovers from panics in handlers to prevent cascade failures
 //
-// For asynchronous processing, handlers should spawn goroutines internally.
 // The context passed to handlers should be respected for cancellation and timeouts.
 //
 // Example:
@@ -79,6 +82,10 @@ func NewInMemoryEventBus() *InMemoryEventBus {
 //	    log.Printf("Event handling failed: %v", err)
 //	}
 func (b *InMemoryEventBus) Publish(ctx context.Context, event Event) error {
+	if b.async != nil {
+		return b.publishAsync(ctx, event)
+	}
+
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
@@ -92,15 +99,18 @@ func (b *InMemoryEventBus) Publish(ctx context.Context, event Event) error {
 	}
 
 	// Execute all handlers with panic recovery
-	var lastErr error
+	var errs []error
 	for i, entry := range entries {
 		if err := b.executeHandlerSafely(ctx, event, entry.handler, i); err != nil {
-			lastErr = err
+			errs = append(errs, err)
 			// Continue processing other handlers even if one fails
 		}
 	}
 
-	return lastErr
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
 }
 
 // executeHandlerSafely executes a handler with panic recovery to prevent
@@ -236,12 +246,13 @@ func (b *InMemoryEventBus) SubscribeWithID(eventName string, handlerID string, h
 //
 // This method:
 //   - Marks the bus as closed to prevent new event publications
+//   - In async mode, waits until queued and in-flight events are handled, for
+//     at most AsyncConfig.DrainTimeout; it then returns ErrDrainTimeout
 //   - Clears all registered handlers and their ID mappings
 //   - Is idempotent (safe to call multiple times)
 //
-// Note: This method does NOT wait for in-flight handler executions to complete.
-// If handlers spawn goroutines, the caller is responsible for ensuring those
-// goroutines complete before shutting down the application.
+// Note: If handlers spawn goroutines of their own, the caller is responsible
+// for ensuring those goroutines complete before shutting down the application.
 //
 // Thread Safety: This method is safe for concurrent use.
 //
@@ -252,15 +263,23 @@ func (b *InMemoryEventBus) SubscribeWithID(eventName string, handlerID string, h
 //	// ... use the bus
 func (b *InMemoryEventBus) Close() error {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	if b.closed {
+		b.mu.Unlock()
 		return nil // Already closed, idempotent
 	}
-
 	b.closed = true
+	b.mu.Unlock()
+
+	// Drain without holding the lock; workers read handlers while finishing
+	var err error
+	if b.async != nil {
+		err = b.async.close()
+	}
+
+	b.mu.Lock()
 	b.handlers = make(map[string][]*handlerEntry)
-	return nil
+	b.mu.Unlock()
+	return err
 }
 
 // IsClosed returns true if the event bus has been closed.
@@ -0,0 +1,329 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+)
+
+// SpillTaskName is the worker task used to hand events to the worker queue
+// when an async event queue is full and the overflow policy is OverflowSpill.
+const SpillTaskName = "events:spill"
+
+// ErrEventQueueFull is returned by an async bus when the event queue is full
+// and the overflow policy does not allow waiting for room.
+var ErrEventQueueFull = errors.New("event queue is full")
+
+// ErrDrainTimeout is returned by Close of an async bus when queued and
+// in-flight events were not handled within AsyncConfig.DrainTimeout.
+var ErrDrainTimeout = errors.New("event queues did not drain before the timeout")
+
+// OverflowPolicy decides what Publish does when an async event queue is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits for room in the queue or for ctx to be done
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDrop rejects the event with ErrEventQueueFull
+	OverflowDrop OverflowPolicy = "drop"
+	// OverflowSpill enqueues the event as a SpillTaskName worker task
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+// AsyncConfig configures the asynchronous mode of InMemoryEventBus
+type AsyncConfig struct {
+	WorkersPerEvent int                     // goroutines per event name (default 1)
+	QueueSize       int                     // buffered events per event name (default 100)
+	Overflow        OverflowPolicy          // behavior when the queue is full (default OverflowBlock)
+	RetryPolicy     infraworker.RetryPolicy // applied per handler; zero value disables retries
+	SpillClient     sharedworker.Client     // worker client used by OverflowSpill
+	DrainTimeout    time.Duration           // how long Close waits for queued events (default 30s)
+
+	// ErrorHandler receives the joined errors of every handler that still
+	// failed after retries. Errors are discarded when nil.
+	ErrorHandler func(ctx context.Context, event Event, err error)
+}
+
+// DefaultAsyncConfig returns a small bounded configuration with the default retry policy
+func DefaultAsyncConfig() AsyncConfig {
+	return AsyncConfig{
+		WorkersPerEvent: 1,
+		QueueSize:       100,
+		Overflow:        OverflowBlock,
+		RetryPolicy:     infraworker.DefaultRetryPolicy(),
+		DrainTimeout:    30 * time.Second,
+	}
+}
+
+// NewAsyncInMemoryEventBus creates an in-memory event bus whose Publish only
+// enqueues the event. Each event name gets its own bounded queue and worker
+// pool, so a slow subscriber of one event does not hold up publishers or the
+// subscribers of other events. Close drains queued and in-flight events and
+// cuts pending retry backoffs short; events still queued after DrainTimeout
+// are dropped and Close returns ErrDrainTimeout.
+//
+// Handlers receive a context that keeps the publisher's values but is not
+// canceled when the publisher's context is.
+//
+// Example usage:
+//
+//	bus := events.NewAsyncInMemoryEventBus(events.AsyncConfig{
+//	    WorkersPerEvent: 4,
+//	    QueueSize:       1000,
+//	    Overflow:        events.OverflowDrop,
+//	    RetryPolicy:     infraworker.DefaultRetryPolicy(),
+//	})
+//	defer bus.Close()
+func NewAsyncInMemoryEventBus(config AsyncConfig) *InMemoryEventBus {
+	if config.WorkersPerEvent <= 0 {
+		config.WorkersPerEvent = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 100
+	}
+	if config.Overflow == "" {
+		config.Overflow = OverflowBlock
+	}
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = 30 * time.Second
+	}
+
+	b := NewInMemoryEventBus()
+	b.async = &asyncDispatcher{
+		config:  config,
+		pools:   make(map[string]*eventPool),
+		stop:    make(chan struct{}),
+		abandon: make(chan struct{}),
+	}
+	return b
+}
+
+// asyncJob is a queued event together with the context it was published with
+type asyncJob struct {
+	ctx   context.Context
+	event Event
+}
+
+// eventPool is the queue and workers serving a single event name
+type eventPool struct {
+	mu      sync.RWMutex // guards closed against publishers registering as senders
+	queue   chan asyncJob
+	closed  bool
+	closing chan struct{}  // closed by close to turn blocked publishers away
+	senders sync.WaitGroup // publishers that may still send on queue
+	wg      sync.WaitGroup
+}
+
+// asyncDispatcher owns the per-event worker pools of an async InMemoryEventBus
+type asyncDispatcher struct {
+	config  AsyncConfig
+	mu      sync.Mutex
+	pools   map[string]*eventPool
+	closed  bool
+	stop    chan struct{} // closed by close to cut retry backoffs short
+	abandon chan struct{} // closed when the drain timed out; queued jobs are dropped
+}
+
+// publishAsync enqueues the event according to the overflow policy
+func (b *InMemoryEventBus) publishAsync(ctx context.Context, event Event) error {
+	if b.IsClosed() {
+		return ErrEventBusClosed
+	}
+
+	pool := b.async.pool(b, event.EventName())
+	if pool == nil {
+		return ErrEventBusClosed
+	}
+
+	// Register as a sender so Close does not close the queue under us, but
+	// send without the lock: a publisher waiting for room must not hold up Close
+	pool.mu.RLock()
+	if pool.closed {
+		pool.mu.RUnlock()
+		return ErrEventBusClosed
+	}
+	pool.senders.Add(1)
+	pool.mu.RUnlock()
+	defer pool.senders.Done()
+
+	job := asyncJob{ctx: context.WithoutCancel(ctx), event: event}
+	switch b.async.config.Overflow {
+	case OverflowDrop:
+		select {
+		case pool.queue <- job:
+			return nil
+		default:
+			return ErrEventQueueFull
+		}
+	case OverflowSpill:
+		select {
+		case pool.queue <- job:
+			return nil
+		default:
+			return b.async.spill(ctx, event)
+		}
+	default:
+		select {
+		case pool.queue <- job:
+			return nil
+		case <-pool.closing:
+			return ErrEventBusClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pool returns the pool for eventName, starting its workers on first use.
+// Returns nil once the dispatcher is closed.
+func (d *asyncDispatcher) pool(b *InMemoryEventBus, eventName string) *eventPool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil
+	}
+	if p, ok := d.pools[eventName]; ok {
+		return p
+	}
+
+	p := &eventPool{queue: make(chan asyncJob, d.config.QueueSize), closing: make(chan struct{})}
+	for i := 0; i < d.config.WorkersPerEvent; i++ {
+		p.wg.Add(1)
+		go d.work(b, p)
+	}
+	d.pools[eventName] = p
+	return p
+}
+
+// work processes jobs until the pool queue is closed and drained, dropping
+// what is left once the drain timed out
+func (d *asyncDispatcher) work(b *InMemoryEventBus, p *eventPool) {
+	defer p.wg.Done()
+	for job := range p.queue {
+		select {
+		case <-d.abandon:
+			continue
+		default:
+		}
+		d.dispatch(b, job)
+	}
+}
+
+// dispatch runs every current handler for the job's event, each with its own retries
+func (d *asyncDispatcher) dispatch(b *InMemoryEventBus, job asyncJob) {
+	b.mu.RLock()
+	entries := append([]*handlerEntry(nil), b.handlers[job.event.EventName()]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for i, entry := range entries {
+		if err := d.runWithRetry(b, job, entry.handler, i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil && d.config.ErrorHandler != nil {
+		d.config.ErrorHandler(job.ctx, job.event, err)
+	}
+}
+
+// runWithRetry executes a handler, retrying failures as allowed by the retry policy
+func (d *asyncDispatcher) runWithRetry(b *InMemoryEventBus, job asyncJob, handler EventHandler, index int) error {
+	policy := d.config.RetryPolicy
+	for attempt := 0; ; attempt++ {
+		err := b.executeHandlerSafely(job.ctx, job.event, handler, index)
+		if err == nil {
+			return nil
+		}
+		if !policy.ShouldRetry(attempt, err.Error()) {
+			return err
+		}
+		select {
+		case <-d.stop:
+			return err
+		case <-job.ctx.Done():
+			return err
+		case <-time.After(policy.CalculateBackoff(attempt + 1)):
+		}
+	}
+}
+
+// spill hands the event to the worker queue as a SpillTaskName task
+func (d *asyncDispatcher) spill(ctx context.Context, event Event) error {
+	if d.config.SpillClient == nil {
+		return ErrEventQueueFull
+	}
+	env, err := NewEnvelope(ctx, event)
+	if err != nil {
+		return err
+	}
+	data, err := env.Marshal()
+	if err != nil {
+		return err
+	}
+	return d.config.SpillClient.Enqueue(ctx, SpillTaskName, sharedworker.TaskPayload{"envelope": string(data)})
+}
+
+// close stops accepting events and waits up to the drain timeout until every
+// queue is drained. Handlers still waiting to be retried give up with their
+// last error.
+func (d *asyncDispatcher) close() error {
+	d.mu.Lock()
+	d.closed = true
+	close(d.stop)
+	pools := make([]*eventPool, 0, len(d.pools))
+	for _, p := range d.pools {
+		pools = append(pools, p)
+	}
+	d.mu.Unlock()
+
+	for _, p := range pools {
+		p.mu.Lock()
+		p.closed = true
+		close(p.closing)
+		p.mu.Unlock()
+	}
+	// Publishers waiting for room give up on closing; the queues can be
+	// closed once none of them can send anymore
+	for _, p := range pools {
+		p.senders.Wait()
+		close(p.queue)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		for _, p := range pools {
+			p.wg.Wait()
+		}
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(d.config.DrainTimeout):
+		close(d.abandon)
+		return ErrDrainTimeout
+	}
+}
+
+// NewSpillTaskHandler returns the worker handler for SpillTaskName tasks.
+// It decodes the spilled envelope and publishes the typed event on bus.
+func NewSpillTaskHandler(bus EventBus, registry *Registry) sharedworker.TaskHandler {
+	return func(ctx context.Context, payload sharedworker.TaskPayload) error {
+		data, _ := payload["envelope"].(string)
+		env, err := UnmarshalEnvelope([]byte(data))
+		if err != nil {
+			return err
+		}
+		eventCtx, event, err := env.Decode(ctx, registry)
+		if err != nil {
+			return err
+		}
+		return bus.Publish(eventCtx, event)
+	}
+}
@@ -0,0 +1,344 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+)
+
+// fakeSpillClient records enqueued tasks
+type fakeSpillClient struct {
+	mu    sync.Mutex
+	tasks []sharedworker.TaskPayload
+}
+
+func (c *fakeSpillClient) Enqueue(ctx context.Context, taskName string, payload sharedworker.TaskPayload, options ...sharedworker.Option) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tasks = append(c.tasks, payload)
+	return nil
+}
+
+func (c *fakeSpillClient) EnqueueDelayed(ctx context.Context, taskName string, payload sharedworker.TaskPayload, delay time.Duration, options ...sharedworker.Option) error {
+	return c.Enqueue(ctx, taskName, payload, options...)
+}
+
+func (c *fakeSpillClient) Close() error                     { return nil }
+func (c *fakeSpillClient) Health(ctx context.Context) error { return nil }
+
+func TestPublish_JoinsHandlerErrors(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	defer bus.Close()
+
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error { return err1 })
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error { return err2 })
+
+	err := bus.Publish(context.Background(), &mockEvent{name: "test.event"})
+
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("Expected both handler errors, got: %v", err)
+	}
+}
+
+func TestAsyncPublish_DoesNotBlockPublisher(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{QueueSize: 10})
+
+	release := make(chan struct{})
+	var handled int32
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		<-release
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- bus.Publish(context.Background(), &mockEvent{name: "test.event"}) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow handler")
+	}
+
+	close(release)
+	bus.Close()
+
+	if atomic.LoadInt32(&handled) != 1 {
+		t.Errorf("Expected handler to run once, got %d", handled)
+	}
+}
+
+func TestAsyncPublish_OverflowDrop(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{QueueSize: 1, Overflow: OverflowDrop})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	defer func() {
+		close(release)
+		bus.Close()
+	}()
+
+	event := &mockEvent{name: "test.event"}
+	_ = bus.Publish(context.Background(), event) // picked up by the worker
+	<-started
+	_ = bus.Publish(context.Background(), event) // fills the queue
+
+	if err := bus.Publish(context.Background(), event); err != ErrEventQueueFull {
+		t.Errorf("Expected ErrEventQueueFull, got: %v", err)
+	}
+}
+
+func TestAsyncPublish_OverflowSpill(t *testing.T) {
+	client := &fakeSpillClient{}
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{QueueSize: 1, Overflow: OverflowSpill, SpillClient: client})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	bus.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	event := orderPlacedEvent{OrderID: "o-1"}
+	_ = bus.Publish(context.Background(), event)
+	<-started
+	_ = bus.Publish(context.Background(), event)
+
+	if err := bus.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	close(release)
+	bus.Close()
+
+	if len(client.tasks) != 1 {
+		t.Fatalf("Expected 1 spilled task, got %d", len(client.tasks))
+	}
+
+	// The spilled task replays the typed event on another bus
+	registry := NewRegistry()
+	RegisterType[orderPlacedEvent](registry)
+	target := NewInMemoryEventBus()
+	defer target.Close()
+	var got orderPlacedEvent
+	target.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		got, _ = event.(orderPlacedEvent)
+		return nil
+	})
+
+	if err := NewSpillTaskHandler(target, registry)(context.Background(), client.tasks[0]); err != nil {
+		t.Fatalf("spill handler error = %v", err)
+	}
+	if got.OrderID != "o-1" {
+		t.Errorf("Expected replayed event, got %+v", got)
+	}
+}
+
+func TestAsyncPublish_RetriesHandler(t *testing.T) {
+	policy := infraworker.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, BackoffMultiplier: 1}
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{RetryPolicy: policy})
+
+	var calls int32
+	done := make(chan struct{})
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("temporary")
+		}
+		close(done)
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), &mockEvent{name: "test.event"})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not retried")
+	}
+	bus.Close()
+
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestAsyncClose_CutsRetryBackoffShort(t *testing.T) {
+	policy := infraworker.RetryPolicy{MaxRetries: 5, InitialBackoff: time.Minute, MaxBackoff: time.Minute, BackoffMultiplier: 1}
+	var reported error
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{
+		RetryPolicy: policy,
+		ErrorHandler: func(ctx context.Context, event Event, err error) {
+			reported = err
+		},
+	})
+
+	failed := make(chan struct{})
+	var once sync.Once
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		once.Do(func() { close(failed) })
+		return errors.New("temporary")
+	})
+
+	_ = bus.Publish(context.Background(), &mockEvent{name: "test.event"})
+	<-failed
+
+	start := time.Now()
+	bus.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close waited %v for a retry backoff", elapsed)
+	}
+	if reported == nil {
+		t.Error("Expected the abandoned handler error to be reported")
+	}
+}
+
+func TestAsyncClose_TurnsBlockedPublisherAway(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{QueueSize: 1})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	event := &mockEvent{name: "test.event"}
+	_ = bus.Publish(context.Background(), event) // picked up by the worker
+	<-started
+	_ = bus.Publish(context.Background(), event) // fills the queue
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- bus.Publish(context.Background(), event) }()
+	time.Sleep(20 * time.Millisecond) // let the publisher wait for room
+
+	closed := make(chan error, 1)
+	go func() { closed <- bus.Close() }()
+
+	select {
+	case err := <-blocked:
+		if err != ErrEventBusClosed {
+			t.Errorf("Expected ErrEventBusClosed, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publisher waiting for room was not released by Close")
+	}
+
+	close(release)
+	if err := <-closed; err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestAsyncClose_DrainTimeout(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{QueueSize: 10, DrainTimeout: 50 * time.Millisecond})
+
+	release := make(chan struct{})
+	defer close(release)
+	var handled int32
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		atomic.AddInt32(&handled, 1)
+		<-release
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), &mockEvent{name: "test.event"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	start := time.Now()
+	if err := bus.Close(); err != ErrDrainTimeout {
+		t.Errorf("Expected ErrDrainTimeout, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close waited %v for a stuck handler", elapsed)
+	}
+	if got := atomic.LoadInt32(&handled); got != 1 {
+		t.Errorf("Expected only the in-flight event to be handled, got %d", got)
+	}
+}
+
+func TestAsyncPublish_ErrorHandlerGetsJoinedErrors(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+
+	var reported error
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{
+		ErrorHandler: func(ctx context.Context, event Event, err error) {
+			reported = err
+		},
+	})
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error { return err1 })
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error { return err2 })
+
+	_ = bus.Publish(context.Background(), &mockEvent{name: "test.event"})
+	bus.Close()
+
+	if !errors.Is(reported, err1) || !errors.Is(reported, err2) {
+		t.Errorf("Expected joined handler errors, got: %v", reported)
+	}
+}
+
+func TestAsyncClose_DrainsQueuedEvents(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{WorkersPerEvent: 2, QueueSize: 50})
+
+	var handled int32
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+
+	for i := 0; i < 20; i++ {
+		if err := bus.Publish(context.Background(), &mockEvent{name: "test.event"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	bus.Close()
+
+	if atomic.LoadInt32(&handled) != 20 {
+		t.Errorf("Expected 20 handled events after Close, got %d", handled)
+	}
+	if err := bus.Publish(context.Background(), &mockEvent{name: "test.event"}); err != ErrEventBusClosed {
+		t.Errorf("Expected ErrEventBusClosed, got: %v", err)
+	}
+}
+
+func TestAsyncPublish_HandlerContextOutlivesPublisher(t *testing.T) {
+	bus := NewAsyncInMemoryEventBus(AsyncConfig{})
+
+	var handlerErr error
+	bus.Subscribe("test.event", func(ctx context.Context, event Event) error {
+		handlerErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = bus.Publish(ctx, &mockEvent{name: "test.event"})
+	cancel()
+	bus.Close()
+
+	if handlerErr != nil {
+		t.Errorf("Expected live handler context, got: %v", handlerErr)
+	}
+}
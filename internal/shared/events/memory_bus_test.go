@@ -142,7 +142,7 @@ func TestPublish_HandlerError(t *testing.T) {
 	event := &mockEvent{name: "test.event", payload: "data"}
 	err := bus.Publish(context.Background(), event)
 
-	// Should return the last error but continue processing
+	// Should return the handler error but continue processing
 	if err == nil {
 		t.Error("Expected error from handler, got nil")
 	}
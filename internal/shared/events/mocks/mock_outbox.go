@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/shared/events/outbox.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	events "github.com/kamil5b/go-pste-monolith/internal/shared/events"
+)
+
+// MockOutboxStore is a mock of OutboxStore interface.
+type MockOutboxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxStoreMockRecorder
+}
+
+// MockOutboxStoreMockRecorder is the mock recorder for MockOutboxStore.
+type MockOutboxStoreMockRecorder struct {
+	mock *MockOutboxStore
+}
+
+// NewMockOutboxStore creates a new mock instance.
+func NewMockOutboxStore(ctrl *gomock.Controller) *MockOutboxStore {
+	mock := &MockOutboxStore{ctrl: ctrl}
+	mock.recorder = &MockOutboxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxStore) EXPECT() *MockOutboxStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimPending mocks base method.
+func (m *MockOutboxStore) ClaimPending(ctx context.Context, opts events.ClaimOptions) ([]events.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPending", ctx, opts)
+	ret0, _ := ret[0].([]events.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPending indicates an expected call of ClaimPending.
+func (mr *MockOutboxStoreMockRecorder) ClaimPending(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPending", reflect.TypeOf((*MockOutboxStore)(nil).ClaimPending), ctx, opts)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockOutboxStore) MarkDispatched(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockOutboxStoreMockRecorder) MarkDispatched(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockOutboxStore)(nil).MarkDispatched), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, cause, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxStoreMockRecorder) MarkFailed(ctx, id, cause, retryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxStore)(nil).MarkFailed), ctx, id, cause, retryAt)
+}
+
+// Save mocks base method.
+func (m *MockOutboxStore) Save(ctx context.Context, msg *events.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOutboxStoreMockRecorder) Save(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOutboxStore)(nil).Save), ctx, msg)
+}
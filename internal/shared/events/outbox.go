@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a serialized event waiting in the transactional outbox.
+// ID doubles as the deduplication ID handed to subscribers by the relay.
+// TraceContext is the publisher's trace, continued by the relay on delivery,
+// and RequestID the publishing request, restored into the relay's context.
+type OutboxMessage struct {
+	ID           string          `db:"id" json:"id"`
+	EventName    string          `db:"event_name" json:"event_name"`
+	Payload      json.RawMessage `db:"payload" json:"payload"`
+	Attempts     int             `db:"attempts" json:"attempts"`
+	LastError    *string         `db:"last_error" json:"last_error,omitempty"`
+	LockedUntil  *time.Time      `db:"locked_until" json:"locked_until,omitempty"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+	DispatchedAt *time.Time      `db:"dispatched_at" json:"dispatched_at,omitempty"`
+	TraceContext tracing.Carrier `db:"trace_context" json:"trace_context,omitempty"`
+	RequestID    string          `db:"request_id" json:"request_id,omitempty"`
+}
+
+// NewOutboxMessage serializes an event into a new outbox message
+func NewOutboxMessage(event Event) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event.Payload())
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxMessage{
+		ID:        uuid.NewString(),
+		EventName: event.EventName(),
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// ClaimOptions controls which pending messages a relay claims and for how long
+type ClaimOptions struct {
+	Limit       int           // maximum number of messages to claim
+	Lease       time.Duration // how long claimed messages stay hidden from other relays
+	MaxAttempts int           // messages with this many failed attempts are no longer claimed
+}
+
+// OutboxStore persists outbox messages next to the aggregate they describe
+type OutboxStore interface {
+	// Save writes msg using the transaction carried by ctx.
+	// Returns ErrNoTransaction when ctx carries none.
+	Save(ctx context.Context, msg *OutboxMessage) error
+
+	// ClaimPending leases undispatched messages, oldest first
+	ClaimPending(ctx context.Context, opts ClaimOptions) ([]OutboxMessage, error)
+
+	// MarkDispatched records that a message was delivered to the bus
+	MarkDispatched(ctx context.Context, id string) error
+
+	// MarkFailed records a failed delivery attempt and keeps the message
+	// hidden from ClaimPending until retryAt
+	MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error
+}
+
+// OutboxEventBus decorates an EventBus with a transactional outbox.
+// Events published while ctx carries an open transaction are written to the
+// outbox in that transaction and delivered later by an OutboxRelay, so they
+// are only seen by subscribers once the transaction commits.
+// Events published outside a transaction go straight to the wrapped bus.
+type OutboxEventBus struct {
+	EventBus
+	store OutboxStore
+}
+
+// NewOutboxEventBus wraps bus so that transactional publishes go through store
+func NewOutboxEventBus(bus EventBus, store OutboxStore) *OutboxEventBus {
+	return &OutboxEventBus{EventBus: bus, store: store}
+}
+
+// Publish stores the event in the outbox when ctx carries a transaction,
+// otherwise it publishes directly on the wrapped bus.
+func (b *OutboxEventBus) Publish(ctx context.Context, event Event) error {
+	msg, err := NewOutboxMessage(event)
+	if err != nil {
+		return err
+	}
+	msg.TraceContext = tracing.Inject(ctx)
+	msg.RequestID, _ = sharedctx.GetRequestID(ctx)
+	if err := b.store.Save(ctx, msg); !errors.Is(err, ErrNoTransaction) {
+		return err
+	}
+	return b.EventBus.Publish(ctx, event)
+}
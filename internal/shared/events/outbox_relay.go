@@ -0,0 +1,178 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	infraworker "github.com/kamil5b/go-pste-monolith/internal/infrastructure/worker"
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+)
+
+var relayLog = logger.Module("outbox")
+
+// OutboxRelayConfig holds polling and retry settings for the outbox relay
+type OutboxRelayConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	Lease          time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration // delay before retrying a message after its first failure
+	MaxBackoff     time.Duration // cap of the delay, which doubles with every failure
+}
+
+// DefaultOutboxRelayConfig returns sensible relay defaults. A message failing
+// every attempt is retried for about eight minutes before it is given up.
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval:   time.Second,
+		BatchSize:      100,
+		Lease:          30 * time.Second,
+		MaxAttempts:    10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+	}
+}
+
+// OutboxRelay moves committed outbox messages onto an EventBus.
+//
+// Delivery is at-least-once: a message is marked dispatched only after the bus
+// accepted it, so a crash in between re-delivers it once its lease expires.
+// Handlers receive the message ID through sharedctx.GetEventID and should use
+// it to drop duplicates.
+//
+// A failed message is retried with exponential backoff. After MaxAttempts
+// failures it stays in the outbox undispatched, is no longer claimed and is
+// logged as exhausted; resetting its attempts to zero requeues it.
+type OutboxRelay struct {
+	store    OutboxStore
+	bus      EventBus
+	registry *Registry
+	config   OutboxRelayConfig
+	backoff  infraworker.RetryPolicy
+}
+
+// NewOutboxRelay creates a relay delivering messages from store to bus.
+// registry rebuilds typed events; a nil registry delivers RawEvent values.
+func NewOutboxRelay(store OutboxStore, bus EventBus, registry *Registry, config OutboxRelayConfig) *OutboxRelay {
+	defaults := DefaultOutboxRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = max(defaults.MaxBackoff, config.InitialBackoff)
+	}
+	if registry == nil {
+		registry = NewRegistry()
+	}
+	return &OutboxRelay{
+		store:    store,
+		bus:      bus,
+		registry: registry,
+		config:   config,
+		backoff: infraworker.RetryPolicy{
+			InitialBackoff:    config.InitialBackoff,
+			MaxBackoff:        config.MaxBackoff,
+			BackoffMultiplier: 2,
+			JitterFraction:    0.1,
+		},
+	}
+}
+
+// Run polls the outbox until ctx is canceled.
+// Delivery failures are recorded on the message, logged and retried on later polls.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.DispatchPending(ctx)
+			if err != nil && ctx.Err() == nil {
+				relayLog.WithContext(ctx).WithField("error", err).Error("Outbox dispatch failed")
+			}
+			if n < r.config.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending claims one batch of pending messages and publishes them.
+// It returns the number of messages claimed and the joined delivery errors.
+func (r *OutboxRelay) DispatchPending(ctx context.Context) (int, error) {
+	msgs, err := r.store.ClaimPending(ctx, ClaimOptions{
+		Limit:       r.config.BatchSize,
+		Lease:       r.config.Lease,
+		MaxAttempts: r.config.MaxAttempts,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, msg := range msgs {
+		if err := r.dispatch(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("outbox message %s: %w", msg.ID, err))
+			if markErr := r.markFailed(ctx, msg, err); markErr != nil {
+				errs = append(errs, markErr)
+			}
+			continue
+		}
+		if err := r.store.MarkDispatched(ctx, msg.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(msgs), errors.Join(errs...)
+}
+
+// markFailed records a failed attempt and schedules the retry, or reports a
+// message that used up its attempts and will not be claimed again
+func (r *OutboxRelay) markFailed(ctx context.Context, msg OutboxMessage, cause error) error {
+	attempt := msg.Attempts + 1
+	if err := r.store.MarkFailed(ctx, msg.ID, cause, time.Now().UTC().Add(r.backoff.CalculateBackoff(attempt))); err != nil {
+		return err
+	}
+	if attempt >= r.config.MaxAttempts {
+		relayLog.WithContext(ctx).WithFields(map[string]interface{}{
+			"outbox_id":  msg.ID,
+			"event_name": msg.EventName,
+			"attempts":   attempt,
+			"error":      cause,
+		}).Error("Outbox message exhausted its delivery attempts and will not be retried")
+	}
+	return nil
+}
+
+func (r *OutboxRelay) dispatch(ctx context.Context, msg OutboxMessage) error {
+	event, err := r.registry.Decode(msg.EventName, msg.Payload)
+	if err != nil {
+		return err
+	}
+	ctx = tracing.Extract(ctx, msg.TraceContext)
+	if msg.RequestID != "" {
+		ctx = sharedctx.WithRequestID(ctx, msg.RequestID)
+	}
+	return r.bus.Publish(sharedctx.WithEventID(ctx, msg.ID), event)
+}
@@ -0,0 +1,379 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+)
+
+type txKey struct{}
+
+// fakeOutboxStore keeps messages in memory and treats a txKey value in the
+// context, or a value under key when set, as an open transaction
+type fakeOutboxStore struct {
+	key        any
+	mu         sync.Mutex
+	messages   []OutboxMessage
+	dispatched map[string]bool
+	failed     map[string]int
+	retryAt    map[string]time.Time
+	saveErr    error
+	claimErr   error
+}
+
+func newFakeOutboxStore() *fakeOutboxStore {
+	return &fakeOutboxStore{dispatched: map[string]bool{}, failed: map[string]int{}, retryAt: map[string]time.Time{}}
+}
+
+func (s *fakeOutboxStore) Save(ctx context.Context, msg *OutboxMessage) error {
+	key := s.key
+	if key == nil {
+		key = txKey{}
+	}
+	if ctx.Value(key) == nil {
+		return ErrNoTransaction
+	}
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, *msg)
+	return nil
+}
+
+func (s *fakeOutboxStore) ClaimPending(ctx context.Context, opts ClaimOptions) ([]OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	var res []OutboxMessage
+	for _, m := range s.messages {
+		if s.dispatched[m.ID] || s.failed[m.ID] >= opts.MaxAttempts || time.Now().Before(s.retryAt[m.ID]) {
+			continue
+		}
+		m.Attempts = s.failed[m.ID]
+		res = append(res, m)
+		if len(res) == opts.Limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeOutboxStore) MarkDispatched(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dispatched[id] = true
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed[id]++
+	s.retryAt[id] = retryAt
+	return nil
+}
+
+// elapseBackoff makes every failed message claimable again
+func (s *fakeOutboxStore) elapseBackoff() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.retryAt)
+}
+
+type orderPlacedEvent struct {
+	OrderID string `json:"order_id"`
+}
+
+func (e orderPlacedEvent) EventName() string { return "order.placed" }
+func (e orderPlacedEvent) Payload() any      { return e }
+
+func TestOutboxEventBus_PublishInTransaction(t *testing.T) {
+	store := newFakeOutboxStore()
+	inner := NewInMemoryEventBus()
+	bus := NewOutboxEventBus(inner, store)
+
+	called := false
+	bus.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		called = true
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), txKey{}, true)
+	if err := bus.Publish(ctx, orderPlacedEvent{OrderID: "o-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if called {
+		t.Error("handler must not run before the outbox is relayed")
+	}
+	if len(store.messages) != 1 {
+		t.Fatalf("outbox has %d messages, want 1", len(store.messages))
+	}
+	if store.messages[0].EventName != "order.placed" {
+		t.Errorf("EventName = %q, want order.placed", store.messages[0].EventName)
+	}
+	if string(store.messages[0].Payload) != `{"order_id":"o-1"}` {
+		t.Errorf("Payload = %s", store.messages[0].Payload)
+	}
+}
+
+func TestOutboxEventBus_PublishWithoutTransaction(t *testing.T) {
+	store := newFakeOutboxStore()
+	bus := NewOutboxEventBus(NewInMemoryEventBus(), store)
+
+	called := false
+	bus.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		called = true
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), orderPlacedEvent{OrderID: "o-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if !called {
+		t.Error("expected direct delivery outside a transaction")
+	}
+	if len(store.messages) != 0 {
+		t.Errorf("outbox has %d messages, want 0", len(store.messages))
+	}
+}
+
+func TestOutboxEventBus_StorePerDatabase(t *testing.T) {
+	type mongoSessionKey struct{}
+	sqlStore, mongoStore := newFakeOutboxStore(), newFakeOutboxStore()
+	mongoStore.key = mongoSessionKey{}
+	inner := NewInMemoryEventBus()
+	bus := NewOutboxEventBus(NewOutboxEventBus(inner, sqlStore), mongoStore)
+
+	calls := 0
+	bus.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	_ = bus.Publish(context.WithValue(context.Background(), txKey{}, true), orderPlacedEvent{OrderID: "o-1"})
+	_ = bus.Publish(context.WithValue(context.Background(), mongoSessionKey{}, true), orderPlacedEvent{OrderID: "o-2"})
+	_ = bus.Publish(context.Background(), orderPlacedEvent{OrderID: "o-3"})
+
+	if len(sqlStore.messages) != 1 || len(mongoStore.messages) != 1 {
+		t.Errorf("outboxes have %d SQL and %d Mongo messages, want 1 each", len(sqlStore.messages), len(mongoStore.messages))
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1 for the event published outside a transaction", calls)
+	}
+}
+
+func TestOutboxEventBus_SaveError(t *testing.T) {
+	store := newFakeOutboxStore()
+	store.saveErr = errors.New("insert failed")
+	bus := NewOutboxEventBus(NewInMemoryEventBus(), store)
+
+	ctx := context.WithValue(context.Background(), txKey{}, true)
+	if err := bus.Publish(ctx, orderPlacedEvent{OrderID: "o-1"}); !errors.Is(err, store.saveErr) {
+		t.Errorf("Publish() error = %v, want %v", err, store.saveErr)
+	}
+}
+
+func TestOutboxRelay_DispatchPending(t *testing.T) {
+	store := newFakeOutboxStore()
+	inner := NewInMemoryEventBus()
+	txCtx := context.WithValue(sharedctx.WithRequestID(context.Background(), "req-123"), txKey{}, true)
+	if err := NewOutboxEventBus(inner, store).Publish(txCtx, orderPlacedEvent{OrderID: "o-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	registry := NewRegistry()
+	RegisterType[orderPlacedEvent](registry)
+
+	var got orderPlacedEvent
+	var gotID, gotRequestID string
+	inner.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		got, _ = event.(orderPlacedEvent)
+		gotID, _ = sharedctx.GetEventID(ctx)
+		gotRequestID, _ = sharedctx.GetRequestID(ctx)
+		return nil
+	})
+
+	relay := NewOutboxRelay(store, inner, registry, OutboxRelayConfig{})
+	n, err := relay.DispatchPending(context.Background())
+	if err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("DispatchPending() = %d, want 1", n)
+	}
+	if got.OrderID != "o-1" {
+		t.Errorf("handler got %+v, want typed event with OrderID o-1", got)
+	}
+	if gotID != store.messages[0].ID {
+		t.Errorf("event ID = %q, want %q", gotID, store.messages[0].ID)
+	}
+	if gotRequestID != "req-123" {
+		t.Errorf("request ID = %q, want req-123", gotRequestID)
+	}
+	if !store.dispatched[gotID] {
+		t.Error("message not marked dispatched")
+	}
+
+	// Nothing left to deliver
+	if n, _ := relay.DispatchPending(context.Background()); n != 0 {
+		t.Errorf("second DispatchPending() = %d, want 0", n)
+	}
+}
+
+func TestOutboxRelay_HandlerFailureIsRetried(t *testing.T) {
+	store := newFakeOutboxStore()
+	inner := NewInMemoryEventBus()
+	txCtx := context.WithValue(context.Background(), txKey{}, true)
+	_ = NewOutboxEventBus(inner, store).Publish(txCtx, orderPlacedEvent{OrderID: "o-1"})
+
+	calls := 0
+	inner.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	relay := NewOutboxRelay(store, inner, nil, OutboxRelayConfig{MaxAttempts: 3, InitialBackoff: time.Minute})
+	if _, err := relay.DispatchPending(context.Background()); err == nil {
+		t.Error("expected delivery error on first attempt")
+	}
+	id := store.messages[0].ID
+	if store.failed[id] != 1 || store.dispatched[id] {
+		t.Fatalf("after failure: failed=%d dispatched=%v", store.failed[id], store.dispatched[id])
+	}
+
+	// The failed message backs off instead of being retried on the next poll
+	if n, _ := relay.DispatchPending(context.Background()); n != 0 {
+		t.Fatalf("DispatchPending() during backoff = %d, want 0", n)
+	}
+
+	store.elapseBackoff()
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("retry error = %v", err)
+	}
+	if !store.dispatched[id] {
+		t.Error("message not dispatched after retry")
+	}
+}
+
+func TestOutboxRelay_BacksOffAndGivesUp(t *testing.T) {
+	var out syncBuffer
+	l, err := logger.NewFromConfig(logger.Config{Output: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetLogger(logger.GetDefaultLogger())
+	logger.SetLogger(l)
+
+	store := newFakeOutboxStore()
+	inner := NewInMemoryEventBus()
+	_ = NewOutboxEventBus(inner, store).Publish(context.WithValue(context.Background(), txKey{}, true), orderPlacedEvent{OrderID: "o-1"})
+	inner.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		return errors.New("broker down")
+	})
+
+	relay := NewOutboxRelay(store, inner, nil, OutboxRelayConfig{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Hour})
+	id := store.messages[0].ID
+	var delays []time.Duration
+	for attempt := 1; attempt <= 3; attempt++ {
+		start := time.Now()
+		if _, err := relay.DispatchPending(context.Background()); err == nil {
+			t.Fatalf("attempt %d: expected delivery error", attempt)
+		}
+		delays = append(delays, store.retryAt[id].Sub(start))
+		store.elapseBackoff()
+	}
+
+	// Doubling from InitialBackoff, plus up to 10% jitter
+	for i, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		if delays[i] < want || delays[i] > want+want/10+time.Second {
+			t.Errorf("backoff after attempt %d = %v, want about %v", i+1, delays[i], want)
+		}
+	}
+
+	if n, _ := relay.DispatchPending(context.Background()); n != 0 {
+		t.Errorf("DispatchPending() after MaxAttempts = %d, want 0", n)
+	}
+	if logged := out.String(); !strings.Contains(logged, "exhausted its delivery attempts") || !strings.Contains(logged, id) {
+		t.Errorf("exhausted message was not logged: %q", logged)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for a logger writing from another goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestOutboxRelay_RunLogsDispatchErrors(t *testing.T) {
+	var out syncBuffer
+	l, err := logger.NewFromConfig(logger.Config{Output: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetLogger(logger.GetDefaultLogger())
+	logger.SetLogger(l)
+
+	store := newFakeOutboxStore()
+	store.claimErr = errors.New("outbox table missing")
+	relay := NewOutboxRelay(store, NewInMemoryEventBus(), nil, OutboxRelayConfig{PollInterval: time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- relay.Run(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "outbox table missing") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	logged := out.String()
+	if !strings.Contains(logged, "Outbox dispatch failed") || !strings.Contains(logged, "outbox table missing") {
+		t.Errorf("claim error was not logged: %q", logged)
+	}
+}
+
+func TestRegistry_DecodeUnknown(t *testing.T) {
+	event, err := NewRegistry().Decode("unknown.event", []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	raw, ok := event.(RawEvent)
+	if !ok {
+		t.Fatalf("Decode() = %T, want RawEvent", event)
+	}
+	if raw.EventName() != "unknown.event" || string(raw.Data) != `{"a":1}` {
+		t.Errorf("unexpected raw event %+v", raw)
+	}
+}
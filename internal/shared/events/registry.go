@@ -0,0 +1,65 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// EventDecoder rebuilds a typed Event from its serialized payload
+type EventDecoder func(data []byte) (Event, error)
+
+// RawEvent is an event whose name has no registered decoder.
+// Its payload is the serialized JSON as it was stored or received.
+type RawEvent struct {
+	Name string
+	Data json.RawMessage
+}
+
+func (e RawEvent) EventName() string { return e.Name }
+func (e RawEvent) Payload() any      { return e.Data }
+
+// Registry maps event names to decoders so events that crossed a serialization
+// boundary (outbox table, message broker) reach subscribers in their typed form.
+type Registry struct {
+	mu       sync.RWMutex
+	decoders map[string]EventDecoder
+}
+
+// NewRegistry creates an empty event type registry
+func NewRegistry() *Registry {
+	return &Registry{decoders: make(map[string]EventDecoder)}
+}
+
+// Register associates a decoder with an event name, replacing any previous one
+func (r *Registry) Register(eventName string, decoder EventDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[eventName] = decoder
+}
+
+// RegisterType registers T under the name returned by its zero value's EventName.
+// Payloads are decoded with encoding/json into a value of T.
+//
+//	events.RegisterType[domain.ProductCreatedEvent](registry)
+func RegisterType[T Event](r *Registry) {
+	var zero T
+	r.Register(zero.EventName(), func(data []byte) (Event, error) {
+		var e T
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+// Decode rebuilds the event registered under eventName.
+// Unknown names are returned as a RawEvent rather than an error.
+func (r *Registry) Decode(eventName string, data []byte) (Event, error) {
+	r.mu.RLock()
+	decoder, ok := r.decoders[eventName]
+	r.mu.RUnlock()
+	if !ok {
+		return RawEvent{Name: eventName, Data: json.RawMessage(data)}, nil
+	}
+	return decoder(data)
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingEventBus opens a producer span per publish and a consumer span per
+// handler run. Broker-backed buses carry the producer span to the consumer in
+// the envelope, so handlers continue the publisher's trace.
+type tracingEventBus struct {
+	EventBus
+}
+
+// NewTracingEventBus wraps bus with tracing spans. Handlers must be subscribed
+// through the returned bus to get a span.
+func NewTracingEventBus(bus EventBus) EventBus {
+	if bus == nil {
+		return bus
+	}
+	return &tracingEventBus{EventBus: bus}
+}
+
+func (b *tracingEventBus) Publish(ctx context.Context, event Event) (err error) {
+	ctx, span := tracing.Start(ctx, "publish "+event.EventName(), trace.SpanKindProducer,
+		attribute.String("messaging.operation.type", "send"),
+		attribute.String("messaging.destination.name", event.EventName()),
+	)
+	defer func() { tracing.End(span, err) }()
+	return b.EventBus.Publish(ctx, event)
+}
+
+func (b *tracingEventBus) Subscribe(eventName string, handler EventHandler) {
+	b.EventBus.Subscribe(eventName, func(ctx context.Context, event Event) (err error) {
+		ctx, span := tracing.Start(ctx, "process "+eventName, trace.SpanKindConsumer,
+			attribute.String("messaging.operation.type", "process"),
+			attribute.String("messaging.destination.name", eventName),
+		)
+		defer func() { tracing.End(span, err) }()
+		return handler(ctx, event)
+	})
+}
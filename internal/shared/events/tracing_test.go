@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newSpanRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestTracingEventBus_HandlerContinuesPublishSpan(t *testing.T) {
+	recorder := newSpanRecorder()
+	bus := NewTracingEventBus(NewInMemoryEventBus())
+
+	bus.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		return errors.New("handler failed")
+	})
+	_ = bus.Publish(context.Background(), orderPlacedEvent{OrderID: "o-1"})
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	publish, handle := spans["publish order.placed"], spans["process order.placed"]
+	if publish == nil || handle == nil {
+		t.Fatalf("spans = %v, want publish and process spans", spans)
+	}
+	if publish.SpanKind() != trace.SpanKindProducer || handle.SpanKind() != trace.SpanKindConsumer {
+		t.Errorf("kinds = %v/%v, want producer/consumer", publish.SpanKind(), handle.SpanKind())
+	}
+	if handle.Parent().SpanID() != publish.SpanContext().SpanID() {
+		t.Error("handler span is not a child of the publish span")
+	}
+	if handle.Status().Code != codes.Error {
+		t.Errorf("handler span status = %v, want error", handle.Status().Code)
+	}
+}
+
+func TestEnvelope_CarriesTraceContext(t *testing.T) {
+	newSpanRecorder()
+	ctx, span := otel.Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+
+	env, err := NewEnvelope(ctx, orderPlacedEvent{OrderID: "o-1"})
+	if err != nil {
+		t.Fatalf("NewEnvelope() error = %v", err)
+	}
+	data, _ := env.Marshal()
+	decoded, err := UnmarshalEnvelope(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEnvelope() error = %v", err)
+	}
+
+	registry := NewRegistry()
+	RegisterType[orderPlacedEvent](registry)
+	consumerCtx, _, err := decoded.Decode(context.Background(), registry)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	remote := trace.SpanContextFromContext(consumerCtx)
+	if remote.TraceID() != span.SpanContext().TraceID() || remote.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("consumer span context = %v, want publisher %v", remote, span.SpanContext())
+	}
+}
+
+func TestOutboxRelay_ContinuesPublisherTrace(t *testing.T) {
+	newSpanRecorder()
+	ctx, span := otel.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	store := newFakeOutboxStore()
+	inner := NewInMemoryEventBus()
+	var handled trace.SpanContext
+	inner.Subscribe("order.placed", func(ctx context.Context, event Event) error {
+		handled = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+
+	bus := NewOutboxEventBus(inner, store)
+	if err := bus.Publish(context.WithValue(ctx, txKey{}, true), orderPlacedEvent{OrderID: "o-1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(store.messages) != 1 || store.messages[0].TraceContext["traceparent"] == "" {
+		t.Fatalf("messages = %+v, want one with a traceparent", store.messages)
+	}
+
+	relay := NewOutboxRelay(store, inner, nil, OutboxRelayConfig{})
+	if _, err := relay.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("DispatchPending() error = %v", err)
+	}
+	if handled.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("relayed handler trace = %v, want %v", handled.TraceID(), span.SpanContext().TraceID())
+	}
+}
@@ -0,0 +1,110 @@
+// Package health aggregates dependency health checks for probes such as the
+// gRPC health service and the HTTP liveness and readiness endpoints.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker reports whether a dependency is usable. cache.Cache,
+// storage.StorageService and email.EmailService satisfy it.
+type Checker interface {
+	Health(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function such as (*sqlx.DB).PingContext to a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Health(ctx context.Context) error { return f(ctx) }
+
+// CheckResult is the outcome of a single check
+type CheckResult struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"-"`
+}
+
+// Report is the outcome of all checks; Status is down if any check failed
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+func (r Report) Healthy() bool { return r.Status == StatusUp }
+
+// withoutErrors copies the report with the check errors cleared
+func (r Report) withoutErrors() Report {
+	if r.Checks == nil {
+		return r
+	}
+	checks := make(map[string]CheckResult, len(r.Checks))
+	for name, result := range r.Checks {
+		result.Error = ""
+		checks[name] = result
+	}
+	r.Checks = checks
+	return r
+}
+
+// Registry holds named checks and runs them together
+type Registry struct {
+	mu     sync.RWMutex
+	checks map[string]Checker
+}
+
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]Checker)}
+}
+
+// Register adds or replaces the check called name
+func (r *Registry) Register(name string, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = checker
+}
+
+// Check runs every check concurrently; a slow dependency is bounded by ctx
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make(map[string]Checker, len(r.checks))
+	for name, checker := range r.checks {
+		checks[name] = checker
+	}
+	r.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks)), CheckedAt: time.Now()}
+	)
+	for name, checker := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := checker.Health(ctx)
+			result := CheckResult{Status: StatusUp, Duration: time.Since(start)}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,130 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("cache", CheckerFunc(func(ctx context.Context) error { return nil }))
+	registry.Register("database", CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") }))
+
+	report := registry.Check(context.Background())
+
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusUp, report.Checks["cache"].Status)
+	assert.Equal(t, StatusDown, report.Checks["database"].Status)
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+}
+
+func TestRegistry_CheckEmpty(t *testing.T) {
+	report := NewRegistry().Check(context.Background())
+
+	assert.True(t, report.Healthy())
+	assert.Empty(t, report.Checks)
+}
+
+func TestRegistry_CheckTimeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	report := registry.Check(ctx)
+
+	assert.False(t, report.Healthy())
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+}
+
+func TestProbes_ReadyCachesReport(t *testing.T) {
+	calls := 0
+	registry := NewRegistry()
+	registry.Register("cache", CheckerFunc(func(ctx context.Context) error {
+		calls++
+		return nil
+	}))
+	probes := NewProbes(registry, ProbeConfig{CacheTTL: time.Minute})
+
+	first := probes.Ready(context.Background())
+	second := probes.Ready(context.Background())
+
+	assert.True(t, first.Healthy())
+	assert.Equal(t, first.CheckedAt, second.CheckedAt)
+	assert.Equal(t, 1, calls)
+}
+
+func TestProbes_ReadyTimeout(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	probes := NewProbes(registry, ProbeConfig{Timeout: 10 * time.Millisecond})
+
+	report := probes.Ready(context.Background())
+
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+}
+
+func TestProbes_Readiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "all up", wantCode: http.StatusOK},
+		{name: "dependency down", err: errors.New("connection refused"), wantCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			c := mocks.NewMockContext(ctrl)
+			registry := NewRegistry()
+			registry.Register("database", CheckerFunc(func(ctx context.Context) error { return tt.err }))
+
+			var out bytes.Buffer
+			l, err := logger.NewFromConfig(logger.Config{Output: &out})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer logger.SetLogger(logger.GetDefaultLogger())
+			logger.SetLogger(l)
+
+			var served Report
+			c.EXPECT().GetContext().Return(context.Background())
+			c.EXPECT().JSON(tt.wantCode, gomock.AssignableToTypeOf(Report{})).DoAndReturn(func(code int, body interface{}) error {
+				served = body.(Report)
+				return nil
+			})
+
+			assert.NoError(t, NewProbes(registry, ProbeConfig{}).Readiness(c))
+			assert.Empty(t, served.Checks["database"].Error, "check errors are not served")
+			if tt.err != nil {
+				assert.Contains(t, out.String(), tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestProbes_Liveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	c := mocks.NewMockContext(ctrl)
+	c.EXPECT().JSON(http.StatusOK, gomock.AssignableToTypeOf(Report{})).Return(nil)
+
+	assert.NoError(t, NewProbes(NewRegistry(), ProbeConfig{}).Liveness(c))
+}
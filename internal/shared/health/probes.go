@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/logger"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+)
+
+var healthLog = logger.Module("health")
+
+const (
+	defaultProbeTimeout  = 2 * time.Second
+	defaultProbeCacheTTL = 5 * time.Second
+)
+
+// ProbeConfig bounds readiness checks; zero values use the defaults
+type ProbeConfig struct {
+	Timeout  time.Duration // per-check deadline
+	CacheTTL time.Duration // how long a readiness report is reused
+}
+
+// Probes serves the HTTP liveness and readiness endpoints
+type Probes struct {
+	registry *Registry
+	config   ProbeConfig
+
+	mu     sync.Mutex
+	report *Report
+}
+
+func NewProbes(registry *Registry, config ProbeConfig) *Probes {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultProbeTimeout
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaultProbeCacheTTL
+	}
+	return &Probes{registry: registry, config: config}
+}
+
+// Liveness reports that the process is up; it checks no dependencies so a
+// failing database does not get the process restarted
+func (p *Probes) Liveness(c sharedctx.Context) error {
+	return c.JSON(http.StatusOK, Report{Status: StatusUp, CheckedAt: time.Now()})
+}
+
+// Readiness reports every dependency with 200 when all are up, 503 otherwise.
+// The endpoint is unauthenticated, so only statuses are served; check errors
+// go to the log.
+func (p *Probes) Readiness(c sharedctx.Context) error {
+	report := p.Ready(c.GetContext()).withoutErrors()
+	code := http.StatusOK
+	if !report.Healthy() {
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, report)
+}
+
+// Ready returns the cached report while it is fresh, otherwise runs the checks.
+// Concurrent callers wait for a single run instead of each hitting the
+// dependencies.
+func (p *Probes) Ready(ctx context.Context) Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.report != nil && time.Since(p.report.CheckedAt) < p.config.CacheTTL {
+		return *p.report
+	}
+
+	// Detached from the request so a client hanging up does not cache failures
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.config.Timeout)
+	defer cancel()
+	report := p.registry.Check(checkCtx)
+	for name, result := range report.Checks {
+		if result.Status != StatusUp {
+			healthLog.WithContext(ctx).WithFields(map[string]interface{}{
+				"check": name,
+				"error": result.Error,
+			}).Warn("Readiness check failed")
+		}
+	}
+	p.report = &report
+	return report
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/cache"
+)
+
+// instrumentedCache counts hits and misses of Get and GetBytes under a name;
+// any read error counts as a miss since callers fall back to the source
+type instrumentedCache struct {
+	cache.Cache
+	name    string
+	metrics *Metrics
+}
+
+// InstrumentCache returns c reporting reads as the cache called name, or c
+// itself when m is nil
+func InstrumentCache(c cache.Cache, name string, m *Metrics) cache.Cache {
+	if m == nil || c == nil {
+		return c
+	}
+	return &instrumentedCache{Cache: c, name: name, metrics: m}
+}
+
+func (c *instrumentedCache) Get(ctx context.Context, key string) (string, error) {
+	value, err := c.Cache.Get(ctx, key)
+	c.observe(err)
+	return value, err
+}
+
+func (c *instrumentedCache) GetBytes(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.Cache.GetBytes(ctx, key)
+	c.observe(err)
+	return value, err
+}
+
+func (c *instrumentedCache) observe(err error) {
+	if err != nil {
+		c.metrics.CacheMiss(c.name)
+		return
+	}
+	c.metrics.CacheHit(c.name)
+}
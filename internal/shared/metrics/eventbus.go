@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+)
+
+// instrumentedEventBus times publishes and the handlers subscribed through it
+type instrumentedEventBus struct {
+	events.EventBus
+	metrics *Metrics
+}
+
+// InstrumentEventBus returns bus recording publish and handler durations, or
+// bus itself when m is nil. Handlers must be subscribed through the returned
+// bus to be timed.
+func InstrumentEventBus(bus events.EventBus, m *Metrics) events.EventBus {
+	if m == nil || bus == nil {
+		return bus
+	}
+	return &instrumentedEventBus{EventBus: bus, metrics: m}
+}
+
+func (b *instrumentedEventBus) Publish(ctx context.Context, event events.Event) error {
+	start := time.Now()
+	err := b.EventBus.Publish(ctx, event)
+	b.metrics.ObserveEventPublish(event.EventName(), time.Since(start), err)
+	return err
+}
+
+func (b *instrumentedEventBus) Subscribe(eventName string, handler events.EventHandler) {
+	b.EventBus.Subscribe(eventName, func(ctx context.Context, event events.Event) error {
+		start := time.Now()
+		err := handler(ctx, event)
+		b.metrics.ObserveEventHandler(eventName, time.Since(start), err)
+		return err
+	})
+}
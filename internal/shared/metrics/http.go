@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+)
+
+// HTTPMiddleware records the requests of one route. It wraps the framework
+// agnostic handler, so the same middleware serves every HTTP adapter.
+func HTTPMiddleware(m *Metrics, method, route string) func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+	return func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+		if m == nil {
+			return next
+		}
+		return func(c sharedctx.Context) error {
+			start := time.Now()
+			recorder := &statusRecorder{Context: c}
+			err := next(recorder)
+
+			status := recorder.status
+			switch {
+			case status == 0 && err != nil:
+				status = http.StatusInternalServerError
+			case status == 0:
+				status = http.StatusOK
+			}
+			m.ObserveHTTPRequest(method, route, status, time.Since(start))
+			return err
+		}
+	}
+}
+
+// statusRecorder remembers the status code written through JSON
+type statusRecorder struct {
+	sharedctx.Context
+	status int
+}
+
+func (r *statusRecorder) JSON(code int, v any) error {
+	if r.status == 0 {
+		r.status = code
+	}
+	return r.Context.JSON(code, v)
+}
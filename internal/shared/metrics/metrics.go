@@ -0,0 +1,202 @@
+// Package metrics records Prometheus metrics for the HTTP and gRPC
+// transports, worker servers, caches, the event bus and database pools.
+//
+// Every recording method is safe to call on a nil *Metrics, so components
+// take an optional *Metrics and the feature flag only decides whether one is
+// created.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultNamespace prefixes every metric name unless configured otherwise
+const DefaultNamespace = "app"
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// Metrics owns a Prometheus registry and the collectors recorded into it
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	tasksProcessed    *prometheus.CounterVec
+	tasksFailed       *prometheus.CounterVec
+	tasksRetried      *prometheus.CounterVec
+	tasksDeadLettered *prometheus.CounterVec
+	taskDuration      *prometheus.HistogramVec
+
+	cacheHits   *prometheus.CounterVec
+	cacheMisses *prometheus.CounterVec
+
+	eventsPublished *prometheus.CounterVec
+	publishDuration *prometheus.HistogramVec
+	handlerDuration *prometheus.HistogramVec
+}
+
+// New creates the collectors under namespace (DefaultNamespace when empty)
+// in a fresh registry that also exports Go runtime and process metrics
+func New(namespace string) *Metrics {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Name: name, Help: help}, labels)
+	}
+	histogram := func(name, help string, labels ...string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: name, Help: help, Buckets: prometheus.DefBuckets,
+		}, labels)
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: counter("http_requests_total", "HTTP requests by method, route and status code.", "method", "route", "status"),
+		httpDuration: histogram("http_request_duration_seconds", "HTTP request latency by method and route.", "method", "route"),
+
+		grpcRequests: counter("grpc_requests_total", "gRPC calls by full method and status code.", "method", "code"),
+		grpcDuration: histogram("grpc_request_duration_seconds", "gRPC call latency by full method.", "method"),
+
+		tasksProcessed:    counter("worker_tasks_processed_total", "Worker tasks that completed successfully.", "backend", "task"),
+		tasksFailed:       counter("worker_tasks_failed_total", "Worker task attempts that returned an error.", "backend", "task"),
+		tasksRetried:      counter("worker_tasks_retried_total", "Failed worker tasks scheduled for another attempt.", "backend", "task"),
+		tasksDeadLettered: counter("worker_tasks_dead_lettered_total", "Worker tasks given up on and dead-lettered.", "backend", "task"),
+		taskDuration:      histogram("worker_task_duration_seconds", "Worker task handler latency.", "backend", "task"),
+
+		cacheHits:   counter("cache_hits_total", "Cache reads that found a value.", "cache"),
+		cacheMisses: counter("cache_misses_total", "Cache reads that found no usable value.", "cache"),
+
+		eventsPublished: counter("events_published_total", "Events published on the event bus by result.", "event", "result"),
+		publishDuration: histogram("event_publish_duration_seconds", "Event bus publish latency.", "event"),
+		handlerDuration: histogram("event_handler_duration_seconds", "Event handler latency by result.", "event", "result"),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.tasksProcessed, m.tasksFailed, m.tasksRetried, m.tasksDeadLettered, m.taskDuration,
+		m.cacheHits, m.cacheMisses,
+		m.eventsPublished, m.publishDuration, m.handlerDuration,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry exposes the registry for collectors defined elsewhere
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// RegisterDB exports connection pool stats of db as go_sql_* metrics labelled db_name
+func (m *Metrics) RegisterDB(name string, db *sql.DB) error {
+	if m == nil || db == nil {
+		return nil
+	}
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveHTTPRequest records a request; route is the registered path
+// template, never the raw URL, to keep label cardinality bounded
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGRPCCall records a call by full method name and status code name
+func (m *Metrics) ObserveGRPCCall(method, code string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.grpcRequests.WithLabelValues(method, code).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// ObserveTask records one handler run; err decides processed or failed
+func (m *Metrics) ObserveTask(backend, task string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.taskDuration.WithLabelValues(backend, task).Observe(duration.Seconds())
+	if err != nil {
+		m.tasksFailed.WithLabelValues(backend, task).Inc()
+		return
+	}
+	m.tasksProcessed.WithLabelValues(backend, task).Inc()
+}
+
+// TaskRetried records a failed task that will be attempted again
+func (m *Metrics) TaskRetried(backend, task string) {
+	if m == nil {
+		return
+	}
+	m.tasksRetried.WithLabelValues(backend, task).Inc()
+}
+
+// TaskDeadLettered records a task that will not be attempted again
+func (m *Metrics) TaskDeadLettered(backend, task string) {
+	if m == nil {
+		return
+	}
+	m.tasksDeadLettered.WithLabelValues(backend, task).Inc()
+}
+
+func (m *Metrics) CacheHit(cache string) {
+	if m == nil {
+		return
+	}
+	m.cacheHits.WithLabelValues(cache).Inc()
+}
+
+func (m *Metrics) CacheMiss(cache string) {
+	if m == nil {
+		return
+	}
+	m.cacheMisses.WithLabelValues(cache).Inc()
+}
+
+func (m *Metrics) ObserveEventPublish(event string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.eventsPublished.WithLabelValues(event, result(err)).Inc()
+	m.publishDuration.WithLabelValues(event).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveEventHandler(event string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.handlerDuration.WithLabelValues(event, result(err)).Observe(duration.Seconds())
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultSuccess
+}
@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	cachemocks "github.com/kamil5b/go-pste-monolith/internal/shared/cache/mocks"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/events"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct{}
+
+func (testEvent) EventName() string { return "product.created" }
+func (testEvent) Payload() any      { return nil }
+
+func TestNilMetricsIsNoop(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveHTTPRequest("GET", "/v1/product", http.StatusOK, time.Millisecond)
+		m.ObserveGRPCCall("/product.v1.ProductService/Get", "OK", time.Millisecond)
+		m.ObserveTask("asynq", "send_email", time.Millisecond, nil)
+		m.TaskRetried("asynq", "send_email")
+		m.TaskDeadLettered("asynq", "send_email")
+		m.CacheHit("product")
+		m.CacheMiss("product")
+		m.ObserveEventPublish("product.created", time.Millisecond, nil)
+		m.ObserveEventHandler("product.created", time.Millisecond, nil)
+		assert.NoError(t, m.RegisterDB("primary", nil))
+	})
+}
+
+func TestObserveTask(t *testing.T) {
+	m := New("")
+
+	m.ObserveTask("redpanda", "send_email", time.Millisecond, nil)
+	m.ObserveTask("redpanda", "send_email", time.Millisecond, errors.New("smtp down"))
+	m.TaskRetried("redpanda", "send_email")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasksProcessed.WithLabelValues("redpanda", "send_email")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasksFailed.WithLabelValues("redpanda", "send_email")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.tasksRetried.WithLabelValues("redpanda", "send_email")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.tasksDeadLettered.WithLabelValues("redpanda", "send_email")))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    func(c sharedctx.Context) error
+		wantStatus string
+	}{
+		{
+			name:       "status written by handler",
+			handler:    func(c sharedctx.Context) error { return c.JSON(http.StatusCreated, nil) },
+			wantStatus: "201",
+		},
+		{
+			name: "first status wins",
+			handler: func(c sharedctx.Context) error {
+				_ = c.JSON(http.StatusUnauthorized, nil)
+				return c.JSON(http.StatusOK, nil)
+			},
+			wantStatus: "401",
+		},
+		{
+			name:       "error without response",
+			handler:    func(c sharedctx.Context) error { return errors.New("boom") },
+			wantStatus: "500",
+		},
+		{
+			name:       "nothing written",
+			handler:    func(c sharedctx.Context) error { return nil },
+			wantStatus: "200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			c := ctxmocks.NewMockContext(ctrl)
+			c.EXPECT().JSON(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			m := New("")
+
+			_ = HTTPMiddleware(m, "POST", "/v1/product")(tt.handler)(c)
+
+			assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("POST", "/v1/product", tt.wantStatus)))
+		})
+	}
+}
+
+func TestHTTPMiddleware_NilMetricsReturnsHandler(t *testing.T) {
+	called := false
+	handler := HTTPMiddleware(nil, "GET", "/v1/product")(func(c sharedctx.Context) error {
+		called = true
+		return nil
+	})
+
+	require.NoError(t, handler(nil))
+	assert.True(t, called)
+}
+
+func TestInstrumentCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inner := cachemocks.NewMockCache(ctrl)
+	inner.EXPECT().GetBytes(gomock.Any(), "product:1").Return([]byte("{}"), nil)
+	inner.EXPECT().GetBytes(gomock.Any(), "product:2").Return(nil, errors.New("cache key not found"))
+	inner.EXPECT().Get(gomock.Any(), "product:3").Return("", errors.New("cache key not found"))
+	m := New("")
+
+	c := InstrumentCache(inner, "product", m)
+	_, _ = c.GetBytes(context.Background(), "product:1")
+	_, _ = c.GetBytes(context.Background(), "product:2")
+	_, _ = c.Get(context.Background(), "product:3")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheHits.WithLabelValues("product")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheMisses.WithLabelValues("product")))
+	assert.Same(t, inner, InstrumentCache(inner, "product", nil))
+}
+
+func TestInstrumentEventBus(t *testing.T) {
+	m := New("")
+	bus := InstrumentEventBus(events.NewInMemoryEventBus(), m)
+	bus.Subscribe("product.created", func(ctx context.Context, event events.Event) error {
+		return errors.New("handler failed")
+	})
+
+	err := bus.Publish(context.Background(), testEvent{})
+
+	require.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.eventsPublished.WithLabelValues("product.created", resultError)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.handlerDuration, "app_event_handler_duration_seconds"))
+}
+
+func TestHandler(t *testing.T) {
+	m := New("pste")
+	m.CacheHit("user")
+
+	count, err := testutil.GatherAndCount(m.Registry(), "pste_cache_hits_total")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NotNil(t, m.Handler())
+}
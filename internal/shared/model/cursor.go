@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// was issued for a different sort order
+var ErrInvalidCursor = sharederrors.ErrInvalidInput.WithMessage("invalid pagination cursor")
+
+// Cursor is the keyset position of the last item on a page. It is handed to
+// clients as an opaque string.
+type Cursor struct {
+	Sort  string `json:"s"`  // sort expression the cursor was issued for
+	Value string `json:"v"`  // sort key value of the last item
+	ID    string `json:"id"` // tie-breaker for items sharing the same sort value
+}
+
+// EncodeCursor serializes a cursor into an opaque URL-safe string
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor and checks that it
+// belongs to the given sort order
+func DecodeCursor(s string, sort SortRequest) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor.WithError(err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor.WithError(err)
+	}
+	if c.Sort != sort.String() || c.ID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -1,10 +1,71 @@
 package model
 
+import "strings"
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
 type PaginationRequest struct {
-	Page  int `json:"page" binding:"required,min=1"`
-	Limit int `json:"limit" binding:"required,min=5,max=100"`
+	Page   int    `json:"page" binding:"omitempty,min=1"`
+	Limit  int    `json:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `json:"cursor,omitempty"` // opaque; takes precedence over Page when set
 }
 
 func (p *PaginationRequest) Offset() int {
 	return (p.Page - 1) * p.Limit
 }
+
+// Normalize fills in defaults and clamps the limit to MaxPageLimit
+func (p *PaginationRequest) Normalize() {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+}
+
+// UsesCursor reports whether the request pages by cursor instead of offset
+func (p *PaginationRequest) UsesCursor() bool {
+	return p.Cursor != ""
+}
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortRequest is a single sort key, e.g. parsed from "-created_at"
+type SortRequest struct {
+	Field     string
+	Direction SortDirection
+}
+
+// ParseSort parses a sort expression where a leading '-' means descending
+// and an optional leading '+' means ascending.
+func ParseSort(s string) SortRequest {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		return SortRequest{Field: s[1:], Direction: SortDesc}
+	case strings.HasPrefix(s, "+"):
+		return SortRequest{Field: s[1:], Direction: SortAsc}
+	default:
+		return SortRequest{Field: s, Direction: SortAsc}
+	}
+}
+
+// String returns the sort expression in the form accepted by ParseSort
+func (s SortRequest) String() string {
+	if s.Direction == SortDesc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
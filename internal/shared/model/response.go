@@ -5,10 +5,11 @@ type CommonResponse struct {
 }
 
 type PaginationMetadata struct {
-	TotalItems int `json:"totalItems"`
-	TotalPages int `json:"totalPages"`
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
+	TotalItems int    `json:"totalItems"`
+	TotalPages int    `json:"totalPages"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type PaginatedResponse[T any] struct {
@@ -18,7 +19,13 @@ type PaginatedResponse[T any] struct {
 }
 
 func NewPaginatedResponse[T any](requestID string, data []T, totalItems int, meta PaginationMetadata) *PaginatedResponse[T] {
-	totalPages := (totalItems + meta.Limit - 1) / meta.Limit
+	totalPages := 0
+	if meta.Limit > 0 {
+		totalPages = (totalItems + meta.Limit - 1) / meta.Limit
+	}
+	if data == nil {
+		data = []T{}
+	}
 
 	return &PaginatedResponse[T]{
 		CommonResponse: CommonResponse{
@@ -29,6 +36,7 @@ func NewPaginatedResponse[T any](requestID string, data []T, totalItems int, met
 			TotalPages: totalPages,
 			Page:       meta.Page,
 			Limit:      meta.Limit,
+			NextCursor: meta.NextCursor,
 		},
 		Data: data,
 	}
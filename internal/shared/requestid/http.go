@@ -0,0 +1,83 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+)
+
+// HTTPMiddleware accepts the caller's X-Request-ID or generates one, stores
+// it in the request context and echoes it in the response header. Error
+// responses (status >= 400) also carry it in the body as "requestId".
+func HTTPMiddleware() func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+	return func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+		return func(c sharedctx.Context) error {
+			id := Resolve(c.GetHeader(Header))
+			c.SetHeader(Header, id)
+			return next(&requestContext{
+				Context: c,
+				ctx:     sharedctx.WithRequestID(c.GetContext(), id),
+				id:      id,
+			})
+		}
+	}
+}
+
+// requestContext hands the request ID to handlers through GetContext and adds
+// it to error bodies
+type requestContext struct {
+	sharedctx.Context
+	ctx context.Context
+	id  string
+}
+
+func (r *requestContext) GetContext() context.Context {
+	return r.ctx
+}
+
+func (r *requestContext) JSON(code int, v any) error {
+	if code >= http.StatusBadRequest {
+		v = withRequestID(v, r.id)
+	}
+	return r.Context.JSON(code, v)
+}
+
+// withRequestID adds the request ID to the error body shapes used by the
+// handlers; plain string messages become {"error": ..., "requestId": ...}
+func withRequestID(v any, id string) any {
+	switch body := v.(type) {
+	case sharederrors.Problem:
+		body.RequestID = id
+		return body
+	case sharederrors.ErrorResponse:
+		body.RequestID = id
+		return body
+	case *sharederrors.ErrorResponse:
+		if body == nil {
+			return v
+		}
+		copied := *body
+		copied.RequestID = id
+		return &copied
+	case string:
+		return map[string]string{"error": body, "requestId": id}
+	case map[string]string:
+		copied := make(map[string]string, len(body)+1)
+		for k, val := range body {
+			copied[k] = val
+		}
+		copied["requestId"] = id
+		return copied
+	case map[string]any:
+		copied := make(map[string]any, len(body)+1)
+		for k, val := range body {
+			copied[k] = val
+		}
+		copied["requestId"] = id
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,47 @@
+// Package requestid assigns every request an ID that follows it through
+// responses, logs, gRPC metadata, events and worker tasks.
+//
+// The ID lives in the context under sharedctx.RequestIDKey; a caller-supplied
+// ID is kept when it looks sane so IDs minted by a gateway or another service
+// correlate across systems.
+package requestid
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID in both directions
+	Header = "X-Request-ID"
+	// MetadataKey carries the request ID in gRPC metadata and message headers
+	MetadataKey = "x-request-id"
+	// MaxLength bounds accepted IDs so callers cannot bloat logs and headers
+	MaxLength = 128
+)
+
+// New generates a request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether id is non-empty, at most MaxLength long and made of
+// printable ASCII without spaces
+func Valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve returns id when it is valid, otherwise a new ID
+func Resolve(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
@@ -0,0 +1,142 @@
+package requestid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("req-123"))
+	assert.True(t, Valid("4bf92f35-77b3-4da6-a3ce-929d0e0e4736"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("has space"))
+	assert.False(t, Valid("line\nbreak"))
+	assert.False(t, Valid(strings.Repeat("a", MaxLength+1)))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "accepts caller ID", incoming: "req-123", keep: true},
+		{name: "generates when missing"},
+		{name: "replaces invalid ID", incoming: "bad id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			c := ctxmocks.NewMockContext(ctrl)
+			c.EXPECT().GetHeader(Header).Return(tt.incoming)
+			c.EXPECT().GetContext().Return(context.Background())
+
+			var sent string
+			c.EXPECT().SetHeader(Header, gomock.Any()).Do(func(_, value string) { sent = value })
+
+			var got string
+			err := HTTPMiddleware()(func(c sharedctx.Context) error {
+				got, _ = sharedctx.GetRequestID(c.GetContext())
+				return nil
+			})(c)
+
+			require.NoError(t, err)
+			require.True(t, Valid(got))
+			assert.Equal(t, got, sent)
+			if tt.keep {
+				assert.Equal(t, tt.incoming, got)
+			} else {
+				assert.NotEqual(t, tt.incoming, got)
+			}
+		})
+	}
+}
+
+func TestHTTPMiddleware_ErrorBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		body any
+		want any
+	}{
+		{
+			name: "string message",
+			code: http.StatusBadRequest,
+			body: "invalid id",
+			want: map[string]string{"error": "invalid id", "requestId": "req-123"},
+		},
+		{
+			name: "string map",
+			code: http.StatusUnauthorized,
+			body: map[string]string{"error": "missing token"},
+			want: map[string]string{"error": "missing token", "requestId": "req-123"},
+		},
+		{
+			name: "error response",
+			code: http.StatusNotFound,
+			body: sharederrors.ErrorResponse{Code: "NOT_FOUND", Message: "product not found"},
+			want: sharederrors.ErrorResponse{Code: "NOT_FOUND", Message: "product not found", RequestID: "req-123"},
+		},
+		{
+			name: "success body untouched",
+			code: http.StatusOK,
+			body: "ok",
+			want: "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			c := ctxmocks.NewMockContext(ctrl)
+			c.EXPECT().GetHeader(Header).Return("req-123")
+			c.EXPECT().GetContext().Return(context.Background())
+			c.EXPECT().SetHeader(Header, "req-123")
+			c.EXPECT().JSON(tt.code, tt.want).Return(nil)
+
+			err := HTTPMiddleware()(func(c sharedctx.Context) error {
+				return c.JSON(tt.code, tt.body)
+			})(c)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHTTPMiddleware_PassesHandlerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	c := ctxmocks.NewMockContext(ctrl)
+	c.EXPECT().GetHeader(Header).Return("")
+	c.EXPECT().GetContext().Return(context.Background())
+	c.EXPECT().SetHeader(Header, gomock.Any())
+
+	boom := errors.New("boom")
+	err := HTTPMiddleware()(func(c sharedctx.Context) error { return boom })(c)
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestTaskPayload_RoundTrip(t *testing.T) {
+	ctx := sharedctx.WithRequestID(context.Background(), "req-123")
+
+	payload := sharedworker.TaskPayload{"user_id": "u-1"}
+	injected := InjectTaskPayload(ctx, payload)
+	assert.NotContains(t, payload, TaskPayloadKey, "caller payload must not be modified")
+	assert.Equal(t, "req-123", injected[TaskPayloadKey])
+
+	got := ExtractTaskPayload(context.Background(), injected)
+	assert.NotContains(t, injected, TaskPayloadKey)
+	assert.Equal(t, "req-123", FromContext(got))
+
+	assert.Equal(t, payload, InjectTaskPayload(context.Background(), payload))
+}
@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+)
+
+// TaskPayloadKey is the reserved payload entry carrying the request ID for
+// queues without message headers. Servers remove it before calling handlers.
+const TaskPayloadKey = "_request_id"
+
+// InjectTaskPayload returns a copy of payload carrying the request ID of ctx,
+// or payload itself when ctx carries none
+func InjectTaskPayload(ctx context.Context, payload sharedworker.TaskPayload) sharedworker.TaskPayload {
+	id, ok := sharedctx.GetRequestID(ctx)
+	if !ok || id == "" {
+		return payload
+	}
+	out := make(sharedworker.TaskPayload, len(payload)+1)
+	for k, v := range payload {
+		out[k] = v
+	}
+	out[TaskPayloadKey] = id
+	return out
+}
+
+// ExtractTaskPayload stores the request ID found in payload in ctx and
+// removes the reserved entry from payload
+func ExtractTaskPayload(ctx context.Context, payload sharedworker.TaskPayload) context.Context {
+	raw, ok := payload[TaskPayloadKey]
+	if !ok {
+		return ctx
+	}
+	delete(payload, TaskPayloadKey)
+	if id, ok := raw.(string); ok && Valid(id) {
+		return sharedctx.WithRequestID(ctx, id)
+	}
+	return ctx
+}
+
+// FromContext returns the request ID of ctx, or "" when it carries none
+func FromContext(ctx context.Context) string {
+	id, _ := sharedctx.GetRequestID(ctx)
+	return id
+}
@@ -0,0 +1,171 @@
+// Package sqldialect hides the syntax differences between the SQL databases
+// the repositories support. Queries are written with ? placeholders and
+// rebound for the database they run on.
+package sqldialect
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Dialect names a supported SQL database; the values match the repository
+// feature flags
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+)
+
+// Parse returns the dialect called name, or an error for anything else such
+// as "mongo" or "disable"
+func Parse(name string) (Dialect, error) {
+	switch d := Dialect(name); d {
+	case Postgres, MySQL, SQLite:
+		return d, nil
+	}
+	return "", fmt.Errorf("unsupported SQL dialect: %q", name)
+}
+
+// Of returns the dialect of db from the driver name it was opened with; a nil
+// db is taken for Postgres
+func Of(db *sqlx.DB) Dialect {
+	if db == nil {
+		return Postgres
+	}
+	switch db.DriverName() {
+	case "mysql":
+		return MySQL
+	case "sqlite3":
+		return SQLite
+	default:
+		return Postgres
+	}
+}
+
+// DriverName returns the database/sql driver name registered for d
+func (d Dialect) DriverName() string {
+	if d == SQLite {
+		return "sqlite3"
+	}
+	return string(d)
+}
+
+// GooseDialect returns the name goose uses for d
+func (d Dialect) GooseDialect() string {
+	if d == SQLite {
+		return "sqlite3"
+	}
+	return string(d)
+}
+
+// ILike returns a case-insensitive LIKE of column against placeholder, with
+// backslash as the escape character. MySQL's default collations and SQLite's
+// LIKE already ignore case.
+func (d Dialect) ILike(column, placeholder string) string {
+	switch d {
+	case MySQL:
+		return column + " LIKE " + placeholder + ` ESCAPE '\\'`
+	case SQLite:
+		return column + " LIKE " + placeholder + ` ESCAPE '\'`
+	default:
+		return column + " ILIKE " + placeholder + ` ESCAPE '\'`
+	}
+}
+
+// Upsert turns insert into an insert that resolves a conflict on the unique
+// conflict columns by applying updates. An update is either a column, which
+// takes the inserted value, or "column=source" to take the inserted value of
+// source. Without updates the conflicting row is left unchanged.
+func (d Dialect) Upsert(insert string, conflict []string, updates ...string) string {
+	if d == MySQL {
+		// MySQL resolves conflicts on any unique key
+		if len(updates) == 0 {
+			return strings.Replace(insert, "INSERT INTO", "INSERT IGNORE INTO", 1)
+		}
+		sets := make([]string, len(updates))
+		for i, u := range updates {
+			column, source := splitUpdate(u)
+			sets[i] = fmt.Sprintf("%s = VALUES(%s)", column, source)
+		}
+		return insert + " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+
+	target := "(" + strings.Join(conflict, ", ") + ")"
+	if len(updates) == 0 {
+		return insert + " ON CONFLICT " + target + " DO NOTHING"
+	}
+	sets := make([]string, len(updates))
+	for i, u := range updates {
+		column, source := splitUpdate(u)
+		sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", column, source)
+	}
+	return insert + " ON CONFLICT " + target + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
+
+func splitUpdate(u string) (column, source string) {
+	if column, source, ok := strings.Cut(u, "="); ok {
+		return strings.TrimSpace(column), strings.TrimSpace(source)
+	}
+	return u, u
+}
+
+// Array returns v as a query argument for a string list column: a text[]
+// on Postgres, JSON text elsewhere
+func (d Dialect) Array(v []string) driver.Valuer {
+	if d == Postgres {
+		return pq.StringArray(v)
+	}
+	return jsonArray(v)
+}
+
+type jsonArray []string
+
+func (a jsonArray) Value() (driver.Value, error) {
+	if a == nil {
+		a = jsonArray{}
+	}
+	b, err := json.Marshal([]string(a))
+	return string(b), err
+}
+
+// StringArray scans a string list column written with Dialect.Array, either a
+// Postgres text[] or a JSON array
+type StringArray []string
+
+func (a *StringArray) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("sqldialect: cannot scan %T into StringArray", src)
+	}
+
+	if strings.HasPrefix(s, "{") {
+		var arr pq.StringArray
+		if err := arr.Scan(s); err != nil {
+			return err
+		}
+		*a = StringArray(arr)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal([]byte(s), &list); err != nil {
+		return errors.Join(errors.New("sqldialect: invalid string list"), err)
+	}
+	*a = list
+	return nil
+}
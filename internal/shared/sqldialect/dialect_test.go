@@ -0,0 +1,89 @@
+package sqldialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, name := range []string{"postgres", "mysql", "sqlite"} {
+		d, err := Parse(name)
+		require.NoError(t, err)
+		assert.Equal(t, Dialect(name), d)
+	}
+	_, err := Parse("mongo")
+	assert.Error(t, err)
+}
+
+func TestDriverName(t *testing.T) {
+	assert.Equal(t, "postgres", Postgres.DriverName())
+	assert.Equal(t, "mysql", MySQL.DriverName())
+	assert.Equal(t, "sqlite3", SQLite.DriverName())
+}
+
+func TestILike(t *testing.T) {
+	assert.Equal(t, `name ILIKE ? ESCAPE '\'`, Postgres.ILike("name", "?"))
+	assert.Equal(t, `name LIKE ? ESCAPE '\\'`, MySQL.ILike("name", "?"))
+	assert.Equal(t, `name LIKE ? ESCAPE '\'`, SQLite.ILike("name", "?"))
+}
+
+func TestUpsert(t *testing.T) {
+	insert := `INSERT INTO t (id, a, created_at) VALUES (?, ?, ?)`
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		updates []string
+		want    string
+	}{
+		{"postgres nothing", Postgres, nil, insert + ` ON CONFLICT (id) DO NOTHING`},
+		{"sqlite nothing", SQLite, nil, insert + ` ON CONFLICT (id) DO NOTHING`},
+		{"mysql nothing", MySQL, nil, `INSERT IGNORE INTO t (id, a, created_at) VALUES (?, ?, ?)`},
+		{
+			"postgres update", Postgres, []string{"a", "updated_at=created_at"},
+			insert + ` ON CONFLICT (id) DO UPDATE SET a = EXCLUDED.a, updated_at = EXCLUDED.created_at`,
+		},
+		{
+			"mysql update", MySQL, []string{"a", "updated_at=created_at"},
+			insert + ` ON DUPLICATE KEY UPDATE a = VALUES(a), updated_at = VALUES(created_at)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dialect.Upsert(insert, []string{"id"}, tt.updates...))
+		})
+	}
+}
+
+func TestStringArray(t *testing.T) {
+	t.Run("postgres round trip", func(t *testing.T) {
+		v, err := Postgres.Array([]string{"a", "b c"}).Value()
+		require.NoError(t, err)
+		var got StringArray
+		require.NoError(t, got.Scan(v))
+		assert.Equal(t, StringArray{"a", "b c"}, got)
+	})
+
+	t.Run("json round trip", func(t *testing.T) {
+		v, err := SQLite.Array([]string{"a", "b"}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, `["a","b"]`, v)
+		var got StringArray
+		require.NoError(t, got.Scan([]byte(v.(string))))
+		assert.Equal(t, StringArray{"a", "b"}, got)
+	})
+
+	t.Run("nil list is empty", func(t *testing.T) {
+		v, err := MySQL.Array(nil).Value()
+		require.NoError(t, err)
+		assert.Equal(t, `[]`, v)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var got StringArray
+		assert.Error(t, got.Scan("not a list"))
+		assert.Error(t, got.Scan(42))
+	})
+}
@@ -0,0 +1,46 @@
+// Package sqltest opens throwaway SQLite databases with the SQLite migrations
+// applied, so repository tests run without an external database.
+package sqltest
+
+import (
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+)
+
+// gooseMu guards goose's package-level dialect and logger
+var gooseMu sync.Mutex
+
+// MigrationsDir returns the directory of the SQLite migrations
+func MigrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "infrastructure", "db", "sql", "migration", "sqlite")
+}
+
+// OpenSQLite returns a migrated SQLite database in a temporary file that is
+// removed when t ends
+func OpenSQLite(t testing.TB) *sqlx.DB {
+	t.Helper()
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL"
+	db, err := sqlx.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+	goose.SetLogger(goose.NopLogger())
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		t.Fatalf("set goose dialect: %v", err)
+	}
+	if err := goose.Up(db.DB, MigrationsDir()); err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,16 @@
+package sqltest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenSQLite(t *testing.T) {
+	db := OpenSQLite(t)
+
+	var roles []string
+	require.NoError(t, db.Select(&roles, `SELECT name FROM auth_roles ORDER BY name`))
+	assert.Equal(t, []string{"admin", "user"}, roles, "the built-in roles are seeded")
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/email"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedEmail opens a client span around every send; recipients are counted
+// rather than recorded to keep addresses out of traces
+type tracedEmail struct {
+	email.EmailService
+	system string
+}
+
+// InstrumentEmail returns s creating a child span per send, labelled with the
+// provider name in system (smtp, mailgun)
+func InstrumentEmail(s email.EmailService, system string) email.EmailService {
+	if s == nil {
+		return s
+	}
+	return &tracedEmail{EmailService: s, system: system}
+}
+
+func (s *tracedEmail) start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("email.system", s.system))
+	return Start(ctx, "email "+op, trace.SpanKindClient, attrs...)
+}
+
+func (s *tracedEmail) Send(ctx context.Context, msg *email.Email) (err error) {
+	recipients := 0
+	if msg != nil {
+		recipients = len(msg.To) + len(msg.CC) + len(msg.BCC)
+	}
+	ctx, span := s.start(ctx, "Send", attribute.Int("email.recipients", recipients))
+	defer func() { End(span, err) }()
+	return s.EmailService.Send(ctx, msg)
+}
+
+func (s *tracedEmail) SendBatch(ctx context.Context, emails []*email.Email) (err error) {
+	ctx, span := s.start(ctx, "SendBatch", attribute.Int("email.messages", len(emails)))
+	defer func() { End(span, err) }()
+	return s.EmailService.SendBatch(ctx, emails)
+}
+
+func (s *tracedEmail) SendTemplate(ctx context.Context, to []string, templateID string, data map[string]interface{}) (err error) {
+	ctx, span := s.start(ctx, "SendTemplate",
+		attribute.Int("email.recipients", len(to)),
+		attribute.String("email.template", templateID),
+	)
+	defer func() { End(span, err) }()
+	return s.EmailService.SendTemplate(ctx, to, templateID, data)
+}
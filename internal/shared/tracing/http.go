@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPMiddleware opens a server span per request of one route, continuing the
+// trace of the incoming traceparent header. Handlers see the span through
+// GetContext, so everything they call becomes part of the request trace.
+func HTTPMiddleware(method, route string) func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+	return func(next func(sharedctx.Context) error) func(sharedctx.Context) error {
+		return func(c sharedctx.Context) error {
+			parent := otel.GetTextMapPropagator().Extract(c.GetContext(), headerCarrier{c})
+			ctx, span := Start(parent, method+" "+route, trace.SpanKindServer,
+				attribute.String("http.request.method", method),
+				attribute.String("http.route", route),
+			)
+			defer span.End()
+
+			traced := &tracedContext{Context: c, ctx: ctx}
+			err := next(traced)
+
+			status := traced.status
+			if status == 0 && err != nil {
+				status = http.StatusInternalServerError
+			}
+			if status != 0 {
+				span.SetAttributes(attribute.Int("http.response.status_code", status))
+			}
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			case status >= http.StatusInternalServerError:
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return err
+		}
+	}
+}
+
+// tracedContext hands the span context to handlers and remembers the status
+// code written through JSON
+type tracedContext struct {
+	sharedctx.Context
+	ctx    context.Context
+	status int
+}
+
+func (t *tracedContext) GetContext() context.Context {
+	return t.ctx
+}
+
+func (t *tracedContext) JSON(code int, v any) error {
+	if t.status == 0 {
+		t.status = code
+	}
+	return t.Context.JSON(code, v)
+}
+
+// headerCarrier reads propagation headers from the request; it never writes
+type headerCarrier struct {
+	c sharedctx.Context
+}
+
+func (h headerCarrier) Get(key string) string { return h.c.GetHeader(key) }
+func (h headerCarrier) Set(string, string)    {}
+func (h headerCarrier) Keys() []string        { return nil }
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/storage"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedStorage opens a client span around every storage call except Health
+type tracedStorage struct {
+	storage.StorageService
+	system string
+}
+
+// InstrumentStorage returns s creating a child span per call, labelled with
+// the backend name in system (s3, gcs, local)
+func InstrumentStorage(s storage.StorageService, system string) storage.StorageService {
+	if s == nil {
+		return s
+	}
+	return &tracedStorage{StorageService: s, system: system}
+}
+
+func (s *tracedStorage) start(ctx context.Context, op, path string) (context.Context, trace.Span) {
+	return Start(ctx, "storage "+op, trace.SpanKindClient,
+		attribute.String("storage.system", s.system),
+		attribute.String("storage.operation", op),
+		attribute.String("storage.path", path),
+	)
+}
+
+func (s *tracedStorage) Upload(ctx context.Context, path string, reader io.Reader, opts *storage.UploadOptions) (obj *storage.StorageObject, err error) {
+	ctx, span := s.start(ctx, "Upload", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.Upload(ctx, path, reader, opts)
+}
+
+func (s *tracedStorage) UploadBytes(ctx context.Context, path string, data []byte, opts *storage.UploadOptions) (obj *storage.StorageObject, err error) {
+	ctx, span := s.start(ctx, "UploadBytes", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.UploadBytes(ctx, path, data, opts)
+}
+
+func (s *tracedStorage) Download(ctx context.Context, path string) (rc io.ReadCloser, err error) {
+	ctx, span := s.start(ctx, "Download", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.Download(ctx, path)
+}
+
+func (s *tracedStorage) GetBytes(ctx context.Context, path string) (data []byte, err error) {
+	ctx, span := s.start(ctx, "GetBytes", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.GetBytes(ctx, path)
+}
+
+func (s *tracedStorage) GetObject(ctx context.Context, path string) (obj *storage.StorageObject, err error) {
+	ctx, span := s.start(ctx, "GetObject", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.GetObject(ctx, path)
+}
+
+func (s *tracedStorage) Delete(ctx context.Context, path string) (err error) {
+	ctx, span := s.start(ctx, "Delete", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.Delete(ctx, path)
+}
+
+func (s *tracedStorage) DeletePrefix(ctx context.Context, prefix string) (err error) {
+	ctx, span := s.start(ctx, "DeletePrefix", prefix)
+	defer func() { End(span, err) }()
+	return s.StorageService.DeletePrefix(ctx, prefix)
+}
+
+func (s *tracedStorage) Exists(ctx context.Context, path string) (ok bool, err error) {
+	ctx, span := s.start(ctx, "Exists", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.Exists(ctx, path)
+}
+
+func (s *tracedStorage) ListObjects(ctx context.Context, prefix string, recursive bool) (objs []*storage.StorageObject, err error) {
+	ctx, span := s.start(ctx, "ListObjects", prefix)
+	defer func() { End(span, err) }()
+	return s.StorageService.ListObjects(ctx, prefix, recursive)
+}
+
+func (s *tracedStorage) GetPresignedURL(ctx context.Context, path string, expiration time.Duration) (url string, err error) {
+	ctx, span := s.start(ctx, "GetPresignedURL", path)
+	defer func() { End(span, err) }()
+	return s.StorageService.GetPresignedURL(ctx, path, expiration)
+}
+
+func (s *tracedStorage) Copy(ctx context.Context, sourcePath, destPath string) (obj *storage.StorageObject, err error) {
+	ctx, span := s.start(ctx, "Copy", sourcePath)
+	span.SetAttributes(attribute.String("storage.destination", destPath))
+	defer func() { End(span, err) }()
+	return s.StorageService.Copy(ctx, sourcePath, destPath)
+}
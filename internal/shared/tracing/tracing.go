@@ -0,0 +1,92 @@
+// Package tracing creates OpenTelemetry spans and carries trace context across
+// HTTP, gRPC, the event bus and worker queues.
+//
+// Spans go through the global tracer provider and propagator, which are no-ops
+// until the exporter is set up at startup, so instrumented code costs next to
+// nothing while tracing is disabled.
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the tracer used by this application
+const InstrumentationName = "github.com/kamil5b/go-pste-monolith"
+
+// Tracer returns the application tracer from the global provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Start opens a span of the given kind as a child of the span in ctx
+func Start(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// End records err on span, marking it failed, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Carrier holds propagated trace context as header-like key/value pairs. It is
+// embedded in event envelopes, outbox rows and task payloads; in SQL it is
+// stored as JSON.
+type Carrier map[string]string
+
+// Inject returns the trace context of ctx, or nil when ctx carries none
+func Inject(ctx context.Context) Carrier {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return Carrier(carrier)
+}
+
+// Extract returns ctx continuing the trace recorded in c
+func Extract(ctx context.Context, c Carrier) context.Context {
+	if len(c) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(c))
+}
+
+// Value implements driver.Valuer; an empty carrier is stored as NULL
+func (c Carrier) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (c *Carrier) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("tracing: cannot scan %T into Carrier", src)
+	}
+}
@@ -0,0 +1,169 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	ctxmocks "github.com/kamil5b/go-pste-monolith/internal/shared/context/mocks"
+	emailmocks "github.com/kamil5b/go-pste-monolith/internal/shared/email/mocks"
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newRecorder installs a recording tracer provider and the W3C propagator
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestInjectExtract(t *testing.T) {
+	newRecorder(t)
+
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx, span := Start(context.Background(), "publish", trace.SpanKindProducer)
+	defer span.End()
+	carrier := Inject(ctx)
+	require.Contains(t, carrier, "traceparent")
+
+	remote := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+	assert.True(t, remote.IsRemote())
+}
+
+func TestCarrier_ValueScan(t *testing.T) {
+	value, err := Carrier(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = Carrier{"traceparent": testTraceparent}.Value()
+	require.NoError(t, err)
+
+	var fromString, fromBytes, fromNull Carrier
+	require.NoError(t, fromString.Scan(value))
+	require.NoError(t, fromBytes.Scan([]byte(value.(string))))
+	require.NoError(t, fromNull.Scan(nil))
+	assert.Equal(t, testTraceparent, fromString["traceparent"])
+	assert.Equal(t, testTraceparent, fromBytes["traceparent"])
+	assert.Nil(t, fromNull)
+	assert.Error(t, fromNull.Scan(42))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    func(c sharedctx.Context) error
+		wantStatus int
+		wantError  bool
+	}{
+		{
+			name:       "success",
+			handler:    func(c sharedctx.Context) error { return c.JSON(http.StatusCreated, nil) },
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "client error is not a span error",
+			handler:    func(c sharedctx.Context) error { return c.JSON(http.StatusNotFound, nil) },
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "server error",
+			handler:    func(c sharedctx.Context) error { return c.JSON(http.StatusServiceUnavailable, nil) },
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  true,
+		},
+		{
+			name:       "error without response",
+			handler:    func(c sharedctx.Context) error { return errors.New("boom") },
+			wantStatus: http.StatusInternalServerError,
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newRecorder(t)
+			ctrl := gomock.NewController(t)
+			c := ctxmocks.NewMockContext(ctrl)
+			c.EXPECT().GetContext().Return(context.Background())
+			c.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(key string) string {
+				if key == "traceparent" {
+					return testTraceparent
+				}
+				return ""
+			}).AnyTimes()
+			c.EXPECT().JSON(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			var handlerSpan trace.SpanContext
+			handler := func(c sharedctx.Context) error {
+				handlerSpan = trace.SpanContextFromContext(c.GetContext())
+				return tt.handler(c)
+			}
+			_ = HTTPMiddleware(http.MethodPost, "/v1/product")(handler)(c)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "POST /v1/product", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Parent().TraceID().String())
+			assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+			assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", tt.wantStatus))
+			assert.Equal(t, tt.wantError, span.Status().Code == codes.Error)
+		})
+	}
+}
+
+func TestTaskPayload_RoundTrip(t *testing.T) {
+	newRecorder(t)
+	ctx, span := Start(context.Background(), "send", trace.SpanKindProducer)
+	defer span.End()
+
+	payload := sharedworker.TaskPayload{"user_id": "u-1"}
+	injected := InjectTaskPayload(ctx, payload)
+	assert.NotContains(t, payload, TaskPayloadKey, "caller payload must not be modified")
+	require.Contains(t, injected, TaskPayloadKey)
+
+	// Simulate the JSON round trip through the queue
+	injected[TaskPayloadKey] = map[string]interface{}{"traceparent": Inject(ctx)["traceparent"]}
+	got := ExtractTaskPayload(context.Background(), injected)
+
+	assert.NotContains(t, injected, TaskPayloadKey)
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(got).TraceID())
+}
+
+func TestInstrumentEmail(t *testing.T) {
+	recorder := newRecorder(t)
+	ctrl := gomock.NewController(t)
+	inner := emailmocks.NewMockEmailService(ctrl)
+	inner.EXPECT().SendTemplate(gomock.Any(), []string{"a@example.com"}, "welcome", gomock.Any()).Return(errors.New("smtp down"))
+
+	ctx, parent := Start(context.Background(), "request", trace.SpanKindServer)
+	err := InstrumentEmail(inner, "smtp").SendTemplate(ctx, []string{"a@example.com"}, "welcome", nil)
+	parent.End()
+
+	require.Error(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "email SendTemplate", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
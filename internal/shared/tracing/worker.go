@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+
+	sharedworker "github.com/kamil5b/go-pste-monolith/internal/shared/worker"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskPayloadKey is the reserved payload entry carrying trace context for
+// queues without message headers. Servers remove it before calling handlers.
+const TaskPayloadKey = "_trace_context"
+
+// InjectTaskPayload returns a copy of payload carrying the trace context of
+// ctx, or payload itself when ctx carries none
+func InjectTaskPayload(ctx context.Context, payload sharedworker.TaskPayload) sharedworker.TaskPayload {
+	carrier := Inject(ctx)
+	if carrier == nil {
+		return payload
+	}
+	out := make(sharedworker.TaskPayload, len(payload)+1)
+	for k, v := range payload {
+		out[k] = v
+	}
+	out[TaskPayloadKey] = map[string]string(carrier)
+	return out
+}
+
+// ExtractTaskPayload continues the trace stored in payload and removes the
+// reserved entry from it
+func ExtractTaskPayload(ctx context.Context, payload sharedworker.TaskPayload) context.Context {
+	raw, ok := payload[TaskPayloadKey]
+	if !ok {
+		return ctx
+	}
+	delete(payload, TaskPayloadKey)
+
+	carrier := Carrier{}
+	switch v := raw.(type) {
+	case map[string]string:
+		carrier = v
+	case map[string]interface{}:
+		for k, value := range v {
+			if s, ok := value.(string); ok {
+				carrier[k] = s
+			}
+		}
+	}
+	return Extract(ctx, carrier)
+}
+
+// StartTask opens a consumer span for one run of a task
+func StartTask(ctx context.Context, backend, taskName string) (context.Context, trace.Span) {
+	return Start(ctx, "process "+taskName, trace.SpanKindConsumer,
+		attribute.String("messaging.system", backend),
+		attribute.String("messaging.operation.type", "process"),
+		attribute.String("messaging.destination.name", taskName),
+	)
+}
+
+// StartEnqueue opens a producer span for enqueueing a task
+func StartEnqueue(ctx context.Context, backend, taskName string) (context.Context, trace.Span) {
+	return Start(ctx, "send "+taskName, trace.SpanKindProducer,
+		attribute.String("messaging.system", backend),
+		attribute.String("messaging.operation.type", "send"),
+		attribute.String("messaging.destination.name", taskName),
+	)
+}
@@ -49,11 +49,12 @@ func (mr *MockUnitOfWorkMockRecorder) DeferErrorContext(ctx, err interface{}) *g
 }
 
 // StartContext mocks base method.
-func (m *MockUnitOfWork) StartContext(ctx context.Context) context.Context {
+func (m *MockUnitOfWork) StartContext(ctx context.Context) (context.Context, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "StartContext", ctx)
 	ret0, _ := ret[0].(context.Context)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // StartContext indicates an expected call of StartContext.
@@ -61,3 +62,17 @@ func (mr *MockUnitOfWorkMockRecorder) StartContext(ctx interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartContext", reflect.TypeOf((*MockUnitOfWork)(nil).StartContext), ctx)
 }
+
+// WithinTransaction mocks base method.
+func (m *MockUnitOfWork) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockUnitOfWorkMockRecorder) WithinTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockUnitOfWork)(nil).WithinTransaction), ctx, fn)
+}
@@ -1,14 +1,32 @@
 package uow
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrRollbackOnly is returned when committing a transaction that a nested
+// unit of work failed in and that cannot be partially rolled back, such as a
+// MongoDB transaction
+var ErrRollbackOnly = errors.New("transaction was marked rollback-only by a nested unit of work")
 
 // UnitOfWork defines the interface for managing transactions
 // This abstraction allows services to work with any database (SQL, MongoDB, etc.)
 type UnitOfWork interface {
-	// StartContext begins a new transaction and returns a context containing it
-	StartContext(ctx context.Context) context.Context
+	// StartContext begins a new transaction and returns a context containing it.
+	// When ctx already carries a transaction the new unit of work is nested in
+	// it, as a savepoint where the database supports one. On error the
+	// returned context is ctx and there is nothing to finish.
+	StartContext(ctx context.Context) (context.Context, error)
 
 	// DeferErrorContext commits or rolls back based on the error
-	// If err is nil, commits the transaction; otherwise rolls back
+	// If err is nil, commits the transaction; otherwise rolls back.
+	// A nested unit of work releases or rolls back to its savepoint.
 	DeferErrorContext(ctx context.Context, err error) error
+
+	// WithinTransaction runs fn in a unit of work, committing when fn returns
+	// nil and rolling back otherwise. An outermost transaction that fails with
+	// a serialization failure, deadlock or transient transaction error is
+	// rolled back and fn is run again, so fn must be safe to repeat.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
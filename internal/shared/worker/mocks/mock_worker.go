@@ -111,6 +111,20 @@ func (mr *MockClientMockRecorder) EnqueueDelayed(ctx, taskName, payload, delay i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDelayed", reflect.TypeOf((*MockClient)(nil).EnqueueDelayed), varargs...)
 }
 
+// Health mocks base method.
+func (m *MockClient) Health(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockClientMockRecorder) Health(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockClient)(nil).Health), ctx)
+}
+
 // MockServer is a mock of Server interface.
 type MockServer struct {
 	ctrl     *gomock.Controller
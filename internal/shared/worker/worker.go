@@ -74,6 +74,9 @@ type Client interface {
 
 	// Close closes the client connection
 	Close() error
+
+	// Health checks if the queue backend is reachable
+	Health(ctx context.Context) error
 }
 
 // Server is responsible for processing tasks from the queue
@@ -44,6 +44,12 @@ func (g *GRPCContext) JSON(code int, v any) error {
 // Param (path param) is not available for gRPC
 func (g *GRPCContext) Param(name string) string { return "" }
 
+// QueryParam (query string) is not available for gRPC
+func (g *GRPCContext) QueryParam(name string) string { return "" }
+
+// FormValue (request body field) is not available for gRPC
+func (g *GRPCContext) FormValue(name string) string { return "" }
+
 // GetUserID attempts to read a user id set in values, else empty string.
 func (g *GRPCContext) GetUserID() string {
 	if v, ok := g.vals["user_id"]; ok {
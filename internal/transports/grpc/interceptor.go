@@ -0,0 +1,37 @@
+package grpctransport
+
+import (
+	"context"
+
+	sharederrors "github.com/kamil5b/go-pste-monolith/internal/shared/errors"
+	grpc "google.golang.org/grpc"
+)
+
+// UnaryErrorInterceptor converts errors returned by handlers, typically
+// sharederrors.DomainError, into gRPC status errors with a matching code.
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, sharederrors.GRPCStatus(err)
+	}
+}
+
+// StreamErrorInterceptor is the streaming counterpart of UnaryErrorInterceptor.
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return sharederrors.GRPCStatus(handler(srv, ss))
+	}
+}
+
+// WrapServerStream returns ss with its context replaced, so stream
+// interceptors can pass values such as the authenticated user to handlers.
+func WrapServerStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context { return w.ctx }
@@ -0,0 +1,45 @@
+package grpctransport
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UserAgent returns the caller's user-agent metadata, which gRPC clients
+// prefix to their own "grpc-go/x.y" agent string.
+func UserAgent(ctx context.Context) string {
+	return firstMetadata(ctx, "user-agent")
+}
+
+// ClientIP returns the first x-forwarded-for address set by a proxy, else the
+// host of the peer connection.
+func ClientIP(ctx context.Context) string {
+	if forwarded := firstMetadata(ctx, "x-forwarded-for"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package grpctransport
+
+import (
+	"context"
+	"time"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryMetricsInterceptor records each call by method and status code. Put it
+// first in the chain so it sees the code produced by the error interceptor.
+func UnaryMetricsInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.ObserveGRPCCall(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor is the streaming counterpart of UnaryMetricsInterceptor;
+// the duration covers the whole stream.
+func StreamMetricsInterceptor(m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.ObserveGRPCCall(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,32 @@
+package grpctransport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryMetricsInterceptor(t *testing.T) {
+	m := metrics.New("")
+	interceptor := UnaryMetricsInterceptor(m)
+	info := &grpc.UnaryServerInfo{FullMethod: "/product.v1.ProductService/Get"}
+
+	_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	count, err := testutil.GatherAndCount(m.Registry(), "app_grpc_requests_total")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count) // one series each for OK and NotFound
+}
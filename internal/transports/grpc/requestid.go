@@ -0,0 +1,59 @@
+package grpctransport
+
+import (
+	"context"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/kamil5b/go-pste-monolith/internal/shared/requestid"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryRequestIDInterceptor accepts the caller's x-request-id metadata or
+// generates one, stores it in the context and returns it in the response
+// header metadata. Put it first in the chain so every later log line sees it.
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := requestid.Resolve(firstMetadata(ctx, requestid.MetadataKey))
+		// SetHeader only fails once headers are sent, which cannot happen yet
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		return handler(sharedctx.WithRequestID(ctx, id), req)
+	}
+}
+
+// StreamRequestIDInterceptor is the streaming counterpart of
+// UnaryRequestIDInterceptor.
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestid.Resolve(firstMetadata(ss.Context(), requestid.MetadataKey))
+		_ = ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+		return handler(srv, WrapServerStream(ss, sharedctx.WithRequestID(ss.Context(), id)))
+	}
+}
+
+// UnaryClientRequestIDInterceptor forwards the request ID of the calling
+// context as x-request-id metadata, so downstream services log the same ID.
+func UnaryClientRequestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestIDInterceptor is the streaming counterpart of
+// UnaryClientRequestIDInterceptor.
+func StreamClientRequestIDInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func outgoingRequestID(ctx context.Context) context.Context {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(requestid.MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+}
@@ -0,0 +1,48 @@
+package grpctransport
+
+import (
+	"context"
+	"testing"
+
+	sharedctx "github.com/kamil5b/go-pste-monolith/internal/shared/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryRequestIDInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/product.v1.ProductService/Get"}
+	run := func(ctx context.Context) string {
+		var got string
+		_, err := UnaryRequestIDInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			got, _ = sharedctx.GetRequestID(ctx)
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return got
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-123"))
+	assert.Equal(t, "req-123", run(incoming))
+
+	invalid := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "has spaces"))
+	assert.NotEqual(t, "has spaces", run(invalid))
+	assert.NotEmpty(t, run(context.Background()))
+}
+
+func TestUnaryClientRequestIDInterceptor(t *testing.T) {
+	var got []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get("x-request-id")
+		return nil
+	}
+
+	ctx := sharedctx.WithRequestID(context.Background(), "req-123")
+	require.NoError(t, UnaryClientRequestIDInterceptor()(ctx, "/user.v1.UserService/Get", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"req-123"}, got)
+
+	require.NoError(t, UnaryClientRequestIDInterceptor()(context.Background(), "/user.v1.UserService/Get", nil, nil, nil, invoker))
+	assert.Empty(t, got)
+}
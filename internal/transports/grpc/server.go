@@ -7,21 +7,37 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
+// defaultHealthCheckInterval is used when EnableHealth gets no interval
+const defaultHealthCheckInterval = 10 * time.Second
+
 // ServiceRegistrar is a function that receives a *grpc.Server and registers
 // generated gRPC services (pb.RegisterXxxServer). Modules provide these
 // functions when wiring the container.
 type ServiceRegistrar func(s *grpc.Server)
 
+// HealthCheckFunc reports whether the server's dependencies are usable
+type HealthCheckFunc func(ctx context.Context) error
+
 // Server is a small wrapper around a gRPC server which allows registering
 // service registration callbacks and starting/stopping the server.
 type Server struct {
 	srv        *grpc.Server
 	registrars []ServiceRegistrar
 	mu         sync.Mutex
+
+	health              *health.Server
+	healthCheck         HealthCheckFunc
+	healthCheckInterval time.Duration
+	reflection          bool
+	shutdownTimeout     time.Duration
 }
 
 // NewServer creates a new Server with optional grpc.ServerOptions.
@@ -37,6 +53,30 @@ func (s *Server) RegisterService(r ServiceRegistrar) {
 	s.registrars = append(s.registrars, r)
 }
 
+// EnableHealth serves grpc.health.v1. While running, check is polled every
+// interval and every registered service, plus the overall "" service, is
+// reported SERVING or NOT_SERVING accordingly. Call this before Start.
+func (s *Server) EnableHealth(check HealthCheckFunc, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	s.health = health.NewServer()
+	s.healthCheck = check
+	s.healthCheckInterval = interval
+}
+
+// EnableReflection serves the gRPC reflection API used by tools such as
+// grpcurl to list services. Call this before Start.
+func (s *Server) EnableReflection() {
+	s.reflection = true
+}
+
+// SetShutdownTimeout bounds how long a graceful stop waits for in-flight
+// calls before the remaining connections are closed; zero waits forever.
+func (s *Server) SetShutdownTimeout(timeout time.Duration) {
+	s.shutdownTimeout = timeout
+}
+
 // Start binds to the given address (host:port) and runs the server until
 // the context is cancelled or an interrupt signal is received.
 // It returns any non-nil error from the listener or server.
@@ -45,13 +85,29 @@ func (s *Server) Start(ctx context.Context, addr string) error {
 	if err != nil {
 		return err
 	}
+	return s.Serve(ctx, lis)
+}
 
+// Serve is Start on an existing listener.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
 	// Register services
 	s.mu.Lock()
 	for _, r := range s.registrars {
 		r(s.srv)
 	}
 	s.mu.Unlock()
+	if s.health != nil {
+		healthpb.RegisterHealthServer(s.srv, s.health)
+	}
+	if s.reflection {
+		reflection.Register(s.srv)
+	}
+
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+	if s.health != nil {
+		go s.watchHealth(healthCtx)
+	}
 
 	// Run server in goroutine
 	serveErr := make(chan error, 1)
@@ -62,21 +118,78 @@ func (s *Server) Start(ctx context.Context, addr string) error {
 	// Watch for context cancellation or OS interrupt
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
 
 	select {
 	case <-ctx.Done():
-		s.srv.GracefulStop()
+		s.Stop()
 		return ctx.Err()
-	case sig := <-sigCh:
-		_ = sig
-		s.srv.GracefulStop()
+	case <-sigCh:
+		s.Stop()
 		return nil
 	case err := <-serveErr:
 		return err
 	}
 }
 
-// Stop gracefully stops the server.
+// Stop drains the server: health checks report NOT_SERVING so load balancers
+// stop routing new calls, then in-flight calls get up to the shutdown timeout
+// to finish before the server is stopped forcefully.
 func (s *Server) Stop() {
-	s.srv.GracefulStop()
+	if s.health != nil {
+		s.health.Shutdown()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+
+	if s.shutdownTimeout <= 0 {
+		<-done
+		return
+	}
+
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		// Closes connections; handlers still running are not waited for
+		s.srv.Stop()
+	}
+}
+
+// watchHealth runs the health check until ctx is done. Each check gets at
+// most one interval so a hung dependency cannot stall status updates.
+func (s *Server) watchHealth(ctx context.Context) {
+	ticker := time.NewTicker(s.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, s.healthCheckInterval)
+		status := healthpb.HealthCheckResponse_SERVING
+		if s.healthCheck != nil && s.healthCheck(checkCtx) != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		cancel()
+
+		// Stopped while checking; Stop has already reported NOT_SERVING
+		if ctx.Err() != nil {
+			return
+		}
+		s.health.SetServingStatus("", status)
+		for name := range s.srv.GetServiceInfo() {
+			if name != healthpb.Health_ServiceDesc.ServiceName {
+				s.health.SetServingStatus(name, status)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
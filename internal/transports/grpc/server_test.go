@@ -0,0 +1,111 @@
+package grpctransport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+func startTestServer(t *testing.T, s *Server) (*grpc.ClientConn, context.CancelFunc, <-chan error) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, lis) }()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, cancel, done
+}
+
+func TestServer_Health(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+
+	s := NewServer()
+	s.EnableHealth(func(ctx context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("database down")
+	}, 20*time.Millisecond)
+
+	conn, cancel, done := startTestServer(t, s)
+	client := healthpb.NewHealthClient(conn)
+
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	assert.Eventually(t, func() bool { return status() == healthpb.HealthCheckResponse_SERVING }, time.Second, 10*time.Millisecond)
+
+	healthy.Store(false)
+	assert.Eventually(t, func() bool { return status() == healthpb.HealthCheckResponse_NOT_SERVING }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestServer_Reflection(t *testing.T) {
+	s := NewServer()
+	s.EnableReflection()
+
+	conn, cancel, done := startTestServer(t, s)
+	defer func() { cancel(); <-done }()
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	defer cancelStream()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(streamCtx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetListServicesResponse().GetService())
+}
+
+func TestServer_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	s := NewServer(grpc.UnknownServiceHandler(func(srv any, stream grpc.ServerStream) error {
+		<-release
+		return nil
+	}))
+	s.SetShutdownTimeout(50 * time.Millisecond)
+
+	conn, cancel, done := startTestServer(t, s)
+
+	// Hold a call open so GracefulStop cannot finish on its own
+	go func() {
+		_ = conn.Invoke(context.Background(), "/test.Blocking/Call", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	cancel()
+	select {
+	case <-done:
+		assert.Less(t, time.Since(start), time.Second)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop after the shutdown timeout")
+	}
+}
@@ -0,0 +1,82 @@
+package grpctransport
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamil5b/go-pste-monolith/internal/shared/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryTracingInterceptor opens a server span per call, continuing the trace
+// of the caller's traceparent metadata. Put it first in the chain so the span
+// covers the other interceptors and records the final status code.
+func UnaryTracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer func() { endServerSpan(span, err) }()
+		return handler(ctx, req)
+	}
+}
+
+// StreamTracingInterceptor is the streaming counterpart of UnaryTracingInterceptor;
+// the span covers the whole stream.
+func StreamTracingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		defer func() { endServerSpan(span, err) }()
+		return handler(srv, WrapServerStream(ss, ctx))
+	}
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return tracing.Start(ctx, strings.TrimPrefix(fullMethod, "/"), trace.SpanKindServer,
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	)
+}
+
+// endServerSpan marks the span failed only for codes that indicate a server
+// side problem; client mistakes such as NotFound stay unset
+func endServerSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// metadataCarrier reads propagation headers from incoming metadata
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	if values := metadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (m metadataCarrier) Set(key, value string) { metadata.MD(m).Set(key, value) }
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,57 @@
+package grpctransport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	tests := []struct {
+		name      string
+		err       error
+		wantError bool
+	}{
+		{name: "ok"},
+		{name: "client error", err: status.Error(codes.NotFound, "product not found")},
+		{name: "server error", err: status.Error(codes.Internal, "database down"), wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			))
+			info := &grpc.UnaryServerInfo{FullMethod: "/product.v1.ProductService/Get"}
+
+			var handlerSpan trace.SpanContext
+			_, _ = UnaryTracingInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return nil, tt.err
+			})
+
+			spans := recorder.Ended()
+			require.NotEmpty(t, spans)
+			span := spans[len(spans)-1]
+			assert.Equal(t, "product.v1.ProductService/Get", span.Name())
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+			assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+			assert.Equal(t, tt.wantError, span.Status().Code == otelcodes.Error)
+		})
+	}
+}
@@ -23,6 +23,12 @@ func (ctx EchoContext) JSON(code int, v any) error {
 func (ctx EchoContext) Param(n string) string {
 	return ctx.c.Param(n)
 }
+func (ctx EchoContext) QueryParam(n string) string {
+	return ctx.c.QueryParam(n)
+}
+func (ctx EchoContext) FormValue(n string) string {
+	return ctx.c.Request().PostFormValue(n)
+}
 func (ctx EchoContext) GetUserID() string {
 	val := ctx.c.Get("user_id")
 	if val == nil {
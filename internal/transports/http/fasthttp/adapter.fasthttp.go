@@ -7,11 +7,27 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
-func AdapterToFastHTTPRoutes[T any](
-	r *router.Group,
+// Router is satisfied by both *router.Router and *router.Group, so routes can
+// be registered at the root as well as under a prefix
+type Router interface {
+	GET(path string, handler fasthttp.RequestHandler)
+	POST(path string, handler fasthttp.RequestHandler)
+	PUT(path string, handler fasthttp.RequestHandler)
+	PATCH(path string, handler fasthttp.RequestHandler)
+	DELETE(path string, handler fasthttp.RequestHandler)
+	Handle(method, path string, handler fasthttp.RequestHandler)
+}
+
+var (
+	_ Router = (*router.Router)(nil)
+	_ Router = (*router.Group)(nil)
+)
+
+func AdapterToFastHTTPRoutes[T any, R Router](
+	r R,
 	route *transportHTTP.Route,
 	domainContext func(*fasthttp.RequestCtx) T,
-) *router.Group {
+) R {
 	handler := func(ctx *fasthttp.RequestCtx) {
 		_ = route.Handler.(func(T) error)(domainContext(ctx))
 	}
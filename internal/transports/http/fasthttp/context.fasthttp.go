@@ -1,6 +1,7 @@
 package fasthttp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -24,7 +25,10 @@ func (c FastHTTPContext) BindQuery(obj any) error  { return nil }
 func (c FastHTTPContext) BindHeader(obj any) error { return nil }
 func (c FastHTTPContext) Bind(obj any) error       { return nil }
 func (c FastHTTPContext) JSON(code int, v any) error {
-	c.ctx.SetContentType("application/json")
+	// Keep a JSON media type set by the handler, e.g. application/problem+json
+	if !bytes.HasSuffix(c.ctx.Response.Header.ContentType(), []byte("+json")) {
+		c.ctx.SetContentType("application/json")
+	}
 	c.ctx.SetStatusCode(code)
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -43,6 +47,12 @@ func (c FastHTTPContext) Param(n string) string {
 	}
 	return ""
 }
+func (c FastHTTPContext) QueryParam(n string) string {
+	return string(c.ctx.QueryArgs().Peek(n))
+}
+func (c FastHTTPContext) FormValue(n string) string {
+	return string(c.ctx.PostArgs().Peek(n))
+}
 func (c FastHTTPContext) GetUserID() string           { return "" }
 func (c FastHTTPContext) Get(key string) any          { return nil }
 func (c FastHTTPContext) Set(key string, value any)   {}
@@ -2,6 +2,7 @@ package fiber
 
 import (
 	"context"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,11 +19,17 @@ func (f FiberContext) BindQuery(obj any) error  { return nil }
 func (f FiberContext) BindHeader(obj any) error { return nil }
 func (f FiberContext) Bind(obj any) error       { return nil }
 func (f FiberContext) JSON(code int, v any) error {
-	f.c.Set("Content-Type", "application/json")
+	// Keep a JSON media type set by the handler, e.g. application/problem+json
+	contentType := fiber.MIMEApplicationJSON
+	if preset := string(f.c.Response().Header.ContentType()); strings.HasSuffix(preset, "+json") {
+		contentType = preset
+	}
 	f.c.Status(code)
-	return f.c.JSON(v)
+	return f.c.JSON(v, contentType)
 }
 func (f FiberContext) Param(n string) string                 { return f.c.Params(n) }
+func (f FiberContext) QueryParam(n string) string            { return f.c.Query(n) }
+func (f FiberContext) FormValue(n string) string             { return f.c.FormValue(n) }
 func (f FiberContext) GetUserID() string                     { return "" }
 func (f FiberContext) Get(key string) any                    { return f.c.Locals(key) }
 func (f FiberContext) Set(key string, value any)             { f.c.Locals(key, value) }
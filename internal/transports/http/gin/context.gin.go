@@ -23,6 +23,12 @@ func (ctx GinContext) JSON(code int, v any) error {
 func (ctx GinContext) Param(n string) string {
 	return ctx.c.Param(n)
 }
+func (ctx GinContext) QueryParam(n string) string {
+	return ctx.c.Query(n)
+}
+func (ctx GinContext) FormValue(n string) string {
+	return ctx.c.PostForm(n)
+}
 func (ctx GinContext) GetUserID() string {
 	val, exists := ctx.c.Get("user_id")
 	if !exists {
@@ -31,7 +31,10 @@ func (ctx NetHTTPContext) BindQuery(obj any) error {
 func (ctx NetHTTPContext) BindHeader(obj any) error { return nil }
 func (ctx NetHTTPContext) Bind(obj any) error       { return nil }
 func (ctx NetHTTPContext) JSON(code int, v any) error {
-	ctx.w.Header().Set("Content-Type", "application/json")
+	// Keep a Content-Type set by the handler, e.g. application/problem+json
+	if ctx.w.Header().Get("Content-Type") == "" {
+		ctx.w.Header().Set("Content-Type", "application/json")
+	}
 	ctx.w.WriteHeader(code)
 	return json.NewEncoder(ctx.w).Encode(v)
 }
@@ -39,6 +42,12 @@ func (ctx NetHTTPContext) Param(n string) string {
 	vars := mux.Vars(ctx.r)
 	return vars[n]
 }
+func (ctx NetHTTPContext) QueryParam(n string) string {
+	return ctx.r.URL.Query().Get(n)
+}
+func (ctx NetHTTPContext) FormValue(n string) string {
+	return ctx.r.PostFormValue(n)
+}
 func (ctx NetHTTPContext) GetUserID() string           { return "" }
 func (ctx NetHTTPContext) Get(key string) any          { return nil }
 func (ctx NetHTTPContext) Set(key string, value any)   {}
@@ -47,6 +47,10 @@ func main() {
 		if err := bootstrap.RunMigration([]string{mtype, action}); err != nil {
 			log.Fatalf("migration failed: %v", err)
 		}
+	case "admin":
+		if err := bootstrap.RunAdmin(args[1:]); err != nil {
+			log.Fatalf("admin command failed: %v", err)
+		}
 	default:
 		log.Fatalf("unknown command: %s", args[0])
 	}
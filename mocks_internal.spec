@@ -3,6 +3,7 @@ github.com/kamil5b/go-pste-monolith/internal/shared/context Context internal/sha
 github.com/kamil5b/go-pste-monolith/internal/shared/uow UnitOfWork internal/shared/uow/mocks/mock_UnitOfWork.go mocks
 github.com/kamil5b/go-pste-monolith/internal/shared/events Event internal/shared/events/mocks/mock_Event.go mocks
 github.com/kamil5b/go-pste-monolith/internal/shared/events EventBus internal/shared/events/mocks/mock_EventBus.go mocks
+github.com/kamil5b/go-pste-monolith/internal/shared/events OutboxStore internal/shared/events/mocks/mock_OutboxStore.go mocks
 github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain Handler internal/modules/auth/domain/mocks/mock_Handler.go mocks
 github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain Service internal/modules/auth/domain/mocks/mock_Service.go mocks
 github.com/kamil5b/go-pste-monolith/internal/modules/auth/domain Repository internal/modules/auth/domain/mocks/mock_Repository.go mocks
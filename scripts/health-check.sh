@@ -6,6 +6,7 @@ set -euo pipefail
 # ============================================================================
 # Checks project health: dependencies, linting, formatting, and structure
 # Usage: ./scripts/health-check.sh [--fix]
+#   APP_URL=http://host:8080 also checks a running server (default localhost:8080)
 # ============================================================================
 
 # Colors
@@ -145,6 +146,21 @@ check_item "config.yaml exists" "test -f config/config.yaml"
 check_item "featureflags.yaml exists" "test -f config/featureflags.yaml"
 echo ""
 
+# Running Service (only when the server is reachable)
+APP_URL="${APP_URL:-http://localhost:8080}"
+echo "${BLUE}🩺 Running Service${NC}"
+if command -v curl &> /dev/null && curl -fsS --max-time 2 "$APP_URL/healthz" > /dev/null 2>&1; then
+  check_item "liveness ($APP_URL/healthz)" "curl -fsS --max-time 2 $APP_URL/healthz"
+  check_item "readiness ($APP_URL/readyz)" "curl -fsS --max-time 5 $APP_URL/readyz"
+  if [ "$FIX" = true ]; then
+    curl -sS --max-time 5 "$APP_URL/readyz" | sed 's/^/    /'
+    echo ""
+  fi
+else
+  echo "  ${YELLOW}⚠️  No server at $APP_URL, skipping${NC}"
+fi
+echo ""
+
 # Summary
 echo "${BOLD}=== Health Check Summary ===${NC}"
 PERCENTAGE=$((HEALTH_SCORE * 100 / CHECKS))
@@ -49,7 +49,7 @@ echo ""
 
 # Create necessary directories
 echo "${BLUE}📁 Creating necessary directories...${NC}"
-mkdir -p internal/infrastructure/db/sql/migration
+mkdir -p internal/infrastructure/db/sql/migration/{postgres,mysql,sqlite}
 mkdir -p internal/infrastructure/db/mongo/migration
 mkdir -p config
 echo "   ✅ Directories created"